@@ -9,18 +9,23 @@ import (
 	"path"
 	"path/filepath"
 	"runtime"
+	"strconv"
 	"strings"
 	"time"
 
 	"github.com/google/go-containerregistry/pkg/authn"
+	"github.com/google/go-containerregistry/pkg/name"
 	"github.com/pkg/errors"
 
+	corev1alpha1 "github.com/pivotal/kpack/pkg/apis/core/v1alpha1"
+	"github.com/pivotal/kpack/pkg/archive"
 	"github.com/pivotal/kpack/pkg/blob"
 	"github.com/pivotal/kpack/pkg/buildchange"
 	"github.com/pivotal/kpack/pkg/cnb"
 	"github.com/pivotal/kpack/pkg/dockercreds"
 	"github.com/pivotal/kpack/pkg/flaghelpers"
 	"github.com/pivotal/kpack/pkg/git"
+	"github.com/pivotal/kpack/pkg/objectstore"
 	"github.com/pivotal/kpack/pkg/registry"
 )
 
@@ -31,8 +36,23 @@ var (
 
 	gitURL         = flag.String("git-url", os.Getenv("GIT_URL"), "The url of the Git repository to initialize.")
 	gitRevision    = flag.String("git-revision", os.Getenv("GIT_REVISION"), "The Git revision to make the repository HEAD.")
+	gitSparse      = flag.Bool("git-sparse", os.Getenv("GIT_SPARSE") == "true", "Check out only the source sub path of the Git repository.")
+	gitSubmodules  = flag.Bool("git-submodules", os.Getenv("GIT_SUBMODULES") == "true", "Recursively check out the Git submodules.")
+	gitLFS         = flag.Bool("git-lfs", os.Getenv("GIT_LFS") == "true", "Download the Git LFS objects.")
+	gitKnownHosts  = flag.String("git-known-hosts", os.Getenv("GIT_KNOWN_HOSTS"), "The ssh known hosts trusted for every Git repository.")
+	gitCABundle    = flag.String("git-ca-bundle", os.Getenv("GIT_CA_BUNDLE"), "The certificate authorities trusted for every Git repository.")
 	blobURL        = flag.String("blob-url", os.Getenv("BLOB_URL"), "The url of the source code blob.")
+	blobDigest     = flag.String("blob-digest", os.Getenv("BLOB_DIGEST"), "The sha256 digest the source code blob must match.")
 	registryImage  = flag.String("registry-image", os.Getenv("REGISTRY_IMAGE"), "The registry location of the source code image.")
+	registryDigest = flag.String("registry-digest", os.Getenv("REGISTRY_DIGEST"), "The digest the source code image was resolved to.")
+	objectEndpoint = flag.String("object-store-endpoint", os.Getenv("OBJECT_STORE_ENDPOINT"), "The endpoint of the object store holding the source code object.")
+	objectRegion   = flag.String("object-store-region", os.Getenv("OBJECT_STORE_REGION"), "The region of the object store holding the source code object.")
+	objectBucket   = flag.String("object-store-bucket", os.Getenv("OBJECT_STORE_BUCKET"), "The bucket of the source code object.")
+	objectKey      = flag.String("object-store-key", os.Getenv("OBJECT_STORE_KEY"), "The key of the source code object.")
+	objectVersion  = flag.String("object-store-version-id", os.Getenv("OBJECT_STORE_VERSION_ID"), "The version id of the source code object.")
+	objectETag     = flag.String("object-store-etag", os.Getenv("OBJECT_STORE_ETAG"), "The ETag the source code object must match.")
+	maxSourceSize  = flag.Int64("archive-max-size", envInt64("ARCHIVE_MAX_SIZE"), "The maximum size in bytes extracted from the source archive.")
+	maxSourceFiles = flag.Int64("archive-max-files", envInt64("ARCHIVE_MAX_FILES"), "The maximum number of files extracted from the source archive.")
 	hostName       = flag.String("dns-probe-hostname", os.Getenv("DNS_PROBE_HOSTNAME"), "hostname to dns poll")
 	sourceSubPath  = flag.String("source-sub-path", os.Getenv("SOURCE_SUB_PATH"), "the subpath inside the source directory that will be the buildpack workspace")
 	buildChanges   = flag.String("build-changes", os.Getenv("BUILD_CHANGES"), "JSON string of build changes and their reason")
@@ -40,6 +60,8 @@ var (
 
 	basicGitCredentials     flaghelpers.CredentialsFlags
 	sshGitCredentials       flaghelpers.CredentialsFlags
+	basicBlobCredentials    flaghelpers.CredentialsFlags
+	bearerBlobCredentials   flaghelpers.CredentialsFlags
 	dockerCredentials       flaghelpers.CredentialsFlags
 	dockerCfgCredentials    flaghelpers.CredentialsFlags
 	dockerConfigCredentials flaghelpers.CredentialsFlags
@@ -49,6 +71,8 @@ var (
 func init() {
 	flag.Var(&basicGitCredentials, "basic-git", "Basic authentication for git of the form 'secretname=git.domain.com'")
 	flag.Var(&sshGitCredentials, "ssh-git", "SSH authentication for git of the form 'secretname=git.domain.com'")
+	flag.Var(&basicBlobCredentials, "basic-blob", "Basic authentication for blobs of the form 'secretname=https://blob.domain.com'")
+	flag.Var(&bearerBlobCredentials, "bearer-blob", "Bearer token authentication for blobs of the form 'secretname=https://blob.domain.com'")
 	flag.Var(&dockerCredentials, "basic-docker", "Basic authentication for docker of the form 'secretname=git.domain.com'")
 	flag.Var(&dockerCfgCredentials, "dockercfg", "Docker Cfg credentials in the form of the path to the credential")
 	flag.Var(&dockerConfigCredentials, "dockerconfig", "Docker Config JSON credentials in the form of the path to the credential")
@@ -61,6 +85,7 @@ const (
 	platformDir                  = "/platform"
 	buildSecretsDir              = "/var/build-secrets"
 	registrySourcePullSecretsDir = "/registrySourcePullSecrets"
+	objectStoreCredentialsDir    = "/objectStoreCredentials"
 	projectMetadataDir           = "/projectMetadata"
 	networkWaitLauncherDir       = "/networkWait"
 	networkWaitLauncherBinary    = "network-wait-launcher.exe"
@@ -179,16 +204,40 @@ func fetchSource(logger *log.Logger, serviceAccountCreds dockercreds.DockerCreds
 			return err
 		}
 
+		gitTrust, err := gitKeychain.Trust()
+		if err != nil {
+			return err
+		}
+		clusterGitTrust := git.Trust{
+			KnownHosts: []byte(*gitKnownHosts),
+			CABundle:   []byte(*gitCABundle),
+		}
+
 		fetcher := git.Fetcher{
-			Logger:   logger,
-			Keychain: gitKeychain,
+			Logger:     logger,
+			Keychain:   gitKeychain,
+			Submodules: *gitSubmodules,
+			LFS:        *gitLFS,
+			Trust:      clusterGitTrust.Merge(gitTrust),
+		}
+		if *gitSparse {
+			fetcher.SparsePath = *sourceSubPath
 		}
 		return fetcher.Fetch(appDir, *gitURL, *gitRevision, projectMetadataDir)
 	case *blobURL != "":
+		logLoadingSecrets(logger, basicBlobCredentials, bearerBlobCredentials)
+
+		blobKeychain, err := blob.NewMountedSecretBlobKeychain(buildSecretsDir, basicBlobCredentials, bearerBlobCredentials)
+		if err != nil {
+			return err
+		}
+
 		fetcher := blob.Fetcher{
-			Logger: logger,
+			Logger:   logger,
+			Keychain: blobKeychain,
+			Limits:   archiveLimits(),
 		}
-		return fetcher.Fetch(appDir, *blobURL)
+		return fetcher.Fetch(appDir, *blobURL, *blobDigest)
 	case *registryImage != "":
 		registrySourcePullSecrets, err := dockercreds.ParseDockerPullSecrets(registrySourcePullSecretsDir)
 		if err != nil {
@@ -199,11 +248,55 @@ func fetchSource(logger *log.Logger, serviceAccountCreds dockercreds.DockerCreds
 			Logger:   logger,
 			Client:   &registry.Client{},
 			Keychain: authn.NewMultiKeychain(registrySourcePullSecrets, serviceAccountCreds),
+			Limits:   archiveLimits(),
+		}
+		sourceImage := *registryImage
+		if *registryDigest != "" {
+			ref, err := name.ParseReference(sourceImage, name.WeakValidation)
+			if err != nil {
+				return err
+			}
+			sourceImage = ref.Context().Digest(*registryDigest).String()
 		}
-		return fetcher.Fetch(appDir, *registryImage)
+		return fetcher.Fetch(appDir, sourceImage)
+	case *objectEndpoint != "":
+		creds, err := objectstore.ReadMountedCredentials(objectStoreCredentialsDir)
+		if err != nil {
+			return err
+		}
+
+		client, err := objectstore.NewClient(*objectEndpoint, *objectRegion, creds)
+		if err != nil {
+			return err
+		}
+
+		fetcher := objectstore.Fetcher{
+			Logger: logger,
+			Client: client,
+			Limits: archiveLimits(),
+		}
+		return fetcher.Fetch(appDir, *objectBucket, *objectKey, corev1alpha1.ObjectVersion{
+			VersionID: *objectVersion,
+			ETag:      *objectETag,
+		})
 	default:
-		return errors.New("no git url, blob url, or registry image provided")
+		return errors.New("no git url, blob url, registry image, or object store endpoint provided")
+	}
+}
+
+func archiveLimits() archive.Limits {
+	return archive.Limits{
+		MaxSize:  *maxSourceSize,
+		MaxFiles: *maxSourceFiles,
+	}
+}
+
+func envInt64(key string) int64 {
+	v, err := strconv.ParseInt(os.Getenv(key), 10, 64)
+	if err != nil {
+		return 0
 	}
+	return v
 }
 
 func logLoadingSecrets(logger *log.Logger, secretsSlices ...[]string) {
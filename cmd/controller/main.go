@@ -12,6 +12,8 @@ import (
 	"github.com/google/go-containerregistry/pkg/authn"
 	"go.uber.org/zap"
 	"golang.org/x/sync/errgroup"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
 	"k8s.io/client-go/dynamic"
 	"k8s.io/client-go/informers"
 	"k8s.io/client-go/kubernetes"
@@ -32,6 +34,7 @@ import (
 	buildapi "github.com/pivotal/kpack/pkg/apis/build/v1alpha2"
 	"github.com/pivotal/kpack/pkg/blob"
 	"github.com/pivotal/kpack/pkg/buildpod"
+	"github.com/pivotal/kpack/pkg/buildqueue"
 	"github.com/pivotal/kpack/pkg/client/clientset/versioned"
 	"github.com/pivotal/kpack/pkg/client/informers/externalversions"
 	"github.com/pivotal/kpack/pkg/cnb"
@@ -39,6 +42,9 @@ import (
 	"github.com/pivotal/kpack/pkg/dockercreds/k8sdockercreds"
 	"github.com/pivotal/kpack/pkg/duckbuilder"
 	"github.com/pivotal/kpack/pkg/git"
+	"github.com/pivotal/kpack/pkg/gitwebhook"
+	"github.com/pivotal/kpack/pkg/notary"
+	"github.com/pivotal/kpack/pkg/objectstore"
 	"github.com/pivotal/kpack/pkg/reconciler"
 	"github.com/pivotal/kpack/pkg/reconciler/build"
 	"github.com/pivotal/kpack/pkg/reconciler/builder"
@@ -46,6 +52,8 @@ import (
 	"github.com/pivotal/kpack/pkg/reconciler/clusterstack"
 	"github.com/pivotal/kpack/pkg/reconciler/clusterstore"
 	"github.com/pivotal/kpack/pkg/reconciler/image"
+	"github.com/pivotal/kpack/pkg/reconciler/imageset"
+	"github.com/pivotal/kpack/pkg/reconciler/previewimageset"
 	"github.com/pivotal/kpack/pkg/reconciler/sourceresolver"
 	"github.com/pivotal/kpack/pkg/registry"
 )
@@ -64,6 +72,33 @@ func getEnvBool(key string, defaultValue bool) bool {
 	return v
 }
 
+func getEnvInt(key string, defaultValue int) int {
+	s := os.Getenv(key)
+	v, err := strconv.Atoi(s)
+	if err != nil {
+		return defaultValue
+	}
+	return v
+}
+
+func getEnvInt64(key string, defaultValue int64) int64 {
+	s := os.Getenv(key)
+	v, err := strconv.ParseInt(s, 10, 64)
+	if err != nil {
+		return defaultValue
+	}
+	return v
+}
+
+func getEnvDuration(key string, defaultValue time.Duration) time.Duration {
+	s := os.Getenv(key)
+	v, err := time.ParseDuration(s)
+	if err != nil {
+		return defaultValue
+	}
+	return v
+}
+
 var (
 	kubeconfig = flag.String("kubeconfig", "", "Path to a kubeconfig. Only required if out-of-cluster.")
 	masterURL  = flag.String("master", "", "The address of the Kubernetes API server. Overrides any value in kubeconfig. Only required if out-of-cluster.")
@@ -74,6 +109,13 @@ var (
 	completionImage        = flag.String("completion-image", os.Getenv("COMPLETION_IMAGE"), "The image used to finish a build")
 	completionWindowsImage = flag.String("completion-windows-image", os.Getenv("COMPLETION_WINDOWS_IMAGE"), "The image used to finish a build on windows")
 	enablePriorityClasses  = flag.Bool("enable-priority-classes", getEnvBool("ENABLE_PRIORITY_CLASSES", false), "if set to true, enables different pod priority classes for normal builds and automated builds")
+	sourcePollingFrequency = flag.Duration("source-polling-frequency", getEnvDuration("SOURCE_POLLING_FREQUENCY", 10*time.Minute), "How often git sources are polled for new revisions")
+	gitWebhookAddress      = flag.String("git-webhook-address", os.Getenv("GIT_WEBHOOK_ADDRESS"), "The address on which to receive git push webhooks, disabled if empty")
+	archiveMaxSize         = flag.Int64("archive-max-size", getEnvInt64("ARCHIVE_MAX_SIZE", 0), "The maximum size in bytes extracted from blob, object store and registry source archives, defaults to 10GiB if 0")
+	archiveMaxFiles        = flag.Int64("archive-max-files", getEnvInt64("ARCHIVE_MAX_FILES", 0), "The maximum number of files extracted from blob, object store and registry source archives, defaults to 1000000 if 0")
+	maxConcurrentBuilds    = flag.Int("max-concurrent-builds", getEnvInt("MAX_CONCURRENT_BUILDS", 0), "The maximum number of builds running in the cluster, unlimited if 0")
+	maxNamespaceBuilds     = flag.Int("max-namespace-concurrent-builds", getEnvInt("MAX_NAMESPACE_CONCURRENT_BUILDS", 0), "The maximum number of builds running in a namespace, unlimited if 0")
+	buildTimeout           = flag.Duration("build-timeout", getEnvDuration("BUILD_TIMEOUT", 0), "The default maximum duration of a build, unlimited if 0")
 )
 
 func main() {
@@ -103,13 +145,16 @@ func main() {
 		Logger:                  logger,
 		Client:                  client,
 		ResyncPeriod:            10 * time.Hour,
-		SourcePollingFrequency:  1 * time.Minute,
+		SourcePollingFrequency:  *sourcePollingFrequency,
 		BuilderPollingFrequency: 1 * time.Minute,
 	}
 
 	informerFactory := externalversions.NewSharedInformerFactory(client, options.ResyncPeriod)
 	buildInformer := informerFactory.Kpack().V1alpha2().Builds()
+	buildRequestInformer := informerFactory.Kpack().V1alpha2().BuildRequests()
 	imageInformer := informerFactory.Kpack().V1alpha2().Images()
+	imageSetInformer := informerFactory.Kpack().V1alpha2().ImageSets()
+	previewImageSetInformer := informerFactory.Kpack().V1alpha2().PreviewImageSets()
 	sourceResolverInformer := informerFactory.Kpack().V1alpha2().SourceResolvers()
 	builderInformer := informerFactory.Kpack().V1alpha2().Builders()
 	clusterBuilderInformer := informerFactory.Kpack().V1alpha2().ClusterBuilders()
@@ -140,6 +185,9 @@ func main() {
 		log.Fatalf("could not get dynamic client: %s", err)
 	}
 
+	gitTrustProvider := config.NewGitTrustProvider()
+	configMapWatcher.Watch(config.GitTrustConfigName, gitTrustProvider.UpdateTrust)
+
 	buildpodGenerator := &buildpod.Generator{
 		BuildPodConfig: buildapi.BuildPodImages{
 			BuildInitImage:         *buildInitImage,
@@ -148,15 +196,23 @@ func main() {
 			BuildInitWindowsImage:  *buildInitWindowsImage,
 			CompletionWindowsImage: *completionWindowsImage,
 		},
-		K8sClient:       k8sClient,
-		KeychainFactory: keychainFactory,
-		ImageFetcher:    &registry.Client{},
-		DynamicClient:   dynamicClient,
+		K8sClient:        k8sClient,
+		KeychainFactory:  keychainFactory,
+		ImageFetcher:     &registry.Client{},
+		DynamicClient:    dynamicClient,
+		GitTrustProvider: gitTrustProvider,
+		ArchiveMaxSize:   *archiveMaxSize,
+		ArchiveMaxFiles:  *archiveMaxFiles,
+		BuildTimeout:     *buildTimeout,
 	}
 
-	gitResolver := git.NewResolver(k8sClient)
-	blobResolver := &blob.Resolver{}
-	registryResolver := &registry.Resolver{}
+	gitResolver := git.NewResolver(k8sClient, gitTrustProvider)
+	blobResolver := blob.NewResolver(k8sClient)
+	registryResolver := &registry.Resolver{
+		KeychainFactory: keychainFactory,
+		Client:          &registry.Client{},
+	}
+	objectStoreResolver := objectstore.NewResolver(k8sClient)
 
 	remoteStoreReader := &cnb.RemoteStoreReader{
 		RegistryClient: &registry.Client{},
@@ -181,9 +237,23 @@ func main() {
 		NewBuildpackRepository: newBuildpackRepository(kpackKeychain),
 	}
 
-	buildController := build.NewController(options, k8sClient, buildInformer, podInformer, metadataRetriever, buildpodGenerator)
-	imageController := image.NewController(options, k8sClient, imageInformer, buildInformer, duckBuilderInformer, sourceResolverInformer, pvcInformer, *enablePriorityClasses)
-	sourceResolverController := sourceresolver.NewController(options, sourceResolverInformer, gitResolver, blobResolver, registryResolver)
+	buildController := build.NewController(options, k8sClient, buildInformer, podInformer, metadataRetriever, buildpodGenerator, &registry.Promoter{KeychainFactory: keychainFactory, K8sClient: k8sClient})
+	retagger := &registry.Retagger{
+		KeychainFactory: keychainFactory,
+		K8sClient:       k8sClient,
+		NotarySigner: &notary.ImageSigner{
+			Logger:  zap.NewStdLog(logger.Desugar()),
+			Client:  &registry.Client{},
+			Factory: &notary.RemoteRepositoryFactory{},
+		},
+	}
+	imageController := image.NewController(options, k8sClient, imageInformer, buildInformer, duckBuilderInformer, sourceResolverInformer, buildRequestInformer, pvcInformer, retagger, gitResolver, *enablePriorityClasses, buildqueue.Limits{
+		MaxBuilds:          *maxConcurrentBuilds,
+		MaxNamespaceBuilds: *maxNamespaceBuilds,
+	})
+	imageSetController := imageset.NewController(options, imageSetInformer, imageInformer, gitResolver)
+	previewImageSetController := previewimageset.NewController(options, previewImageSetInformer, imageInformer, gitResolver)
+	sourceResolverController := sourceresolver.NewController(options, sourceResolverInformer, gitResolver, blobResolver, registryResolver, objectStoreResolver)
 	builderController, builderResync := builder.NewController(options, builderInformer, builderCreator, keychainFactory, clusterStoreInformer, clusterStackInformer)
 	clusterBuilderController, clusterBuilderResync := clusterBuilder.NewController(options, clusterBuilderInformer, builderCreator, keychainFactory, clusterStoreInformer, clusterStackInformer)
 	clusterStoreController := clusterstore.NewController(options, keychainFactory, clusterStoreInformer, remoteStoreReader)
@@ -198,7 +268,10 @@ func main() {
 
 	waitForSync(stopChan,
 		buildInformer.Informer(),
+		buildRequestInformer.Informer(),
 		imageInformer.Informer(),
+		imageSetInformer.Informer(),
+		previewImageSetInformer.Informer(),
 		sourceResolverInformer.Informer(),
 		pvcInformer.Informer(),
 		podInformer.Informer(),
@@ -208,10 +281,11 @@ func main() {
 		clusterStackInformer.Informer(),
 	)
 
-	err = runGroup(
-		ctx,
+	runners := []doneFunc{
 		run(clusterStackController, routinesPerController),
 		run(imageController, routinesPerController),
+		run(imageSetController, routinesPerController),
+		run(previewImageSetController, routinesPerController),
 		run(buildController, routinesPerController),
 		run(builderController, routinesPerController),
 		run(clusterBuilderController, routinesPerController),
@@ -225,7 +299,40 @@ func main() {
 			<-done
 			return profilingServer.Shutdown(ctx)
 		},
-	)
+	}
+
+	if *gitWebhookAddress != "" {
+		webhookSecretInformerFactory := informers.NewSharedInformerFactoryWithOptions(k8sClient, options.ResyncPeriod,
+			informers.WithTweakListOptions(func(listOptions *metav1.ListOptions) {
+				listOptions.FieldSelector = fields.OneTermEqualSelector("metadata.name", gitwebhook.SecretName).String()
+			}))
+		webhookSecretInformer := webhookSecretInformerFactory.Core().V1().Secrets()
+		webhookSecretInformer.Informer()
+		webhookSecretInformerFactory.Start(stopChan)
+		waitForSync(stopChan, webhookSecretInformer.Informer())
+
+		gitWebhookServer := &http.Server{
+			Addr: *gitWebhookAddress,
+			Handler: &gitwebhook.Handler{
+				Logger:               logger,
+				SecretLister:         webhookSecretInformer.Lister(),
+				SourceResolverLister: sourceResolverInformer.Lister(),
+				Enqueue:              sourceResolverController.Enqueue,
+			},
+		}
+
+		runners = append(runners,
+			func(done <-chan struct{}) error {
+				return gitWebhookServer.ListenAndServe()
+			},
+			func(done <-chan struct{}) error {
+				<-done
+				return gitWebhookServer.Shutdown(ctx)
+			},
+		)
+	}
+
+	err = runGroup(ctx, runners...)
 	if err != nil && err != http.ErrServerClosed {
 		logger.Fatalw("Error running controller", zap.Error(err))
 	}
@@ -27,12 +27,15 @@ import (
 )
 
 var types = map[schema.GroupVersionKind]resourcesemantics.GenericCRD{
-	v1alpha2.SchemeGroupVersion.WithKind("Image"):          &v1alpha2.Image{},
-	v1alpha2.SchemeGroupVersion.WithKind("Build"):          &v1alpha2.Build{},
-	v1alpha2.SchemeGroupVersion.WithKind("Builder"):        &v1alpha2.Builder{},
-	v1alpha2.SchemeGroupVersion.WithKind("ClusterBuilder"): &v1alpha2.ClusterBuilder{},
-	v1alpha2.SchemeGroupVersion.WithKind("ClusterStore"):   &v1alpha2.ClusterStore{},
-	v1alpha2.SchemeGroupVersion.WithKind("ClusterStack"):   &v1alpha2.ClusterStack{},
+	v1alpha2.SchemeGroupVersion.WithKind("Image"):           &v1alpha2.Image{},
+	v1alpha2.SchemeGroupVersion.WithKind("ImageSet"):        &v1alpha2.ImageSet{},
+	v1alpha2.SchemeGroupVersion.WithKind("PreviewImageSet"): &v1alpha2.PreviewImageSet{},
+	v1alpha2.SchemeGroupVersion.WithKind("BuildRequest"):    &v1alpha2.BuildRequest{},
+	v1alpha2.SchemeGroupVersion.WithKind("Build"):           &v1alpha2.Build{},
+	v1alpha2.SchemeGroupVersion.WithKind("Builder"):         &v1alpha2.Builder{},
+	v1alpha2.SchemeGroupVersion.WithKind("ClusterBuilder"):  &v1alpha2.ClusterBuilder{},
+	v1alpha2.SchemeGroupVersion.WithKind("ClusterStore"):    &v1alpha2.ClusterStore{},
+	v1alpha2.SchemeGroupVersion.WithKind("ClusterStack"):    &v1alpha2.ClusterStack{},
 }
 
 func init() {
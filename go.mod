@@ -21,6 +21,7 @@ require (
 	github.com/google/go-containerregistry v0.8.1-0.20220125170349-50dfc2733d10
 	github.com/google/go-containerregistry/pkg/authn/k8schain v0.0.0-20220125170349-50dfc2733d10
 	github.com/jinzhu/gorm v1.9.12 // indirect
+	github.com/klauspost/compress v1.15.1
 	github.com/libgit2/git2go/v33 v33.0.4
 	github.com/matthewmcnew/archtest v0.0.0-20191014222827-a111193b50ad
 	github.com/mgutz/ansi v0.0.0-20170206155736-9520e82c474b
@@ -31,6 +32,7 @@ require (
 	github.com/spf13/pflag v1.0.5
 	github.com/stretchr/testify v1.7.0
 	github.com/theupdateframework/notary v0.6.2-0.20200804143915-84287fd8df4f
+	github.com/ulikunitz/xz v0.5.10
 	github.com/vdemeester/k8s-pkg-credentialprovider v1.20.7
 	github.com/whilp/git-urls v1.0.0
 	go.uber.org/zap v1.20.0
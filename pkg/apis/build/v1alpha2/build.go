@@ -2,6 +2,7 @@ package v1alpha2
 
 import (
 	"strconv"
+	"time"
 
 	"github.com/google/go-containerregistry/pkg/name"
 	corev1 "k8s.io/api/core/v1"
@@ -35,6 +36,9 @@ func (b *Build) CnbBindings() corev1alpha1.CNBBindings {
 	return b.Spec.CNBBindings
 }
 
+// IsRunning reports whether the build has not finished. A build that is
+// requested to be cancelled keeps running until it is failed with the
+// BuildCancelled reason or its pod completes.
 func (b *Build) IsRunning() bool {
 	if b == nil {
 		return false
@@ -42,6 +46,13 @@ func (b *Build) IsRunning() bool {
 	return b.Status.GetCondition(corev1alpha1.ConditionSucceeded).IsUnknown()
 }
 
+func (b *Build) CancelRequested() bool {
+	if b == nil {
+		return false
+	}
+	return b.Annotations[BuildCancelAnnotation] == "true"
+}
+
 func (b *Build) BuildRef() string {
 	if b == nil {
 		return ""
@@ -132,6 +143,78 @@ func (b *Build) IsFailure() bool {
 	return b.Status.GetCondition(corev1alpha1.ConditionSucceeded).IsFalse()
 }
 
+// retryableSteps fetch source or talk to registries, so their failures are
+// often transient.
+var retryableSteps = map[string]bool{
+	"prepare": true,
+	"analyze": true,
+	"restore": true,
+	"export":  true,
+}
+
+// IsRetryable reports whether the build failed in a way that a retry of the
+// same source and builder may succeed. Builds that timed out, were cancelled
+// or failed to detect or build the app are not retryable.
+func (b *Build) IsRetryable() bool {
+	if !b.IsFailure() {
+		return false
+	}
+
+	switch b.Status.GetCondition(corev1alpha1.ConditionSucceeded).Reason {
+	case BuildTimedOut, BuildCancelled:
+		return false
+	}
+
+	if len(b.Status.StepStates) == 0 {
+		return false
+	}
+
+	last := len(b.Status.StepsCompleted) - 1
+	if last < 0 || last >= len(b.Status.StepStates) {
+		return true
+	}
+
+	terminated := b.Status.StepStates[last].Terminated
+	if terminated == nil || terminated.ExitCode == 0 {
+		return true
+	}
+	return retryableSteps[b.Status.StepsCompleted[last]]
+}
+
+func (b *Build) RetryAttempt() int64 {
+	if b == nil {
+		return 0
+	}
+
+	attempt, err := strconv.ParseInt(b.Annotations[BuildRetryAttemptAnnotation], 10, 64)
+	if err != nil {
+		return 0
+	}
+	return attempt
+}
+
+// QueuedAt returns when the image of the build was queued waiting for the
+// build to be admitted, or the zero time when the build was not queued.
+func (b *Build) QueuedAt() time.Time {
+	if b == nil {
+		return time.Time{}
+	}
+
+	queuedAt, err := time.Parse(time.RFC3339, b.Annotations[BuildQueuedAtAnnotation])
+	if err != nil {
+		return time.Time{}
+	}
+	return queuedAt
+}
+
+func (b *Build) FinishedAt() time.Time {
+	condition := b.Status.GetCondition(corev1alpha1.ConditionSucceeded)
+	if condition == nil {
+		return time.Time{}
+	}
+	return condition.LastTransitionTime.Inner.Time
+}
+
 func (b *Build) PodName() string {
 	return kmeta.ChildName(b.Name, "-build-pod")
 }
@@ -6,6 +6,21 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
+const (
+	BuildTimedOut  = "BuildTimedOut"
+	BuildCancelled = "BuildCancelled"
+
+	// PromotionRetrying is the reason of a failed promotion that is retried.
+	PromotionRetrying = "PromotionRetrying"
+	// PromotionFailed is the reason of a promotion that is not retried after
+	// failing too many times.
+	PromotionFailed = "PromotionFailed"
+
+	// BuildCancelAnnotation requests that a running build is cancelled when
+	// set to "true".
+	BuildCancelAnnotation = "kpack.io/cancel"
+)
+
 func (bs *BuildStatus) Error(err error) {
 	bs.Conditions = corev1alpha1.Conditions{
 		{
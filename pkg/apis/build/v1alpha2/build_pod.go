@@ -4,7 +4,9 @@ import (
 	"encoding/json"
 	"fmt"
 	"path/filepath"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/Masterminds/semver/v3"
 	"github.com/google/go-containerregistry/pkg/name"
@@ -14,6 +16,7 @@ import (
 	"knative.dev/pkg/kmeta"
 
 	corev1alpha1 "github.com/pivotal/kpack/pkg/apis/core/v1alpha1"
+	secretpkg "github.com/pivotal/kpack/pkg/secret"
 )
 
 const (
@@ -23,6 +26,7 @@ const (
 	BuildLabel                             = "kpack.io/build"
 	DOCKERSecretAnnotationPrefix           = "kpack.io/docker"
 	GITSecretAnnotationPrefix              = "kpack.io/git"
+	BlobSecretAnnotationPrefix             = "kpack.io/blob"
 	COSIGNDockerMediaTypesAnnotationPrefix = "kpack.io/cosign.docker-media-types"
 	COSIGNRespositoryAnnotationPrefix      = "kpack.io/cosign.repository"
 	COSIGNSecretDataCosignKey              = "cosign.key"
@@ -35,6 +39,7 @@ const (
 	homeDir                      = "home-dir"
 	workspaceDir                 = "workspace-dir"
 	registrySourcePullSecretsDir = "registry-source-pull-secrets-dir"
+	objectStoreCredentialsDir    = "object-store-credentials-dir"
 
 	notaryDirName = "notary-dir"
 	reportDirName = "report-dir"
@@ -82,6 +87,11 @@ type BuildContext struct {
 	Secrets               []corev1.Secret
 	Bindings              []ServiceBinding
 	ImagePullSecrets      []corev1.LocalObjectReference
+	GitKnownHosts         string
+	GitCABundle           string
+	ArchiveMaxSize        int64
+	ArchiveMaxFiles       int64
+	BuildTimeout          time.Duration
 }
 
 func (c BuildContext) os() string {
@@ -131,6 +141,11 @@ var (
 		MountPath: "/registrySourcePullSecrets",
 		ReadOnly:  true,
 	}
+	objectStoreCredentialsVolume = corev1.VolumeMount{
+		Name:      objectStoreCredentialsDir,
+		MountPath: "/objectStoreCredentials",
+		ReadOnly:  true,
+	}
 	notaryV1Volume = corev1.VolumeMount{
 		Name:      notaryDirName,
 		MountPath: "/var/notary/v1",
@@ -175,7 +190,7 @@ func (b *Build) BuildPod(images BuildPodImages, buildContext BuildContext) (*cor
 		return nil, err
 	}
 
-	secretVolumes, secretVolumeMounts, secretArgs := b.setupSecretVolumesAndArgs(buildContext.Secrets, gitAndDockerSecrets)
+	secretVolumes, secretVolumeMounts, secretArgs := b.setupSecretVolumesAndArgs(buildContext.Secrets, sourceAndDockerSecrets)
 	cosignVolumes, cosignVolumeMounts, cosignSecretArgs := b.setupCosignVolumes(buildContext.Secrets)
 	imagePullVolumes, imagePullVolumeMounts, imagePullArgs := b.setupImagePullVolumes(buildContext.ImagePullSecrets)
 
@@ -316,8 +331,9 @@ func (b *Build) BuildPod(images BuildPodImages, buildContext BuildContext) (*cor
 		},
 		Spec: corev1.PodSpec{
 			// If the build fails, don't restart it.
-			RestartPolicy:     corev1.RestartPolicyNever,
-			PriorityClassName: b.PriorityClassName(),
+			RestartPolicy:         corev1.RestartPolicyNever,
+			PriorityClassName:     b.PriorityClassName(),
+			ActiveDeadlineSeconds: b.activeDeadlineSeconds(buildContext),
 			Containers: steps(func(step func(corev1.Container, ...stepModifier)) {
 				step(corev1.Container{
 					Name:    "completion",
@@ -354,7 +370,7 @@ func (b *Build) BuildPod(images BuildPodImages, buildContext BuildContext) (*cor
 						Args:      append(secretArgs, imagePullArgs...),
 						Resources: b.Spec.Resources,
 						Env: append(
-							b.Spec.Source.Source().BuildEnvVars(),
+							b.sourceEnvVars(buildContext),
 							corev1.EnvVar{
 								Name:  "SOURCE_SUB_PATH",
 								Value: b.Spec.Source.SubPath,
@@ -391,6 +407,7 @@ func (b *Build) BuildPod(images BuildPodImages, buildContext BuildContext) (*cor
 							imagePullVolumeMounts,
 							[]corev1.VolumeMount{
 								registrySourcePullSecretsVolume,
+								objectStoreCredentialsVolume,
 								platformVolume,
 								sourceVolume,
 								homeVolume,
@@ -592,6 +609,7 @@ func (b *Build) BuildPod(images BuildPodImages, buildContext BuildContext) (*cor
 						},
 					},
 					b.Spec.Source.Source().ImagePullSecretsVolume(registrySourcePullSecretsDir),
+					b.objectStoreCredentialsVolume(),
 					b.notarySecretVolume(),
 				},
 				bindingVolumes),
@@ -653,6 +671,55 @@ func noOpModifer(container corev1.Container) corev1.Container {
 	return container
 }
 
+func (b *Build) sourceEnvVars(buildContext BuildContext) []corev1.EnvVar {
+	envVars := b.Spec.Source.Source().BuildEnvVars()
+	if resolved := b.Spec.ResolvedSource; b.Spec.Source.Registry != nil && resolved != nil && resolved.RegistryDigest != "" {
+		// the source image is pulled by the digest it was resolved to
+		envVars = append(envVars, corev1.EnvVar{Name: "REGISTRY_DIGEST", Value: resolved.RegistryDigest})
+	}
+	if b.Spec.Source.Git == nil {
+		if buildContext.ArchiveMaxSize > 0 {
+			envVars = append(envVars, corev1.EnvVar{Name: "ARCHIVE_MAX_SIZE", Value: strconv.FormatInt(buildContext.ArchiveMaxSize, 10)})
+		}
+		if buildContext.ArchiveMaxFiles > 0 {
+			envVars = append(envVars, corev1.EnvVar{Name: "ARCHIVE_MAX_FILES", Value: strconv.FormatInt(buildContext.ArchiveMaxFiles, 10)})
+		}
+		return envVars
+	}
+
+	if buildContext.GitKnownHosts != "" {
+		envVars = append(envVars, corev1.EnvVar{Name: "GIT_KNOWN_HOSTS", Value: buildContext.GitKnownHosts})
+	}
+	if buildContext.GitCABundle != "" {
+		envVars = append(envVars, corev1.EnvVar{Name: "GIT_CA_BUNDLE", Value: buildContext.GitCABundle})
+	}
+	return envVars
+}
+
+func (b *Build) activeDeadlineSeconds(buildContext BuildContext) *int64 {
+	if b.Spec.ActiveDeadlineSeconds != nil {
+		return b.Spec.ActiveDeadlineSeconds
+	}
+	if buildContext.BuildTimeout <= 0 {
+		return nil
+	}
+	seconds := int64(buildContext.BuildTimeout.Seconds())
+	return &seconds
+}
+
+func (b *Build) objectStoreCredentialsVolume() corev1.Volume {
+	if b.Spec.Source.ObjectStore == nil {
+		return corev1.Volume{
+			Name: objectStoreCredentialsDir,
+			VolumeSource: corev1.VolumeSource{
+				EmptyDir: &corev1.EmptyDirVolumeSource{},
+			},
+		}
+	}
+
+	return b.Spec.Source.ObjectStore.CredentialsVolume(objectStoreCredentialsDir)
+}
+
 func (b *Build) notarySecretVolume() corev1.Volume {
 	config := b.NotaryV1Config()
 	if config == nil {
@@ -736,7 +803,8 @@ func (b *Build) rebasePod(buildContext BuildContext, images BuildPodImages) (*co
 					b.notarySecretVolume(),
 				},
 			),
-			RestartPolicy: corev1.RestartPolicyNever,
+			RestartPolicy:         corev1.RestartPolicyNever,
+			ActiveDeadlineSeconds: b.activeDeadlineSeconds(buildContext),
 			Containers: []corev1.Container{
 				{
 					Name:    "completion",
@@ -812,8 +880,8 @@ func (b *Build) cacheVolume(os string) []corev1.Volume {
 	}}
 }
 
-func gitAndDockerSecrets(secret corev1.Secret) bool {
-	return secret.Annotations[GITSecretAnnotationPrefix] != "" || dockerSecrets(secret)
+func sourceAndDockerSecrets(secret corev1.Secret) bool {
+	return secret.Annotations[GITSecretAnnotationPrefix] != "" || secret.Annotations[BlobSecretAnnotationPrefix] != "" || dockerSecrets(secret)
 }
 
 func dockerSecrets(secret corev1.Secret) bool {
@@ -843,6 +911,12 @@ func (b *Build) setupSecretVolumesAndArgs(secrets []corev1.Secret, filter func(s
 		case secret.Type == corev1.SecretTypeSSHAuth:
 			annotatedUrl := secret.Annotations[GITSecretAnnotationPrefix]
 			args = append(args, fmt.Sprintf("-ssh-%s=%s=%s", "git", secret.Name, annotatedUrl))
+		case secret.Type == corev1.SecretTypeBasicAuth && secret.Annotations[BlobSecretAnnotationPrefix] != "":
+			annotatedUrl := secret.Annotations[BlobSecretAnnotationPrefix]
+			args = append(args, fmt.Sprintf("-basic-%s=%s=%s", "blob", secret.Name, annotatedUrl))
+		case secret.Annotations[BlobSecretAnnotationPrefix] != "" && len(secret.Data[secretpkg.BearerTokenKey]) > 0:
+			annotatedUrl := secret.Annotations[BlobSecretAnnotationPrefix]
+			args = append(args, fmt.Sprintf("-bearer-%s=%s=%s", "blob", secret.Name, annotatedUrl))
 		default:
 			//ignoring secret
 			continue
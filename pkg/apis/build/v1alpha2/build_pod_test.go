@@ -347,6 +347,24 @@ func testBuildPod(t *testing.T, when spec.G, it spec.S) {
 			assert.Equal(t, map[string]string{"kubernetes.io/os": "linux"}, pod.Spec.NodeSelector)
 		})
 
+		it("sets the pod active deadline from the build timeout", func() {
+			pod, err := build.BuildPod(config, buildContext)
+			require.NoError(t, err)
+			assert.Nil(t, pod.Spec.ActiveDeadlineSeconds)
+
+			buildContext.BuildTimeout = 2 * time.Hour
+			pod, err = build.BuildPod(config, buildContext)
+			require.NoError(t, err)
+			require.NotNil(t, pod.Spec.ActiveDeadlineSeconds)
+			assert.Equal(t, int64(7200), *pod.Spec.ActiveDeadlineSeconds)
+
+			deadline := int64(600)
+			build.Spec.ActiveDeadlineSeconds = &deadline
+			pod, err = build.BuildPod(config, buildContext)
+			require.NoError(t, err)
+			assert.Equal(t, &deadline, pod.Spec.ActiveDeadlineSeconds)
+		})
+
 		it("configures the pod security context to match the builder config user and group", func() {
 			pod, err := build.BuildPod(config, buildContext)
 			require.NoError(t, err)
@@ -719,6 +737,51 @@ func testBuildPod(t *testing.T, when spec.G, it spec.S) {
 			)
 		})
 
+		it("configures the prepare step with the git fetch options", func() {
+			build.Spec.Source.Git.Fetch = &corev1alpha1.GitFetchOptions{
+				Sparse:     true,
+				Submodules: true,
+				LFS:        true,
+			}
+			pod, err := build.BuildPod(config, buildContext)
+			require.NoError(t, err)
+
+			assert.Contains(t, pod.Spec.InitContainers[0].Env,
+				corev1.EnvVar{
+					Name:  "GIT_SPARSE",
+					Value: "true",
+				})
+			assert.Contains(t, pod.Spec.InitContainers[0].Env,
+				corev1.EnvVar{
+					Name:  "GIT_SUBMODULES",
+					Value: "true",
+				})
+			assert.Contains(t, pod.Spec.InitContainers[0].Env,
+				corev1.EnvVar{
+					Name:  "GIT_LFS",
+					Value: "true",
+				})
+		})
+
+		it("configures the prepare step with the cluster git trust", func() {
+			buildContext.GitKnownHosts = "github.com ssh-ed25519 AAAA"
+			buildContext.GitCABundle = "some-ca"
+
+			pod, err := build.BuildPod(config, buildContext)
+			require.NoError(t, err)
+
+			assert.Contains(t, pod.Spec.InitContainers[0].Env,
+				corev1.EnvVar{
+					Name:  "GIT_KNOWN_HOSTS",
+					Value: "github.com ssh-ed25519 AAAA",
+				})
+			assert.Contains(t, pod.Spec.InitContainers[0].Env,
+				corev1.EnvVar{
+					Name:  "GIT_CA_BUNDLE",
+					Value: "some-ca",
+				})
+		})
+
 		it("configures prepare with the blob source", func() {
 			build.Spec.Source.Git = nil
 			build.Spec.Source.Blob = &corev1alpha1.Blob{
@@ -736,6 +799,96 @@ func testBuildPod(t *testing.T, when spec.G, it spec.S) {
 				})
 		})
 
+		it("configures prepare with the archive limits for archive sources", func() {
+			buildContext.ArchiveMaxSize = 1024
+			buildContext.ArchiveMaxFiles = 10
+
+			pod, err := build.BuildPod(config, buildContext)
+			require.NoError(t, err)
+
+			for _, env := range pod.Spec.InitContainers[0].Env {
+				assert.NotContains(t, []string{"ARCHIVE_MAX_SIZE", "ARCHIVE_MAX_FILES"}, env.Name)
+			}
+
+			build.Spec.Source.Git = nil
+			build.Spec.Source.Blob = &corev1alpha1.Blob{
+				URL: "https://some-blobstore.example.com/some-blob.tar.zst",
+			}
+			pod, err = build.BuildPod(config, buildContext)
+			require.NoError(t, err)
+
+			assert.Subset(t, pod.Spec.InitContainers[0].Env, []corev1.EnvVar{
+				{Name: "ARCHIVE_MAX_SIZE", Value: "1024"},
+				{Name: "ARCHIVE_MAX_FILES", Value: "10"},
+			})
+		})
+
+		it("configures prepare with the blob digest and blob credentials", func() {
+			build.Spec.Source.Git = nil
+			build.Spec.Source.Blob = &corev1alpha1.Blob{
+				URL:    "https://some-blobstore.example.com/some-blob",
+				Digest: "sha256:b94d27b9934d3e08a52e52d7da7dabfac484efe37a5380ee9088f7ace2efcde9",
+			}
+			buildContext.Secrets = []corev1.Secret{
+				{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: "blob-basic",
+						Annotations: map[string]string{
+							buildapi.BlobSecretAnnotationPrefix: "https://some-blobstore.example.com",
+						},
+					},
+					Type: corev1.SecretTypeBasicAuth,
+				},
+				{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: "blob-bearer",
+						Annotations: map[string]string{
+							buildapi.BlobSecretAnnotationPrefix: "https://artifacts.example.com",
+						},
+					},
+					Data: map[string][]byte{
+						"token": []byte("some-token"),
+					},
+					Type: corev1.SecretTypeOpaque,
+				},
+				{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: "blob-without-token",
+						Annotations: map[string]string{
+							buildapi.BlobSecretAnnotationPrefix: "https://ignored.example.com",
+						},
+					},
+					Type: corev1.SecretTypeOpaque,
+				},
+			}
+
+			pod, err := build.BuildPod(config, buildContext)
+			require.NoError(t, err)
+
+			assert.Contains(t, pod.Spec.InitContainers[0].Env,
+				corev1.EnvVar{
+					Name:  "BLOB_DIGEST",
+					Value: "sha256:b94d27b9934d3e08a52e52d7da7dabfac484efe37a5380ee9088f7ace2efcde9",
+				})
+			assert.Subset(t, pod.Spec.InitContainers[0].Args, []string{
+				"-basic-blob=blob-basic=https://some-blobstore.example.com",
+				"-bearer-blob=blob-bearer=https://artifacts.example.com",
+			})
+			for _, arg := range pod.Spec.InitContainers[0].Args {
+				assert.NotContains(t, arg, "blob-without-token")
+			}
+			assert.Subset(t, pod.Spec.InitContainers[0].VolumeMounts, []corev1.VolumeMount{
+				{
+					Name:      "secret-volume-blob-basic",
+					MountPath: "/var/build-secrets/blob-basic",
+				},
+				{
+					Name:      "secret-volume-blob-bearer",
+					MountPath: "/var/build-secrets/blob-bearer",
+				},
+			})
+		})
+
 		it("configures prepare with the registry source and a secret volume when is imagePullSecrets provided", func() {
 			build.Spec.Source.Git = nil
 			build.Spec.Source.Blob = nil
@@ -774,6 +927,66 @@ func testBuildPod(t *testing.T, when spec.G, it spec.S) {
 				})
 		})
 
+		it("configures prepare with the resolved digest of the registry source", func() {
+			build.Spec.Source.Git = nil
+			build.Spec.Source.Blob = nil
+			build.Spec.Source.Registry = &corev1alpha1.Registry{
+				Image: "some-registry.io/some-image:latest",
+			}
+			build.Spec.ResolvedSource = &buildapi.BuildResolvedSource{
+				RegistryDigest: "sha256:b94d27b9934d3e08a52e52d7da7dabfac484efe37a5380ee9088f7ace2efcde9",
+			}
+			pod, err := build.BuildPod(config, buildContext)
+			require.NoError(t, err)
+
+			assert.Subset(t, pod.Spec.InitContainers[0].Env, []corev1.EnvVar{
+				{Name: "REGISTRY_IMAGE", Value: "some-registry.io/some-image:latest"},
+				{Name: "REGISTRY_DIGEST", Value: "sha256:b94d27b9934d3e08a52e52d7da7dabfac484efe37a5380ee9088f7ace2efcde9"},
+			})
+		})
+
+		it("configures prepare with the object store source and mounts its credentials secret", func() {
+			build.Spec.Source.Git = nil
+			build.Spec.Source.Blob = nil
+			build.Spec.Source.ObjectStore = &corev1alpha1.ObjectStore{
+				Endpoint:  "https://minio.example.com",
+				Bucket:    "some-bucket",
+				Key:       "app.tar.gz",
+				SecretRef: &corev1.LocalObjectReference{Name: "minio-creds"},
+				Version:   &corev1alpha1.ObjectVersion{VersionID: "some-version", ETag: `"some-etag"`},
+			}
+			pod, err := build.BuildPod(config, buildContext)
+			require.NoError(t, err)
+
+			assert.Contains(t, pod.Spec.InitContainers[0].VolumeMounts,
+				corev1.VolumeMount{
+					Name:      "object-store-credentials-dir",
+					MountPath: "/objectStoreCredentials",
+					ReadOnly:  true,
+				})
+
+			match := 0
+			for _, v := range pod.Spec.Volumes {
+				switch v.Name {
+				case "object-store-credentials-dir":
+					require.NotNil(t, v.Secret)
+					assert.Equal(t, "minio-creds", v.Secret.SecretName)
+					match++
+				case "registry-source-pull-secrets-dir":
+					assert.Nil(t, v.Secret)
+				}
+			}
+			assert.Equal(t, 1, match)
+
+			assert.Subset(t, pod.Spec.InitContainers[0].Env, []corev1.EnvVar{
+				{Name: "OBJECT_STORE_ENDPOINT", Value: "https://minio.example.com"},
+				{Name: "OBJECT_STORE_BUCKET", Value: "some-bucket"},
+				{Name: "OBJECT_STORE_KEY", Value: "app.tar.gz"},
+				{Name: "OBJECT_STORE_VERSION_ID", Value: "some-version"},
+				{Name: "OBJECT_STORE_ETAG", Value: `"some-etag"`},
+			})
+		})
+
 		it("configures detect step", func() {
 			pod, err := build.BuildPod(config, buildContext)
 			require.NoError(t, err)
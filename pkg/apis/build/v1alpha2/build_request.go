@@ -0,0 +1,81 @@
+package v1alpha2
+
+import (
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	corev1alpha1 "github.com/pivotal/kpack/pkg/apis/core/v1alpha1"
+)
+
+const (
+	BuildRequestLabel = "image.kpack.io/buildRequest"
+
+	BuildRequestSourceNotGit     = "SourceNotGit"
+	BuildRequestRevisionNotFound = "RevisionNotFound"
+)
+
+// Pending reports whether the build of the request has not been created yet.
+func (r *BuildRequest) Pending() bool {
+	return r.Status.BuildName == "" && !r.Status.GetCondition(corev1alpha1.ConditionSucceeded).IsFalse()
+}
+
+// BuildCreated reports the progress and result of build on the request.
+func (r *BuildRequest) BuildCreated(build *Build) {
+	r.Status.BuildName = build.Name
+	r.Status.LatestImage = ""
+	if build.IsSuccess() {
+		r.Status.LatestImage = build.BuiltImage()
+	}
+
+	condition := build.Status.GetCondition(corev1alpha1.ConditionSucceeded)
+	if condition == nil || condition.IsUnknown() {
+		r.Status.Conditions = corev1alpha1.Conditions{
+			{
+				Type:               corev1alpha1.ConditionSucceeded,
+				Status:             corev1.ConditionUnknown,
+				Message:            fmt.Sprintf("%s is executing", build.Name),
+				LastTransitionTime: corev1alpha1.VolatileTime{Inner: metav1.Now()},
+			},
+		}
+		return
+	}
+
+	r.Status.Conditions = corev1alpha1.Conditions{
+		{
+			Type:               corev1alpha1.ConditionSucceeded,
+			Status:             condition.Status,
+			Reason:             condition.Reason,
+			Message:            condition.Message,
+			LastTransitionTime: corev1alpha1.VolatileTime{Inner: metav1.Now()},
+		},
+	}
+}
+
+// Queued reports that the build of the request waits for reason, such as the
+// build queue or a rollback of the image.
+func (r *BuildRequest) Queued(reason, message string) {
+	r.Status.Conditions = corev1alpha1.Conditions{
+		{
+			Type:               corev1alpha1.ConditionSucceeded,
+			Status:             corev1.ConditionUnknown,
+			Reason:             reason,
+			Message:            message,
+			LastTransitionTime: corev1alpha1.VolatileTime{Inner: metav1.Now()},
+		},
+	}
+}
+
+// Failed reports that the build of the request cannot be created.
+func (r *BuildRequest) Failed(reason, message string) {
+	r.Status.Conditions = corev1alpha1.Conditions{
+		{
+			Type:               corev1alpha1.ConditionSucceeded,
+			Status:             corev1.ConditionFalse,
+			Reason:             reason,
+			Message:            message,
+			LastTransitionTime: corev1alpha1.VolatileTime{Inner: metav1.Now()},
+		},
+	}
+}
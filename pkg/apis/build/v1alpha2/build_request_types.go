@@ -0,0 +1,55 @@
+package v1alpha2
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	corev1alpha1 "github.com/pivotal/kpack/pkg/apis/core/v1alpha1"
+)
+
+// +genclient
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// +k8s:openapi-gen=true
+type BuildRequest struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   BuildRequestSpec   `json:"spec"`
+	Status BuildRequestStatus `json:"status,omitempty"`
+}
+
+// +k8s:openapi-gen=true
+type BuildRequestSpec struct {
+	// ImageName is the name of the image in the namespace of the request
+	// that is built.
+	ImageName string `json:"imageName"`
+	// Revision is the git revision that is built instead of the revision of
+	// the image.
+	Revision string `json:"revision"`
+	// Tag is built instead of the tags of the image when set.
+	Tag string `json:"tag,omitempty"`
+}
+
+// +k8s:openapi-gen=true
+type BuildRequestStatus struct {
+	corev1alpha1.Status `json:",inline"`
+	// BuildName is the name of the build of the request.
+	BuildName   string `json:"buildName,omitempty"`
+	LatestImage string `json:"latestImage,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// +k8s:openapi-gen=true
+type BuildRequestList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata"`
+
+	// +k8s:listType=atomic
+	Items []BuildRequest `json:"items"`
+}
+
+func (*BuildRequest) GetGroupVersionKind() schema.GroupVersionKind {
+	return SchemeGroupVersion.WithKind("BuildRequest")
+}
@@ -0,0 +1,50 @@
+package v1alpha2
+
+import (
+	"context"
+
+	"knative.dev/pkg/apis"
+	"knative.dev/pkg/kmp"
+
+	"github.com/pivotal/kpack/pkg/apis/validate"
+)
+
+func (r *BuildRequest) SetDefaults(context.Context) {
+}
+
+func (r *BuildRequest) Validate(ctx context.Context) *apis.FieldError {
+	return r.Spec.Validate(ctx).ViaField("spec")
+}
+
+func (rs *BuildRequestSpec) Validate(ctx context.Context) *apis.FieldError {
+	errs := validate.FieldNotEmpty(rs.ImageName, "imageName").
+		Also(validate.FieldNotEmpty(rs.Revision, "revision"))
+
+	if rs.Tag != "" {
+		errs = errs.Also(validate.Tag(rs.Tag))
+	}
+
+	return errs.Also(rs.validateImmutableFields(ctx))
+}
+
+func (rs *BuildRequestSpec) validateImmutableFields(ctx context.Context) *apis.FieldError {
+	if !apis.IsInUpdate(ctx) {
+		return nil
+	}
+
+	original := apis.GetBaseline(ctx).(*BuildRequest)
+	if diff, err := kmp.ShortDiff(&original.Spec, rs); err != nil {
+		return &apis.FieldError{
+			Message: "Failed to diff BuildRequest",
+			Paths:   []string{"spec"},
+			Details: err.Error(),
+		}
+	} else if diff != "" {
+		return &apis.FieldError{
+			Message: "Immutable fields changed (-old +new)",
+			Paths:   []string{"spec"},
+			Details: diff,
+		}
+	}
+	return nil
+}
@@ -0,0 +1,60 @@
+package v1alpha2
+
+import (
+	"context"
+	"testing"
+
+	"github.com/sclevine/spec"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"knative.dev/pkg/apis"
+)
+
+func TestBuildRequestValidation(t *testing.T) {
+	spec.Run(t, "Build Request Validation", testBuildRequestValidation)
+}
+
+func testBuildRequestValidation(t *testing.T, when spec.G, it spec.S) {
+	ctx := context.TODO()
+	request := &BuildRequest{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "hotfix",
+		},
+		Spec: BuildRequestSpec{
+			ImageName: "some-image",
+			Revision:  "v1.2.3",
+			Tag:       "registry.io/app:hotfix",
+		},
+	}
+
+	when("Validate", func() {
+		it("returns nil on no validation error", func() {
+			assert.Nil(t, request.Validate(ctx))
+
+			request.Spec.Tag = ""
+			assert.Nil(t, request.Validate(ctx))
+		})
+
+		it("requires an image name and revision", func() {
+			request.Spec.ImageName = ""
+			request.Spec.Revision = ""
+
+			assert.Equal(t, apis.ErrMissingField("spec.imageName", "spec.revision").Error(), request.Validate(ctx).Error())
+		})
+
+		it("validates the tag", func() {
+			request.Spec.Tag = "Invalid/Tag"
+
+			assert.Equal(t, apis.ErrInvalidValue("Invalid/Tag", "spec.tag").Error(), request.Validate(ctx).Error())
+		})
+
+		it("does not allow the spec to change", func() {
+			original := request.DeepCopy()
+			request.Spec.Revision = "v1.2.4"
+
+			err := request.Validate(apis.WithinUpdate(ctx, original))
+			assert.NotNil(t, err)
+			assert.Contains(t, err.Error(), "Immutable fields changed (-old +new)")
+		})
+	})
+}
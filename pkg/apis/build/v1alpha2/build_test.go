@@ -86,3 +86,71 @@ func TestBuildLifecycle(t *testing.T) {
 		},
 	}))
 }
+
+func TestIsRetryable(t *testing.T) {
+	failedIn := func(steps ...string) *Build {
+		build := &Build{
+			Status: BuildStatus{
+				Status: corev1alpha1.Status{
+					Conditions: corev1alpha1.Conditions{
+						{
+							Type:   corev1alpha1.ConditionSucceeded,
+							Status: corev1.ConditionFalse,
+						},
+					},
+				},
+				StepsCompleted: steps,
+			},
+		}
+		for i := range steps {
+			exitCode := int32(0)
+			if i == len(steps)-1 {
+				exitCode = 1
+			}
+			build.Status.StepStates = append(build.Status.StepStates, corev1.ContainerState{
+				Terminated: &corev1.ContainerStateTerminated{ExitCode: exitCode},
+			})
+		}
+		return build
+	}
+
+	require.True(t, failedIn("prepare").IsRetryable())
+	require.True(t, failedIn("prepare", "analyze", "detect", "restore", "build", "export").IsRetryable())
+	require.False(t, failedIn("prepare", "analyze", "detect").IsRetryable())
+	require.False(t, failedIn("prepare", "analyze", "detect", "restore", "build").IsRetryable())
+
+	timedOut := failedIn("prepare", "analyze")
+	timedOut.Status.Conditions[0].Reason = BuildTimedOut
+	require.False(t, timedOut.IsRetryable())
+
+	cancelled := failedIn("prepare", "analyze")
+	cancelled.Status.Conditions[0].Reason = BuildCancelled
+	require.False(t, cancelled.IsRetryable())
+
+	require.False(t, (&Build{}).IsRetryable())
+}
+
+func TestIsRunning(t *testing.T) {
+	build := &Build{
+		Status: BuildStatus{
+			Status: corev1alpha1.Status{
+				Conditions: corev1alpha1.Conditions{
+					{
+						Type:   corev1alpha1.ConditionSucceeded,
+						Status: corev1.ConditionUnknown,
+					},
+				},
+			},
+		},
+	}
+	require.True(t, build.IsRunning())
+	require.False(t, build.CancelRequested())
+
+	build.Annotations = map[string]string{BuildCancelAnnotation: "true"}
+	require.True(t, build.IsRunning())
+	require.True(t, build.CancelRequested())
+
+	build.Status.Conditions[0].Status = corev1.ConditionFalse
+	build.Status.Conditions[0].Reason = BuildCancelled
+	require.False(t, build.IsRunning())
+}
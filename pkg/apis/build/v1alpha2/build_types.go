@@ -50,7 +50,10 @@ type BuildSpec struct {
 	Builder            corev1alpha1.BuildBuilderSpec `json:"builder,omitempty"`
 	ServiceAccountName string                        `json:"serviceAccountName,omitempty"`
 	Source             corev1alpha1.SourceConfig     `json:"source"`
-	Cache              *BuildCacheConfig             `json:"cache,omitempty"`
+	// ResolvedSource is what the SourceResolver resolved Source to beyond
+	// its revision, such as the tag selected by a tag constraint.
+	ResolvedSource *BuildResolvedSource `json:"resolvedSource,omitempty"`
+	Cache          *BuildCacheConfig    `json:"cache,omitempty"`
 	// +listType
 	Services Services `json:"services,omitempty"`
 	// +listType
@@ -64,12 +67,15 @@ type BuildSpec struct {
 	Cosign                *CosignConfig               `json:"cosign,omitempty"`
 	DefaultProcess        string                      `json:"defaultProcess,omitempty"`
 	// +listType
-	Tolerations       []corev1.Toleration `json:"tolerations,omitempty"`
-	NodeSelector      map[string]string   `json:"nodeSelector,omitempty"`
-	Affinity          *corev1.Affinity    `json:"affinity,omitempty"`
-	RuntimeClassName  *string             `json:"runtimeClassName,omitempty"`
-	SchedulerName     string              `json:"schedulerName,omitempty"`
-	PriorityClassName string              `json:"priorityClassName,omitempty"`
+	Tolerations           []corev1.Toleration `json:"tolerations,omitempty"`
+	NodeSelector          map[string]string   `json:"nodeSelector,omitempty"`
+	Affinity              *corev1.Affinity    `json:"affinity,omitempty"`
+	RuntimeClassName      *string             `json:"runtimeClassName,omitempty"`
+	SchedulerName         string              `json:"schedulerName,omitempty"`
+	PriorityClassName     string              `json:"priorityClassName,omitempty"`
+	ActiveDeadlineSeconds *int64              `json:"activeDeadlineSeconds,omitempty"`
+	// +listType
+	Promotions []ImagePromotion `json:"promotions,omitempty"`
 }
 
 func (bs *BuildSpec) NeedVolumeCache() bool {
@@ -125,6 +131,57 @@ type BuildStatus struct {
 	StepStates []corev1.ContainerState `json:"stepStates,omitempty"`
 	// +listType
 	StepsCompleted []string `json:"stepsCompleted,omitempty"`
+	// InterruptedStep is the step that was running when the build was
+	// cancelled.
+	InterruptedStep string `json:"interruptedStep,omitempty"`
+	// +listType
+	Promotions []BuildPromotionStatus `json:"promotions,omitempty"`
+	// Steps are the timings and exit codes of the steps of the build that
+	// started, in the order they run.
+	// +listType
+	Steps []BuildStepStatus `json:"steps,omitempty"`
+	// QueueDuration is the time from the creation of the build, or from
+	// when its image was queued by the concurrent build limits, until its
+	// first step started.
+	QueueDuration *metav1.Duration `json:"queueDuration,omitempty"`
+	// BuildDuration is the time from the start of the first step of the
+	// build until its last step finished.
+	BuildDuration *metav1.Duration `json:"buildDuration,omitempty"`
+}
+
+// +k8s:openapi-gen=true
+type BuildResolvedSource struct {
+	// GitTag is the tag selected by the tag constraint of a git source.
+	GitTag string `json:"gitTag,omitempty"`
+	// BlobFingerprint identifies the contents of a polled blob source.
+	BlobFingerprint *corev1alpha1.BlobFingerprint `json:"blobFingerprint,omitempty"`
+	// RegistryDigest is the digest the source image was resolved to.
+	RegistryDigest string `json:"registryDigest,omitempty"`
+}
+
+// +k8s:openapi-gen=true
+type BuildStepStatus struct {
+	Name       string           `json:"name"`
+	StartedAt  *metav1.Time     `json:"startedAt,omitempty"`
+	FinishedAt *metav1.Time     `json:"finishedAt,omitempty"`
+	Duration   *metav1.Duration `json:"duration,omitempty"`
+	ExitCode   *int32           `json:"exitCode,omitempty"`
+}
+
+// +k8s:openapi-gen=true
+type BuildPromotionStatus struct {
+	Repository string `json:"repository"`
+	// Image is the promoted image with its digest.
+	Image string `json:"image,omitempty"`
+	// Status is True when the image is promoted, Unknown while a failed
+	// promotion is retried and False once it is no longer retried.
+	Status  corev1.ConditionStatus `json:"status"`
+	Reason  string                 `json:"reason,omitempty"`
+	Message string                 `json:"message,omitempty"`
+	// Attempts is how many times the promotion has been attempted.
+	Attempts int64 `json:"attempts,omitempty"`
+	// LastAttemptTime is when the promotion was last attempted.
+	LastAttemptTime *metav1.Time `json:"lastAttemptTime,omitempty"`
 }
 
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
@@ -25,6 +25,11 @@ func (b *Build) Validate(ctx context.Context) *apis.FieldError {
 }
 
 func (bs *BuildSpec) Validate(ctx context.Context) *apis.FieldError {
+	var tag string
+	if len(bs.Tags) > 0 {
+		tag = bs.Tags[0]
+	}
+
 	return validate.ListNotEmpty(bs.Tags, "tags").
 		Also(validate.Tags(bs.Tags, "tags")).
 		Also(bs.Cache.Validate(ctx).ViaField("cache")).
@@ -35,7 +40,16 @@ func (bs *BuildSpec) Validate(ctx context.Context) *apis.FieldError {
 		Also(bs.validateImmutableFields(ctx)).
 		Also(validateCnbBindings(ctx, bs.CNBBindings).ViaField("cnbBindings")).
 		Also(bs.validateNodeSelector(ctx)).
-		Also(validateNotary(ctx, bs.Notary).ViaField("notary"))
+		Also(validateNotary(ctx, bs.Notary).ViaField("notary")).
+		Also(bs.validateActiveDeadlineSeconds()).
+		Also(validatePromotions(tag, bs.Promotions).ViaField("promotions"))
+}
+
+func (bs *BuildSpec) validateActiveDeadlineSeconds() *apis.FieldError {
+	if bs.ActiveDeadlineSeconds != nil && *bs.ActiveDeadlineSeconds < 1 {
+		return apis.ErrInvalidValue(*bs.ActiveDeadlineSeconds, "activeDeadlineSeconds")
+	}
+	return nil
 }
 
 func resourceCreatedByKpackController(info *authv1.UserInfo) bool {
@@ -108,7 +108,7 @@ func testBuildValidation(t *testing.T, when spec.G, it spec.S) {
 		it("missing source", func() {
 			build.Spec.Source = corev1alpha1.SourceConfig{}
 
-			assertValidationError(build, context.TODO(), apis.ErrMissingOneOf("git", "blob", "registry").ViaField("spec", "source"))
+			assertValidationError(build, context.TODO(), apis.ErrMissingOneOf("git", "blob", "registry", "objectStore").ViaField("spec", "source"))
 		})
 
 		it("validates git url", func() {
@@ -143,6 +143,13 @@ func testBuildValidation(t *testing.T, when spec.G, it spec.S) {
 			assertValidationError(build, context.TODO(), apis.ErrMissingField("image").ViaField("spec", "source", "registry"))
 		})
 
+		it("validates active deadline seconds is positive", func() {
+			deadline := int64(0)
+			build.Spec.ActiveDeadlineSeconds = &deadline
+
+			assertValidationError(build, context.TODO(), apis.ErrInvalidValue(deadline, "spec.activeDeadlineSeconds"))
+		})
+
 		it("validates valid lastBuilt Image", func() {
 			build.Spec.LastBuild = &LastBuild{Image: "invalid@@"}
 
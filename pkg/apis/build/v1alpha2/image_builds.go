@@ -11,6 +11,7 @@ import (
 	"knative.dev/pkg/kmeta"
 
 	corev1alpha1 "github.com/pivotal/kpack/pkg/apis/core/v1alpha1"
+	"github.com/pivotal/kpack/pkg/cron"
 )
 
 const (
@@ -22,11 +23,23 @@ const (
 	BuildChangesAnnotation = "image.kpack.io/buildChanges"
 	BuildNeededAnnotation  = "image.kpack.io/additionalBuildNeeded"
 
+	BuildRetryAttemptAnnotation = "image.kpack.io/retryAttempt"
+	// BuildQueuedAtAnnotation is the RFC3339 time the image of a build was
+	// queued before the build was admitted by the concurrent build limits.
+	BuildQueuedAtAnnotation = "image.kpack.io/queuedAt"
+
 	BuildReasonConfig    = "CONFIG"
 	BuildReasonCommit    = "COMMIT"
 	BuildReasonBuildpack = "BUILDPACK"
 	BuildReasonStack     = "STACK"
 	BuildReasonTrigger   = "TRIGGER"
+	BuildReasonBlob      = "BLOB"
+	BuildReasonObject    = "OBJECT"
+	BuildReasonRegistry  = "REGISTRY"
+	BuildReasonRetry     = "RETRY"
+	BuildReasonScheduled = "SCHEDULED"
+	BuildReasonManual    = "MANUAL"
+	BuildReasonRollback  = "ROLLBACK"
 )
 
 type BuildReason string
@@ -51,10 +64,11 @@ func (im *Image) Build(sourceResolver *SourceResolver, builder BuilderResource,
 			}),
 		},
 		Spec: BuildSpec{
-			Tags:                  im.generateTags(buildNumber),
+			Tags:                  im.generateTags(buildNumber, sourceResolver, builder),
 			Builder:               builder.BuildBuilderSpec(),
 			ServiceAccountName:    im.Spec.ServiceAccountName,
 			Source:                sourceResolver.SourceConfig(),
+			ResolvedSource:        buildResolvedSource(sourceResolver.Status.Source),
 			Cache:                 im.getBuildCacheConfig(),
 			Services:              im.Services(),
 			CNBBindings:           im.CNBBindings(),
@@ -71,6 +85,56 @@ func (im *Image) Build(sourceResolver *SourceResolver, builder BuilderResource,
 			RuntimeClassName:      im.RuntimeClassName(),
 			SchedulerName:         im.SchedulerName(),
 			PriorityClassName:     priorityClass,
+			ActiveDeadlineSeconds: im.ActiveDeadlineSeconds(),
+			Promotions:            im.Spec.Promotions,
+		},
+	}
+}
+
+// RetryBuild returns a build that retries the failed latestBuild with its
+// resolved source and builder.
+func (im *Image) RetryBuild(sourceResolver *SourceResolver, builder BuilderResource, latestBuild *Build, changes string, nextBuildNumber int64, priorityClass string) *Build {
+	build := im.Build(sourceResolver, builder, latestBuild, BuildReasonRetry, changes, nextBuildNumber, priorityClass)
+	build.Annotations[BuildRetryAttemptAnnotation] = strconv.FormatInt(latestBuild.RetryAttempt()+1, 10)
+	build.Spec.Builder = latestBuild.Spec.Builder
+	build.Spec.Source = latestBuild.Spec.Source
+	build.Spec.ResolvedSource = latestBuild.Spec.ResolvedSource
+	return build
+}
+
+// ManualBuild returns a build of the revision of request, and of its tag when
+// set, with the configuration of the image. The image must have a git source
+// and resolved is the git source resolved at the revision of request.
+func (im *Image) ManualBuild(builder BuilderResource, latestBuild *Build, request *BuildRequest, resolved corev1alpha1.ResolvedSourceConfig, changes string, nextBuildNumber int64, priorityClass string) *Build {
+	sourceResolver := im.RequestedSourceResolver(request)
+	sourceResolver.Status.Source = resolved
+	build := im.Build(sourceResolver, builder, latestBuild, BuildReasonManual, changes, nextBuildNumber, priorityClass)
+	build.Labels[BuildRequestLabel] = request.Name
+	if request.Spec.Tag != "" {
+		build.Spec.Tags = []string{request.Spec.Tag}
+	}
+	return build
+}
+
+// RequestedSourceResolver returns a source resolver of the git source of the
+// image at the revision of request. It is never created in the cluster.
+func (im *Image) RequestedSourceResolver(request *BuildRequest) *SourceResolver {
+	git := im.Spec.Source.Git
+	return &SourceResolver{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      request.Name,
+			Namespace: im.Namespace,
+		},
+		Spec: SourceResolverSpec{
+			ServiceAccountName: im.Spec.ServiceAccountName,
+			Source: corev1alpha1.SourceConfig{
+				Git: &corev1alpha1.Git{
+					URL:      git.URL,
+					Revision: request.Spec.Revision,
+					Fetch:    git.Fetch,
+				},
+				SubPath: im.Spec.Source.SubPath,
+			},
 		},
 	}
 }
@@ -83,6 +147,14 @@ func (is *ImageSpec) NeedRegistryCache() bool {
 	return is.Cache != nil && is.Cache.Registry != nil && is.Cache.Registry.Tag != ""
 }
 
+func (s *ImageSchedule) CronSchedule() (*cron.Schedule, error) {
+	location, err := time.LoadLocation(s.TimeZone)
+	if err != nil {
+		return nil, err
+	}
+	return cron.Parse(s.Cron, location)
+}
+
 func (im *Image) getBuildCacheConfig() *BuildCacheConfig {
 	buildCacheConfig := BuildCacheConfig{}
 
@@ -97,12 +169,31 @@ func (im *Image) getBuildCacheConfig() *BuildCacheConfig {
 	return &buildCacheConfig
 }
 
+// buildResolvedSource returns the parts of source that are not in its
+// SourceConfig and are compared with the next resolved source to find changes.
+func buildResolvedSource(source corev1alpha1.ResolvedSourceConfig) *BuildResolvedSource {
+	var resolved BuildResolvedSource
+	switch {
+	case source.Git != nil:
+		resolved.GitTag = source.Git.Tag
+	case source.Blob != nil:
+		resolved.BlobFingerprint = source.Blob.Fingerprint.DeepCopy()
+	case source.Registry != nil:
+		resolved.RegistryDigest = source.Registry.Digest
+	}
+
+	if resolved == (BuildResolvedSource{}) {
+		return nil
+	}
+	return &resolved
+}
+
 func lastBuild(latestBuild *Build) *LastBuild {
 	if latestBuild == nil {
 		return nil
 	}
 
-	if latestBuild.IsFailure() {
+	if !latestBuild.IsSuccess() {
 		return latestBuild.Spec.LastBuild
 	}
 
@@ -114,12 +205,24 @@ func lastBuild(latestBuild *Build) *LastBuild {
 }
 
 func (im *Image) LatestForImage(build *Build) string {
+	if im.RolledBackPast(build) {
+		return im.Status.Rollback.Image
+	}
 	if build.IsSuccess() {
 		return build.BuiltImage()
 	}
 	return im.Status.LatestImage
 }
 
+// RolledBackPast returns whether the tags of the image were rolled back after
+// build was created and so still point at the image of the rollback.
+func (im *Image) RolledBackPast(build *Build) bool {
+	if im.Status.Rollback == nil {
+		return false
+	}
+	return build == nil || build.CreationTimestamp.Before(&im.Status.Rollback.RolledBackAt)
+}
+
 func (im *Image) Services() Services {
 	if im.Spec.Build == nil {
 		return nil
@@ -184,6 +287,14 @@ func (im *Image) SchedulerName() string {
 	return im.Spec.Build.SchedulerName
 }
 
+func (im *Image) ActiveDeadlineSeconds() *int64 {
+	if im.Spec.Build == nil || im.Spec.Build.Timeout == nil {
+		return nil
+	}
+	seconds := int64(im.Spec.Build.Timeout.Duration.Seconds())
+	return &seconds
+}
+
 func (im *Image) CacheName() string {
 	return kmeta.ChildName(im.Name, "-cache")
 }
@@ -230,8 +341,8 @@ func (im *Image) SourceResolver() *SourceResolver {
 	}
 }
 
-func (im *Image) generateTags(buildNumber string) []string {
-	if im.disableAdditionalImageNames() {
+func (im *Image) generateTags(buildNumber string, sourceResolver *SourceResolver, builder BuilderResource) []string {
+	if im.disableAdditionalImageNames() && len(im.Spec.TagTemplates) == 0 {
 		return append([]string{im.Spec.Tag}, im.Spec.AdditionalTags...)
 	}
 	now := time.Now()
@@ -242,16 +353,18 @@ func (im *Image) generateTags(buildNumber string) []string {
 		// in this case we can just ignore any additional image names
 		return nil
 	}
-
-	tagName := tag.TagStr() + "-"
-	if tagName == "latest-" {
-		tagName = ""
+	repository := tag.RegistryStr() + "/" + tag.RepositoryStr()
+
+	tags := []string{im.Spec.Tag}
+	if !im.disableAdditionalImageNames() {
+		tagName := tag.TagStr() + "-"
+		if tagName == "latest-" {
+			tagName = ""
+		}
+		tags = append(tags, repository+":"+tagName+"b"+buildNumber+"."+now.Format("20060102")+"."+fmt.Sprintf("%02d%02d%02d", now.Hour(), now.Minute(), now.Second()))
 	}
-	return append([]string{
-		im.Spec.Tag,
-		tag.RegistryStr() + "/" + tag.RepositoryStr() + ":" + tagName + "b" + buildNumber + "." + now.Format("20060102") + "." + fmt.Sprintf("%02d%02d%02d", now.Hour(), now.Minute(), now.Second())},
-		im.Spec.AdditionalTags...,
-	)
+	tags = append(tags, im.Spec.AdditionalTags...)
+	return append(tags, renderTagTemplates(repository, im.Spec.TagTemplates, newTagTemplateData(buildNumber, now, sourceResolver, builder))...)
 }
 
 func (im *Image) generateBuildName(buildNumber string) string {
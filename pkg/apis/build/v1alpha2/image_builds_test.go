@@ -2,6 +2,7 @@ package v1alpha2
 
 import (
 	"testing"
+	"time"
 
 	"github.com/sclevine/spec"
 	"github.com/stretchr/testify/assert"
@@ -148,6 +149,18 @@ func testImageBuilds(t *testing.T, when spec.G, it spec.S) {
 			assert.Nil(t, build.Spec.Source.Registry)
 		})
 
+		it("records the resolved source", func() {
+			sourceResolver.Status.Source.Git.Tag = "v1.2.3"
+
+			build := image.Build(sourceResolver, builder, latestBuild, "", "", 27, "")
+			assert.Equal(t, &BuildResolvedSource{GitTag: "v1.2.3"}, build.Spec.ResolvedSource)
+		})
+
+		it("does not record a resolved source without a tag, fingerprint or digest", func() {
+			build := image.Build(sourceResolver, builder, latestBuild, "", "", 27, "")
+			assert.Nil(t, build.Spec.ResolvedSource)
+		})
+
 		it("sets blob url when image source is blob", func() {
 			sourceResolver.Status.Source = corev1alpha1.ResolvedSourceConfig{
 				Blob: &corev1alpha1.ResolvedBlobSource{
@@ -225,6 +238,61 @@ func testImageBuilds(t *testing.T, when spec.G, it spec.S) {
 			})
 		})
 
+		when("tag templates are provided", func() {
+			sourceResolver.Spec.Source = corev1alpha1.SourceConfig{
+				Git: &corev1alpha1.Git{
+					URL:      "https://some.git/url",
+					Revision: "feature/some-branch",
+				},
+			}
+			sourceResolver.Status.Source = corev1alpha1.ResolvedSourceConfig{
+				Git: &corev1alpha1.ResolvedGitSource{
+					URL:      "https://some.git/url",
+					Revision: "0123456789abcdef",
+					Type:     corev1alpha1.Branch,
+				},
+			}
+			builder.LatestStackID = "io.buildpacks.stacks.jammy"
+
+			it("renders the templates as tags of the image repository", func() {
+				image.Spec.Tag = "gcr.io/imagename/foo:test"
+				image.Spec.TagTemplates = []string{
+					"{{.Branch}}-{{.ShortSHA}}",
+					"{{.Revision}}",
+					"{{.StackID}}-b{{.BuildNumber}}",
+					"{{.Timestamp}}",
+				}
+
+				build := image.Build(sourceResolver, builder, latestBuild, "", "", 12, "")
+				require.Len(t, build.Spec.Tags, 6)
+				assert.Equal(t, "gcr.io/imagename/foo:feature-some-branch-0123456", build.Spec.Tags[2])
+				assert.Equal(t, "gcr.io/imagename/foo:0123456789abcdef", build.Spec.Tags[3])
+				assert.Equal(t, "gcr.io/imagename/foo:io.buildpacks.stacks.jammy-b12", build.Spec.Tags[4])
+				assert.Regexp(t, "gcr.io/imagename/foo:\\d{8}\\.\\d{6}", build.Spec.Tags[5])
+			})
+
+			it("renders git tags", func() {
+				sourceResolver.Status.Source.Git.Type = corev1alpha1.Tag
+				sourceResolver.Spec.Source.Git.Revision = "v1.2.3"
+				image.Spec.Tag = "gcr.io/imagename/foo"
+				image.Spec.ImageTaggingStrategy = corev1alpha1.None
+				image.Spec.TagTemplates = []string{"{{.SemverTag}}", "{{.Tag}}"}
+
+				build := image.Build(sourceResolver, builder, latestBuild, "", "", 1, "")
+				assert.Equal(t, []string{"gcr.io/imagename/foo", "gcr.io/imagename/foo:1.2.3", "gcr.io/imagename/foo:v1.2.3"}, build.Spec.Tags)
+			})
+
+			it("skips templates that render to an empty tag", func() {
+				sourceResolver.Status.Source.Git.Type = corev1alpha1.Commit
+				image.Spec.Tag = "gcr.io/imagename/foo"
+				image.Spec.ImageTaggingStrategy = corev1alpha1.None
+				image.Spec.TagTemplates = []string{"{{.Branch}}", "{{.SemverTag}}"}
+
+				build := image.Build(sourceResolver, builder, latestBuild, "", "", 1, "")
+				assert.Equal(t, []string{"gcr.io/imagename/foo"}, build.Spec.Tags)
+			})
+		})
+
 		it("generates a build name less than 64 characters", func() {
 			image.Name = "long-image-name-1234567890-1234567890-1234567890-1234567890-1234567890"
 			build := image.Build(sourceResolver, builder, latestBuild, "", "", 1, "")
@@ -232,6 +300,17 @@ func testImageBuilds(t *testing.T, when spec.G, it spec.S) {
 			assert.True(t, len(build.Name) < 64, "expected %s to be less than 64", build.Name)
 		})
 
+		it("adds the promotions to the build spec", func() {
+			image.Spec.Promotions = []ImagePromotion{
+				{
+					Repository:       "dr.registry.io/app",
+					ImagePullSecrets: []corev1.LocalObjectReference{{Name: "dr-secret"}},
+				},
+			}
+			build := image.Build(sourceResolver, builder, latestBuild, "", "", 1, "")
+			assert.Equal(t, image.Spec.Promotions, build.Spec.Promotions)
+		})
+
 		it("adds the env vars to the build spec", func() {
 			image.Spec.Build = &ImageBuild{
 				Env: []corev1.EnvVar{
@@ -289,6 +368,19 @@ func testImageBuilds(t *testing.T, when spec.G, it spec.S) {
 			assert.Equal(t, image.Spec.Build.Affinity, build.Spec.Affinity)
 		})
 
+		it("sets the active deadline from the build timeout", func() {
+			build := image.Build(sourceResolver, builder, latestBuild, "", "", 1, "")
+			assert.Nil(t, build.Spec.ActiveDeadlineSeconds)
+
+			image.Spec.Build = &ImageBuild{
+				Timeout: &metav1.Duration{Duration: 90 * time.Minute},
+			}
+
+			build = image.Build(sourceResolver, builder, latestBuild, "", "", 1, "")
+			require.NotNil(t, build.Spec.ActiveDeadlineSeconds)
+			assert.Equal(t, int64(5400), *build.Spec.ActiveDeadlineSeconds)
+		})
+
 		it("sets the notary config when present", func() {
 			image.Spec.Notary = &corev1alpha1.NotaryConfig{
 				V1: &corev1alpha1.NotaryV1Config{
@@ -343,6 +435,117 @@ func testImageBuilds(t *testing.T, when spec.G, it spec.S) {
 			assert.Equal(t, image.Spec.Cosign, build.Spec.Cosign)
 		})
 	})
+
+	when("#retryBuild", func() {
+		latestBuild.Annotations = map[string]string{BuildRetryAttemptAnnotation: "1"}
+		latestBuild.Spec.Source = corev1alpha1.SourceConfig{
+			Git: &corev1alpha1.Git{
+				URL:      "https://some.git/url",
+				Revision: "previous-revision",
+			},
+		}
+		latestBuild.Spec.ResolvedSource = &BuildResolvedSource{GitTag: "v1.0.0"}
+
+		sourceResolver.Status.Source = corev1alpha1.ResolvedSourceConfig{
+			Git: &corev1alpha1.ResolvedGitSource{
+				URL:      "https://some.git/url",
+				Revision: "new-revision",
+				Type:     corev1alpha1.Commit,
+			},
+		}
+
+		it("reuses the source and builder of the latest build", func() {
+			builder.LatestImage = "some/builder@sha256:new-digest"
+
+			build := image.RetryBuild(sourceResolver, builder, latestBuild, "some-changes", 2, BuildPriorityClassLow)
+			assert.Equal(t, latestBuild.Spec.Source, build.Spec.Source)
+			assert.Equal(t, latestBuild.Spec.ResolvedSource, build.Spec.ResolvedSource)
+			assert.Equal(t, latestBuild.Spec.Builder, build.Spec.Builder)
+		})
+
+		it("increments the retry attempt annotation", func() {
+			build := image.RetryBuild(sourceResolver, builder, latestBuild, "some-changes", 2, BuildPriorityClassLow)
+			assert.Equal(t, "2", build.Annotations[BuildRetryAttemptAnnotation])
+			assert.Equal(t, BuildReasonRetry, build.Annotations[BuildReasonAnnotation])
+			assert.Equal(t, int64(2), build.RetryAttempt())
+		})
+	})
+
+	when("#manualBuild", func() {
+		image.Spec.Source = corev1alpha1.SourceConfig{
+			Git: &corev1alpha1.Git{
+				URL:      "https://some.git/url",
+				Revision: "main",
+				Fetch:    &corev1alpha1.GitFetchOptions{Sparse: true},
+			},
+			SubPath: "some/path",
+		}
+		request := &BuildRequest{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "hotfix",
+			},
+			Spec: BuildRequestSpec{
+				ImageName: "image-name",
+				Revision:  "v1.2.3",
+			},
+		}
+		resolved := corev1alpha1.ResolvedSourceConfig{
+			Git: &corev1alpha1.ResolvedGitSource{
+				URL:      "https://some.git/url",
+				Revision: "0f4b1a6a3b2c1d4e5f60718293a4b5c6d7e8f901",
+				Type:     corev1alpha1.Tag,
+				SubPath:  "some/path",
+				Fetch:    &corev1alpha1.GitFetchOptions{Sparse: true},
+			},
+		}
+
+		it("resolves the requested revision of the git source of the image", func() {
+			sourceResolver := image.RequestedSourceResolver(request)
+
+			assert.Equal(t, "hotfix", sourceResolver.Name)
+			assert.Equal(t, image.Namespace, sourceResolver.Namespace)
+			assert.Equal(t, image.Spec.ServiceAccountName, sourceResolver.Spec.ServiceAccountName)
+			assert.Equal(t, corev1alpha1.SourceConfig{
+				Git: &corev1alpha1.Git{
+					URL:      "https://some.git/url",
+					Revision: "v1.2.3",
+					Fetch:    &corev1alpha1.GitFetchOptions{Sparse: true},
+				},
+				SubPath: "some/path",
+			}, sourceResolver.Spec.Source)
+		})
+
+		it("builds the resolved commit of the requested revision", func() {
+			build := image.ManualBuild(builder, latestBuild, request, resolved, "some-changes", 3, "")
+
+			assert.Equal(t, corev1alpha1.SourceConfig{
+				Git: &corev1alpha1.Git{
+					URL:      "https://some.git/url",
+					Revision: "0f4b1a6a3b2c1d4e5f60718293a4b5c6d7e8f901",
+					Fetch:    &corev1alpha1.GitFetchOptions{Sparse: true},
+				},
+				SubPath: "some/path",
+			}, build.Spec.Source)
+			assert.Equal(t, BuildReasonManual, build.Annotations[BuildReasonAnnotation])
+			assert.Equal(t, "hotfix", build.Labels[BuildRequestLabel])
+			assert.Equal(t, "image-name-build-3", build.Name)
+			assert.Equal(t, []string{"some/image"}, build.Spec.Tags[:1])
+		})
+
+		it("renders tag templates with the resolved commit", func() {
+			image.Spec.TagTemplates = []string{"{{.Revision}}"}
+
+			build := image.ManualBuild(builder, latestBuild, request, resolved, "some-changes", 3, "")
+			assert.Contains(t, build.Spec.Tags, "index.docker.io/some/image:0f4b1a6a3b2c1d4e5f60718293a4b5c6d7e8f901")
+		})
+
+		it("builds only the tag of the request when set", func() {
+			request.Spec.Tag = "some/image:hotfix"
+
+			build := image.ManualBuild(builder, latestBuild, request, resolved, "some-changes", 3, "")
+			assert.Equal(t, []string{"some/image:hotfix"}, build.Spec.Tags)
+		})
+	})
 }
 
 type TestBuilderResource struct {
@@ -351,6 +554,7 @@ type TestBuilderResource struct {
 	ImagePullSecrets []corev1.LocalObjectReference
 	LatestImage      string
 	LatestRunImage   string
+	LatestStackID    string
 	Name             string
 }
 
@@ -373,6 +577,10 @@ func (t TestBuilderResource) RunImage() string {
 	return t.LatestRunImage
 }
 
+func (t TestBuilderResource) StackID() string {
+	return t.LatestStackID
+}
+
 func (t TestBuilderResource) GetName() string {
 	return t.Name
 }
@@ -12,6 +12,10 @@ import (
 const (
 	BuilderNotFound = "BuilderNotFound"
 	BuilderNotReady = "BuilderNotReady"
+	BuildQueued     = "BuildQueued"
+	BuildPending    = "BuildPending"
+	RolledBack      = "RolledBack"
+	RollbackFailed  = "RollbackFailed"
 )
 
 func (im *Image) BuilderNotFound() corev1alpha1.Conditions {
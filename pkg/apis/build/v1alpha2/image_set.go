@@ -0,0 +1,115 @@
+package v1alpha2
+
+import (
+	"path"
+	"regexp"
+	"strings"
+	"text/template"
+
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"knative.dev/pkg/kmeta"
+)
+
+const (
+	ImageSetLabel = "imageset.kpack.io/imageSet"
+
+	DiscoveryFailed = "DiscoveryFailed"
+	ImagesNotReady  = "ImagesNotReady"
+	ImageConflict   = "ImageConflict"
+)
+
+// imageSetTemplateData are the values available to the tag of the template
+// of an ImageSet.
+type imageSetTemplateData struct {
+	// Name is the name of the parameters of the image.
+	Name string
+	// SubPath is the source subPath of the image.
+	SubPath string
+}
+
+var invalidImageSetNameChars = regexp.MustCompile(`[^a-z0-9-]+`)
+
+// Images returns the images of the image set, one for each of its parameters
+// and each of discoveredPaths.
+func (s *ImageSet) Images(discoveredPaths []string) ([]*Image, error) {
+	parameters := append([]ImageSetParameters{}, s.Spec.Parameters...)
+
+	names := map[string]bool{}
+	for _, p := range parameters {
+		names[p.Name] = true
+	}
+	for _, discovered := range discoveredPaths {
+		name := discoveredImageName(discovered)
+		if name == "" || names[name] {
+			continue
+		}
+		names[name] = true
+		parameters = append(parameters, ImageSetParameters{Name: name, SubPath: discovered})
+	}
+
+	images := make([]*Image, 0, len(parameters))
+	for _, p := range parameters {
+		image, err := s.image(p)
+		if err != nil {
+			return nil, err
+		}
+		images = append(images, image)
+	}
+	return images, nil
+}
+
+func (s *ImageSet) ImageName(name string) string {
+	return kmeta.ChildName(s.Name, "-"+name)
+}
+
+func (s *ImageSet) image(p ImageSetParameters) (*Image, error) {
+	spec := s.Spec.Template.DeepCopy()
+	if p.SubPath != "" {
+		spec.Source.SubPath = p.SubPath
+	}
+
+	if p.Tag != "" {
+		spec.Tag = p.Tag
+	} else {
+		tag, err := renderImageSetTag(spec.Tag, imageSetTemplateData{Name: p.Name, SubPath: spec.Source.SubPath})
+		if err != nil {
+			return nil, errors.Wrapf(err, "rendering tag of %s", p.Name)
+		}
+		spec.Tag = tag
+	}
+
+	return &Image{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      s.ImageName(p.Name),
+			Namespace: s.Namespace,
+			OwnerReferences: []metav1.OwnerReference{
+				*kmeta.NewControllerRef(s),
+			},
+			Labels: combine(s.Labels, map[string]string{
+				ImageSetLabel: s.Name,
+			}),
+		},
+		Spec: *spec,
+	}, nil
+}
+
+func renderImageSetTag(text string, data interface{}) (string, error) {
+	tmpl, err := template.New("tag").Parse(text)
+	if err != nil {
+		return "", err
+	}
+
+	var rendered strings.Builder
+	if err := tmpl.Execute(&rendered, data); err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(rendered.String()), nil
+}
+
+// discoveredImageName is the last directory of a discovered path as a DNS
+// label.
+func discoveredImageName(discovered string) string {
+	name := invalidImageSetNameChars.ReplaceAllString(strings.ToLower(path.Base(discovered)), "-")
+	return strings.Trim(name, "-")
+}
@@ -0,0 +1,111 @@
+package v1alpha2
+
+import (
+	"testing"
+
+	"github.com/sclevine/spec"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"knative.dev/pkg/kmeta"
+
+	corev1alpha1 "github.com/pivotal/kpack/pkg/apis/core/v1alpha1"
+)
+
+func TestImageSet(t *testing.T) {
+	spec.Run(t, "Image Set", testImageSet)
+}
+
+func testImageSet(t *testing.T, when spec.G, it spec.S) {
+	imageSet := &ImageSet{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "apps",
+			Namespace: "some-namespace",
+			Labels: map[string]string{
+				"some/label": "to-pass-through",
+			},
+		},
+		Spec: ImageSetSpec{
+			Template: ImageSpec{
+				Tag: "registry.io/apps/{{.Name}}",
+				Builder: corev1.ObjectReference{
+					Kind: "ClusterBuilder",
+					Name: "builder-name",
+				},
+				Source: corev1alpha1.SourceConfig{
+					Git: &corev1alpha1.Git{
+						URL:      "https://github.com/some/monorepo",
+						Revision: "main",
+					},
+					SubPath: "default",
+				},
+			},
+		},
+	}
+
+	when("#Images", func() {
+		it("creates an image for each parameter", func() {
+			imageSet.Spec.Parameters = []ImageSetParameters{
+				{Name: "api", SubPath: "services/api"},
+				{Name: "web", SubPath: "services/web", Tag: "other.io/web"},
+				{Name: "default"},
+			}
+
+			images, err := imageSet.Images(nil)
+			require.NoError(t, err)
+			require.Len(t, images, 3)
+
+			assert.Equal(t, "apps-api", images[0].Name)
+			assert.Equal(t, "some-namespace", images[0].Namespace)
+			assert.Equal(t, []metav1.OwnerReference{*kmeta.NewControllerRef(imageSet)}, images[0].OwnerReferences)
+			assert.Equal(t, map[string]string{
+				"some/label":  "to-pass-through",
+				ImageSetLabel: "apps",
+			}, images[0].Labels)
+			assert.Equal(t, "registry.io/apps/api", images[0].Spec.Tag)
+			assert.Equal(t, "services/api", images[0].Spec.Source.SubPath)
+			assert.Equal(t, imageSet.Spec.Template.Builder, images[0].Spec.Builder)
+
+			assert.Equal(t, "other.io/web", images[1].Spec.Tag)
+			assert.Equal(t, "services/web", images[1].Spec.Source.SubPath)
+
+			assert.Equal(t, "registry.io/apps/default", images[2].Spec.Tag)
+			assert.Equal(t, "default", images[2].Spec.Source.SubPath)
+		})
+
+		it("creates an image for each discovered path", func() {
+			imageSet.Spec.Template.Tag = "registry.io/{{.SubPath}}"
+
+			images, err := imageSet.Images([]string{"apps/api", "apps/Web_UI", "apps/api"})
+			require.NoError(t, err)
+			require.Len(t, images, 2)
+
+			assert.Equal(t, "apps-api", images[0].Name)
+			assert.Equal(t, "registry.io/apps/api", images[0].Spec.Tag)
+			assert.Equal(t, "apps/api", images[0].Spec.Source.SubPath)
+
+			assert.Equal(t, "apps-web-ui", images[1].Name)
+			assert.Equal(t, "registry.io/apps/Web_UI", images[1].Spec.Tag)
+			assert.Equal(t, "apps/Web_UI", images[1].Spec.Source.SubPath)
+		})
+
+		it("does not modify the template", func() {
+			imageSet.Spec.Parameters = []ImageSetParameters{{Name: "api", SubPath: "services/api"}}
+
+			_, err := imageSet.Images(nil)
+			require.NoError(t, err)
+
+			assert.Equal(t, "registry.io/apps/{{.Name}}", imageSet.Spec.Template.Tag)
+			assert.Equal(t, "default", imageSet.Spec.Template.Source.SubPath)
+		})
+
+		it("returns an error when the tag cannot be rendered", func() {
+			imageSet.Spec.Template.Tag = "registry.io/{{.Unknown}}"
+			imageSet.Spec.Parameters = []ImageSetParameters{{Name: "api"}}
+
+			_, err := imageSet.Images(nil)
+			assert.Error(t, err)
+		})
+	})
+}
@@ -0,0 +1,92 @@
+package v1alpha2
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	corev1alpha1 "github.com/pivotal/kpack/pkg/apis/core/v1alpha1"
+)
+
+// +genclient
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// +k8s:openapi-gen=true
+type ImageSet struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   ImageSetSpec   `json:"spec"`
+	Status ImageSetStatus `json:"status,omitempty"`
+}
+
+// +k8s:openapi-gen=true
+type ImageSetSpec struct {
+	// Template is the spec of every image of the set. Its tag is a Go
+	// template rendered with the Name and SubPath of each image, such as
+	// "registry.io/apps/{{.Name}}".
+	Template ImageSpec `json:"template"`
+	// Parameters create an image for each entry.
+	// +listType
+	Parameters []ImageSetParameters `json:"parameters,omitempty"`
+	// Discovery creates an image for each directory of the git source of
+	// the template that it matches.
+	Discovery *ImageSetDiscovery `json:"discovery,omitempty"`
+}
+
+// +k8s:openapi-gen=true
+type ImageSetParameters struct {
+	// Name is appended to the name of the image set to name the image.
+	Name string `json:"name"`
+	// SubPath is the source subPath of the image. Defaults to the subPath
+	// of the template.
+	SubPath string `json:"subPath,omitempty"`
+	// Tag is the tag of the image. Defaults to the rendered tag of the
+	// template.
+	Tag string `json:"tag,omitempty"`
+}
+
+// +k8s:openapi-gen=true
+type ImageSetDiscovery struct {
+	// Path is the directory of the git source whose subdirectories are
+	// discovered. Defaults to the root of the repository.
+	Path string `json:"path,omitempty"`
+	// File is a file that a subdirectory must contain to be discovered,
+	// such as project.toml.
+	File string `json:"file,omitempty"`
+}
+
+// +k8s:openapi-gen=true
+type ImageSetStatus struct {
+	corev1alpha1.Status `json:",inline"`
+	// +listType
+	Images []ImageSetImageStatus `json:"images,omitempty"`
+
+	// DiscoveredPaths are the directories found by the discovery of the
+	// image set.
+	// +listType
+	DiscoveredPaths   []string     `json:"discoveredPaths,omitempty"`
+	LastDiscoveryTime *metav1.Time `json:"lastDiscoveryTime,omitempty"`
+}
+
+// +k8s:openapi-gen=true
+type ImageSetImageStatus struct {
+	Name        string                 `json:"name"`
+	Ready       corev1.ConditionStatus `json:"ready"`
+	LatestImage string                 `json:"latestImage,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// +k8s:openapi-gen=true
+type ImageSetList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata"`
+
+	// +k8s:listType=atomic
+	Items []ImageSet `json:"items"`
+}
+
+func (*ImageSet) GetGroupVersionKind() schema.GroupVersionKind {
+	return SchemeGroupVersion.WithKind("ImageSet")
+}
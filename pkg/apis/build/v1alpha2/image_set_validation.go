@@ -0,0 +1,127 @@
+package v1alpha2
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/util/validation"
+	"knative.dev/pkg/apis"
+
+	"github.com/pivotal/kpack/pkg/apis/validate"
+)
+
+func (s *ImageSet) SetDefaults(ctx context.Context) {
+	image := &Image{Spec: s.Spec.Template}
+	image.SetDefaults(ctx)
+	s.Spec.Template = image.Spec
+}
+
+func (s *ImageSet) Validate(ctx context.Context) *apis.FieldError {
+	return s.Spec.Validate(ctx).ViaField("spec")
+}
+
+// templateContext replaces the baseline of an update with an image of the
+// original template, so the template is validated as the images of the set.
+func templateContext(ctx context.Context) context.Context {
+	if !apis.IsInUpdate(ctx) {
+		return ctx
+	}
+
+	original, ok := apis.GetBaseline(ctx).(*ImageSet)
+	if !ok {
+		return ctx
+	}
+
+	spec := original.Spec.Template.DeepCopy()
+	spec.Tag, _ = renderImageSetTag(spec.Tag, templateValidationData(spec))
+	return apis.WithinUpdate(ctx, &Image{Spec: *spec})
+}
+
+func (ss *ImageSetSpec) Validate(ctx context.Context) *apis.FieldError {
+	var errs *apis.FieldError
+	switch {
+	case len(ss.Parameters) == 0 && ss.Discovery == nil:
+		errs = errs.Also(apis.ErrMissingOneOf("parameters", "discovery"))
+	case len(ss.Parameters) > 0 && ss.Discovery != nil:
+		errs = errs.Also(apis.ErrMultipleOneOf("parameters", "discovery"))
+	}
+
+	if ss.Discovery != nil && ss.Template.Source.Git == nil {
+		errs = errs.Also(apis.ErrGeneric("discovery requires a git source", "template.source.git"))
+	}
+
+	return errs.
+		Also(ss.validateTemplate(templateContext(ctx)).ViaField("template")).
+		Also(ss.validateParameters(ctx))
+}
+
+func (ss *ImageSetSpec) validateTemplate(ctx context.Context) *apis.FieldError {
+	spec := ss.Template.DeepCopy()
+
+	tag, err := renderImageSetTag(spec.Tag, templateValidationData(spec))
+	if err != nil {
+		fieldErr := apis.ErrInvalidValue(spec.Tag, "tag")
+		fieldErr.Details = err.Error()
+		return fieldErr
+	}
+	spec.Tag = tag
+
+	return spec.ValidateSpec(ctx)
+}
+
+func (ss *ImageSetSpec) validateParameters(ctx context.Context) *apis.FieldError {
+	var original map[string]ImageSetParameters
+	if baseline, ok := apis.GetBaseline(ctx).(*ImageSet); ok {
+		original = map[string]ImageSetParameters{}
+		for _, p := range baseline.Spec.Parameters {
+			original[p.Name] = p
+		}
+	}
+
+	var errs *apis.FieldError
+	names := map[string]int{}
+	for i, p := range ss.Parameters {
+		if p.Name == "" {
+			errs = errs.Also(apis.ErrMissingField("name").ViaFieldIndex("parameters", i))
+			continue
+		}
+
+		if msgs := validation.IsDNS1123Label(p.Name); len(msgs) > 0 {
+			errs = errs.Also((&apis.FieldError{
+				Message: fmt.Sprintf("invalid value: %s", p.Name),
+				Paths:   []string{"name"},
+				Details: strings.Join(msgs, ","),
+			}).ViaFieldIndex("parameters", i))
+		}
+
+		if n, ok := names[p.Name]; ok {
+			errs = errs.Also(
+				apis.ErrGeneric(
+					fmt.Sprintf("duplicate name %q", p.Name),
+					fmt.Sprintf("parameters[%d].name", n),
+					fmt.Sprintf("parameters[%d].name", i),
+				),
+			)
+			continue
+		}
+		names[p.Name] = i
+
+		if p.Tag != "" {
+			errs = errs.Also(validate.Tag(p.Tag).ViaFieldIndex("parameters", i))
+		}
+		if o, ok := original[p.Name]; ok {
+			errs = errs.Also(validate.ImmutableField(o.Tag, p.Tag, "tag").ViaFieldIndex("parameters", i))
+		}
+	}
+	return errs
+}
+
+// templateValidationData is an example of the values the tag of the template
+// is rendered with.
+func templateValidationData(spec *ImageSpec) imageSetTemplateData {
+	return imageSetTemplateData{
+		Name:    "image",
+		SubPath: spec.Source.SubPath,
+	}
+}
@@ -0,0 +1,157 @@
+package v1alpha2
+
+import (
+	"context"
+	"testing"
+
+	"github.com/sclevine/spec"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"knative.dev/pkg/apis"
+
+	corev1alpha1 "github.com/pivotal/kpack/pkg/apis/core/v1alpha1"
+)
+
+func TestImageSetValidation(t *testing.T) {
+	spec.Run(t, "Image Set Validation", testImageSetValidation)
+}
+
+func testImageSetValidation(t *testing.T, when spec.G, it spec.S) {
+	ctx := context.TODO()
+	imageSet := &ImageSet{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "apps",
+		},
+		Spec: ImageSetSpec{
+			Template: ImageSpec{
+				Tag: "registry.io/apps/{{.Name}}",
+				Builder: corev1.ObjectReference{
+					Kind: "ClusterBuilder",
+					Name: "builder-name",
+				},
+				Source: corev1alpha1.SourceConfig{
+					Git: &corev1alpha1.Git{
+						URL:      "https://github.com/some/monorepo",
+						Revision: "main",
+					},
+				},
+			},
+			Parameters: []ImageSetParameters{
+				{Name: "api", SubPath: "services/api"},
+				{Name: "web", SubPath: "services/web", Tag: "registry.io/web"},
+			},
+		},
+	}
+
+	when("Default", func() {
+		it("defaults the template", func() {
+			imageSet.SetDefaults(ctx)
+
+			assert.Equal(t, "default", imageSet.Spec.Template.ServiceAccountName)
+			assert.Equal(t, corev1alpha1.BuildNumber, imageSet.Spec.Template.ImageTaggingStrategy)
+			assert.Equal(t, defaultFailedBuildHistoryLimit, *imageSet.Spec.Template.FailedBuildHistoryLimit)
+			assert.Equal(t, defaultSuccessfulBuildHistoryLimit, *imageSet.Spec.Template.SuccessBuildHistoryLimit)
+		})
+	})
+
+	when("Validate", func() {
+		it.Before(func() {
+			imageSet.SetDefaults(ctx)
+		})
+
+		it("returns nil on no validation error", func() {
+			assert.Nil(t, imageSet.Validate(ctx))
+
+			imageSet.Spec.Parameters = nil
+			imageSet.Spec.Discovery = &ImageSetDiscovery{Path: "services", File: "project.toml"}
+			assert.Nil(t, imageSet.Validate(ctx))
+		})
+
+		it("requires either parameters or discovery", func() {
+			imageSet.Spec.Parameters = nil
+			assert.Equal(t, apis.ErrMissingOneOf("spec.parameters", "spec.discovery").Error(), imageSet.Validate(ctx).Error())
+
+			imageSet.Spec.Parameters = []ImageSetParameters{{Name: "api"}}
+			imageSet.Spec.Discovery = &ImageSetDiscovery{Path: "services"}
+			assert.Equal(t, apis.ErrMultipleOneOf("spec.parameters", "spec.discovery").Error(), imageSet.Validate(ctx).Error())
+		})
+
+		it("requires a git source for discovery", func() {
+			imageSet.Spec.Parameters = nil
+			imageSet.Spec.Discovery = &ImageSetDiscovery{Path: "services"}
+			imageSet.Spec.Template.Source = corev1alpha1.SourceConfig{
+				Blob: &corev1alpha1.Blob{URL: "https://some-blob.io/source.zip"},
+			}
+
+			assert.Equal(t, apis.ErrGeneric("discovery requires a git source", "spec.template.source.git").Error(), imageSet.Validate(ctx).Error())
+		})
+
+		it("validates the template as an image", func() {
+			imageSet.Spec.Template.Builder.Kind = "Unknown"
+
+			assert.Equal(t, apis.ErrInvalidValue("Unknown", "spec.template.builder.kind").Error(), imageSet.Validate(ctx).Error())
+		})
+
+		it("validates the tag of the template", func() {
+			imageSet.Spec.Template.Tag = "registry.io/apps/{{.Unknown}}"
+
+			err := imageSet.Validate(ctx)
+			assert.NotNil(t, err)
+			assert.Contains(t, err.Error(), "invalid value: registry.io/apps/{{.Unknown}}: spec.template.tag")
+
+			imageSet.Spec.Template.Tag = ""
+			assert.Equal(t, apis.ErrMissingField("spec.template.tag").Error(), imageSet.Validate(ctx).Error())
+		})
+
+		it("validates the parameters", func() {
+			imageSet.Spec.Parameters = []ImageSetParameters{
+				{Name: "api"},
+				{Name: ""},
+				{Name: "Not_A_Label"},
+				{Name: "api"},
+				{Name: "web", Tag: "invalid tag"},
+			}
+
+			err := imageSet.Validate(ctx)
+			assert.NotNil(t, err)
+			assert.Contains(t, err.Error(), "missing field(s): spec.parameters[1].name")
+			assert.Contains(t, err.Error(), "invalid value: Not_A_Label: spec.parameters[2].name")
+			assert.Contains(t, err.Error(), `duplicate name "api": spec.parameters[0].name, spec.parameters[3].name`)
+			assert.Contains(t, err.Error(), "invalid value: invalid tag: spec.parameters[4].tag")
+		})
+
+		when("updating", func() {
+			var original *ImageSet
+
+			it.Before(func() {
+				original = imageSet.DeepCopy()
+			})
+
+			it("does not allow the tag of the template to change", func() {
+				imageSet.Spec.Template.Tag = "registry.io/other/{{.Name}}"
+
+				err := imageSet.Validate(apis.WithinUpdate(ctx, original))
+				assert.NotNil(t, err)
+				assert.Contains(t, err.Error(), "Immutable field changed: spec.template.tag")
+			})
+
+			it("does not allow the tag of a parameter to change", func() {
+				imageSet.Spec.Parameters[1].Tag = "registry.io/other-web"
+
+				err := imageSet.Validate(apis.WithinUpdate(ctx, original))
+				assert.NotNil(t, err)
+				assert.Contains(t, err.Error(), "Immutable field changed: spec.parameters[1].tag")
+			})
+
+			it("allows parameters to be added and removed", func() {
+				imageSet.Spec.Parameters = []ImageSetParameters{
+					{Name: "web", SubPath: "services/web", Tag: "registry.io/web"},
+					{Name: "worker", SubPath: "services/worker"},
+				}
+
+				assert.Nil(t, imageSet.Validate(apis.WithinUpdate(ctx, original)))
+			})
+		})
+	})
+}
@@ -0,0 +1,103 @@
+package v1alpha2
+
+import (
+	"regexp"
+	"strings"
+	"text/template"
+	"time"
+
+	corev1alpha1 "github.com/pivotal/kpack/pkg/apis/core/v1alpha1"
+)
+
+// tagTemplateData are the values available to the tagTemplates of an Image.
+type tagTemplateData struct {
+	// BuildNumber is the number of the build.
+	BuildNumber string
+	// Timestamp is the time the build was created, such as 20060102.150405.
+	Timestamp string
+	// Revision is the resolved git commit.
+	Revision string
+	// ShortSHA is the first seven characters of the resolved git commit.
+	ShortSHA string
+	// Branch is the git branch the revision was resolved from.
+	Branch string
+	// Tag is the git tag the revision was resolved from.
+	Tag string
+	// SemverTag is the git tag without a leading v.
+	SemverTag string
+	// StackID is the ID of the builder's stack.
+	StackID string
+}
+
+const (
+	maxTagLength   = 128
+	shortSHALength = 7
+)
+
+var invalidTagChars = regexp.MustCompile(`[^A-Za-z0-9_.-]`)
+
+func newTagTemplateData(buildNumber string, now time.Time, sourceResolver *SourceResolver, builder BuilderResource) tagTemplateData {
+	data := tagTemplateData{
+		BuildNumber: buildNumber,
+		Timestamp:   now.Format("20060102.150405"),
+		StackID:     builder.StackID(),
+	}
+
+	git := sourceResolver.Status.Source.Git
+	if git == nil {
+		return data
+	}
+
+	data.Revision = git.Revision
+	data.ShortSHA = git.Revision
+	if len(data.ShortSHA) > shortSHALength {
+		data.ShortSHA = data.ShortSHA[:shortSHALength]
+	}
+
+	requested := ""
+	if sourceResolver.Spec.Source.Git != nil {
+		requested = sourceResolver.Spec.Source.Git.Revision
+	}
+	switch {
+	case git.Tag != "":
+		data.Tag = git.Tag
+	case git.Type == corev1alpha1.Tag:
+		data.Tag = requested
+	case git.Type == corev1alpha1.Branch:
+		data.Branch = requested
+	}
+	data.SemverTag = strings.TrimPrefix(data.Tag, "v")
+	return data
+}
+
+func parseTagTemplate(text string) (*template.Template, error) {
+	return template.New("tag").Parse(text)
+}
+
+// renderTagTemplates renders each tag template into a tag of repository.
+// Characters that are not valid in a tag are replaced with a dash, and
+// templates that render to an empty tag are skipped.
+func renderTagTemplates(repository string, templates []string, data tagTemplateData) []string {
+	var tags []string
+	for _, text := range templates {
+		tmpl, err := parseTagTemplate(text)
+		if err != nil {
+			continue
+		}
+
+		var rendered strings.Builder
+		if err := tmpl.Execute(&rendered, data); err != nil {
+			continue
+		}
+
+		tag := strings.TrimLeft(invalidTagChars.ReplaceAllString(rendered.String(), "-"), ".-")
+		if len(tag) > maxTagLength {
+			tag = tag[:maxTagLength]
+		}
+		if tag == "" {
+			continue
+		}
+		tags = append(tags, repository+":"+tag)
+	}
+	return tags
+}
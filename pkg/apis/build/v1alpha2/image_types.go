@@ -54,7 +54,62 @@ type ImageSpec struct {
 	Cosign                   *CosignConfig                     `json:"cosign,omitempty"`
 	DefaultProcess           string                            `json:"defaultProcess,omitempty"`
 	// +listType
-	AdditionalTags []string `json:"additionalTags,omitempty"`
+	AdditionalTags []string          `json:"additionalTags,omitempty"`
+	RetryPolicy    *ImageRetryPolicy `json:"retryPolicy,omitempty"`
+	Paused         bool              `json:"paused,omitempty"`
+	Schedule       *ImageSchedule    `json:"schedule,omitempty"`
+	// TagTemplates are Go templates of additional tags of the built image,
+	// such as "{{.Branch}}-{{.ShortSHA}}".
+	// +listType
+	TagTemplates []string `json:"tagTemplates,omitempty"`
+	// Promotions are repositories, outside the registry of the tag, that
+	// successfully built images are copied to.
+	// +listType
+	Promotions []ImagePromotion `json:"promotions,omitempty"`
+	// BuildHistoryMaxAge is the age after which finished builds are deleted
+	// regardless of the build history limits.
+	BuildHistoryMaxAge *metav1.Duration `json:"buildHistoryMaxAge,omitempty"`
+	// Rollback points the tag and additional tags of the image back at the
+	// image of an earlier build. No builds are created until it is removed.
+	Rollback *ImageRollback `json:"rollback,omitempty"`
+}
+
+// +k8s:openapi-gen=true
+type ImageRollback struct {
+	// BuildNumber is the number of the successful build whose image the
+	// tags are pointed at.
+	BuildNumber int64 `json:"buildNumber"`
+}
+
+// +k8s:openapi-gen=true
+type ImagePromotion struct {
+	// Repository is the repository the built image and its cosign
+	// signatures are copied to, such as dr.registry.io/project/app.
+	Repository string `json:"repository"`
+	// ImagePullSecrets are the credentials of the repository in addition
+	// to the secrets of the service account.
+	// +listType
+	ImagePullSecrets []corev1.LocalObjectReference `json:"imagePullSecrets,omitempty"`
+}
+
+// +k8s:openapi-gen=true
+type ImageRetryPolicy struct {
+	// MaxAttempts is the number of times a build that failed with a
+	// retryable failure is retried.
+	MaxAttempts int64 `json:"maxAttempts"`
+	// Backoff is the delay before the first retry. It doubles with every
+	// attempt, up to an hour.
+	Backoff *metav1.Duration `json:"backoff,omitempty"`
+}
+
+// +k8s:openapi-gen=true
+type ImageSchedule struct {
+	// Cron is a cron expression with five fields, such as "0 3 * * 1", or a
+	// descriptor, such as "@weekly", that schedules builds.
+	Cron string `json:"cron"`
+	// TimeZone is the IANA time zone of the cron expression. Defaults to
+	// UTC.
+	TimeZone string `json:"timeZone,omitempty"`
 }
 
 // +k8s:openapi-gen=true
@@ -72,6 +127,7 @@ type ImageBuild struct {
 	Affinity         *corev1.Affinity    `json:"affinity,omitempty"`
 	RuntimeClassName *string             `json:"runtimeClassName,omitempty"`
 	SchedulerName    string              `json:"schedulerName,omitempty"`
+	Timeout          *metav1.Duration    `json:"timeout,omitempty"`
 }
 
 // +k8s:openapi-gen=true
@@ -106,6 +162,29 @@ type ImageStatus struct {
 	BuildCounter               int64  `json:"buildCounter,omitempty"`
 	BuildCacheName             string `json:"buildCacheName,omitempty"`
 	LatestBuildReason          string `json:"latestBuildReason,omitempty"`
+	LatestBuildRetryAttempt    int64  `json:"latestBuildRetryAttempt,omitempty"`
+
+	// NextScheduledBuild is when the schedule of the image next creates a
+	// build.
+	NextScheduledBuild *metav1.Time `json:"nextScheduledBuild,omitempty"`
+
+	// Rollback is the rollback the tags of the image point at. It is
+	// cleared once a build succeeds after the rollback is removed.
+	Rollback *ImageRollbackStatus `json:"rollback,omitempty"`
+
+	// AverageBuildDuration is the average build duration of the latest
+	// successful builds of the image.
+	AverageBuildDuration *metav1.Duration `json:"averageBuildDuration,omitempty"`
+}
+
+// +k8s:openapi-gen=true
+type ImageRollbackStatus struct {
+	BuildNumber int64  `json:"buildNumber"`
+	BuildName   string `json:"buildName"`
+	// Image is the image, with its digest, the tags were pointed at.
+	Image           string      `json:"image"`
+	ImageGeneration int64       `json:"imageGeneration"`
+	RolledBackAt    metav1.Time `json:"rolledBackAt"`
 }
 
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
@@ -128,3 +207,5 @@ func (i *Image) NamespacedName() types.NamespacedName {
 }
 
 const ConditionBuilderReady corev1alpha1.ConditionType = "BuilderReady"
+
+const ConditionPaused corev1alpha1.ConditionType = "Paused"
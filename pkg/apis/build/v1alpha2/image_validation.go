@@ -3,11 +3,14 @@ package v1alpha2
 import (
 	"context"
 	"fmt"
+	"io"
 	"strings"
+	"time"
 
 	"github.com/google/go-containerregistry/pkg/name"
 	v1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/validation"
 	"knative.dev/pkg/apis"
 
@@ -25,6 +28,7 @@ const (
 var (
 	defaultFailedBuildHistoryLimit     int64 = 10
 	defaultSuccessfulBuildHistoryLimit int64 = 10
+	defaultRetryBackoff                      = metav1.Duration{Duration: 30 * time.Second}
 	defaultCacheSize                   resource.Quantity
 )
 
@@ -49,6 +53,10 @@ func (i *Image) SetDefaults(ctx context.Context) {
 		i.Spec.SuccessBuildHistoryLimit = &defaultSuccessfulBuildHistoryLimit
 	}
 
+	if i.Spec.RetryPolicy != nil && i.Spec.RetryPolicy.Backoff == nil {
+		i.Spec.RetryPolicy.Backoff = defaultRetryBackoff.DeepCopy()
+	}
+
 	if i.Spec.Cache == nil && ctx.Value(HasDefaultStorageClass) != nil {
 		i.Spec.Cache = &ImageCacheConfig{
 			Volume: &ImagePersistentVolumeCache{
@@ -89,7 +97,12 @@ func (is *ImageSpec) ValidateSpec(ctx context.Context) *apis.FieldError {
 		Also(is.validateVolumeCache(ctx)).
 		Also(validateNotary(ctx, is.Notary).ViaField("notary")).
 		Also(is.Cosign.Validate(ctx).ViaField("cosign")).
-		Also(is.validateBuildHistoryLimit())
+		Also(is.validateBuildHistoryLimit()).
+		Also(is.RetryPolicy.Validate(ctx).ViaField("retryPolicy")).
+		Also(is.Schedule.Validate(ctx).ViaField("schedule")).
+		Also(is.validateTagTemplates()).
+		Also(validatePromotions(is.Tag, is.Promotions).ViaField("promotions")).
+		Also(is.Rollback.Validate(ctx).ViaField("rollback"))
 }
 
 func (is *ImageSpec) validateTag(ctx context.Context) *apis.FieldError {
@@ -126,6 +139,57 @@ func (is *ImageSpec) validateSameRegistry() *apis.FieldError {
 	return nil
 }
 
+func (is *ImageSpec) validateTagTemplates() *apis.FieldError {
+	var errs *apis.FieldError
+	for i, text := range is.TagTemplates {
+		tmpl, err := parseTagTemplate(text)
+		if err == nil {
+			err = tmpl.Execute(io.Discard, tagTemplateData{})
+		}
+		if err != nil {
+			fieldErr := apis.ErrInvalidArrayValue(text, "tagTemplates", i)
+			fieldErr.Details = err.Error()
+			errs = errs.Also(fieldErr)
+		}
+	}
+	return errs
+}
+
+// validatePromotions validates that promotions are unique repositories outside
+// the registry of tag.
+func validatePromotions(tag string, promotions []ImagePromotion) *apis.FieldError {
+	var errs *apis.FieldError
+	repositories := map[string]int{}
+	for i, p := range promotions {
+		if p.Repository == "" {
+			errs = errs.Also(apis.ErrMissingField("repository").ViaIndex(i))
+			continue
+		}
+
+		if repository, err := name.NewRepository(p.Repository, name.WeakValidation); err != nil {
+			errs = errs.Also(apis.ErrInvalidValue(p.Repository, "repository").ViaIndex(i))
+		} else if t, err := name.NewTag(tag, name.WeakValidation); err == nil && repository.RegistryStr() == t.RegistryStr() {
+			errs = errs.Also(&apis.FieldError{
+				Message: "repository must be outside the registry of the tag",
+				Paths:   []string{fmt.Sprintf("[%d].repository", i)},
+				Details: fmt.Sprintf("registry: %s", t.RegistryStr()),
+			})
+		}
+		if n, ok := repositories[p.Repository]; ok {
+			errs = errs.Also(
+				apis.ErrGeneric(
+					fmt.Sprintf("duplicate repository %q", p.Repository),
+					fmt.Sprintf("[%d].repository", n),
+					fmt.Sprintf("[%d].repository", i),
+				),
+			)
+			continue
+		}
+		repositories[p.Repository] = i
+	}
+	return errs
+}
+
 func (is *ImageSpec) validateVolumeCache(ctx context.Context) *apis.FieldError {
 	if is.Cache != nil && is.Cache.Volume != nil && ctx.Value(HasDefaultStorageClass) == nil {
 		return apis.ErrGeneric("spec.cache.volume.size cannot be set with no default StorageClass")
@@ -164,10 +228,58 @@ func (ib *ImageBuild) Validate(ctx context.Context) *apis.FieldError {
 		}
 	}
 
+	if ib.Timeout != nil && ib.Timeout.Duration < time.Second {
+		return apis.ErrInvalidValue(ib.Timeout.Duration.String(), "timeout")
+	}
+
 	return ib.Services.Validate(ctx).ViaField("services").
 		Also(validateCnbBindings(ctx, ib.CNBBindings).ViaField("cnbBindings"))
 }
 
+func (r *ImageRollback) Validate(ctx context.Context) *apis.FieldError {
+	if r == nil {
+		return nil
+	}
+
+	if r.BuildNumber < 1 {
+		return apis.ErrInvalidValue(r.BuildNumber, "buildNumber")
+	}
+	return nil
+}
+
+func (rp *ImageRetryPolicy) Validate(ctx context.Context) *apis.FieldError {
+	if rp == nil {
+		return nil
+	}
+
+	var errs *apis.FieldError
+	if rp.MaxAttempts < 1 {
+		errs = errs.Also(apis.ErrInvalidValue(rp.MaxAttempts, "maxAttempts"))
+	}
+	if rp.Backoff != nil && rp.Backoff.Duration < time.Second {
+		errs = errs.Also(apis.ErrInvalidValue(rp.Backoff.Duration.String(), "backoff"))
+	}
+	return errs
+}
+
+func (s *ImageSchedule) Validate(ctx context.Context) *apis.FieldError {
+	if s == nil {
+		return nil
+	}
+
+	if _, err := time.LoadLocation(s.TimeZone); err != nil {
+		return apis.ErrInvalidValue(s.TimeZone, "timeZone")
+	}
+	if _, err := s.CronSchedule(); err != nil {
+		return &apis.FieldError{
+			Message: fmt.Sprintf("invalid value: %s", s.Cron),
+			Paths:   []string{"cron"},
+			Details: err.Error(),
+		}
+	}
+	return nil
+}
+
 func validateBuilder(builder v1.ObjectReference) *apis.FieldError {
 	if builder.Name == "" {
 		return apis.ErrMissingField("name")
@@ -191,6 +303,9 @@ func (is *ImageSpec) validateBuildHistoryLimit() *apis.FieldError {
 	if *is.SuccessBuildHistoryLimit < 1 {
 		return apis.ErrGeneric(errMsg, "successBuildHistoryLimit")
 	}
+	if is.BuildHistoryMaxAge != nil && is.BuildHistoryMaxAge.Duration <= 0 {
+		return apis.ErrGeneric("build history max age must be greater than 0", "buildHistoryMaxAge")
+	}
 	return nil
 }
 
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/pkg/errors"
 	"github.com/sclevine/spec"
@@ -89,6 +90,14 @@ func testImageValidation(t *testing.T, when spec.G, it spec.S) {
 			assert.Equal(t, image.Spec.ImageTaggingStrategy, corev1alpha1.BuildNumber)
 		})
 
+		it("defaults the retry backoff to 30s", func() {
+			image.Spec.RetryPolicy = &ImageRetryPolicy{MaxAttempts: 3}
+
+			image.SetDefaults(ctx)
+
+			assert.Equal(t, &metav1.Duration{Duration: 30 * time.Second}, image.Spec.RetryPolicy.Backoff)
+		})
+
 		it("defaults SuccessBuildHistoryLimit,FailedBuildHistoryLimit to 10", func() {
 			image.Spec.SuccessBuildHistoryLimit = nil
 			image.Spec.FailedBuildHistoryLimit = nil
@@ -210,7 +219,7 @@ func testImageValidation(t *testing.T, when spec.G, it spec.S) {
 		it("missing source", func() {
 			image.Spec.Source = corev1alpha1.SourceConfig{}
 
-			assertValidationError(image, ctx, apis.ErrMissingOneOf("git", "blob", "registry").ViaField("spec", "source"))
+			assertValidationError(image, ctx, apis.ErrMissingOneOf("git", "blob", "registry", "objectStore").ViaField("spec", "source"))
 		})
 
 		it("validates git url", func() {
@@ -231,6 +240,35 @@ func testImageValidation(t *testing.T, when spec.G, it spec.S) {
 			assertValidationError(image, ctx, apis.ErrMissingField("revision").ViaField("spec", "source", "git"))
 		})
 
+		it("validates git tag constraint", func() {
+			image.Spec.Source.Git = &corev1alpha1.Git{
+				URL:           "http://github.com/url",
+				TagConstraint: ">=2.0.0 <3.0.0",
+			}
+			assert.Nil(t, image.Validate(ctx))
+
+			image.Spec.Source.Git.TagConstraint = "release-*"
+			assert.Nil(t, image.Validate(ctx))
+
+			image.Spec.Source.Git.TagConstraint = "release-[invalid"
+			assertValidationError(image, ctx, apis.ErrInvalidValue("release-[invalid", "tagConstraint").ViaField("spec", "source", "git"))
+
+			image.Spec.Source.Git.TagConstraint = "v1.*"
+			image.Spec.Source.Git.Revision = "master"
+			assertValidationError(image, ctx, apis.ErrMultipleOneOf("revision", "tagConstraint").ViaField("spec", "source", "git"))
+		})
+
+		it("validates git path globs", func() {
+			image.Spec.Source.Git = &corev1alpha1.Git{
+				URL:          "http://github.com/url",
+				Revision:     "master",
+				IncludePaths: []string{"services/api/**", "[invalid"},
+				ExcludePaths: []string{"docs/*.md"},
+			}
+
+			assertValidationError(image, ctx, apis.ErrInvalidArrayValue("[invalid", "includePaths", 1).ViaField("spec", "source", "git"))
+		})
+
 		it("validates blob url", func() {
 			image.Spec.Source.Git = nil
 			image.Spec.Source.Blob = &corev1alpha1.Blob{URL: ""}
@@ -238,6 +276,38 @@ func testImageValidation(t *testing.T, when spec.G, it spec.S) {
 			assertValidationError(image, ctx, apis.ErrMissingField("url").ViaField("spec", "source", "blob"))
 		})
 
+		it("validates blob digest", func() {
+			image.Spec.Source.Git = nil
+			image.Spec.Source.Blob = &corev1alpha1.Blob{
+				URL:    "https://artifacts.example.com/app.zip",
+				Digest: "sha256:b94d27b9934d3e08a52e52d7da7dabfac484efe37a5380ee9088f7ace2efcde9",
+			}
+			assert.Nil(t, image.Validate(ctx))
+
+			image.Spec.Source.Blob.Digest = "md5:1234"
+			assertValidationError(image, ctx, apis.ErrInvalidValue("md5:1234", "digest").ViaField("spec", "source", "blob"))
+		})
+
+		it("validates object store fields", func() {
+			image.Spec.Source.Git = nil
+			image.Spec.Source.ObjectStore = &corev1alpha1.ObjectStore{
+				Endpoint: "https://minio.example.com:9000",
+				Bucket:   "some-bucket",
+				Key:      "app.tar.gz",
+			}
+			assert.Nil(t, image.Validate(ctx))
+
+			image.Spec.Source.ObjectStore = &corev1alpha1.ObjectStore{}
+			assertValidationError(image, ctx, apis.ErrMissingField("endpoint", "bucket", "key").ViaField("spec", "source", "objectStore"))
+
+			image.Spec.Source.ObjectStore = &corev1alpha1.ObjectStore{
+				Endpoint: "minio.example.com",
+				Bucket:   "some-bucket",
+				Key:      "app.tar.gz",
+			}
+			assertValidationError(image, ctx, apis.ErrInvalidValue("minio.example.com", "endpoint").ViaField("spec", "source", "objectStore"))
+		})
+
 		it("validates registry image exists", func() {
 			image.Spec.Source.Git = nil
 			image.Spec.Source.Registry = &corev1alpha1.Registry{Image: ""}
@@ -285,6 +355,14 @@ func testImageValidation(t *testing.T, when spec.G, it spec.S) {
 
 		})
 
+		it("buildHistoryMaxAge is not positive", func() {
+			image.Spec.BuildHistoryMaxAge = &metav1.Duration{Duration: 0}
+			assertValidationError(image, ctx, apis.ErrGeneric("build history max age must be greater than 0", "spec.buildHistoryMaxAge"))
+
+			image.Spec.BuildHistoryMaxAge = &metav1.Duration{Duration: 7 * 24 * time.Hour}
+			assert.Nil(t, image.Validate(ctx))
+		})
+
 		it("validates cache size is not set when there is no default StorageClass", func() {
 			ctx = context.TODO()
 
@@ -513,6 +591,81 @@ func testImageValidation(t *testing.T, when spec.G, it spec.S) {
 			assert.EqualError(t, err, "only one type of cache can be specified: spec.cache.registry, spec.cache.volume")
 		})
 
+		it("validates the build timeout is at least a second", func() {
+			image.Spec.Build.Timeout = &metav1.Duration{Duration: 0}
+			assertValidationError(image, ctx, apis.ErrInvalidValue("0s", "spec.build.timeout"))
+
+			image.Spec.Build.Timeout = &metav1.Duration{Duration: 30 * time.Minute}
+			assert.Nil(t, image.Validate(ctx))
+		})
+
+		it("validates the retry policy", func() {
+			image.Spec.RetryPolicy = &ImageRetryPolicy{MaxAttempts: 0, Backoff: &metav1.Duration{Duration: time.Minute}}
+			assertValidationError(image, ctx, apis.ErrInvalidValue(int64(0), "spec.retryPolicy.maxAttempts"))
+
+			image.Spec.RetryPolicy = &ImageRetryPolicy{MaxAttempts: 3, Backoff: &metav1.Duration{Duration: 0}}
+			assertValidationError(image, ctx, apis.ErrInvalidValue("0s", "spec.retryPolicy.backoff"))
+
+			image.Spec.RetryPolicy = &ImageRetryPolicy{MaxAttempts: 3, Backoff: &metav1.Duration{Duration: time.Minute}}
+			assert.Nil(t, image.Validate(ctx))
+		})
+
+		it("validates the schedule", func() {
+			image.Spec.Schedule = &ImageSchedule{Cron: "0 3 * *"}
+			assertValidationError(image, ctx, &apis.FieldError{
+				Message: "invalid value: 0 3 * *",
+				Paths:   []string{"spec.schedule.cron"},
+				Details: `expected 5 fields in cron expression "0 3 * *", found 4`,
+			})
+
+			image.Spec.Schedule = &ImageSchedule{Cron: "0 3 * * mon", TimeZone: "Not/AZone"}
+			assertValidationError(image, ctx, apis.ErrInvalidValue("Not/AZone", "spec.schedule.timeZone"))
+
+			image.Spec.Schedule = &ImageSchedule{Cron: "0 3 * * mon", TimeZone: "Europe/Berlin"}
+			assert.Nil(t, image.Validate(ctx))
+		})
+
+		it("validates the tag templates", func() {
+			image.Spec.TagTemplates = []string{"{{.Branch}}-{{.ShortSHA}}", "{{.Unknown}}", "{{.Branch"}
+			err := image.Validate(ctx)
+			assert.NotNil(t, err)
+			assert.Contains(t, err.Error(), "invalid value: {{.Unknown}}: spec.tagTemplates[1]")
+			assert.Contains(t, err.Error(), "invalid value: {{.Branch: spec.tagTemplates[2]")
+
+			image.Spec.TagTemplates = []string{"{{.Branch}}-{{.ShortSHA}}", "{{.SemverTag}}", "{{.StackID}}-{{.BuildNumber}}-{{.Timestamp}}"}
+			assert.Nil(t, image.Validate(ctx))
+		})
+
+		it("validates the promotions", func() {
+			image.Spec.Promotions = []ImagePromotion{
+				{Repository: "dr.registry.io/app"},
+				{Repository: ""},
+				{Repository: "Invalid Repository"},
+				{Repository: "dr.registry.io/app"},
+				{Repository: "index.docker.io/other/app"},
+			}
+			err := image.Validate(ctx)
+			assert.NotNil(t, err)
+			assert.Contains(t, err.Error(), "missing field(s): spec.promotions[1].repository")
+			assert.Contains(t, err.Error(), "invalid value: Invalid Repository: spec.promotions[2].repository")
+			assert.Contains(t, err.Error(), `duplicate repository "dr.registry.io/app": spec.promotions[0].repository, spec.promotions[3].repository`)
+			assert.Contains(t, err.Error(), "repository must be outside the registry of the tag: spec.promotions[4].repository")
+
+			image.Spec.Promotions = []ImagePromotion{
+				{Repository: "dr.registry.io/app", ImagePullSecrets: []corev1.LocalObjectReference{{Name: "dr-secret"}}},
+				{Repository: "other.registry.io/app"},
+			}
+			assert.Nil(t, image.Validate(ctx))
+		})
+
+		it("validates the rollback build number", func() {
+			image.Spec.Rollback = &ImageRollback{BuildNumber: 0}
+			assertValidationError(image, ctx, apis.ErrInvalidValue(int64(0), "spec.rollback.buildNumber"))
+
+			image.Spec.Rollback = &ImageRollback{BuildNumber: 3}
+			assert.Nil(t, image.Validate(ctx))
+		})
+
 		it("validates kubernetes.io/os node selector is unset", func() {
 			image.Spec.Build.NodeSelector = map[string]string{k8sOSLabel: "some-os"}
 			assertValidationError(image, ctx, apis.ErrInvalidKeyName(k8sOSLabel, "spec.build.nodeSelector", "os is determined automatically"))
@@ -0,0 +1,97 @@
+package v1alpha2
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"knative.dev/pkg/kmeta"
+)
+
+const (
+	PreviewImageSetLabel = "imageset.kpack.io/previewImageSet"
+
+	ListPreviewsFailed = "ListPreviewsFailed"
+)
+
+// previewTemplateData are the values available to the tag of the template of
+// a PreviewImageSet.
+type previewTemplateData struct {
+	// Name is the name of the preview.
+	Name string
+	// Number is the number of the pull request of the preview.
+	Number int64
+	// Branch is the head branch of the preview.
+	Branch string
+}
+
+// Images returns an image for each of previews built from its revision.
+func (s *PreviewImageSet) Images(previews []Preview) ([]*Image, error) {
+	images := make([]*Image, 0, len(previews))
+	names := map[string]bool{}
+	for _, p := range previews {
+		if names[p.Name] {
+			continue
+		}
+		names[p.Name] = true
+
+		image, err := s.image(p)
+		if err != nil {
+			return nil, err
+		}
+		images = append(images, image)
+	}
+	return images, nil
+}
+
+func (s *PreviewImageSet) ImageName(name string) string {
+	return kmeta.ChildName(s.Name, "-"+name)
+}
+
+func (s *PreviewImageSet) image(p Preview) (*Image, error) {
+	spec := s.Spec.Template.DeepCopy()
+	if spec.Source.Git != nil {
+		spec.Source.Git.Revision = p.Revision
+	}
+
+	tag, err := renderImageSetTag(spec.Tag, previewTemplateData{Name: p.Name, Number: p.Number, Branch: p.Branch})
+	if err != nil {
+		return nil, errors.Wrapf(err, "rendering tag of %s", p.Name)
+	}
+	spec.Tag = tag
+
+	return &Image{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      s.ImageName(p.Name),
+			Namespace: s.Namespace,
+			OwnerReferences: []metav1.OwnerReference{
+				*kmeta.NewControllerRef(s),
+			},
+			Labels: combine(s.Labels, map[string]string{
+				PreviewImageSetLabel: s.Name,
+			}),
+		},
+		Spec: *spec,
+	}, nil
+}
+
+// PullRequestPreview returns the preview of the pull request number with the
+// head branch and ref.
+func PullRequestPreview(number int64, branch, ref string) Preview {
+	return Preview{
+		Name:     "pr-" + strconv.FormatInt(number, 10),
+		Number:   number,
+		Branch:   branch,
+		Revision: ref,
+	}
+}
+
+// BranchPreview returns the preview of branch.
+func BranchPreview(branch string) Preview {
+	return Preview{
+		Name:     strings.Trim(invalidImageSetNameChars.ReplaceAllString(strings.ToLower(branch), "-"), "-"),
+		Branch:   branch,
+		Revision: branch,
+	}
+}
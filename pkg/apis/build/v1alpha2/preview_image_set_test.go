@@ -0,0 +1,86 @@
+package v1alpha2
+
+import (
+	"testing"
+
+	"github.com/sclevine/spec"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"knative.dev/pkg/kmeta"
+
+	corev1alpha1 "github.com/pivotal/kpack/pkg/apis/core/v1alpha1"
+)
+
+func TestPreviewImageSet(t *testing.T) {
+	spec.Run(t, "Preview Image Set", testPreviewImageSet)
+}
+
+func testPreviewImageSet(t *testing.T, when spec.G, it spec.S) {
+	previewImageSet := &PreviewImageSet{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "app",
+			Namespace: "some-namespace",
+			Labels: map[string]string{
+				"some/label": "to-pass-through",
+			},
+		},
+		Spec: PreviewImageSetSpec{
+			Template: ImageSpec{
+				Tag: "registry.io/app:{{.Name}}",
+				Builder: corev1.ObjectReference{
+					Kind: "ClusterBuilder",
+					Name: "builder-name",
+				},
+				Source: corev1alpha1.SourceConfig{
+					Git: &corev1alpha1.Git{
+						URL:      "https://github.com/some/app",
+						Revision: "main",
+					},
+				},
+			},
+			Provider: PreviewProvider{
+				GitHub: &PullRequestProvider{},
+			},
+		},
+	}
+
+	when("#Images", func() {
+		it("creates an image for each preview built from its revision", func() {
+			images, err := previewImageSet.Images([]Preview{
+				PullRequestPreview(42, "feature/login", "refs/pull/42/head"),
+				BranchPreview("Feature/Sign_Up"),
+				PullRequestPreview(42, "feature/login", "refs/pull/42/head"),
+			})
+			require.NoError(t, err)
+			require.Len(t, images, 2)
+
+			assert.Equal(t, "app-pr-42", images[0].Name)
+			assert.Equal(t, "some-namespace", images[0].Namespace)
+			assert.Equal(t, []metav1.OwnerReference{*kmeta.NewControllerRef(previewImageSet)}, images[0].OwnerReferences)
+			assert.Equal(t, map[string]string{
+				"some/label":         "to-pass-through",
+				PreviewImageSetLabel: "app",
+			}, images[0].Labels)
+			assert.Equal(t, "registry.io/app:pr-42", images[0].Spec.Tag)
+			assert.Equal(t, "refs/pull/42/head", images[0].Spec.Source.Git.Revision)
+			assert.Equal(t, "https://github.com/some/app", images[0].Spec.Source.Git.URL)
+
+			assert.Equal(t, "app-feature-sign-up", images[1].Name)
+			assert.Equal(t, "registry.io/app:feature-sign-up", images[1].Spec.Tag)
+			assert.Equal(t, "Feature/Sign_Up", images[1].Spec.Source.Git.Revision)
+
+			assert.Equal(t, "main", previewImageSet.Spec.Template.Source.Git.Revision)
+		})
+
+		it("renders the tag with the number and branch of the preview", func() {
+			previewImageSet.Spec.Template.Tag = "registry.io/app:{{.Number}}-{{.Branch}}"
+
+			images, err := previewImageSet.Images([]Preview{PullRequestPreview(7, "fix", "refs/pull/7/head")})
+			require.NoError(t, err)
+
+			assert.Equal(t, "registry.io/app:7-fix", images[0].Spec.Tag)
+		})
+	})
+}
@@ -0,0 +1,99 @@
+package v1alpha2
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	corev1alpha1 "github.com/pivotal/kpack/pkg/apis/core/v1alpha1"
+)
+
+// +genclient
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// +k8s:openapi-gen=true
+type PreviewImageSet struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   PreviewImageSetSpec   `json:"spec"`
+	Status PreviewImageSetStatus `json:"status,omitempty"`
+}
+
+// +k8s:openapi-gen=true
+type PreviewImageSetSpec struct {
+	// Template is the spec of the image of every preview. Its source must be
+	// a git repository and its tag is a Go template rendered with the Name,
+	// Number and Branch of each preview, such as "registry.io/app:pr-{{.Number}}".
+	Template ImageSpec `json:"template"`
+	// Provider lists the previews of the git repository of the template.
+	Provider PreviewProvider `json:"provider"`
+}
+
+// +k8s:openapi-gen=true
+type PreviewProvider struct {
+	// GitHub creates a preview for each open pull request.
+	GitHub *PullRequestProvider `json:"github,omitempty"`
+	// GitLab creates a preview for each open merge request.
+	GitLab *PullRequestProvider `json:"gitlab,omitempty"`
+	// Branches creates a preview for each branch that matches a pattern.
+	Branches *BranchProvider `json:"branches,omitempty"`
+}
+
+// +k8s:openapi-gen=true
+type PullRequestProvider struct {
+	// URL is the https base url of the api of the provider. Defaults to the
+	// public api of the provider.
+	URL string `json:"url,omitempty"`
+	// SecretRef is a secret in the namespace of the preview image set with
+	// the api token in its token key or basic auth password. It is required
+	// to authenticate to an api outside the host of the git repository.
+	SecretRef *corev1.LocalObjectReference `json:"secretRef,omitempty"`
+}
+
+// +k8s:openapi-gen=true
+type BranchProvider struct {
+	// Pattern is a shell pattern matched against the names of the branches,
+	// such as "feature/*".
+	Pattern string `json:"pattern"`
+}
+
+// +k8s:openapi-gen=true
+type Preview struct {
+	// Name is appended to the name of the preview image set to name the
+	// image of the preview.
+	Name string `json:"name"`
+	// Number is the number of the pull request of the preview.
+	Number int64 `json:"number,omitempty"`
+	// Branch is the head branch of the preview.
+	Branch string `json:"branch,omitempty"`
+	// Revision is the git revision the image of the preview is built from.
+	Revision string `json:"revision"`
+}
+
+// +k8s:openapi-gen=true
+type PreviewImageSetStatus struct {
+	corev1alpha1.Status `json:",inline"`
+	// +listType
+	Images []ImageSetImageStatus `json:"images,omitempty"`
+
+	// Previews are the previews last listed by the provider.
+	// +listType
+	Previews     []Preview    `json:"previews,omitempty"`
+	LastPollTime *metav1.Time `json:"lastPollTime,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// +k8s:openapi-gen=true
+type PreviewImageSetList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata"`
+
+	// +k8s:listType=atomic
+	Items []PreviewImageSet `json:"items"`
+}
+
+func (*PreviewImageSet) GetGroupVersionKind() schema.GroupVersionKind {
+	return SchemeGroupVersion.WithKind("PreviewImageSet")
+}
@@ -0,0 +1,138 @@
+package v1alpha2
+
+import (
+	"context"
+	"net/url"
+	"path"
+
+	"knative.dev/pkg/apis"
+)
+
+func (s *PreviewImageSet) SetDefaults(ctx context.Context) {
+	image := &Image{Spec: s.Spec.Template}
+	image.SetDefaults(ctx)
+	s.Spec.Template = image.Spec
+}
+
+func (s *PreviewImageSet) Validate(ctx context.Context) *apis.FieldError {
+	return s.Spec.Validate(ctx).ViaField("spec")
+}
+
+func (ps *PreviewImageSetSpec) Validate(ctx context.Context) *apis.FieldError {
+	var errs *apis.FieldError
+	if ps.Template.Source.Git == nil {
+		errs = errs.Also(apis.ErrGeneric("previews require a git source", "template.source.git"))
+	}
+
+	return errs.
+		Also(ps.validateTemplate(previewTemplateContext(ctx)).ViaField("template")).
+		Also(ps.Provider.Validate(ctx).ViaField("provider"))
+}
+
+// validateTemplate validates the template as the image of an example preview.
+// The tag of the template must be unique for each preview.
+func (ps *PreviewImageSetSpec) validateTemplate(ctx context.Context) *apis.FieldError {
+	spec := ps.Template.DeepCopy()
+
+	tag, err := renderImageSetTag(spec.Tag, previewValidationData)
+	if err != nil {
+		fieldErr := apis.ErrInvalidValue(spec.Tag, "tag")
+		fieldErr.Details = err.Error()
+		return fieldErr
+	}
+
+	if spec.Tag != "" {
+		other, err := renderImageSetTag(spec.Tag, previewTemplateData{Name: "other", Number: 2, Branch: "other"})
+		if err == nil && other == tag {
+			return apis.ErrGeneric("tag must be unique for each preview, such as registry.io/app:pr-{{.Number}}", "tag")
+		}
+	}
+	spec.Tag = tag
+
+	return spec.ValidateSpec(ctx)
+}
+
+func (pp *PreviewProvider) Validate(ctx context.Context) *apis.FieldError {
+	var providers []string
+	if pp.GitHub != nil {
+		providers = append(providers, "github")
+	}
+	if pp.GitLab != nil {
+		providers = append(providers, "gitlab")
+	}
+	if pp.Branches != nil {
+		providers = append(providers, "branches")
+	}
+
+	switch {
+	case len(providers) == 0:
+		return apis.ErrMissingOneOf("github", "gitlab", "branches")
+	case len(providers) > 1:
+		return apis.ErrMultipleOneOf(providers...)
+	}
+
+	return pp.GitHub.Validate(ctx).ViaField("github").
+		Also(pp.GitLab.Validate(ctx).ViaField("gitlab")).
+		Also(pp.Branches.Validate(ctx).ViaField("branches"))
+}
+
+func (p *PullRequestProvider) Validate(context.Context) *apis.FieldError {
+	if p == nil || p.URL == "" {
+		return nil
+	}
+
+	u, err := url.Parse(p.URL)
+	if err != nil || u.Scheme == "" || u.Host == "" {
+		return apis.ErrInvalidValue(p.URL, "url")
+	}
+
+	if u.Scheme != "https" {
+		fieldErr := apis.ErrInvalidValue(p.URL, "url")
+		fieldErr.Details = "the url must use https"
+		return fieldErr
+	}
+	return nil
+}
+
+func (b *BranchProvider) Validate(context.Context) *apis.FieldError {
+	if b == nil {
+		return nil
+	}
+
+	if b.Pattern == "" {
+		return apis.ErrMissingField("pattern")
+	}
+
+	if _, err := path.Match(b.Pattern, ""); err != nil {
+		fieldErr := apis.ErrInvalidValue(b.Pattern, "pattern")
+		fieldErr.Details = err.Error()
+		return fieldErr
+	}
+	return nil
+}
+
+// previewTemplateContext replaces the baseline of an update with an image of
+// the original template, so the template is validated as the images of the
+// previews.
+func previewTemplateContext(ctx context.Context) context.Context {
+	if !apis.IsInUpdate(ctx) {
+		return ctx
+	}
+
+	original, ok := apis.GetBaseline(ctx).(*PreviewImageSet)
+	if !ok {
+		return ctx
+	}
+
+	spec := original.Spec.Template.DeepCopy()
+	spec.Tag, _ = renderImageSetTag(spec.Tag, previewValidationData)
+	return apis.WithinUpdate(ctx, &Image{Spec: *spec})
+}
+
+// previewValidationData is an example of the values the tag of the template is
+// rendered with.
+var previewValidationData = previewTemplateData{
+	Name:   "pr-1",
+	Number: 1,
+	Branch: "main",
+}
@@ -0,0 +1,131 @@
+package v1alpha2
+
+import (
+	"context"
+	"testing"
+
+	"github.com/sclevine/spec"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"knative.dev/pkg/apis"
+
+	corev1alpha1 "github.com/pivotal/kpack/pkg/apis/core/v1alpha1"
+)
+
+func TestPreviewImageSetValidation(t *testing.T) {
+	spec.Run(t, "Preview Image Set Validation", testPreviewImageSetValidation)
+}
+
+func testPreviewImageSetValidation(t *testing.T, when spec.G, it spec.S) {
+	ctx := context.TODO()
+	previewImageSet := &PreviewImageSet{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "app",
+		},
+		Spec: PreviewImageSetSpec{
+			Template: ImageSpec{
+				Tag: "registry.io/app:pr-{{.Number}}",
+				Builder: corev1.ObjectReference{
+					Kind: "ClusterBuilder",
+					Name: "builder-name",
+				},
+				Source: corev1alpha1.SourceConfig{
+					Git: &corev1alpha1.Git{
+						URL:      "https://github.com/some/app",
+						Revision: "main",
+					},
+				},
+			},
+			Provider: PreviewProvider{
+				GitHub: &PullRequestProvider{},
+			},
+		},
+	}
+
+	when("Default", func() {
+		it("defaults the template", func() {
+			previewImageSet.SetDefaults(ctx)
+
+			assert.Equal(t, "default", previewImageSet.Spec.Template.ServiceAccountName)
+			assert.Equal(t, corev1alpha1.BuildNumber, previewImageSet.Spec.Template.ImageTaggingStrategy)
+		})
+	})
+
+	when("Validate", func() {
+		it.Before(func() {
+			previewImageSet.SetDefaults(ctx)
+		})
+
+		it("returns nil on no validation error", func() {
+			assert.Nil(t, previewImageSet.Validate(ctx))
+
+			previewImageSet.Spec.Provider = PreviewProvider{GitLab: &PullRequestProvider{URL: "https://gitlab.example.com"}}
+			assert.Nil(t, previewImageSet.Validate(ctx))
+
+			previewImageSet.Spec.Provider = PreviewProvider{Branches: &BranchProvider{Pattern: "feature/*"}}
+			assert.Nil(t, previewImageSet.Validate(ctx))
+		})
+
+		it("requires a git source", func() {
+			previewImageSet.Spec.Template.Source = corev1alpha1.SourceConfig{
+				Blob: &corev1alpha1.Blob{URL: "https://some-blob.io/source.zip"},
+			}
+
+			assert.Equal(t, apis.ErrGeneric("previews require a git source", "spec.template.source.git").Error(), previewImageSet.Validate(ctx).Error())
+		})
+
+		it("requires exactly one provider", func() {
+			previewImageSet.Spec.Provider = PreviewProvider{}
+			assert.Equal(t, apis.ErrMissingOneOf("spec.provider.github", "spec.provider.gitlab", "spec.provider.branches").Error(), previewImageSet.Validate(ctx).Error())
+
+			previewImageSet.Spec.Provider = PreviewProvider{GitHub: &PullRequestProvider{}, Branches: &BranchProvider{Pattern: "*"}}
+			assert.Equal(t, apis.ErrMultipleOneOf("spec.provider.github", "spec.provider.branches").Error(), previewImageSet.Validate(ctx).Error())
+		})
+
+		it("validates the provider", func() {
+			previewImageSet.Spec.Provider = PreviewProvider{GitHub: &PullRequestProvider{URL: "not-a-url"}}
+			assert.Equal(t, apis.ErrInvalidValue("not-a-url", "spec.provider.github.url").Error(), previewImageSet.Validate(ctx).Error())
+
+			previewImageSet.Spec.Provider = PreviewProvider{GitLab: &PullRequestProvider{URL: "http://gitlab.example.com"}}
+			err := previewImageSet.Validate(ctx)
+			assert.NotNil(t, err)
+			assert.Contains(t, err.Error(), "invalid value: http://gitlab.example.com: spec.provider.gitlab.url")
+			assert.Contains(t, err.Error(), "the url must use https")
+
+			previewImageSet.Spec.Provider = PreviewProvider{Branches: &BranchProvider{}}
+			assert.Equal(t, apis.ErrMissingField("spec.provider.branches.pattern").Error(), previewImageSet.Validate(ctx).Error())
+
+			previewImageSet.Spec.Provider = PreviewProvider{Branches: &BranchProvider{Pattern: "feature/["}}
+			err = previewImageSet.Validate(ctx)
+			assert.NotNil(t, err)
+			assert.Contains(t, err.Error(), "invalid value: feature/[: spec.provider.branches.pattern")
+		})
+
+		it("requires a tag that is unique for each preview", func() {
+			previewImageSet.Spec.Template.Tag = "registry.io/app:preview"
+
+			assert.Equal(t, apis.ErrGeneric("tag must be unique for each preview, such as registry.io/app:pr-{{.Number}}", "spec.template.tag").Error(), previewImageSet.Validate(ctx).Error())
+		})
+
+		it("validates the template as an image", func() {
+			previewImageSet.Spec.Template.Tag = "registry.io/app:{{.Unknown}}"
+			err := previewImageSet.Validate(ctx)
+			assert.NotNil(t, err)
+			assert.Contains(t, err.Error(), "invalid value: registry.io/app:{{.Unknown}}: spec.template.tag")
+
+			previewImageSet.Spec.Template.Tag = "registry.io/app:pr-{{.Number}}"
+			previewImageSet.Spec.Template.Builder.Kind = "Unknown"
+			assert.Equal(t, apis.ErrInvalidValue("Unknown", "spec.template.builder.kind").Error(), previewImageSet.Validate(ctx).Error())
+		})
+
+		it("does not allow the tag of the template to change", func() {
+			original := previewImageSet.DeepCopy()
+			previewImageSet.Spec.Template.Tag = "registry.io/other:pr-{{.Number}}"
+
+			err := previewImageSet.Validate(apis.WithinUpdate(ctx, original))
+			assert.NotNil(t, err)
+			assert.Contains(t, err.Error(), "Immutable field changed: spec.template.tag")
+		})
+	})
+}
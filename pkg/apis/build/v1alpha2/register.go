@@ -51,6 +51,12 @@ func addKnownTypes(scheme *runtime.Scheme) error {
 		&BuildList{},
 		&Image{},
 		&ImageList{},
+		&ImageSet{},
+		&ImageSetList{},
+		&PreviewImageSet{},
+		&PreviewImageSetList{},
+		&BuildRequest{},
+		&BuildRequestList{},
 		&SourceResolver{},
 		&SourceResolverList{},
 		&ClusterStack{},
@@ -32,6 +32,37 @@ func (sr *SourceResolver) ResolvedSource(config corev1alpha1.ResolvedSourceConfi
 	})
 }
 
+// ResolveFailed marks the SourceResolver as not ready when the source cannot
+// be resolved until the user intervenes.
+func (sr *SourceResolver) ResolveFailed(reason, message string) {
+	sr.Status.Conditions = []corev1alpha1.Condition{{
+		Type:    corev1alpha1.ConditionReady,
+		Status:  corev1.ConditionFalse,
+		Reason:  reason,
+		Message: message,
+	}}
+}
+
+// PollingFailed keeps the last resolved source of a ready SourceResolver
+// when it cannot be polled and reports the failure on the ActivePolling
+// condition. A SourceResolver that is not ready fails to resolve.
+func (sr *SourceResolver) PollingFailed(reason, message string) {
+	if !sr.Ready() {
+		sr.ResolveFailed(reason, message)
+		return
+	}
+
+	sr.Status.Conditions = []corev1alpha1.Condition{
+		*sr.Status.GetCondition(corev1alpha1.ConditionReady),
+		{
+			Type:    ActivePolling,
+			Status:  corev1.ConditionTrue,
+			Reason:  reason,
+			Message: message,
+		},
+	}
+}
+
 func (sr *SourceResolver) PollingReady() bool {
 	return sr.Status.GetCondition(ActivePolling).IsTrue()
 }
@@ -53,6 +84,26 @@ func (sr SourceResolver) IsRegistry() bool {
 	return sr.Spec.Source.Registry != nil
 }
 
+func (sr SourceResolver) IsObjectStore() bool {
+	return sr.Spec.Source.ObjectStore != nil
+}
+
+// LastResolvedGitSource returns the git source resolved for the current spec, if any.
+func (sr *SourceResolver) LastResolvedGitSource() *corev1alpha1.ResolvedGitSource {
+	if sr.Status.ObservedGeneration != sr.Generation {
+		return nil
+	}
+	return sr.Status.Source.Git
+}
+
+// LastResolvedBlobSource returns the blob source resolved for the current spec, if any.
+func (sr *SourceResolver) LastResolvedBlobSource() *corev1alpha1.ResolvedBlobSource {
+	if sr.Status.ObservedGeneration != sr.Generation {
+		return nil
+	}
+	return sr.Status.Source.Blob
+}
+
 func (st *SourceResolver) SourceConfig() corev1alpha1.SourceConfig {
 	return st.Status.Source.ResolvedSource().SourceConfig()
 }
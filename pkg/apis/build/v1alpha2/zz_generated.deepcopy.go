@@ -28,6 +28,22 @@ import (
 	runtime "k8s.io/apimachinery/pkg/runtime"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BranchProvider) DeepCopyInto(out *BranchProvider) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BranchProvider.
+func (in *BranchProvider) DeepCopy() *BranchProvider {
+	if in == nil {
+		return nil
+	}
+	out := new(BranchProvider)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Build) DeepCopyInto(out *Build) {
 	*out = *in
@@ -184,6 +200,141 @@ func (in *BuildPodImages) DeepCopy() *BuildPodImages {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BuildPromotionStatus) DeepCopyInto(out *BuildPromotionStatus) {
+	*out = *in
+	if in.LastAttemptTime != nil {
+		in, out := &in.LastAttemptTime, &out.LastAttemptTime
+		*out = (*in).DeepCopy()
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BuildPromotionStatus.
+func (in *BuildPromotionStatus) DeepCopy() *BuildPromotionStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(BuildPromotionStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BuildRequest) DeepCopyInto(out *BuildRequest) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	out.Spec = in.Spec
+	in.Status.DeepCopyInto(&out.Status)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BuildRequest.
+func (in *BuildRequest) DeepCopy() *BuildRequest {
+	if in == nil {
+		return nil
+	}
+	out := new(BuildRequest)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *BuildRequest) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BuildRequestList) DeepCopyInto(out *BuildRequestList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]BuildRequest, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BuildRequestList.
+func (in *BuildRequestList) DeepCopy() *BuildRequestList {
+	if in == nil {
+		return nil
+	}
+	out := new(BuildRequestList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *BuildRequestList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BuildRequestSpec) DeepCopyInto(out *BuildRequestSpec) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BuildRequestSpec.
+func (in *BuildRequestSpec) DeepCopy() *BuildRequestSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(BuildRequestSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BuildRequestStatus) DeepCopyInto(out *BuildRequestStatus) {
+	*out = *in
+	in.Status.DeepCopyInto(&out.Status)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BuildRequestStatus.
+func (in *BuildRequestStatus) DeepCopy() *BuildRequestStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(BuildRequestStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BuildResolvedSource) DeepCopyInto(out *BuildResolvedSource) {
+	*out = *in
+	if in.BlobFingerprint != nil {
+		in, out := &in.BlobFingerprint, &out.BlobFingerprint
+		*out = new(v1alpha1.BlobFingerprint)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BuildResolvedSource.
+func (in *BuildResolvedSource) DeepCopy() *BuildResolvedSource {
+	if in == nil {
+		return nil
+	}
+	out := new(BuildResolvedSource)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *BuildSpec) DeepCopyInto(out *BuildSpec) {
 	*out = *in
@@ -194,6 +345,11 @@ func (in *BuildSpec) DeepCopyInto(out *BuildSpec) {
 	}
 	in.Builder.DeepCopyInto(&out.Builder)
 	in.Source.DeepCopyInto(&out.Source)
+	if in.ResolvedSource != nil {
+		in, out := &in.ResolvedSource, &out.ResolvedSource
+		*out = new(BuildResolvedSource)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.Cache != nil {
 		in, out := &in.Cache, &out.Cache
 		*out = new(BuildCacheConfig)
@@ -258,6 +414,18 @@ func (in *BuildSpec) DeepCopyInto(out *BuildSpec) {
 		*out = new(string)
 		**out = **in
 	}
+	if in.ActiveDeadlineSeconds != nil {
+		in, out := &in.ActiveDeadlineSeconds, &out.ActiveDeadlineSeconds
+		*out = new(int64)
+		**out = **in
+	}
+	if in.Promotions != nil {
+		in, out := &in.Promotions, &out.Promotions
+		*out = make([]ImagePromotion, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	return
 }
 
@@ -309,6 +477,30 @@ func (in *BuildStatus) DeepCopyInto(out *BuildStatus) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.Promotions != nil {
+		in, out := &in.Promotions, &out.Promotions
+		*out = make([]BuildPromotionStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Steps != nil {
+		in, out := &in.Steps, &out.Steps
+		*out = make([]BuildStepStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.QueueDuration != nil {
+		in, out := &in.QueueDuration, &out.QueueDuration
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.BuildDuration != nil {
+		in, out := &in.BuildDuration, &out.BuildDuration
+		*out = new(metav1.Duration)
+		**out = **in
+	}
 	return
 }
 
@@ -322,6 +514,40 @@ func (in *BuildStatus) DeepCopy() *BuildStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BuildStepStatus) DeepCopyInto(out *BuildStepStatus) {
+	*out = *in
+	if in.StartedAt != nil {
+		in, out := &in.StartedAt, &out.StartedAt
+		*out = (*in).DeepCopy()
+	}
+	if in.FinishedAt != nil {
+		in, out := &in.FinishedAt, &out.FinishedAt
+		*out = (*in).DeepCopy()
+	}
+	if in.Duration != nil {
+		in, out := &in.Duration, &out.Duration
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.ExitCode != nil {
+		in, out := &in.ExitCode, &out.ExitCode
+		*out = new(int32)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BuildStepStatus.
+func (in *BuildStepStatus) DeepCopy() *BuildStepStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(BuildStepStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Builder) DeepCopyInto(out *Builder) {
 	*out = *in
@@ -935,6 +1161,11 @@ func (in *ImageBuild) DeepCopyInto(out *ImageBuild) {
 		*out = new(string)
 		**out = **in
 	}
+	if in.Timeout != nil {
+		in, out := &in.Timeout, &out.Timeout
+		*out = new(metav1.Duration)
+		**out = **in
+	}
 	return
 }
 
@@ -1045,6 +1276,264 @@ func (in *ImagePersistentVolumeCache) DeepCopy() *ImagePersistentVolumeCache {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ImagePromotion) DeepCopyInto(out *ImagePromotion) {
+	*out = *in
+	if in.ImagePullSecrets != nil {
+		in, out := &in.ImagePullSecrets, &out.ImagePullSecrets
+		*out = make([]v1.LocalObjectReference, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ImagePromotion.
+func (in *ImagePromotion) DeepCopy() *ImagePromotion {
+	if in == nil {
+		return nil
+	}
+	out := new(ImagePromotion)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ImageRetryPolicy) DeepCopyInto(out *ImageRetryPolicy) {
+	*out = *in
+	if in.Backoff != nil {
+		in, out := &in.Backoff, &out.Backoff
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ImageRetryPolicy.
+func (in *ImageRetryPolicy) DeepCopy() *ImageRetryPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(ImageRetryPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ImageRollback) DeepCopyInto(out *ImageRollback) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ImageRollback.
+func (in *ImageRollback) DeepCopy() *ImageRollback {
+	if in == nil {
+		return nil
+	}
+	out := new(ImageRollback)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ImageRollbackStatus) DeepCopyInto(out *ImageRollbackStatus) {
+	*out = *in
+	in.RolledBackAt.DeepCopyInto(&out.RolledBackAt)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ImageRollbackStatus.
+func (in *ImageRollbackStatus) DeepCopy() *ImageRollbackStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ImageRollbackStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ImageSchedule) DeepCopyInto(out *ImageSchedule) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ImageSchedule.
+func (in *ImageSchedule) DeepCopy() *ImageSchedule {
+	if in == nil {
+		return nil
+	}
+	out := new(ImageSchedule)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ImageSet) DeepCopyInto(out *ImageSet) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ImageSet.
+func (in *ImageSet) DeepCopy() *ImageSet {
+	if in == nil {
+		return nil
+	}
+	out := new(ImageSet)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ImageSet) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ImageSetDiscovery) DeepCopyInto(out *ImageSetDiscovery) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ImageSetDiscovery.
+func (in *ImageSetDiscovery) DeepCopy() *ImageSetDiscovery {
+	if in == nil {
+		return nil
+	}
+	out := new(ImageSetDiscovery)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ImageSetImageStatus) DeepCopyInto(out *ImageSetImageStatus) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ImageSetImageStatus.
+func (in *ImageSetImageStatus) DeepCopy() *ImageSetImageStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ImageSetImageStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ImageSetList) DeepCopyInto(out *ImageSetList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ImageSet, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ImageSetList.
+func (in *ImageSetList) DeepCopy() *ImageSetList {
+	if in == nil {
+		return nil
+	}
+	out := new(ImageSetList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ImageSetList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ImageSetParameters) DeepCopyInto(out *ImageSetParameters) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ImageSetParameters.
+func (in *ImageSetParameters) DeepCopy() *ImageSetParameters {
+	if in == nil {
+		return nil
+	}
+	out := new(ImageSetParameters)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ImageSetSpec) DeepCopyInto(out *ImageSetSpec) {
+	*out = *in
+	in.Template.DeepCopyInto(&out.Template)
+	if in.Parameters != nil {
+		in, out := &in.Parameters, &out.Parameters
+		*out = make([]ImageSetParameters, len(*in))
+		copy(*out, *in)
+	}
+	if in.Discovery != nil {
+		in, out := &in.Discovery, &out.Discovery
+		*out = new(ImageSetDiscovery)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ImageSetSpec.
+func (in *ImageSetSpec) DeepCopy() *ImageSetSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ImageSetSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ImageSetStatus) DeepCopyInto(out *ImageSetStatus) {
+	*out = *in
+	in.Status.DeepCopyInto(&out.Status)
+	if in.Images != nil {
+		in, out := &in.Images, &out.Images
+		*out = make([]ImageSetImageStatus, len(*in))
+		copy(*out, *in)
+	}
+	if in.DiscoveredPaths != nil {
+		in, out := &in.DiscoveredPaths, &out.DiscoveredPaths
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.LastDiscoveryTime != nil {
+		in, out := &in.LastDiscoveryTime, &out.LastDiscoveryTime
+		*out = (*in).DeepCopy()
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ImageSetStatus.
+func (in *ImageSetStatus) DeepCopy() *ImageSetStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ImageSetStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ImageSpec) DeepCopyInto(out *ImageSpec) {
 	*out = *in
@@ -1085,6 +1574,38 @@ func (in *ImageSpec) DeepCopyInto(out *ImageSpec) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.RetryPolicy != nil {
+		in, out := &in.RetryPolicy, &out.RetryPolicy
+		*out = new(ImageRetryPolicy)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Schedule != nil {
+		in, out := &in.Schedule, &out.Schedule
+		*out = new(ImageSchedule)
+		**out = **in
+	}
+	if in.TagTemplates != nil {
+		in, out := &in.TagTemplates, &out.TagTemplates
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Promotions != nil {
+		in, out := &in.Promotions, &out.Promotions
+		*out = make([]ImagePromotion, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.BuildHistoryMaxAge != nil {
+		in, out := &in.BuildHistoryMaxAge, &out.BuildHistoryMaxAge
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.Rollback != nil {
+		in, out := &in.Rollback, &out.Rollback
+		*out = new(ImageRollback)
+		**out = **in
+	}
 	return
 }
 
@@ -1102,6 +1623,20 @@ func (in *ImageSpec) DeepCopy() *ImageSpec {
 func (in *ImageStatus) DeepCopyInto(out *ImageStatus) {
 	*out = *in
 	in.Status.DeepCopyInto(&out.Status)
+	if in.NextScheduledBuild != nil {
+		in, out := &in.NextScheduledBuild, &out.NextScheduledBuild
+		*out = (*in).DeepCopy()
+	}
+	if in.Rollback != nil {
+		in, out := &in.Rollback, &out.Rollback
+		*out = new(ImageRollbackStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.AverageBuildDuration != nil {
+		in, out := &in.AverageBuildDuration, &out.AverageBuildDuration
+		*out = new(metav1.Duration)
+		**out = **in
+	}
 	return
 }
 
@@ -1149,6 +1684,184 @@ func (in *NamespacedBuilderSpec) DeepCopy() *NamespacedBuilderSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Preview) DeepCopyInto(out *Preview) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Preview.
+func (in *Preview) DeepCopy() *Preview {
+	if in == nil {
+		return nil
+	}
+	out := new(Preview)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PreviewImageSet) DeepCopyInto(out *PreviewImageSet) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PreviewImageSet.
+func (in *PreviewImageSet) DeepCopy() *PreviewImageSet {
+	if in == nil {
+		return nil
+	}
+	out := new(PreviewImageSet)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *PreviewImageSet) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PreviewImageSetList) DeepCopyInto(out *PreviewImageSetList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]PreviewImageSet, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PreviewImageSetList.
+func (in *PreviewImageSetList) DeepCopy() *PreviewImageSetList {
+	if in == nil {
+		return nil
+	}
+	out := new(PreviewImageSetList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *PreviewImageSetList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PreviewImageSetSpec) DeepCopyInto(out *PreviewImageSetSpec) {
+	*out = *in
+	in.Template.DeepCopyInto(&out.Template)
+	in.Provider.DeepCopyInto(&out.Provider)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PreviewImageSetSpec.
+func (in *PreviewImageSetSpec) DeepCopy() *PreviewImageSetSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(PreviewImageSetSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PreviewImageSetStatus) DeepCopyInto(out *PreviewImageSetStatus) {
+	*out = *in
+	in.Status.DeepCopyInto(&out.Status)
+	if in.Images != nil {
+		in, out := &in.Images, &out.Images
+		*out = make([]ImageSetImageStatus, len(*in))
+		copy(*out, *in)
+	}
+	if in.Previews != nil {
+		in, out := &in.Previews, &out.Previews
+		*out = make([]Preview, len(*in))
+		copy(*out, *in)
+	}
+	if in.LastPollTime != nil {
+		in, out := &in.LastPollTime, &out.LastPollTime
+		*out = (*in).DeepCopy()
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PreviewImageSetStatus.
+func (in *PreviewImageSetStatus) DeepCopy() *PreviewImageSetStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(PreviewImageSetStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PreviewProvider) DeepCopyInto(out *PreviewProvider) {
+	*out = *in
+	if in.GitHub != nil {
+		in, out := &in.GitHub, &out.GitHub
+		*out = new(PullRequestProvider)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.GitLab != nil {
+		in, out := &in.GitLab, &out.GitLab
+		*out = new(PullRequestProvider)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Branches != nil {
+		in, out := &in.Branches, &out.Branches
+		*out = new(BranchProvider)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PreviewProvider.
+func (in *PreviewProvider) DeepCopy() *PreviewProvider {
+	if in == nil {
+		return nil
+	}
+	out := new(PreviewProvider)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PullRequestProvider) DeepCopyInto(out *PullRequestProvider) {
+	*out = *in
+	if in.SecretRef != nil {
+		in, out := &in.SecretRef, &out.SecretRef
+		*out = new(v1.LocalObjectReference)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PullRequestProvider.
+func (in *PullRequestProvider) DeepCopy() *PullRequestProvider {
+	if in == nil {
+		return nil
+	}
+	out := new(PullRequestProvider)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *RegistryCache) DeepCopyInto(out *RegistryCache) {
 	*out = *in
@@ -1,16 +1,19 @@
 package v1alpha1
 
 import (
+	"strings"
+
 	corev1 "k8s.io/api/core/v1"
 )
 
 // +k8s:openapi-gen=true
 // +k8s:deepcopy-gen=true
 type SourceConfig struct {
-	Git      *Git      `json:"git,omitempty"`
-	Blob     *Blob     `json:"blob,omitempty"`
-	Registry *Registry `json:"registry,omitempty"`
-	SubPath  string    `json:"subPath,omitempty"`
+	Git         *Git         `json:"git,omitempty"`
+	Blob        *Blob        `json:"blob,omitempty"`
+	Registry    *Registry    `json:"registry,omitempty"`
+	ObjectStore *ObjectStore `json:"objectStore,omitempty"`
+	SubPath     string       `json:"subPath,omitempty"`
 }
 
 func (sc *SourceConfig) Source() Source {
@@ -20,6 +23,8 @@ func (sc *SourceConfig) Source() Source {
 		return sc.Blob
 	} else if sc.Registry != nil {
 		return sc.Registry
+	} else if sc.ObjectStore != nil {
+		return sc.ObjectStore
 	}
 	return nil
 }
@@ -34,10 +39,32 @@ type Source interface {
 type Git struct {
 	URL      string `json:"url"`
 	Revision string `json:"revision"`
+	// TagConstraint follows the newest tag matching a glob, such as v1.*,
+	// or a semver constraint, such as >=2.0.0 <3.0.0, instead of Revision.
+	TagConstraint string `json:"tagConstraint,omitempty"`
+	// IncludePaths are globs, relative to the repository root, of the paths
+	// that trigger a new revision. Defaults to the SubPath when empty.
+	// +listType
+	IncludePaths []string `json:"includePaths,omitempty"`
+	// ExcludePaths are globs of paths that never trigger a new revision.
+	// +listType
+	ExcludePaths []string         `json:"excludePaths,omitempty"`
+	Fetch        *GitFetchOptions `json:"fetch,omitempty"`
+}
+
+// +k8s:openapi-gen=true
+// +k8s:deepcopy-gen=true
+type GitFetchOptions struct {
+	// Sparse checks out only the files below the SubPath.
+	Sparse bool `json:"sparse,omitempty"`
+	// Submodules recursively checks out the submodules of the repository.
+	Submodules bool `json:"submodules,omitempty"`
+	// LFS downloads the Git LFS objects referenced by the checked out files.
+	LFS bool `json:"lfs,omitempty"`
 }
 
 func (g *Git) BuildEnvVars() []corev1.EnvVar {
-	return []corev1.EnvVar{
+	envVars := []corev1.EnvVar{
 		{
 			Name:  "GIT_URL",
 			Value: g.URL,
@@ -47,6 +74,17 @@ func (g *Git) BuildEnvVars() []corev1.EnvVar {
 			Value: g.Revision,
 		},
 	}
+
+	if g.Fetch != nil && g.Fetch.Sparse {
+		envVars = append(envVars, corev1.EnvVar{Name: "GIT_SPARSE", Value: "true"})
+	}
+	if g.Fetch != nil && g.Fetch.Submodules {
+		envVars = append(envVars, corev1.EnvVar{Name: "GIT_SUBMODULES", Value: "true"})
+	}
+	if g.Fetch != nil && g.Fetch.LFS {
+		envVars = append(envVars, corev1.EnvVar{Name: "GIT_LFS", Value: "true"})
+	}
+	return envVars
 }
 
 func (in *Git) ImagePullSecretsVolume(name string) corev1.Volume {
@@ -62,6 +100,18 @@ func (in *Git) ImagePullSecretsVolume(name string) corev1.Volume {
 // +k8s:deepcopy-gen=true
 type Blob struct {
 	URL string `json:"url"`
+	// Digest, of the form sha256:<hex>, that the downloaded blob must match.
+	Digest string `json:"digest,omitempty"`
+}
+
+// BlobFingerprint is reported by the blob server in response to a HEAD
+// request and changes when the blob is replaced.
+// +k8s:openapi-gen=true
+// +k8s:deepcopy-gen=true
+type BlobFingerprint struct {
+	ETag          string `json:"etag,omitempty"`
+	LastModified  string `json:"lastModified,omitempty"`
+	ContentLength int64  `json:"contentLength,omitempty"`
 }
 
 func (b *Blob) ImagePullSecretsVolume(name string) corev1.Volume {
@@ -74,12 +124,17 @@ func (b *Blob) ImagePullSecretsVolume(name string) corev1.Volume {
 }
 
 func (b *Blob) BuildEnvVars() []corev1.EnvVar {
-	return []corev1.EnvVar{
+	envVars := []corev1.EnvVar{
 		{
 			Name:  "BLOB_URL",
 			Value: b.URL,
 		},
 	}
+
+	if b.Digest != "" {
+		envVars = append(envVars, corev1.EnvVar{Name: "BLOB_DIGEST", Value: b.Digest})
+	}
+	return envVars
 }
 
 // +k8s:openapi-gen=true
@@ -121,12 +176,97 @@ func (r *Registry) BuildEnvVars() []corev1.EnvVar {
 	}
 }
 
+// +k8s:openapi-gen=true
+// +k8s:deepcopy-gen=true
+type ObjectStore struct {
+	// Endpoint of the S3 compatible object storage, such as
+	// https://minio.example.com:9000. Objects are addressed path style.
+	Endpoint string `json:"endpoint"`
+	// Region the requests are signed for. Defaults to us-east-1.
+	Region string `json:"region,omitempty"`
+	Bucket string `json:"bucket"`
+	Key    string `json:"key"`
+	// SecretRef references a Secret with the accessKeyId, secretAccessKey
+	// and optional sessionToken used to access the object.
+	SecretRef *corev1.LocalObjectReference `json:"secretRef,omitempty"`
+	// Version identifies the object when the source was resolved.
+	Version *ObjectVersion `json:"version,omitempty"`
+}
+
+// +k8s:openapi-gen=true
+// +k8s:deepcopy-gen=true
+type ObjectVersion struct {
+	VersionID string `json:"versionId,omitempty"`
+	ETag      string `json:"etag,omitempty"`
+}
+
+func (o *ObjectStore) ImagePullSecretsVolume(name string) corev1.Volume {
+	return corev1.Volume{
+		Name: name,
+		VolumeSource: corev1.VolumeSource{
+			EmptyDir: &corev1.EmptyDirVolumeSource{},
+		},
+	}
+}
+
+// CredentialsVolume mounts the secret referenced by SecretRef, or an empty
+// directory when the object is read anonymously.
+func (o *ObjectStore) CredentialsVolume(name string) corev1.Volume {
+	if o.SecretRef != nil && o.SecretRef.Name != "" {
+		return corev1.Volume{
+			Name: name,
+			VolumeSource: corev1.VolumeSource{
+				Secret: &corev1.SecretVolumeSource{
+					SecretName: o.SecretRef.Name,
+				},
+			},
+		}
+	}
+
+	return corev1.Volume{
+		Name: name,
+		VolumeSource: corev1.VolumeSource{
+			EmptyDir: &corev1.EmptyDirVolumeSource{},
+		},
+	}
+}
+
+func (o *ObjectStore) BuildEnvVars() []corev1.EnvVar {
+	envVars := []corev1.EnvVar{
+		{
+			Name:  "OBJECT_STORE_ENDPOINT",
+			Value: o.Endpoint,
+		},
+		{
+			Name:  "OBJECT_STORE_REGION",
+			Value: o.Region,
+		},
+		{
+			Name:  "OBJECT_STORE_BUCKET",
+			Value: o.Bucket,
+		},
+		{
+			Name:  "OBJECT_STORE_KEY",
+			Value: o.Key,
+		},
+	}
+
+	if o.Version != nil && o.Version.VersionID != "" {
+		envVars = append(envVars, corev1.EnvVar{Name: "OBJECT_STORE_VERSION_ID", Value: o.Version.VersionID})
+	}
+	if o.Version != nil && o.Version.ETag != "" {
+		envVars = append(envVars, corev1.EnvVar{Name: "OBJECT_STORE_ETAG", Value: o.Version.ETag})
+	}
+	return envVars
+}
+
 // +k8s:openapi-gen=true
 // +k8s:deepcopy-gen=true
 type ResolvedSourceConfig struct {
-	Git      *ResolvedGitSource      `json:"git,omitempty"`
-	Blob     *ResolvedBlobSource     `json:"blob,omitempty"`
-	Registry *ResolvedRegistrySource `json:"registry,omitempty"`
+	Git         *ResolvedGitSource         `json:"git,omitempty"`
+	Blob        *ResolvedBlobSource        `json:"blob,omitempty"`
+	Registry    *ResolvedRegistrySource    `json:"registry,omitempty"`
+	ObjectStore *ResolvedObjectStoreSource `json:"objectStore,omitempty"`
 }
 
 func (sc ResolvedSourceConfig) ResolvedSource() ResolvedSource {
@@ -136,6 +276,8 @@ func (sc ResolvedSourceConfig) ResolvedSource() ResolvedSource {
 		return sc.Blob
 	} else if sc.Registry != nil {
 		return sc.Registry
+	} else if sc.ObjectStore != nil {
+		return sc.ObjectStore
 	}
 	return nil
 }
@@ -158,10 +300,16 @@ const (
 // +k8s:openapi-gen=true
 // +k8s:deepcopy-gen=true
 type ResolvedGitSource struct {
-	URL      string        `json:"url"`
-	Revision string        `json:"revision"`
-	SubPath  string        `json:"subPath,omitempty"`
-	Type     GitSourceKind `json:"type"`
+	URL      string           `json:"url"`
+	Revision string           `json:"revision"`
+	Tag      string           `json:"tag,omitempty"`
+	SubPath  string           `json:"subPath,omitempty"`
+	Type     GitSourceKind    `json:"type"`
+	Fetch    *GitFetchOptions `json:"fetch,omitempty"`
+	// SkippedRevisions are the most recent commits after Revision that did
+	// not change any path matched by the include and exclude paths.
+	// +listType
+	SkippedRevisions []string `json:"skippedRevisions,omitempty"`
 }
 
 func (gs *ResolvedGitSource) SourceConfig() SourceConfig {
@@ -169,6 +317,7 @@ func (gs *ResolvedGitSource) SourceConfig() SourceConfig {
 		Git: &Git{
 			URL:      gs.URL,
 			Revision: gs.Revision,
+			Fetch:    gs.Fetch,
 		},
 		SubPath: gs.SubPath,
 	}
@@ -185,14 +334,17 @@ func (gs *ResolvedGitSource) IsPollable() bool {
 // +k8s:openapi-gen=true
 // +k8s:deepcopy-gen=true
 type ResolvedBlobSource struct {
-	URL     string `json:"url"`
-	SubPath string `json:"subPath,omitempty"`
+	URL         string           `json:"url"`
+	Digest      string           `json:"digest,omitempty"`
+	Fingerprint *BlobFingerprint `json:"fingerprint,omitempty"`
+	SubPath     string           `json:"subPath,omitempty"`
 }
 
 func (bs *ResolvedBlobSource) SourceConfig() SourceConfig {
 	return SourceConfig{
 		Blob: &Blob{
-			URL: bs.URL,
+			URL:    bs.URL,
+			Digest: bs.Digest,
 		},
 		SubPath: bs.SubPath,
 	}
@@ -203,13 +355,14 @@ func (bs *ResolvedBlobSource) IsUnknown() bool {
 }
 
 func (bs *ResolvedBlobSource) IsPollable() bool {
-	return false
+	return bs.Fingerprint != nil
 }
 
 // +k8s:openapi-gen=true
 // +k8s:deepcopy-gen=true
 type ResolvedRegistrySource struct {
 	Image   string `json:"image"`
+	Digest  string `json:"digest,omitempty"`
 	SubPath string `json:"subPath,omitempty"`
 	// +patchMergeKey=name
 	// +patchStrategy=merge
@@ -231,6 +384,43 @@ func (rs *ResolvedRegistrySource) IsUnknown() bool {
 	return false
 }
 
+// IsPollable is true for images referenced by a tag, which may be pushed
+// again.
 func (rs *ResolvedRegistrySource) IsPollable() bool {
+	return rs.Digest != "" && !strings.Contains(rs.Image, "@")
+}
+
+// +k8s:openapi-gen=true
+// +k8s:deepcopy-gen=true
+type ResolvedObjectStoreSource struct {
+	Endpoint  string                       `json:"endpoint"`
+	Region    string                       `json:"region,omitempty"`
+	Bucket    string                       `json:"bucket"`
+	Key       string                       `json:"key"`
+	SecretRef *corev1.LocalObjectReference `json:"secretRef,omitempty"`
+	Version   ObjectVersion                `json:"version"`
+	SubPath   string                       `json:"subPath,omitempty"`
+}
+
+func (os *ResolvedObjectStoreSource) SourceConfig() SourceConfig {
+	version := os.Version
+	return SourceConfig{
+		ObjectStore: &ObjectStore{
+			Endpoint:  os.Endpoint,
+			Region:    os.Region,
+			Bucket:    os.Bucket,
+			Key:       os.Key,
+			SecretRef: os.SecretRef,
+			Version:   &version,
+		},
+		SubPath: os.SubPath,
+	}
+}
+
+func (os *ResolvedObjectStoreSource) IsUnknown() bool {
 	return false
 }
+
+func (os *ResolvedObjectStoreSource) IsPollable() bool {
+	return true
+}
@@ -2,14 +2,18 @@ package v1alpha1
 
 import (
 	"context"
+	"net/url"
+	"path"
+	"regexp"
 
+	"github.com/Masterminds/semver/v3"
 	"knative.dev/pkg/apis"
 
 	"github.com/pivotal/kpack/pkg/apis/validate"
 )
 
 func (s *SourceConfig) Validate(ctx context.Context) *apis.FieldError {
-	sources := make([]string, 0, 3)
+	sources := make([]string, 0, 4)
 	if s.Git != nil {
 		sources = append(sources, "git")
 	}
@@ -19,9 +23,12 @@ func (s *SourceConfig) Validate(ctx context.Context) *apis.FieldError {
 	if s.Registry != nil {
 		sources = append(sources, "registry")
 	}
+	if s.ObjectStore != nil {
+		sources = append(sources, "objectStore")
+	}
 
 	if len(sources) == 0 {
-		return apis.ErrMissingOneOf("git", "blob", "registry")
+		return apis.ErrMissingOneOf("git", "blob", "registry", "objectStore")
 	}
 
 	if len(sources) != 1 {
@@ -30,7 +37,8 @@ func (s *SourceConfig) Validate(ctx context.Context) *apis.FieldError {
 
 	return (s.Git.Validate(ctx).ViaField("git")).
 		Also(s.Blob.Validate(ctx).ViaField("blob")).
-		Also(s.Registry.Validate(ctx).ViaField("registry"))
+		Also(s.Registry.Validate(ctx).ViaField("registry")).
+		Also(s.ObjectStore.Validate(ctx).ViaField("objectStore"))
 }
 
 func (g *Git) Validate(ctx context.Context) *apis.FieldError {
@@ -39,7 +47,37 @@ func (g *Git) Validate(ctx context.Context) *apis.FieldError {
 	}
 
 	return validate.FieldNotEmpty(g.URL, "url").
-		Also(validate.FieldNotEmpty(g.Revision, "revision"))
+		Also(g.validateRevision()).
+		Also(validatePathGlobs(g.IncludePaths, "includePaths")).
+		Also(validatePathGlobs(g.ExcludePaths, "excludePaths"))
+}
+
+func (g *Git) validateRevision() *apis.FieldError {
+	if g.TagConstraint == "" {
+		return validate.FieldNotEmpty(g.Revision, "revision")
+	}
+
+	if g.Revision != "" {
+		return apis.ErrMultipleOneOf("revision", "tagConstraint")
+	}
+
+	if _, err := semver.NewConstraint(g.TagConstraint); err == nil {
+		return nil
+	}
+	if _, err := path.Match(g.TagConstraint, ""); err != nil {
+		return apis.ErrInvalidValue(g.TagConstraint, "tagConstraint")
+	}
+	return nil
+}
+
+func validatePathGlobs(globs []string, field string) *apis.FieldError {
+	var errs *apis.FieldError
+	for i, glob := range globs {
+		if _, err := path.Match(glob, ""); err != nil {
+			errs = errs.Also(apis.ErrInvalidArrayValue(glob, field, i))
+		}
+	}
+	return errs
 }
 
 func (b *Blob) Validate(ctx context.Context) *apis.FieldError {
@@ -47,7 +85,17 @@ func (b *Blob) Validate(ctx context.Context) *apis.FieldError {
 		return nil
 	}
 
-	return validate.FieldNotEmpty(b.URL, "url")
+	return validate.FieldNotEmpty(b.URL, "url").
+		Also(validateDigest(b.Digest))
+}
+
+var digestRegexp = regexp.MustCompile(`^sha256:[a-f0-9]{64}$`)
+
+func validateDigest(digest string) *apis.FieldError {
+	if digest != "" && !digestRegexp.MatchString(digest) {
+		return apis.ErrInvalidValue(digest, "digest")
+	}
+	return nil
 }
 
 func (r *Registry) Validate(ctx context.Context) *apis.FieldError {
@@ -57,3 +105,26 @@ func (r *Registry) Validate(ctx context.Context) *apis.FieldError {
 
 	return validate.Image(r.Image)
 }
+
+func (o *ObjectStore) Validate(ctx context.Context) *apis.FieldError {
+	if o == nil {
+		return nil
+	}
+
+	return validate.FieldNotEmpty(o.Endpoint, "endpoint").
+		Also(validateEndpoint(o.Endpoint)).
+		Also(validate.FieldNotEmpty(o.Bucket, "bucket")).
+		Also(validate.FieldNotEmpty(o.Key, "key"))
+}
+
+func validateEndpoint(endpoint string) *apis.FieldError {
+	if endpoint == "" {
+		return nil
+	}
+
+	u, err := url.Parse(endpoint)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return apis.ErrInvalidValue(endpoint, "endpoint")
+	}
+	return nil
+}
@@ -41,6 +41,22 @@ func (in *Blob) DeepCopy() *Blob {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BlobFingerprint) DeepCopyInto(out *BlobFingerprint) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BlobFingerprint.
+func (in *BlobFingerprint) DeepCopy() *BlobFingerprint {
+	if in == nil {
+		return nil
+	}
+	out := new(BlobFingerprint)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *BuildBuilderSpec) DeepCopyInto(out *BuildBuilderSpec) {
 	*out = *in
@@ -232,6 +248,21 @@ func (in *Condition) DeepCopy() *Condition {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Git) DeepCopyInto(out *Git) {
 	*out = *in
+	if in.IncludePaths != nil {
+		in, out := &in.IncludePaths, &out.IncludePaths
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ExcludePaths != nil {
+		in, out := &in.ExcludePaths, &out.ExcludePaths
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Fetch != nil {
+		in, out := &in.Fetch, &out.Fetch
+		*out = new(GitFetchOptions)
+		**out = **in
+	}
 	return
 }
 
@@ -245,6 +276,22 @@ func (in *Git) DeepCopy() *Git {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GitFetchOptions) DeepCopyInto(out *GitFetchOptions) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GitFetchOptions.
+func (in *GitFetchOptions) DeepCopy() *GitFetchOptions {
+	if in == nil {
+		return nil
+	}
+	out := new(GitFetchOptions)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *NotaryConfig) DeepCopyInto(out *NotaryConfig) {
 	*out = *in
@@ -299,6 +346,48 @@ func (in *NotaryV1Config) DeepCopy() *NotaryV1Config {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ObjectStore) DeepCopyInto(out *ObjectStore) {
+	*out = *in
+	if in.SecretRef != nil {
+		in, out := &in.SecretRef, &out.SecretRef
+		*out = new(v1.LocalObjectReference)
+		**out = **in
+	}
+	if in.Version != nil {
+		in, out := &in.Version, &out.Version
+		*out = new(ObjectVersion)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ObjectStore.
+func (in *ObjectStore) DeepCopy() *ObjectStore {
+	if in == nil {
+		return nil
+	}
+	out := new(ObjectStore)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ObjectVersion) DeepCopyInto(out *ObjectVersion) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ObjectVersion.
+func (in *ObjectVersion) DeepCopy() *ObjectVersion {
+	if in == nil {
+		return nil
+	}
+	out := new(ObjectVersion)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *OrderEntry) DeepCopyInto(out *OrderEntry) {
 	*out = *in
@@ -344,6 +433,11 @@ func (in *Registry) DeepCopy() *Registry {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ResolvedBlobSource) DeepCopyInto(out *ResolvedBlobSource) {
 	*out = *in
+	if in.Fingerprint != nil {
+		in, out := &in.Fingerprint, &out.Fingerprint
+		*out = new(BlobFingerprint)
+		**out = **in
+	}
 	return
 }
 
@@ -360,6 +454,16 @@ func (in *ResolvedBlobSource) DeepCopy() *ResolvedBlobSource {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ResolvedGitSource) DeepCopyInto(out *ResolvedGitSource) {
 	*out = *in
+	if in.Fetch != nil {
+		in, out := &in.Fetch, &out.Fetch
+		*out = new(GitFetchOptions)
+		**out = **in
+	}
+	if in.SkippedRevisions != nil {
+		in, out := &in.SkippedRevisions, &out.SkippedRevisions
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
@@ -373,6 +477,28 @@ func (in *ResolvedGitSource) DeepCopy() *ResolvedGitSource {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ResolvedObjectStoreSource) DeepCopyInto(out *ResolvedObjectStoreSource) {
+	*out = *in
+	if in.SecretRef != nil {
+		in, out := &in.SecretRef, &out.SecretRef
+		*out = new(v1.LocalObjectReference)
+		**out = **in
+	}
+	out.Version = in.Version
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResolvedObjectStoreSource.
+func (in *ResolvedObjectStoreSource) DeepCopy() *ResolvedObjectStoreSource {
+	if in == nil {
+		return nil
+	}
+	out := new(ResolvedObjectStoreSource)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ResolvedRegistrySource) DeepCopyInto(out *ResolvedRegistrySource) {
 	*out = *in
@@ -400,18 +526,23 @@ func (in *ResolvedSourceConfig) DeepCopyInto(out *ResolvedSourceConfig) {
 	if in.Git != nil {
 		in, out := &in.Git, &out.Git
 		*out = new(ResolvedGitSource)
-		**out = **in
+		(*in).DeepCopyInto(*out)
 	}
 	if in.Blob != nil {
 		in, out := &in.Blob, &out.Blob
 		*out = new(ResolvedBlobSource)
-		**out = **in
+		(*in).DeepCopyInto(*out)
 	}
 	if in.Registry != nil {
 		in, out := &in.Registry, &out.Registry
 		*out = new(ResolvedRegistrySource)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.ObjectStore != nil {
+		in, out := &in.ObjectStore, &out.ObjectStore
+		*out = new(ResolvedObjectStoreSource)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -431,7 +562,7 @@ func (in *SourceConfig) DeepCopyInto(out *SourceConfig) {
 	if in.Git != nil {
 		in, out := &in.Git, &out.Git
 		*out = new(Git)
-		**out = **in
+		(*in).DeepCopyInto(*out)
 	}
 	if in.Blob != nil {
 		in, out := &in.Blob, &out.Blob
@@ -443,6 +574,11 @@ func (in *SourceConfig) DeepCopyInto(out *SourceConfig) {
 		*out = new(Registry)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.ObjectStore != nil {
+		in, out := &in.ObjectStore, &out.ObjectStore
+		*out = new(ObjectStore)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
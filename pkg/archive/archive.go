@@ -3,13 +3,109 @@ package archive
 import (
 	"archive/tar"
 	"archive/zip"
+	"bytes"
+	"compress/bzip2"
 	"compress/gzip"
+	"errors"
 	"io"
 	"net/http"
 	"os"
-	"path/filepath"
+
+	"github.com/klauspost/compress/zstd"
+	"github.com/ulikunitz/xz"
+)
+
+// ErrUnsupportedArchive is returned by Extract for files that are not a zip,
+// tar, or gzip, xz, bzip2 or zstd compressed tar archive.
+var ErrUnsupportedArchive = errors.New("unsupported archive type")
+
+type format string
+
+const (
+	formatZip     format = "zip"
+	formatTar     format = "tar"
+	formatTarGZ   format = "tar.gz"
+	formatTarXZ   format = "tar.xz"
+	formatTarBZ2  format = "tar.bz2"
+	formatTarZstd format = "tar.zst"
+	formatUnknown format = ""
 )
 
+var (
+	gzipMagic  = []byte{0x1f, 0x8b}
+	xzMagic    = []byte{0xfd, '7', 'z', 'X', 'Z', 0x00}
+	bzip2Magic = []byte("BZh")
+	zstdMagic  = []byte{0x28, 0xb5, 0x2f, 0xfd}
+)
+
+// Extract detects whether file is a zip, tar, or compressed tar archive and
+// extracts it into dir within limits.
+func Extract(file *os.File, dir string, limits Limits) error {
+	f, err := classifyFile(file)
+	if err != nil {
+		return err
+	}
+
+	switch f {
+	case formatZip:
+		info, err := file.Stat()
+		if err != nil {
+			return err
+		}
+		return ExtractZip(file, info.Size(), dir, limits)
+	case formatTarGZ:
+		return ExtractTarGZ(file, dir, limits)
+	case formatTarXZ:
+		return ExtractTarXZ(file, dir, limits)
+	case formatTarBZ2:
+		return ExtractTarBZ2(file, dir, limits)
+	case formatTarZstd:
+		return ExtractTarZstd(file, dir, limits)
+	case formatTar:
+		if !IsTar(file.Name()) {
+			return ErrUnsupportedArchive
+		}
+		return ExtractTar(file, dir, limits)
+	default:
+		return ErrUnsupportedArchive
+	}
+}
+
+func classifyFile(reader io.ReadSeeker) (format, error) {
+	buf := make([]byte, 512)
+	n, err := reader.Read(buf)
+	if err != nil {
+		return formatUnknown, err
+	}
+	buf = buf[:n]
+
+	_, err = reader.Seek(0, 0)
+	if err != nil {
+		return formatUnknown, err
+	}
+
+	switch {
+	case bytes.HasPrefix(buf, gzipMagic):
+		return formatTarGZ, nil
+	case bytes.HasPrefix(buf, xzMagic):
+		return formatTarXZ, nil
+	case bytes.HasPrefix(buf, bzip2Magic):
+		return formatTarBZ2, nil
+	case bytes.HasPrefix(buf, zstdMagic):
+		return formatTarZstd, nil
+	}
+
+	// http://golang.org/pkg/net/http/#DetectContentType
+	switch http.DetectContentType(buf) {
+	case "application/zip":
+		return formatZip, nil
+	case "application/octet-stream":
+		return formatTar, nil
+	default:
+		return formatUnknown, nil
+	}
+}
+
 func IsTar(fileName string) bool {
 	file, err := os.Open(fileName)
 	if err != nil {
@@ -25,7 +121,12 @@ func IsTar(fileName string) bool {
 	return true
 }
 
-func ExtractTar(reader io.Reader, dir string) error {
+func ExtractTar(reader io.Reader, dir string, limits Limits) error {
+	e, err := newExtractor(dir, limits)
+	if err != nil {
+		return err
+	}
+
 	tarReader := tar.NewReader(reader)
 	for {
 		header, err := tarReader.Next()
@@ -35,28 +136,21 @@ func ExtractTar(reader io.Reader, dir string) error {
 			return err
 		}
 
-		filePath := filepath.Join(dir, header.Name)
 		switch header.Typeflag {
 		case tar.TypeDir:
-			err := os.MkdirAll(filePath, header.FileInfo().Mode())
-			if err != nil {
+			if err := e.mkdir(header.Name, header.FileInfo().Mode()); err != nil {
 				return err
 			}
 		case tar.TypeReg:
-			if err = os.MkdirAll(filepath.Dir(filePath), os.ModePerm); err != nil {
+			if err := e.writeFile(header.Name, header.FileInfo().Mode(), tarReader); err != nil {
 				return err
 			}
-
-			outFile, err := os.OpenFile(filePath, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, header.FileInfo().Mode())
-			if err != nil {
+		case tar.TypeSymlink:
+			if err := e.symlink(header.Name, header.Linkname); err != nil {
 				return err
 			}
-
-			if _, err := io.Copy(outFile, tarReader); err != nil {
-				return err
-			}
-
-			if err := outFile.Close(); err != nil {
+		case tar.TypeLink:
+			if err := e.link(header.Name, header.Linkname); err != nil {
 				return err
 			}
 		}
@@ -64,13 +158,36 @@ func ExtractTar(reader io.Reader, dir string) error {
 	return nil
 }
 
-func ExtractTarGZ(reader io.Reader, dir string) error {
+func ExtractTarGZ(reader io.Reader, dir string, limits Limits) error {
 	gzr, err := gzip.NewReader(reader)
 	if err != nil {
 		return err
 	}
 
-	return ExtractTar(gzr, dir)
+	return ExtractTar(gzr, dir, limits)
+}
+
+func ExtractTarXZ(reader io.Reader, dir string, limits Limits) error {
+	xzr, err := xz.NewReader(reader)
+	if err != nil {
+		return err
+	}
+
+	return ExtractTar(xzr, dir, limits)
+}
+
+func ExtractTarBZ2(reader io.Reader, dir string, limits Limits) error {
+	return ExtractTar(bzip2.NewReader(reader), dir, limits)
+}
+
+func ExtractTarZstd(reader io.Reader, dir string, limits Limits) error {
+	zr, err := zstd.NewReader(reader)
+	if err != nil {
+		return err
+	}
+	defer zr.Close()
+
+	return ExtractTar(zr, dir, limits)
 }
 
 func IsZip(fileName string) bool {
@@ -90,46 +207,37 @@ func IsZip(fileName string) bool {
 	return http.DetectContentType(buf) == "application/zip"
 }
 
-func ExtractZip(reader io.ReaderAt, size int64, dir string) error {
+func ExtractZip(reader io.ReaderAt, size int64, dir string, limits Limits) error {
+	e, err := newExtractor(dir, limits)
+	if err != nil {
+		return err
+	}
+
 	zipReader, err := zip.NewReader(reader, size)
 	if err != nil {
 		return err
 	}
 
 	for _, file := range zipReader.File {
-		filePath := filepath.Join(dir, file.Name)
 		fileMode := file.Mode()
 		if isFatFile(file.FileHeader) {
 			fileMode = 0777
 		}
 
 		if file.FileInfo().IsDir() {
-			err := os.MkdirAll(filePath, fileMode)
-			if err != nil {
+			if err := e.mkdir(file.Name, fileMode); err != nil {
 				return err
 			}
 			continue
 		}
 
-		if err = os.MkdirAll(filepath.Dir(filePath), os.ModePerm); err != nil {
-			return err
-		}
-
-		outFile, err := os.OpenFile(filePath, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, fileMode)
-		if err != nil {
-			return err
-		}
-
 		srcFile, err := file.Open()
 		if err != nil {
 			return err
 		}
 
-		if _, err := io.Copy(outFile, srcFile); err != nil {
-			return err
-		}
-
-		if err := outFile.Close(); err != nil {
+		if err := e.writeFile(file.Name, fileMode, srcFile); err != nil {
+			srcFile.Close()
 			return err
 		}
 
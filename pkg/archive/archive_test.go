@@ -0,0 +1,193 @@
+package archive_test
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/sclevine/spec"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/pivotal/kpack/pkg/archive"
+)
+
+func TestArchive(t *testing.T) {
+	spec.Run(t, "testArchive", testArchive)
+}
+
+type tarEntry struct {
+	header   tar.Header
+	contents string
+}
+
+func file(name, contents string) tarEntry {
+	return tarEntry{header: tar.Header{Name: name, Typeflag: tar.TypeReg, Mode: 0644, Size: int64(len(contents))}, contents: contents}
+}
+
+func symlink(name, target string) tarEntry {
+	return tarEntry{header: tar.Header{Name: name, Typeflag: tar.TypeSymlink, Linkname: target, Mode: 0777}}
+}
+
+func hardlink(name, target string) tarEntry {
+	return tarEntry{header: tar.Header{Name: name, Typeflag: tar.TypeLink, Linkname: target, Mode: 0644}}
+}
+
+func testArchive(t *testing.T, when spec.G, it spec.S) {
+	var (
+		parentDir string
+		dir       string
+	)
+
+	it.Before(func() {
+		var err error
+		parentDir, err = ioutil.TempDir("", "archive-test")
+		require.NoError(t, err)
+
+		dir = filepath.Join(parentDir, "workspace")
+	})
+
+	it.After(func() {
+		require.NoError(t, os.RemoveAll(parentDir))
+	})
+
+	tarball := func(entries ...tarEntry) *bytes.Reader {
+		buf := &bytes.Buffer{}
+		tw := tar.NewWriter(buf)
+		for _, entry := range entries {
+			header := entry.header
+			require.NoError(t, tw.WriteHeader(&header))
+			_, err := tw.Write([]byte(entry.contents))
+			require.NoError(t, err)
+		}
+		require.NoError(t, tw.Close())
+		return bytes.NewReader(buf.Bytes())
+	}
+
+	assertNotCreated := func(name string) {
+		t.Helper()
+		_, err := os.Lstat(filepath.Join(parentDir, name))
+		assert.True(t, os.IsNotExist(err), "expected %s to not exist", name)
+	}
+
+	when("#ExtractTar", func() {
+		it("extracts files and links that stay within the directory", func() {
+			err := archive.ExtractTar(tarball(
+				file("app/main.go", "package main"),
+				symlink("app/current", "main.go"),
+				symlink("app/vendor", "../lib"),
+				hardlink("app/copy.go", "app/main.go"),
+				file("lib/lib.go", "package lib"),
+			), dir, archive.Limits{})
+			require.NoError(t, err)
+
+			contents, err := ioutil.ReadFile(filepath.Join(dir, "app", "current"))
+			require.NoError(t, err)
+			assert.Equal(t, "package main", string(contents))
+
+			contents, err = ioutil.ReadFile(filepath.Join(dir, "app", "vendor", "lib.go"))
+			require.NoError(t, err)
+			assert.Equal(t, "package lib", string(contents))
+
+			contents, err = ioutil.ReadFile(filepath.Join(dir, "app", "copy.go"))
+			require.NoError(t, err)
+			assert.Equal(t, "package main", string(contents))
+		})
+
+		it("rejects entries outside of the directory", func() {
+			err := archive.ExtractTar(tarball(file("../evil", "evil")), dir, archive.Limits{})
+			require.EqualError(t, err, `archive entry "../evil" is outside the target directory`)
+			assertNotCreated("evil")
+		})
+
+		it("rejects symlinks leaving the directory", func() {
+			err := archive.ExtractTar(tarball(symlink("escape", "../..")), dir, archive.Limits{})
+			require.EqualError(t, err, `archive entry "escape" links to "../.." outside the target directory`)
+
+			err = archive.ExtractTar(tarball(symlink("etc", "/etc")), dir, archive.Limits{})
+			require.EqualError(t, err, `archive entry "etc" links to "/etc" outside the target directory`)
+		})
+
+		it("rejects entries written through a chain of symlinks leaving the directory", func() {
+			err := archive.ExtractTar(tarball(
+				symlink("a/up", ".."),
+				symlink("escape", "a/up/.."),
+				file("escape/evil", "evil"),
+			), dir, archive.Limits{})
+			require.EqualError(t, err, `archive entry "escape/evil" is outside the target directory`)
+			assertNotCreated("evil")
+		})
+
+		it("replaces a symlink instead of writing through it", func() {
+			require.NoError(t, os.MkdirAll(dir, os.ModePerm))
+			require.NoError(t, ioutil.WriteFile(filepath.Join(dir, "target"), []byte("original"), 0644))
+
+			err := archive.ExtractTar(tarball(
+				symlink("link", "target"),
+				file("link", "replaced"),
+			), dir, archive.Limits{})
+			require.NoError(t, err)
+
+			contents, err := ioutil.ReadFile(filepath.Join(dir, "target"))
+			require.NoError(t, err)
+			assert.Equal(t, "original", string(contents))
+		})
+
+		it("rejects hard links to files outside of the directory", func() {
+			err := archive.ExtractTar(tarball(hardlink("passwd", "../../etc/passwd")), dir, archive.Limits{})
+			require.EqualError(t, err, `archive entry "passwd" links to "../../etc/passwd" outside the target directory`)
+		})
+
+		it("enforces the maximum number of files", func() {
+			err := archive.ExtractTar(tarball(
+				file("one", "1"),
+				file("two", "2"),
+				file("three", "3"),
+			), dir, archive.Limits{MaxFiles: 2})
+			require.EqualError(t, err, "archive exceeds the maximum of 2 files")
+		})
+
+		it("enforces the maximum extracted size", func() {
+			err := archive.ExtractTar(tarball(
+				file("one", "12345"),
+				file("two", "67890"),
+			), dir, archive.Limits{MaxSize: 8})
+			require.EqualError(t, err, "archive exceeds the maximum extracted size of 8 bytes")
+		})
+	})
+
+	when("#ExtractZip", func() {
+		zipball := func(names ...string) *bytes.Reader {
+			buf := &bytes.Buffer{}
+			zw := zip.NewWriter(buf)
+			for _, name := range names {
+				w, err := zw.Create(name)
+				require.NoError(t, err)
+				_, err = w.Write([]byte("contents"))
+				require.NoError(t, err)
+			}
+			require.NoError(t, zw.Close())
+			return bytes.NewReader(buf.Bytes())
+		}
+
+		it("rejects entries outside of the directory", func() {
+			reader := zipball("../evil")
+			err := archive.ExtractZip(reader, reader.Size(), dir, archive.Limits{})
+			require.EqualError(t, err, `archive entry "../evil" is outside the target directory`)
+			assertNotCreated("evil")
+		})
+
+		it("enforces the limits", func() {
+			reader := zipball("one", "two")
+			err := archive.ExtractZip(reader, reader.Size(), dir, archive.Limits{MaxFiles: 1})
+			require.EqualError(t, err, "archive exceeds the maximum of 1 files")
+
+			err = archive.ExtractZip(reader, reader.Size(), dir, archive.Limits{MaxSize: 10})
+			require.EqualError(t, err, "archive exceeds the maximum extracted size of 10 bytes")
+		})
+	})
+}
@@ -0,0 +1,203 @@
+package archive
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	DefaultMaxSize  int64 = 10 * 1024 * 1024 * 1024
+	DefaultMaxFiles int64 = 1000000
+)
+
+// Limits bound what an archive may extract. Zero values use the defaults.
+type Limits struct {
+	// MaxSize is the total size in bytes of the extracted files.
+	MaxSize int64
+	// MaxFiles is the number of extracted files, directories and links.
+	MaxFiles int64
+}
+
+func (l Limits) maxSize() int64 {
+	if l.MaxSize <= 0 {
+		return DefaultMaxSize
+	}
+	return l.MaxSize
+}
+
+func (l Limits) maxFiles() int64 {
+	if l.MaxFiles <= 0 {
+		return DefaultMaxFiles
+	}
+	return l.MaxFiles
+}
+
+// extractor writes archive entries below dir. Entries, and the links they
+// are written through, may not leave dir.
+type extractor struct {
+	dir     string
+	realDir string
+	limits  Limits
+	files   int64
+	size    int64
+}
+
+func newExtractor(dir string, limits Limits) (*extractor, error) {
+	dir, err := filepath.Abs(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := os.MkdirAll(dir, os.ModePerm); err != nil {
+		return nil, err
+	}
+
+	realDir, err := filepath.EvalSymlinks(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	return &extractor{
+		dir:     dir,
+		realDir: realDir,
+		limits:  limits,
+	}, nil
+}
+
+func (e *extractor) mkdir(name string, mode os.FileMode) error {
+	path, err := e.entry(name)
+	if err != nil {
+		return err
+	}
+
+	return os.MkdirAll(path, mode)
+}
+
+func (e *extractor) writeFile(name string, mode os.FileMode, reader io.Reader) error {
+	path, err := e.entry(name)
+	if err != nil {
+		return err
+	}
+
+	if err := e.prepare(path); err != nil {
+		return err
+	}
+
+	outFile, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, mode)
+	if err != nil {
+		return err
+	}
+	defer outFile.Close()
+
+	remaining := e.limits.maxSize() - e.size
+	n, err := io.CopyN(outFile, reader, remaining+1)
+	e.size += n
+	if err != nil && err != io.EOF {
+		return err
+	}
+	if e.size > e.limits.maxSize() {
+		return errors.Errorf("archive exceeds the maximum extracted size of %d bytes", e.limits.maxSize())
+	}
+
+	return outFile.Close()
+}
+
+func (e *extractor) symlink(name, target string) error {
+	path, err := e.entry(name)
+	if err != nil {
+		return err
+	}
+
+	if filepath.IsAbs(target) || !within(e.dir, filepath.Join(filepath.Dir(path), target)) {
+		return errors.Errorf("archive entry %q links to %q outside the target directory", name, target)
+	}
+
+	if err := e.prepare(path); err != nil {
+		return err
+	}
+
+	return os.Symlink(target, path)
+}
+
+func (e *extractor) link(name, target string) error {
+	path, err := e.entry(name)
+	if err != nil {
+		return err
+	}
+
+	targetPath, err := e.path(target)
+	if err != nil {
+		return errors.Errorf("archive entry %q links to %q outside the target directory", name, target)
+	}
+
+	if err := e.prepare(path); err != nil {
+		return err
+	}
+
+	return os.Link(targetPath, path)
+}
+
+// entry counts the entry name against the file limit and returns where it
+// is extracted to.
+func (e *extractor) entry(name string) (string, error) {
+	e.files++
+	if e.files > e.limits.maxFiles() {
+		return "", errors.Errorf("archive exceeds the maximum of %d files", e.limits.maxFiles())
+	}
+
+	return e.path(name)
+}
+
+// path returns where name is extracted to. It fails for names outside of the
+// target directory, either by name or through a symlink extracted earlier.
+func (e *extractor) path(name string) (string, error) {
+	path := filepath.Join(e.dir, name)
+	if !within(e.dir, path) {
+		return "", errors.Errorf("archive entry %q is outside the target directory", name)
+	}
+
+	existing := filepath.Dir(path)
+	for {
+		if _, err := os.Lstat(existing); err == nil {
+			break
+		} else if !os.IsNotExist(err) {
+			return "", err
+		}
+		existing = filepath.Dir(existing)
+	}
+
+	realExisting, err := filepath.EvalSymlinks(existing)
+	if err != nil {
+		return "", err
+	}
+	if !within(e.realDir, realExisting) {
+		return "", errors.Errorf("archive entry %q is outside the target directory", name)
+	}
+
+	return path, nil
+}
+
+// prepare creates the parent directories of path and removes a file or link
+// already at path, so it is replaced instead of written through.
+func (e *extractor) prepare(path string) error {
+	if err := os.MkdirAll(filepath.Dir(path), os.ModePerm); err != nil {
+		return err
+	}
+
+	if info, err := os.Lstat(path); err == nil && !info.IsDir() {
+		return os.Remove(path)
+	}
+	return nil
+}
+
+func within(dir, path string) bool {
+	rel, err := filepath.Rel(dir, path)
+	if err != nil {
+		return false
+	}
+	return rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator))
+}
@@ -1,6 +1,8 @@
 package blob
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
 	"io"
 	"io/ioutil"
 	"log"
@@ -13,48 +15,40 @@ import (
 	"github.com/pivotal/kpack/pkg/archive"
 )
 
-var unexpectedBlobTypeError = errors.New("unexpected blob file type, must be one of .zip, .tar.gz, .tar, .jar")
+var unexpectedBlobTypeError = errors.New("unexpected blob file type, must be one of .zip, .jar, .tar, .tar.gz, .tar.xz, .tar.bz2, .tar.zst")
 
 type Fetcher struct {
-	Logger *log.Logger
+	Logger   *log.Logger
+	Keychain *Keychain
+	Limits   archive.Limits
 }
 
-func (f *Fetcher) Fetch(dir string, blobURL string) error {
+// Fetch downloads and extracts the blob at blobURL into dir. The blob is
+// verified against digest, of the form sha256:<hex>, when it is not empty.
+func (f *Fetcher) Fetch(dir string, blobURL string, digest string) error {
 	u, err := url.Parse(blobURL)
 	if err != nil {
 		return err
 	}
 	f.Logger.Printf("Downloading %s%s...", u.Host, u.Path)
 
-	file, err := downloadBlob(blobURL)
+	file, err := f.downloadBlob(blobURL)
 	if err != nil {
 		return err
 	}
 	defer os.RemoveAll(file.Name())
 
-	mediaType, err := classifyFile(file)
-	if err != nil {
-		return err
-	}
-
-	switch mediaType {
-	case "application/zip":
-		info, err := file.Stat()
-		if err != nil {
+	if digest != "" {
+		if err := verifyDigest(file, digest); err != nil {
 			return err
 		}
-		err = archive.ExtractZip(file, info.Size(), dir)
-	case "application/x-gzip":
-		err = archive.ExtractTarGZ(file, dir)
-	case "application/octet-stream":
-		if !archive.IsTar(file.Name()) {
-			return unexpectedBlobTypeError
-		}
-		err = archive.ExtractTar(file, dir)
-	default:
-		return unexpectedBlobTypeError
+		f.Logger.Printf("Verified blob digest %s", digest)
 	}
-	if err != nil {
+
+	err = archive.Extract(file, dir, f.Limits)
+	if err == archive.ErrUnsupportedArchive {
+		return unexpectedBlobTypeError
+	} else if err != nil {
 		return err
 	}
 
@@ -63,8 +57,24 @@ func (f *Fetcher) Fetch(dir string, blobURL string) error {
 	return nil
 }
 
-func downloadBlob(blobURL string) (*os.File, error) {
-	resp, err := http.Get(blobURL)
+func (f *Fetcher) downloadBlob(blobURL string) (*os.File, error) {
+	req, err := http.NewRequest(http.MethodGet, blobURL, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	client := http.DefaultClient
+	if cred, ok := f.Keychain.resolve(blobURL); ok {
+		f.Logger.Printf("Using blob secret %q", cred.secretName)
+		cred.authorize(req)
+
+		client, err = cred.client()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	resp, err := client.Do(req)
 	if err != nil {
 		return nil, err
 	}
@@ -92,17 +102,19 @@ func downloadBlob(blobURL string) (*os.File, error) {
 	return file, nil
 }
 
-func classifyFile(reader io.ReadSeeker) (string, error) {
-	buf := make([]byte, 512)
-	_, err := reader.Read(buf)
-	if err != nil {
-		return "", err
+func verifyDigest(file *os.File, digest string) error {
+	hash := sha256.New()
+	if _, err := io.Copy(hash, file); err != nil {
+		return err
 	}
 
-	_, err = reader.Seek(0, 0)
-	if err != nil {
-		return "", err
+	if _, err := file.Seek(0, 0); err != nil {
+		return err
 	}
 
-	return http.DetectContentType(buf), nil
+	actual := "sha256:" + hex.EncodeToString(hash.Sum(nil))
+	if actual != digest {
+		return errors.Errorf("blob digest mismatch: expected %s but downloaded %s", digest, actual)
+	}
+	return nil
 }
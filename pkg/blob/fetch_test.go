@@ -2,6 +2,8 @@ package blob_test
 
 import (
 	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
 	"io/ioutil"
 	"log"
@@ -43,10 +45,10 @@ func testBlobFetcher(t *testing.T, when spec.G, it spec.S) {
 		require.NoError(t, os.RemoveAll(dir))
 	})
 
-	for _, f := range []string{"test.zip", "test.tar", "test.tar.gz"} {
+	for _, f := range []string{"test.zip", "test.tar", "test.tar.gz", "test.tar.xz", "test.tar.bz2", "test.tar.zst"} {
 		testFile := f
 		it("unpacks "+testFile, func() {
-			err := fetcher.Fetch(dir, fmt.Sprintf("%s/%s", server.URL, testFile))
+			err := fetcher.Fetch(dir, fmt.Sprintf("%s/%s", server.URL, testFile), "")
 			require.NoError(t, err)
 
 			files, err := ioutil.ReadDir(dir)
@@ -75,7 +77,7 @@ func testBlobFetcher(t *testing.T, when spec.G, it spec.S) {
 		// Set no umask to test file mode
 		oldMask := syscall.Umask(0)
 		defer syscall.Umask(oldMask)
-		err := fetcher.Fetch(dir, fmt.Sprintf("%s/%s", server.URL, "fat-zip.zip"))
+		err := fetcher.Fetch(dir, fmt.Sprintf("%s/%s", server.URL, "fat-zip.zip"), "")
 		require.NoError(t, err)
 
 		files, err := ioutil.ReadDir(dir)
@@ -91,7 +93,7 @@ func testBlobFetcher(t *testing.T, when spec.G, it spec.S) {
 	})
 
 	it("sets the correct file mode", func() {
-		err := fetcher.Fetch(dir, fmt.Sprintf("%s/%s", server.URL, "test-exe.tar"))
+		err := fetcher.Fetch(dir, fmt.Sprintf("%s/%s", server.URL, "test-exe.tar"), "")
 		require.NoError(t, err)
 
 		files, err := ioutil.ReadDir(dir)
@@ -117,17 +119,126 @@ func testBlobFetcher(t *testing.T, when spec.G, it spec.S) {
 
 	it("errors when url is inaccessible", func() {
 		url := fmt.Sprintf("%s/%s", server.URL, "invalid.zip")
-		err := fetcher.Fetch(dir, fmt.Sprintf("%s/%s", server.URL, "invalid.zip"))
+		err := fetcher.Fetch(dir, fmt.Sprintf("%s/%s", server.URL, "invalid.zip"), "")
 		require.EqualError(t, err, fmt.Sprintf("failed to get blob %s", url))
 	})
 
 	it("errors when the blob file type is unexpected", func() {
-		err := fetcher.Fetch(dir, fmt.Sprintf("%s/%s", server.URL, "test.txt"))
-		require.EqualError(t, err, "unexpected blob file type, must be one of .zip, .tar.gz, .tar, .jar")
+		err := fetcher.Fetch(dir, fmt.Sprintf("%s/%s", server.URL, "test.txt"), "")
+		require.EqualError(t, err, "unexpected blob file type, must be one of .zip, .jar, .tar, .tar.gz, .tar.xz, .tar.bz2, .tar.zst")
 	})
 
 	it("errors when the blob content type is unexpected", func() {
-		err := fetcher.Fetch(dir, fmt.Sprintf("%s/%s", server.URL, "test.html"))
-		require.EqualError(t, err, "unexpected blob file type, must be one of .zip, .tar.gz, .tar, .jar")
+		err := fetcher.Fetch(dir, fmt.Sprintf("%s/%s", server.URL, "test.html"), "")
+		require.EqualError(t, err, "unexpected blob file type, must be one of .zip, .jar, .tar, .tar.gz, .tar.xz, .tar.bz2, .tar.zst")
+	})
+
+	when("a digest is provided", func() {
+		digest := func(name string) string {
+			contents, err := ioutil.ReadFile(filepath.Join("testdata", name))
+			require.NoError(t, err)
+			sum := sha256.Sum256(contents)
+			return "sha256:" + hex.EncodeToString(sum[:])
+		}
+
+		it("verifies the blob", func() {
+			err := fetcher.Fetch(dir, fmt.Sprintf("%s/%s", server.URL, "test.zip"), digest("test.zip"))
+			require.NoError(t, err)
+
+			require.Contains(t, output.String(), "Verified blob digest")
+			require.Contains(t, output.String(), "Successfully downloaded")
+		})
+
+		it("errors when the blob does not match the digest", func() {
+			err := fetcher.Fetch(dir, fmt.Sprintf("%s/%s", server.URL, "test.zip"), digest("test.tar"))
+			require.EqualError(t, err, fmt.Sprintf("blob digest mismatch: expected %s but downloaded %s", digest("test.tar"), digest("test.zip")))
+
+			files, err := ioutil.ReadDir(dir)
+			require.NoError(t, err)
+			require.Empty(t, files)
+		})
+	})
+
+	when("the blob server requires authentication", func() {
+		var (
+			authServer *httptest.Server
+			secretsDir string
+		)
+
+		writeSecret := func(name string, data map[string]string) {
+			require.NoError(t, os.MkdirAll(filepath.Join(secretsDir, name), 0777))
+			for key, value := range data {
+				require.NoError(t, ioutil.WriteFile(filepath.Join(secretsDir, name, key), []byte(value), 0600))
+			}
+		}
+
+		it.Before(func() {
+			authServer = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				username, password, ok := r.BasicAuth()
+				basicAuthorized := ok && username == "some-username" && password == "some-password"
+				if !basicAuthorized && r.Header.Get("Authorization") != "Bearer some-token" {
+					w.WriteHeader(http.StatusUnauthorized)
+					return
+				}
+				handler.ServeHTTP(w, r)
+			}))
+
+			var err error
+			secretsDir, err = ioutil.TempDir("", "blob-secrets")
+			require.NoError(t, err)
+		})
+
+		it.After(func() {
+			authServer.Close()
+			require.NoError(t, os.RemoveAll(secretsDir))
+		})
+
+		it("uses a basic auth secret matching the url", func() {
+			writeSecret("basic", map[string]string{"username": "some-username", "password": "some-password"})
+			writeSecret("other", map[string]string{"username": "other-username", "password": "other-password"})
+
+			keychain, err := blob.NewMountedSecretBlobKeychain(secretsDir, []string{"basic=" + authServer.URL, "other=https://other.example.com"}, nil)
+			require.NoError(t, err)
+			fetcher.Keychain = keychain
+
+			err = fetcher.Fetch(dir, fmt.Sprintf("%s/%s", authServer.URL, "test.zip"), "")
+			require.NoError(t, err)
+			require.Contains(t, output.String(), `Using blob secret "basic"`)
+		})
+
+		it("uses a bearer token secret matching the url", func() {
+			writeSecret("bearer", map[string]string{"token": "some-token\n"})
+
+			keychain, err := blob.NewMountedSecretBlobKeychain(secretsDir, nil, []string{"bearer=" + authServer.URL})
+			require.NoError(t, err)
+			fetcher.Keychain = keychain
+
+			err = fetcher.Fetch(dir, fmt.Sprintf("%s/%s", authServer.URL, "test.zip"), "")
+			require.NoError(t, err)
+		})
+
+		it("uses a secret annotated with a url containing =", func() {
+			writeSecret("bearer", map[string]string{"token": "some-token"})
+
+			url := fmt.Sprintf("%s/%s", authServer.URL, "test.zip?version=2")
+			keychain, err := blob.NewMountedSecretBlobKeychain(secretsDir, nil, []string{"bearer=" + url})
+			require.NoError(t, err)
+			fetcher.Keychain = keychain
+
+			err = fetcher.Fetch(dir, url, "")
+			require.NoError(t, err)
+		})
+
+		it("errors without a matching secret", func() {
+			writeSecret("other", map[string]string{"token": "some-token"})
+
+			keychain, err := blob.NewMountedSecretBlobKeychain(secretsDir, nil, []string{"other=" + authServer.URL + "/other"})
+			require.NoError(t, err)
+			fetcher.Keychain = keychain
+
+			url := fmt.Sprintf("%s/%s", authServer.URL, "test.zip")
+			err = fetcher.Fetch(dir, url, "")
+			require.EqualError(t, err, fmt.Sprintf("failed to get blob %s", url))
+		})
 	})
 }
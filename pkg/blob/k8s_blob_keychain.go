@@ -0,0 +1,62 @@
+package blob
+
+import (
+	"context"
+	"net/http"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	k8sclient "k8s.io/client-go/kubernetes"
+
+	buildapi "github.com/pivotal/kpack/pkg/apis/build/v1alpha2"
+	"github.com/pivotal/kpack/pkg/secret"
+)
+
+type k8sBlobKeychainFactory struct {
+	secretFetcher secret.Fetcher
+}
+
+func newK8sBlobKeychainFactory(k8sClient k8sclient.Interface) *k8sBlobKeychainFactory {
+	return &k8sBlobKeychainFactory{secretFetcher: secret.Fetcher{Client: k8sClient}}
+}
+
+// KeychainForServiceAccount returns the credentials of the blob secrets of
+// serviceAccount, classified the same way as the secrets mounted into builds.
+func (k *k8sBlobKeychainFactory) KeychainForServiceAccount(ctx context.Context, namespace, serviceAccount string) (*Keychain, error) {
+	secrets, err := k.secretFetcher.SecretsForServiceAccount(ctx, serviceAccount, namespace)
+	if err != nil && !k8serrors.IsNotFound(err) {
+		return nil, err
+	}
+
+	var creds []blobCredential
+	for _, s := range secrets {
+		url := s.Annotations[buildapi.BlobSecretAnnotationPrefix]
+		switch {
+		case url == "":
+			continue
+		case s.Type == corev1.SecretTypeBasicAuth:
+			username, password := string(s.Data[corev1.BasicAuthUsernameKey]), string(s.Data[corev1.BasicAuthPasswordKey])
+			creds = append(creds, blobCredential{
+				url:        url,
+				secretName: s.Name,
+				authorize: func(req *http.Request) {
+					req.SetBasicAuth(username, password)
+				},
+				caCert: string(s.Data[secret.CACertKey]),
+			})
+		case len(s.Data[secret.BearerTokenKey]) > 0:
+			token := strings.TrimSpace(string(s.Data[secret.BearerTokenKey]))
+			creds = append(creds, blobCredential{
+				url:        url,
+				secretName: s.Name,
+				authorize: func(req *http.Request) {
+					req.Header.Set("Authorization", "Bearer "+token)
+				},
+				caCert: string(s.Data[secret.CACertKey]),
+			})
+		}
+	}
+
+	return &Keychain{creds: creds}, nil
+}
@@ -0,0 +1,146 @@
+package blob
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"net/http"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/pivotal/kpack/pkg/secret"
+)
+
+type blobCredential struct {
+	url        string
+	secretName string
+	authorize  func(req *http.Request)
+	caCert     string
+}
+
+// Keychain holds the credentials of the blob secrets mounted into the build.
+type Keychain struct {
+	creds []blobCredential
+}
+
+func NewMountedSecretBlobKeychain(volumeName string, basicAuthSecrets, bearerTokenSecrets []string) (*Keychain, error) {
+	var creds []blobCredential
+
+	for _, s := range basicAuthSecrets {
+		secretName, url, err := parseSecretArg(s)
+		if err != nil {
+			return nil, err
+		}
+
+		basicAuth, err := secret.ReadBasicAuthSecret(volumeName, secretName)
+		if err != nil {
+			return nil, errors.Wrapf(err, "reading blob secret %s", secretName)
+		}
+
+		creds = append(creds, blobCredential{
+			url:        url,
+			secretName: secretName,
+			authorize: func(req *http.Request) {
+				req.SetBasicAuth(basicAuth.Username, basicAuth.Password)
+			},
+			caCert: basicAuth.CACert,
+		})
+	}
+
+	for _, s := range bearerTokenSecrets {
+		secretName, url, err := parseSecretArg(s)
+		if err != nil {
+			return nil, err
+		}
+
+		bearerToken, err := secret.ReadBearerTokenSecret(volumeName, secretName)
+		if err != nil {
+			return nil, errors.Wrapf(err, "reading blob secret %s", secretName)
+		}
+
+		creds = append(creds, blobCredential{
+			url:        url,
+			secretName: secretName,
+			authorize: func(req *http.Request) {
+				req.Header.Set("Authorization", "Bearer "+strings.TrimSpace(bearerToken.Token))
+			},
+			caCert: bearerToken.CACert,
+		})
+	}
+
+	return &Keychain{creds: creds}, nil
+}
+
+func parseSecretArg(arg string) (string, string, error) {
+	splitSecret := strings.SplitN(arg, "=", 2)
+	if len(splitSecret) != 2 {
+		return "", "", errors.Errorf("could not parse blob secret argument %s", arg)
+	}
+	return splitSecret[0], splitSecret[1], nil
+}
+
+// resolve returns the credential whose annotated url is the longest prefix of
+// blobURL. Annotated urls without a scheme match any scheme.
+func (k *Keychain) resolve(blobURL string) (blobCredential, bool) {
+	if k == nil {
+		return blobCredential{}, false
+	}
+
+	var (
+		match   blobCredential
+		matched bool
+	)
+	for _, cred := range k.creds {
+		if urlPrefixMatch(blobURL, cred.url) && (!matched || len(withoutScheme(cred.url)) > len(withoutScheme(match.url))) {
+			match = cred
+			matched = true
+		}
+	}
+	return match, matched
+}
+
+func urlPrefixMatch(blobURL, prefix string) bool {
+	if strings.Contains(prefix, "://") && !strings.HasPrefix(strings.ToLower(blobURL), strings.ToLower(schemeOf(prefix))+"://") {
+		return false
+	}
+
+	target := withoutScheme(blobURL)
+	prefix = strings.TrimSuffix(withoutScheme(prefix), "/")
+	if !strings.HasPrefix(target, prefix) {
+		return false
+	}
+
+	rest := target[len(prefix):]
+	return rest == "" || strings.HasPrefix(rest, "/") || strings.HasPrefix(rest, "?")
+}
+
+func schemeOf(url string) string {
+	return url[:strings.Index(url, "://")]
+}
+
+func withoutScheme(url string) string {
+	if i := strings.Index(url, "://"); i >= 0 {
+		return url[i+len("://"):]
+	}
+	return url
+}
+
+// client returns a http client trusting the system certificate authorities
+// and the ca.crt of cred.
+func (c blobCredential) client() (*http.Client, error) {
+	if c.caCert == "" {
+		return http.DefaultClient, nil
+	}
+
+	roots, err := x509.SystemCertPool()
+	if err != nil {
+		roots = x509.NewCertPool()
+	}
+	if !roots.AppendCertsFromPEM([]byte(c.caCert)) {
+		return nil, errors.Errorf("parsing %s of blob secret %s: no certificates found", secret.CACertKey, c.secretName)
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.TLSClientConfig = &tls.Config{RootCAs: roots}
+	return &http.Client{Transport: transport}, nil
+}
@@ -2,23 +2,126 @@ package blob
 
 import (
 	"context"
+	"net/http"
+
+	"github.com/pkg/errors"
+	k8sclient "k8s.io/client-go/kubernetes"
 
 	buildapi "github.com/pivotal/kpack/pkg/apis/build/v1alpha2"
 	corev1alpha1 "github.com/pivotal/kpack/pkg/apis/core/v1alpha1"
 )
 
+const FingerprintFailedReason = "BlobFingerprintFailed"
+
+// FingerprintError is returned when a blob that was fingerprinted before
+// cannot be fingerprinted again. The last fingerprint is kept.
+type FingerprintError struct {
+	err error
+}
+
+func (e *FingerprintError) Error() string {
+	return e.err.Error()
+}
+
+func (e *FingerprintError) Reason() string {
+	return FingerprintFailedReason
+}
+
+func (e *FingerprintError) Temporary() bool {
+	return true
+}
+
 type Resolver struct {
+	// K8sClient is used to read the blob secrets of the service account
+	// authorizing the HEAD requests. Requests are anonymous when it is nil.
+	K8sClient k8sclient.Interface
+	// Client is used for the HEAD requests fingerprinting blobs and defaults
+	// to http.DefaultClient.
+	Client *http.Client
 }
 
-func (*Resolver) Resolve(ctx context.Context, sourceResolver *buildapi.SourceResolver) (corev1alpha1.ResolvedSourceConfig, error) {
-	return corev1alpha1.ResolvedSourceConfig{
-		Blob: &corev1alpha1.ResolvedBlobSource{
-			URL:     sourceResolver.Spec.Source.Blob.URL,
-			SubPath: sourceResolver.Spec.Source.SubPath,
-		},
-	}, nil
+func NewResolver(k8sClient k8sclient.Interface) *Resolver {
+	return &Resolver{K8sClient: k8sClient}
+}
+
+func (r *Resolver) Resolve(ctx context.Context, sourceResolver *buildapi.SourceResolver) (corev1alpha1.ResolvedSourceConfig, error) {
+	resolved := &corev1alpha1.ResolvedBlobSource{
+		URL:     sourceResolver.Spec.Source.Blob.URL,
+		Digest:  sourceResolver.Spec.Source.Blob.Digest,
+		SubPath: sourceResolver.Spec.Source.SubPath,
+	}
+
+	// a blob pinned to a digest cannot change
+	if resolved.Digest == "" {
+		keychain, err := r.keychain(ctx, sourceResolver)
+		if err != nil {
+			return corev1alpha1.ResolvedSourceConfig{}, err
+		}
+
+		fingerprint, err := r.fingerprint(ctx, keychain, resolved.URL)
+		if last := sourceResolver.LastResolvedBlobSource(); err != nil && last != nil && last.Fingerprint != nil {
+			return corev1alpha1.ResolvedSourceConfig{}, &FingerprintError{err: errors.Wrapf(err, "fingerprinting %s", resolved.URL)}
+		}
+		resolved.Fingerprint = fingerprint
+	}
+
+	return corev1alpha1.ResolvedSourceConfig{Blob: resolved}, nil
 }
 
 func (*Resolver) CanResolve(sourceResolver *buildapi.SourceResolver) bool {
 	return sourceResolver.IsBlob()
 }
+
+func (r *Resolver) keychain(ctx context.Context, sourceResolver *buildapi.SourceResolver) (*Keychain, error) {
+	if r.K8sClient == nil {
+		return nil, nil
+	}
+	return newK8sBlobKeychainFactory(r.K8sClient).KeychainForServiceAccount(ctx, sourceResolver.Namespace, sourceResolver.Spec.ServiceAccountName)
+}
+
+// fingerprint returns the ETag, Last-Modified and Content-Length reported for
+// url. Blobs without a fingerprint are not polled for changes.
+func (r *Resolver) fingerprint(ctx context.Context, keychain *Keychain, url string) (*corev1alpha1.BlobFingerprint, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodHead, url, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	client := r.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	if cred, ok := keychain.resolve(url); ok {
+		cred.authorize(req)
+		if cred.caCert != "" {
+			client, err = cred.client()
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, errors.Errorf("unexpected status code %d", resp.StatusCode)
+	}
+
+	fingerprint := &corev1alpha1.BlobFingerprint{
+		ETag:         resp.Header.Get("ETag"),
+		LastModified: resp.Header.Get("Last-Modified"),
+	}
+	if resp.ContentLength > 0 {
+		fingerprint.ContentLength = resp.ContentLength
+	}
+
+	if *fingerprint == (corev1alpha1.BlobFingerprint{}) {
+		return nil, nil
+	}
+	return fingerprint, nil
+}
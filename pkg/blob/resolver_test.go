@@ -0,0 +1,222 @@
+package blob_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/sclevine/spec"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+
+	buildapi "github.com/pivotal/kpack/pkg/apis/build/v1alpha2"
+	corev1alpha1 "github.com/pivotal/kpack/pkg/apis/core/v1alpha1"
+	"github.com/pivotal/kpack/pkg/blob"
+)
+
+func TestBlobResolver(t *testing.T) {
+	spec.Run(t, "testBlobResolver", testBlobResolver)
+}
+
+func testBlobResolver(t *testing.T, when spec.G, it spec.S) {
+	var (
+		resolver = &blob.Resolver{}
+		headers  http.Header
+		status   int
+		server   *httptest.Server
+
+		authorization string
+	)
+
+	it.Before(func() {
+		headers = http.Header{}
+		status = http.StatusOK
+		server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, http.MethodHead, r.Method)
+			authorization = r.Header.Get("Authorization")
+			for k, v := range headers {
+				w.Header()[k] = v
+			}
+			w.WriteHeader(status)
+		}))
+	})
+
+	it.After(func() {
+		server.Close()
+	})
+
+	sourceResolver := func() *buildapi.SourceResolver {
+		return &buildapi.SourceResolver{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "some-source-resolver",
+				Namespace: "some-namespace",
+			},
+			Spec: buildapi.SourceResolverSpec{
+				ServiceAccountName: "some-service-account",
+				Source: corev1alpha1.SourceConfig{
+					Blob: &corev1alpha1.Blob{
+						URL: server.URL + "/latest.zip",
+					},
+					SubPath: "some-path",
+				},
+			},
+		}
+	}
+
+	when("#Resolve", func() {
+		it("fingerprints the blob", func() {
+			headers.Set("ETag", `"some-etag"`)
+			headers.Set("Last-Modified", "Wed, 21 Oct 2015 07:28:00 GMT")
+			headers.Set("Content-Length", "1234")
+
+			resolved, err := resolver.Resolve(context.Background(), sourceResolver())
+			require.NoError(t, err)
+
+			assert.Equal(t, corev1alpha1.ResolvedSourceConfig{
+				Blob: &corev1alpha1.ResolvedBlobSource{
+					URL: server.URL + "/latest.zip",
+					Fingerprint: &corev1alpha1.BlobFingerprint{
+						ETag:          `"some-etag"`,
+						LastModified:  "Wed, 21 Oct 2015 07:28:00 GMT",
+						ContentLength: 1234,
+					},
+					SubPath: "some-path",
+				},
+			}, resolved)
+			assert.True(t, resolved.Blob.IsPollable())
+		})
+
+		it("does not fingerprint a blob pinned to a digest", func() {
+			headers.Set("ETag", `"some-etag"`)
+
+			pinned := sourceResolver()
+			pinned.Spec.Source.Blob.Digest = "sha256:b94d27b9934d3e08a52e52d7da7dabfac484efe37a5380ee9088f7ace2efcde9"
+
+			resolved, err := resolver.Resolve(context.Background(), pinned)
+			require.NoError(t, err)
+
+			assert.Equal(t, corev1alpha1.ResolvedSourceConfig{
+				Blob: &corev1alpha1.ResolvedBlobSource{
+					URL:     server.URL + "/latest.zip",
+					Digest:  "sha256:b94d27b9934d3e08a52e52d7da7dabfac484efe37a5380ee9088f7ace2efcde9",
+					SubPath: "some-path",
+				},
+			}, resolved)
+			assert.False(t, resolved.Blob.IsPollable())
+		})
+
+		it("is not pollable when the server does not fingerprint the blob", func() {
+			resolved, err := resolver.Resolve(context.Background(), sourceResolver())
+			require.NoError(t, err)
+
+			assert.Nil(t, resolved.Blob.Fingerprint)
+			assert.False(t, resolved.Blob.IsPollable())
+		})
+
+		it("is not pollable when the HEAD request fails", func() {
+			headers.Set("ETag", `"some-etag"`)
+			status = http.StatusMethodNotAllowed
+
+			resolved, err := resolver.Resolve(context.Background(), sourceResolver())
+			require.NoError(t, err)
+
+			assert.Equal(t, server.URL+"/latest.zip", resolved.Blob.URL)
+			assert.False(t, resolved.Blob.IsPollable())
+		})
+
+		it("keeps the last fingerprint when the HEAD request fails", func() {
+			status = http.StatusServiceUnavailable
+
+			fingerprinted := sourceResolver()
+			fingerprinted.Status.Source = corev1alpha1.ResolvedSourceConfig{
+				Blob: &corev1alpha1.ResolvedBlobSource{
+					URL:         server.URL + "/latest.zip",
+					Fingerprint: &corev1alpha1.BlobFingerprint{ETag: `"some-etag"`},
+				},
+			}
+
+			_, err := resolver.Resolve(context.Background(), fingerprinted)
+
+			var fingerprintErr *blob.FingerprintError
+			require.True(t, errors.As(err, &fingerprintErr))
+			assert.Equal(t, blob.FingerprintFailedReason, fingerprintErr.Reason())
+			assert.True(t, fingerprintErr.Temporary())
+			assert.Contains(t, err.Error(), "unexpected status code 503")
+		})
+
+		when("the service account has blob secrets", func() {
+			var k8sClient *fake.Clientset
+
+			it.Before(func() {
+				k8sClient = fake.NewSimpleClientset(
+					&corev1.ServiceAccount{
+						ObjectMeta: metav1.ObjectMeta{
+							Name:      "some-service-account",
+							Namespace: "some-namespace",
+						},
+						Secrets: []corev1.ObjectReference{{Name: "basic"}, {Name: "bearer"}, {Name: "git"}},
+					},
+					&corev1.Secret{
+						ObjectMeta: metav1.ObjectMeta{
+							Name:        "basic",
+							Namespace:   "some-namespace",
+							Annotations: map[string]string{buildapi.BlobSecretAnnotationPrefix: "https://other.example.com"},
+						},
+						Type: corev1.SecretTypeBasicAuth,
+						Data: map[string][]byte{
+							corev1.BasicAuthUsernameKey: []byte("some-username"),
+							corev1.BasicAuthPasswordKey: []byte("some-password"),
+						},
+					},
+					&corev1.Secret{
+						ObjectMeta: metav1.ObjectMeta{
+							Name:        "bearer",
+							Namespace:   "some-namespace",
+							Annotations: map[string]string{buildapi.BlobSecretAnnotationPrefix: server.URL},
+						},
+						Data: map[string][]byte{
+							"token": []byte("some-token\n"),
+						},
+					},
+					&corev1.Secret{
+						ObjectMeta: metav1.ObjectMeta{
+							Name:        "git",
+							Namespace:   "some-namespace",
+							Annotations: map[string]string{buildapi.GITSecretAnnotationPrefix: server.URL},
+						},
+						Type: corev1.SecretTypeBasicAuth,
+						Data: map[string][]byte{
+							corev1.BasicAuthUsernameKey: []byte("git-username"),
+							corev1.BasicAuthPasswordKey: []byte("git-password"),
+						},
+					},
+				)
+				resolver = blob.NewResolver(k8sClient)
+			})
+
+			it("authorizes the HEAD request with the blob secret matching the url", func() {
+				headers.Set("ETag", `"some-etag"`)
+
+				resolved, err := resolver.Resolve(context.Background(), sourceResolver())
+				require.NoError(t, err)
+
+				assert.Equal(t, "Bearer some-token", authorization)
+				assert.True(t, resolved.Blob.IsPollable())
+			})
+
+			it("does not authorize the HEAD request without a matching blob secret", func() {
+				require.NoError(t, k8sClient.CoreV1().Secrets("some-namespace").Delete(context.Background(), "bearer", metav1.DeleteOptions{}))
+
+				_, err := resolver.Resolve(context.Background(), sourceResolver())
+				require.NoError(t, err)
+
+				assert.Empty(t, authorization)
+			})
+		})
+	})
+}
@@ -0,0 +1,54 @@
+package buildchange
+
+import (
+	buildapi "github.com/pivotal/kpack/pkg/apis/build/v1alpha2"
+	corev1alpha1 "github.com/pivotal/kpack/pkg/apis/core/v1alpha1"
+)
+
+func NewBlobChange(oldBlob *corev1alpha1.Blob, oldFingerprint *corev1alpha1.BlobFingerprint, newBlob *corev1alpha1.Blob, newFingerprint *corev1alpha1.BlobFingerprint) Change {
+	return blobChange{
+		oldBlob:        oldBlob,
+		oldFingerprint: oldFingerprint,
+		newBlob:        newBlob,
+		newFingerprint: newFingerprint,
+	}
+}
+
+type blobChange struct {
+	oldBlob        *corev1alpha1.Blob
+	oldFingerprint *corev1alpha1.BlobFingerprint
+	newBlob        *corev1alpha1.Blob
+	newFingerprint *corev1alpha1.BlobFingerprint
+}
+
+func (b blobChange) Reason() buildapi.BuildReason { return buildapi.BuildReasonBlob }
+
+func (b blobChange) IsBuildRequired() (bool, error) {
+	if b.newFingerprint == nil {
+		return false, nil
+	}
+
+	// Builds from before the blob was fingerprinted are rebuilt once to record
+	// the fingerprint, unless they downloaded the same blob pinned by its digest
+	if b.oldFingerprint == nil {
+		return !b.sameDigest(), nil
+	}
+	return *b.oldFingerprint != *b.newFingerprint, nil
+}
+
+func (b blobChange) sameDigest() bool {
+	return b.oldBlob != nil && b.newBlob != nil &&
+		b.oldBlob.URL == b.newBlob.URL &&
+		b.oldBlob.Digest != "" && b.oldBlob.Digest == b.newBlob.Digest
+}
+
+func (b blobChange) Old() interface{} {
+	if b.oldFingerprint == nil {
+		return nil
+	}
+	return b.oldFingerprint
+}
+
+func (b blobChange) New() interface{} { return b.newFingerprint }
+
+func (b blobChange) Priority() buildapi.BuildPriority { return buildapi.BuildPriorityHigh }
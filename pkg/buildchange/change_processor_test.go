@@ -107,6 +107,32 @@ func testChangeProcessor(t *testing.T, when spec.G, it spec.S) {
 					assert.NoError(t, err)
 				})
 			})
+
+			when("has tagged revisions", func() {
+				change := buildchange.NewTaggedCommitChange("old-revision", "v1.0.0", "new-revision", "v1.1.0")
+				expectedChangesStr := testhelpers.CompactJSON(`
+[
+  {
+    "reason": "COMMIT",
+    "old": {
+      "revision": "old-revision",
+      "tag": "v1.0.0"
+    },
+    "new": {
+      "revision": "new-revision",
+      "tag": "v1.1.0"
+    }
+  }
+]`)
+
+				it("includes the tags in the ChangeSummary", func() {
+					summary, err := cp.Process(change).Summarize()
+					assert.NoError(t, err)
+					assert.True(t, summary.HasChanges)
+					assert.Equal(t, "COMMIT", summary.ReasonsStr)
+					assert.Equal(t, expectedChangesStr, summary.ChangesStr)
+				})
+			})
 		})
 
 		when("CONFIG", func() {
@@ -387,6 +413,218 @@ func testChangeProcessor(t *testing.T, when spec.G, it spec.S) {
 				})
 			})
 		})
+
+		when("BLOB", func() {
+			when("has no difference", func() {
+				change := buildchange.NewBlobChange(
+					&corev1alpha1.Blob{URL: "some-url"}, &corev1alpha1.BlobFingerprint{ETag: "some-etag", ContentLength: 10},
+					&corev1alpha1.Blob{URL: "some-url"}, &corev1alpha1.BlobFingerprint{ETag: "some-etag", ContentLength: 10},
+				)
+
+				it("returns the correct ChangeSummary and does not error", func() {
+					summary, err := cp.Process(change).Summarize()
+					assert.NoError(t, err)
+					assert.False(t, summary.HasChanges)
+					assert.Equal(t, buildapi.BuildPriorityNone, summary.Priority)
+				})
+			})
+
+			when("the old build was not fingerprinted", func() {
+				it("requires a build to record the fingerprint", func() {
+					change := buildchange.NewBlobChange(
+						&corev1alpha1.Blob{URL: "some-url"}, nil,
+						&corev1alpha1.Blob{URL: "some-url"}, &corev1alpha1.BlobFingerprint{ETag: "some-etag"},
+					)
+
+					summary, err := cp.Process(change).Summarize()
+					assert.NoError(t, err)
+					assert.True(t, summary.HasChanges)
+					assert.Equal(t, buildapi.BuildReasonBlob, summary.ReasonsStr)
+					assert.Equal(t, `[{"reason":"BLOB","new":{"etag":"some-etag"}}]`, summary.ChangesStr)
+				})
+
+				it("does not require a build when the old build downloaded the same digest", func() {
+					change := buildchange.NewBlobChange(
+						&corev1alpha1.Blob{URL: "some-url", Digest: "sha256:some-digest"}, nil,
+						&corev1alpha1.Blob{URL: "some-url", Digest: "sha256:some-digest"}, &corev1alpha1.BlobFingerprint{ETag: "some-etag"},
+					)
+
+					summary, err := cp.Process(change).Summarize()
+					assert.NoError(t, err)
+					assert.False(t, summary.HasChanges)
+				})
+			})
+
+			when("has difference", func() {
+				change := buildchange.NewBlobChange(
+					&corev1alpha1.Blob{URL: "some-url"}, &corev1alpha1.BlobFingerprint{LastModified: "Wed, 21 Oct 2015 07:28:00 GMT", ContentLength: 10},
+					&corev1alpha1.Blob{URL: "some-url"}, &corev1alpha1.BlobFingerprint{LastModified: "Thu, 22 Oct 2015 07:28:00 GMT", ContentLength: 12},
+				)
+				expectedChangesStr := testhelpers.CompactJSON(`
+[
+  {
+    "reason": "BLOB",
+    "old": {
+      "lastModified": "Wed, 21 Oct 2015 07:28:00 GMT",
+      "contentLength": 10
+    },
+    "new": {
+      "lastModified": "Thu, 22 Oct 2015 07:28:00 GMT",
+      "contentLength": 12
+    }
+  }
+]`)
+
+				it("returns the correct ChangeSummary and does not error", func() {
+					summary, err := cp.Process(change).Summarize()
+					assert.NoError(t, err)
+					assert.True(t, summary.HasChanges)
+					assert.Equal(t, "BLOB", summary.ReasonsStr)
+					assert.Equal(t, expectedChangesStr, summary.ChangesStr)
+					assert.Equal(t, buildapi.BuildPriorityHigh, summary.Priority)
+				})
+			})
+		})
+
+		when("OBJECT", func() {
+			when("has no difference", func() {
+				change := buildchange.NewObjectChange(
+					&corev1alpha1.ObjectVersion{VersionID: "some-version", ETag: "some-etag"},
+					&corev1alpha1.ObjectVersion{VersionID: "some-version", ETag: "some-etag"},
+				)
+
+				it("returns the correct ChangeSummary and does not error", func() {
+					summary, err := cp.Process(change).Summarize()
+					assert.NoError(t, err)
+					assert.False(t, summary.HasChanges)
+					assert.Equal(t, buildapi.BuildPriorityNone, summary.Priority)
+				})
+			})
+
+			when("has difference", func() {
+				change := buildchange.NewObjectChange(
+					&corev1alpha1.ObjectVersion{ETag: "old-etag"},
+					&corev1alpha1.ObjectVersion{ETag: "new-etag"},
+				)
+				expectedChangesStr := testhelpers.CompactJSON(`
+[
+  {
+    "reason": "OBJECT",
+    "old": {
+      "etag": "old-etag"
+    },
+    "new": {
+      "etag": "new-etag"
+    }
+  }
+]`)
+
+				it("returns the correct ChangeSummary and does not error", func() {
+					summary, err := cp.Process(change).Summarize()
+					assert.NoError(t, err)
+					assert.True(t, summary.HasChanges)
+					assert.Equal(t, "OBJECT", summary.ReasonsStr)
+					assert.Equal(t, expectedChangesStr, summary.ChangesStr)
+					assert.Equal(t, buildapi.BuildPriorityHigh, summary.Priority)
+				})
+			})
+		})
+
+		when("REGISTRY", func() {
+			when("the old build was not resolved to a digest", func() {
+				change := buildchange.NewRegistryChange("", "sha256:new")
+
+				it("does not require a build", func() {
+					summary, err := cp.Process(change).Summarize()
+					assert.NoError(t, err)
+					assert.False(t, summary.HasChanges)
+				})
+			})
+
+			when("has difference", func() {
+				change := buildchange.NewRegistryChange("sha256:old", "sha256:new")
+				expectedChangesStr := testhelpers.CompactJSON(`
+[
+  {
+    "reason": "REGISTRY",
+    "old": "sha256:old",
+    "new": "sha256:new"
+  }
+]`)
+
+				it("returns the correct ChangeSummary and does not error", func() {
+					summary, err := cp.Process(change).Summarize()
+					assert.NoError(t, err)
+					assert.True(t, summary.HasChanges)
+					assert.Equal(t, "REGISTRY", summary.ReasonsStr)
+					assert.Equal(t, expectedChangesStr, summary.ChangesStr)
+					assert.Equal(t, buildapi.BuildPriorityHigh, summary.Priority)
+				})
+			})
+		})
+
+		when("RETRY", func() {
+			change := buildchange.NewRetryChange(1, 2)
+			expectedChangesStr := testhelpers.CompactJSON(`
+[
+  {
+    "reason": "RETRY",
+    "old": 1,
+    "new": 2
+  }
+]`)
+
+			it("returns the correct ChangeSummary and does not error", func() {
+				summary, err := cp.Process(change).Summarize()
+				assert.NoError(t, err)
+				assert.True(t, summary.HasChanges)
+				assert.Equal(t, "RETRY", summary.ReasonsStr)
+				assert.Equal(t, expectedChangesStr, summary.ChangesStr)
+				assert.Equal(t, buildapi.BuildPriorityLow, summary.Priority)
+			})
+		})
+
+		when("SCHEDULED", func() {
+			change := buildchange.NewScheduledChange("2021-03-01T00:00:00Z", "2021-03-07T00:00:00Z")
+			expectedChangesStr := testhelpers.CompactJSON(`
+[
+  {
+    "reason": "SCHEDULED",
+    "old": "2021-03-01T00:00:00Z",
+    "new": "2021-03-07T00:00:00Z"
+  }
+]`)
+
+			it("returns the correct ChangeSummary and does not error", func() {
+				summary, err := cp.Process(change).Summarize()
+				assert.NoError(t, err)
+				assert.True(t, summary.HasChanges)
+				assert.Equal(t, "SCHEDULED", summary.ReasonsStr)
+				assert.Equal(t, expectedChangesStr, summary.ChangesStr)
+				assert.Equal(t, buildapi.BuildPriorityLow, summary.Priority)
+			})
+		})
+
+		when("MANUAL", func() {
+			change := buildchange.NewManualChange("current-revision", "requested-revision")
+			expectedChangesStr := testhelpers.CompactJSON(`
+[
+  {
+    "reason": "MANUAL",
+    "old": "current-revision",
+    "new": "requested-revision"
+  }
+]`)
+
+			it("returns the correct ChangeSummary and does not error", func() {
+				summary, err := cp.Process(change).Summarize()
+				assert.NoError(t, err)
+				assert.True(t, summary.HasChanges)
+				assert.Equal(t, "MANUAL", summary.ReasonsStr)
+				assert.Equal(t, expectedChangesStr, summary.ChangesStr)
+				assert.Equal(t, buildapi.BuildPriorityHigh, summary.Priority)
+			})
+		})
 	})
 
 	when("multiple changes with difference are processed", func() {
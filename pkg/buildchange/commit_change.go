@@ -9,17 +9,42 @@ func NewCommitChange(oldRevision, newRevision string) Change {
 	}
 }
 
+// NewTaggedCommitChange is a commit change for git sources that follow a tag
+// constraint, recording the tags the revisions were resolved from.
+func NewTaggedCommitChange(oldRevision, oldTag, newRevision, newTag string) Change {
+	return commitChange{
+		oldRevision: oldRevision,
+		oldTag:      oldTag,
+		newRevision: newRevision,
+		newTag:      newTag,
+	}
+}
+
 type commitChange struct {
 	newRevision string
+	newTag      string
 	oldRevision string
+	oldTag      string
+}
+
+type TaggedRevision struct {
+	Revision string `json:"revision"`
+	Tag      string `json:"tag,omitempty"`
 }
 
 func (c commitChange) Reason() buildapi.BuildReason { return buildapi.BuildReasonCommit }
 
 func (c commitChange) IsBuildRequired() (bool, error) { return c.oldRevision != c.newRevision, nil }
 
-func (c commitChange) Old() interface{} { return c.oldRevision }
+func (c commitChange) Old() interface{} { return c.revision(c.oldRevision, c.oldTag) }
 
-func (c commitChange) New() interface{} { return c.newRevision }
+func (c commitChange) New() interface{} { return c.revision(c.newRevision, c.newTag) }
 
 func (c commitChange) Priority() buildapi.BuildPriority { return buildapi.BuildPriorityHigh }
+
+func (c commitChange) revision(revision, tag string) interface{} {
+	if c.oldTag == "" && c.newTag == "" {
+		return revision
+	}
+	return TaggedRevision{Revision: revision, Tag: tag}
+}
@@ -44,8 +44,27 @@ func (c configChange) IsBuildRequired() (bool, error) {
 		c.new.Source.Git.Revision = ""
 	}
 
+	// Object version changes are considered as OBJECT change
+	var oldObjectVersion, newObjectVersion *corev1alpha1.ObjectVersion
+
+	if c.old.Source.ObjectStore != nil {
+		oldObjectVersion = c.old.Source.ObjectStore.Version
+		c.old.Source.ObjectStore.Version = nil
+	}
+	if c.new.Source.ObjectStore != nil {
+		newObjectVersion = c.new.Source.ObjectStore.Version
+		c.new.Source.ObjectStore.Version = nil
+	}
+
 	valid := !equality.Semantic.DeepEqual(c.old, c.new)
 
+	if c.old.Source.ObjectStore != nil {
+		c.old.Source.ObjectStore.Version = oldObjectVersion
+	}
+	if c.new.Source.ObjectStore != nil {
+		c.new.Source.ObjectStore.Version = newObjectVersion
+	}
+
 	if c.old.Source.Git != nil {
 		c.old.Source.Git.Revision = oldGitRevision
 	}
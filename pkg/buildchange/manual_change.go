@@ -0,0 +1,29 @@
+package buildchange
+
+import (
+	buildapi "github.com/pivotal/kpack/pkg/apis/build/v1alpha2"
+)
+
+func NewManualChange(oldRevision, newRevision string) Change {
+	return manualChange{
+		oldRevision: oldRevision,
+		newRevision: newRevision,
+	}
+}
+
+type manualChange struct {
+	oldRevision string
+	newRevision string
+}
+
+func (m manualChange) Reason() buildapi.BuildReason { return buildapi.BuildReasonManual }
+
+func (m manualChange) IsBuildRequired() (bool, error) {
+	return m.newRevision != "", nil
+}
+
+func (m manualChange) Old() interface{} { return m.oldRevision }
+
+func (m manualChange) New() interface{} { return m.newRevision }
+
+func (m manualChange) Priority() buildapi.BuildPriority { return buildapi.BuildPriorityHigh }
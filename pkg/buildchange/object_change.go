@@ -0,0 +1,33 @@
+package buildchange
+
+import (
+	buildapi "github.com/pivotal/kpack/pkg/apis/build/v1alpha2"
+	corev1alpha1 "github.com/pivotal/kpack/pkg/apis/core/v1alpha1"
+)
+
+func NewObjectChange(oldVersion, newVersion *corev1alpha1.ObjectVersion) Change {
+	return objectChange{
+		oldVersion: oldVersion,
+		newVersion: newVersion,
+	}
+}
+
+type objectChange struct {
+	oldVersion *corev1alpha1.ObjectVersion
+	newVersion *corev1alpha1.ObjectVersion
+}
+
+func (o objectChange) Reason() buildapi.BuildReason { return buildapi.BuildReasonObject }
+
+func (o objectChange) IsBuildRequired() (bool, error) {
+	if o.oldVersion == nil || o.newVersion == nil {
+		return false, nil
+	}
+	return *o.oldVersion != *o.newVersion, nil
+}
+
+func (o objectChange) Old() interface{} { return o.oldVersion }
+
+func (o objectChange) New() interface{} { return o.newVersion }
+
+func (o objectChange) Priority() buildapi.BuildPriority { return buildapi.BuildPriorityHigh }
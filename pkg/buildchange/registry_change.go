@@ -0,0 +1,33 @@
+package buildchange
+
+import (
+	buildapi "github.com/pivotal/kpack/pkg/apis/build/v1alpha2"
+)
+
+func NewRegistryChange(oldDigest, newDigest string) Change {
+	return registryChange{
+		oldDigest: oldDigest,
+		newDigest: newDigest,
+	}
+}
+
+type registryChange struct {
+	oldDigest string
+	newDigest string
+}
+
+func (r registryChange) Reason() buildapi.BuildReason { return buildapi.BuildReasonRegistry }
+
+func (r registryChange) IsBuildRequired() (bool, error) {
+	// Builds from before source images were resolved to a digest are not rebuilt
+	if r.oldDigest == "" || r.newDigest == "" {
+		return false, nil
+	}
+	return r.oldDigest != r.newDigest, nil
+}
+
+func (r registryChange) Old() interface{} { return r.oldDigest }
+
+func (r registryChange) New() interface{} { return r.newDigest }
+
+func (r registryChange) Priority() buildapi.BuildPriority { return buildapi.BuildPriorityHigh }
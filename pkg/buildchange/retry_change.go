@@ -0,0 +1,29 @@
+package buildchange
+
+import (
+	buildapi "github.com/pivotal/kpack/pkg/apis/build/v1alpha2"
+)
+
+func NewRetryChange(oldAttempt, newAttempt int64) Change {
+	return retryChange{
+		oldAttempt: oldAttempt,
+		newAttempt: newAttempt,
+	}
+}
+
+type retryChange struct {
+	oldAttempt int64
+	newAttempt int64
+}
+
+func (r retryChange) Reason() buildapi.BuildReason { return buildapi.BuildReasonRetry }
+
+func (r retryChange) IsBuildRequired() (bool, error) {
+	return r.newAttempt > r.oldAttempt, nil
+}
+
+func (r retryChange) Old() interface{} { return r.oldAttempt }
+
+func (r retryChange) New() interface{} { return r.newAttempt }
+
+func (r retryChange) Priority() buildapi.BuildPriority { return buildapi.BuildPriorityLow }
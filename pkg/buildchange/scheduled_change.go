@@ -0,0 +1,29 @@
+package buildchange
+
+import (
+	buildapi "github.com/pivotal/kpack/pkg/apis/build/v1alpha2"
+)
+
+func NewScheduledChange(lastBuildTime, scheduledTime string) Change {
+	return scheduledChange{
+		lastBuildTime: lastBuildTime,
+		scheduledTime: scheduledTime,
+	}
+}
+
+type scheduledChange struct {
+	lastBuildTime string
+	scheduledTime string
+}
+
+func (s scheduledChange) Reason() buildapi.BuildReason { return buildapi.BuildReasonScheduled }
+
+func (s scheduledChange) IsBuildRequired() (bool, error) {
+	return s.scheduledTime != "", nil
+}
+
+func (s scheduledChange) Old() interface{} { return s.lastBuildTime }
+
+func (s scheduledChange) New() interface{} { return s.scheduledTime }
+
+func (s scheduledChange) Priority() buildapi.BuildPriority { return buildapi.BuildPriorityLow }
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"strconv"
+	"time"
 
 	"github.com/buildpacks/lifecycle/platform"
 	"github.com/google/go-containerregistry/pkg/authn"
@@ -34,12 +35,25 @@ type ImageFetcher interface {
 	Fetch(keychain authn.Keychain, repoName string) (ggcrv1.Image, string, error)
 }
 
+type GitTrustProvider interface {
+	GitKnownHosts() []byte
+	GitCABundle() []byte
+}
+
 type Generator struct {
-	BuildPodConfig  buildapi.BuildPodImages
-	K8sClient       k8sclient.Interface
-	KeychainFactory registry.KeychainFactory
-	ImageFetcher    ImageFetcher
-	DynamicClient   dynamic.Interface
+	BuildPodConfig   buildapi.BuildPodImages
+	K8sClient        k8sclient.Interface
+	KeychainFactory  registry.KeychainFactory
+	ImageFetcher     ImageFetcher
+	DynamicClient    dynamic.Interface
+	GitTrustProvider GitTrustProvider
+	// ArchiveMaxSize and ArchiveMaxFiles limit the source archives extracted
+	// by builds. Zero uses the build-init defaults.
+	ArchiveMaxSize  int64
+	ArchiveMaxFiles int64
+	// BuildTimeout is the default maximum duration of a build pod. Zero is
+	// unlimited.
+	BuildTimeout time.Duration
 }
 
 type BuildPodable interface {
@@ -69,12 +83,21 @@ func (g *Generator) Generate(ctx context.Context, build BuildPodable) (*v1.Pod,
 		return nil, err
 	}
 
-	return build.BuildPod(g.BuildPodConfig, buildapi.BuildContext{
+	buildContext := buildapi.BuildContext{
 		BuildPodBuilderConfig: buildPodBuilderConfig,
 		Secrets:               secrets,
 		Bindings:              bindings,
 		ImagePullSecrets:      imagePullSecrets,
-	})
+		ArchiveMaxSize:        g.ArchiveMaxSize,
+		ArchiveMaxFiles:       g.ArchiveMaxFiles,
+		BuildTimeout:          g.BuildTimeout,
+	}
+	if g.GitTrustProvider != nil {
+		buildContext.GitKnownHosts = string(g.GitTrustProvider.GitKnownHosts())
+		buildContext.GitCABundle = string(g.GitTrustProvider.GitCABundle())
+	}
+
+	return build.BuildPod(g.BuildPodConfig, buildContext)
 }
 
 func (g *Generator) fetchServiceBindings(ctx context.Context, build BuildPodable) ([]buildapi.ServiceBinding, error) {
@@ -260,6 +260,29 @@ func testGenerator(t *testing.T, when spec.G, it spec.S) {
 			}}, build.buildPodCalls)
 		})
 
+		it("passes the cluster git trust to the build context", func() {
+			var build = &testBuildPodable{
+				serviceAccount: serviceAccountName,
+				namespace:      namespace,
+				buildBuilderSpec: corev1alpha1.BuildBuilderSpec{
+					Image:            linuxBuilderImage,
+					ImagePullSecrets: builderPullSecrets,
+				},
+			}
+
+			generator.GitTrustProvider = fakeGitTrustProvider{
+				knownHosts: "github.com ssh-ed25519 AAAA",
+				caBundle:   "some-ca",
+			}
+
+			_, err := generator.Generate(context.TODO(), build)
+			require.NoError(t, err)
+
+			require.Len(t, build.buildPodCalls, 1)
+			assert.Equal(t, "github.com ssh-ed25519 AAAA", build.buildPodCalls[0].BuildContext.GitKnownHosts)
+			assert.Equal(t, "some-ca", build.buildPodCalls[0].BuildContext.GitCABundle)
+		})
+
 		it("dedups duplicate secrets on the service account", func() {
 			var build = &testBuildPodable{
 				serviceAccount: serviceAccountName,
@@ -577,3 +600,16 @@ func createImage(t *testing.T, os string) ggcrv1.Image {
 	require.NoError(t, err)
 	return image
 }
+
+type fakeGitTrustProvider struct {
+	knownHosts string
+	caBundle   string
+}
+
+func (f fakeGitTrustProvider) GitKnownHosts() []byte {
+	return []byte(f.knownHosts)
+}
+
+func (f fakeGitTrustProvider) GitCABundle() []byte {
+	return []byte(f.caBundle)
+}
@@ -0,0 +1,330 @@
+package buildqueue
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"k8s.io/apimachinery/pkg/types"
+
+	buildapi "github.com/pivotal/kpack/pkg/apis/build/v1alpha2"
+)
+
+// admissionTimeout is how long an admitted image counts as running before
+// its build is observed.
+const admissionTimeout = time.Minute
+
+// Limits are the maximum number of concurrently running builds. Zero is
+// unlimited.
+type Limits struct {
+	MaxBuilds          int
+	MaxNamespaceBuilds int
+}
+
+func (l Limits) unlimited() bool {
+	return l.MaxBuilds <= 0 && l.MaxNamespaceBuilds <= 0
+}
+
+// Queue admits image builds while the running builds are within the limits.
+// Images waiting for a build are admitted by build priority, then from the
+// namespace with the fewest running builds, and then in the order they were
+// queued. The running builds are tracked from the build informer events.
+type Queue struct {
+	limits    Limits
+	enqueue   func(types.NamespacedName)
+	now       func() time.Time
+	afterFunc func(time.Duration, func())
+
+	m        sync.Mutex
+	waiting  map[types.NamespacedName]waiter
+	admitted map[types.NamespacedName]admission
+	builds   map[types.NamespacedName]bool
+	running  runningBuilds
+}
+
+type waiter struct {
+	priority buildapi.BuildPriority
+	since    time.Time
+}
+
+type admission struct {
+	at          time.Time
+	queuedSince time.Time
+}
+
+// New returns a Queue that calls enqueue for waiting images when a running
+// build finishes or an admitted image releases its capacity.
+func New(limits Limits, enqueue func(types.NamespacedName)) *Queue {
+	return &Queue{
+		limits:  limits,
+		enqueue: enqueue,
+		now:     time.Now,
+		afterFunc: func(d time.Duration, f func()) {
+			time.AfterFunc(d, f)
+		},
+		waiting:  map[types.NamespacedName]waiter{},
+		admitted: map[types.NamespacedName]admission{},
+		builds:   map[types.NamespacedName]bool{},
+		running:  runningBuilds{namespaces: map[string]int{}},
+	}
+}
+
+// Admit reports whether image may create a build of priority now. An image
+// that is not admitted is queued and the returned message explains why.
+func (q *Queue) Admit(image types.NamespacedName, priority buildapi.BuildPriority) (bool, string, error) {
+	if q == nil || q.limits.unlimited() {
+		return true, "", nil
+	}
+
+	q.m.Lock()
+	defer q.m.Unlock()
+
+	now := q.now()
+	running := q.runningAndAdmitted()
+
+	w, ok := q.waiting[image]
+	if !ok {
+		w.since = now
+	}
+	w.priority = priority
+	q.waiting[image] = w
+
+	message := q.queuedMessage(image, running)
+	candidates := make([]types.NamespacedName, 0, len(q.waiting))
+	for key := range q.waiting {
+		candidates = append(candidates, key)
+	}
+
+	for {
+		next, ok := q.next(candidates, running)
+		if !ok {
+			return false, message, nil
+		}
+
+		if next == image {
+			delete(q.waiting, image)
+			q.admitted[image] = admission{at: now, queuedSince: w.since}
+			q.afterFunc(admissionTimeout, q.expireAdmissions)
+			return true, "", nil
+		}
+
+		running.add(next.Namespace)
+		candidates = remove(candidates, next)
+	}
+}
+
+// QueuedAt returns when the admitted image was queued. It reports false when
+// the image was admitted without waiting.
+func (q *Queue) QueuedAt(image types.NamespacedName) (time.Time, bool) {
+	if q == nil {
+		return time.Time{}, false
+	}
+
+	q.m.Lock()
+	defer q.m.Unlock()
+
+	a, ok := q.admitted[image]
+	if !ok || !a.queuedSince.Before(a.at) {
+		return time.Time{}, false
+	}
+	return a.queuedSince, true
+}
+
+// BuildUpdated records whether build is running. The waiting images are
+// enqueued when a running build finishes.
+func (q *Queue) BuildUpdated(build *buildapi.Build) {
+	if q == nil {
+		return
+	}
+
+	q.m.Lock()
+	image := types.NamespacedName{Namespace: build.Namespace, Name: build.Labels[buildapi.ImageLabel]}
+	if a, ok := q.admitted[image]; ok && a.at.Before(build.CreationTimestamp.Time.Add(time.Second)) {
+		delete(q.admitted, image)
+	}
+	finished := q.setRunning(build, build.IsRunning())
+	q.m.Unlock()
+
+	if finished {
+		q.wake()
+	}
+}
+
+// BuildDeleted stops counting build. The waiting images are enqueued when
+// the build was running.
+func (q *Queue) BuildDeleted(build *buildapi.Build) {
+	if q == nil {
+		return
+	}
+
+	q.m.Lock()
+	finished := q.setRunning(build, false)
+	q.m.Unlock()
+
+	if finished {
+		q.wake()
+	}
+}
+
+// setRunning updates the running counts and reports whether a running build
+// stopped running.
+func (q *Queue) setRunning(build *buildapi.Build, running bool) bool {
+	key := types.NamespacedName{Namespace: build.Namespace, Name: build.Name}
+	if q.builds[key] == running {
+		return false
+	}
+
+	if running {
+		q.builds[key] = true
+		q.running.add(build.Namespace)
+		return false
+	}
+
+	delete(q.builds, key)
+	q.running.remove(build.Namespace)
+	return true
+}
+
+// Remove drops image from the queue once it no longer needs a build. The
+// waiting images are enqueued when image was admitted without a build.
+func (q *Queue) Remove(image types.NamespacedName) {
+	if q == nil {
+		return
+	}
+
+	q.m.Lock()
+	_, admitted := q.admitted[image]
+	delete(q.waiting, image)
+	delete(q.admitted, image)
+	q.m.Unlock()
+
+	if admitted {
+		q.wake()
+	}
+}
+
+// expireAdmissions stops counting the admitted images whose build was not
+// observed within the admission timeout and wakes the waiting images into
+// the freed capacity.
+func (q *Queue) expireAdmissions() {
+	q.m.Lock()
+	expired := false
+	for image, a := range q.admitted {
+		if q.now().Sub(a.at) >= admissionTimeout {
+			delete(q.admitted, image)
+			expired = true
+		}
+	}
+	q.m.Unlock()
+
+	if expired {
+		q.wake()
+	}
+}
+
+// wake enqueues the waiting images so they are admitted into capacity freed
+// by a finished build or a released admission.
+func (q *Queue) wake() {
+	q.m.Lock()
+	waiting := make([]types.NamespacedName, 0, len(q.waiting))
+	for key := range q.waiting {
+		waiting = append(waiting, key)
+	}
+	q.m.Unlock()
+
+	for _, key := range waiting {
+		q.enqueue(key)
+	}
+}
+
+// next returns the candidate admitted next into the remaining capacity.
+func (q *Queue) next(candidates []types.NamespacedName, running runningBuilds) (types.NamespacedName, bool) {
+	if q.limits.MaxBuilds > 0 && running.total >= q.limits.MaxBuilds {
+		return types.NamespacedName{}, false
+	}
+
+	var admissible []types.NamespacedName
+	for _, key := range candidates {
+		if q.limits.MaxNamespaceBuilds > 0 && running.namespaces[key.Namespace] >= q.limits.MaxNamespaceBuilds {
+			continue
+		}
+		admissible = append(admissible, key)
+	}
+	if len(admissible) == 0 {
+		return types.NamespacedName{}, false
+	}
+
+	sort.Slice(admissible, func(i, j int) bool {
+		a, b := q.waiting[admissible[i]], q.waiting[admissible[j]]
+		if a.priority != b.priority {
+			return a.priority > b.priority
+		}
+
+		aRunning, bRunning := running.namespaces[admissible[i].Namespace], running.namespaces[admissible[j].Namespace]
+		if aRunning != bRunning {
+			return aRunning < bRunning
+		}
+
+		if !a.since.Equal(b.since) {
+			return a.since.Before(b.since)
+		}
+		return admissible[i].String() < admissible[j].String()
+	})
+	return admissible[0], true
+}
+
+func (q *Queue) queuedMessage(image types.NamespacedName, running runningBuilds) string {
+	if q.limits.MaxNamespaceBuilds > 0 && running.namespaces[image.Namespace] >= q.limits.MaxNamespaceBuilds {
+		return fmt.Sprintf("Build is queued, namespace %s has reached the limit of %d running builds", image.Namespace, q.limits.MaxNamespaceBuilds)
+	}
+	if q.limits.MaxBuilds > 0 && running.total >= q.limits.MaxBuilds {
+		return fmt.Sprintf("Build is queued, the cluster has reached the limit of %d running builds", q.limits.MaxBuilds)
+	}
+	return "Build is queued behind builds with a higher priority or from namespaces with fewer running builds"
+}
+
+type runningBuilds struct {
+	total      int
+	namespaces map[string]int
+}
+
+func (r *runningBuilds) add(namespace string) {
+	r.total++
+	r.namespaces[namespace]++
+}
+
+func (r *runningBuilds) remove(namespace string) {
+	r.total--
+	r.namespaces[namespace]--
+	if r.namespaces[namespace] <= 0 {
+		delete(r.namespaces, namespace)
+	}
+}
+
+// runningAndAdmitted counts the running builds and the admitted images whose
+// build has not been observed yet.
+func (q *Queue) runningAndAdmitted() runningBuilds {
+	running := runningBuilds{total: q.running.total, namespaces: make(map[string]int, len(q.running.namespaces))}
+	for namespace, count := range q.running.namespaces {
+		running.namespaces[namespace] = count
+	}
+
+	for image, a := range q.admitted {
+		if q.now().Sub(a.at) >= admissionTimeout {
+			continue
+		}
+		running.add(image.Namespace)
+	}
+
+	return running
+}
+
+func remove(keys []types.NamespacedName, key types.NamespacedName) []types.NamespacedName {
+	for i := range keys {
+		if keys[i] == key {
+			return append(keys[:i], keys[i+1:]...)
+		}
+	}
+	return keys
+}
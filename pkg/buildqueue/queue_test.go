@@ -0,0 +1,265 @@
+package buildqueue_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/sclevine/spec"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	buildapi "github.com/pivotal/kpack/pkg/apis/build/v1alpha2"
+	corev1alpha1 "github.com/pivotal/kpack/pkg/apis/core/v1alpha1"
+	"github.com/pivotal/kpack/pkg/buildqueue"
+)
+
+func TestQueue(t *testing.T) {
+	spec.Run(t, "testQueue", testQueue)
+}
+
+func testQueue(t *testing.T, when spec.G, it spec.S) {
+	var (
+		builds   []*buildapi.Build
+		enqueued []types.NamespacedName
+	)
+
+	queue := func(limits buildqueue.Limits) *buildqueue.Queue {
+		q := buildqueue.New(limits, func(key types.NamespacedName) {
+			enqueued = append(enqueued, key)
+		})
+		for _, build := range builds {
+			q.BuildUpdated(build)
+		}
+		return q
+	}
+
+	running := func(b ...*buildapi.Build) {
+		builds = append(builds, b...)
+	}
+
+	build := func(namespace, image string, status corev1.ConditionStatus) *buildapi.Build {
+		return &buildapi.Build{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:              image + "-build-1",
+				Namespace:         namespace,
+				Labels:            map[string]string{buildapi.ImageLabel: image},
+				CreationTimestamp: metav1.Now(),
+			},
+			Status: buildapi.BuildStatus{
+				Status: corev1alpha1.Status{
+					Conditions: corev1alpha1.Conditions{
+						{Type: corev1alpha1.ConditionSucceeded, Status: status},
+					},
+				},
+			},
+		}
+	}
+
+	key := func(namespace, name string) types.NamespacedName {
+		return types.NamespacedName{Namespace: namespace, Name: name}
+	}
+
+	admit := func(q *buildqueue.Queue, image types.NamespacedName, priority buildapi.BuildPriority) (bool, string) {
+		t.Helper()
+		admitted, message, err := q.Admit(image, priority)
+		require.NoError(t, err)
+		return admitted, message
+	}
+
+	it("admits every build without limits", func() {
+		running(build("ns", "running", corev1.ConditionUnknown))
+		q := queue(buildqueue.Limits{})
+
+		admitted, message := admit(q, key("ns", "image"), buildapi.BuildPriorityLow)
+		assert.True(t, admitted)
+		assert.Empty(t, message)
+	})
+
+	it("treats a nil queue as unlimited", func() {
+		var q *buildqueue.Queue
+
+		admitted, _ := admit(q, key("ns", "image"), buildapi.BuildPriorityLow)
+		assert.True(t, admitted)
+		q.Remove(key("ns", "image"))
+		q.BuildUpdated(build("ns", "running", corev1.ConditionUnknown))
+		q.BuildDeleted(build("ns", "running", corev1.ConditionUnknown))
+	})
+
+	it("queues builds when the cluster limit is reached", func() {
+		running(
+			build("ns", "running", corev1.ConditionUnknown),
+			build("ns", "finished", corev1.ConditionTrue),
+		)
+		q := queue(buildqueue.Limits{MaxBuilds: 2})
+
+		admitted, _ := admit(q, key("ns", "first"), buildapi.BuildPriorityLow)
+		assert.True(t, admitted)
+
+		admitted, message := admit(q, key("ns", "second"), buildapi.BuildPriorityLow)
+		assert.False(t, admitted)
+		assert.Equal(t, "Build is queued, the cluster has reached the limit of 2 running builds", message)
+	})
+
+	it("queues builds when the namespace limit is reached", func() {
+		running(build("busy", "running", corev1.ConditionUnknown))
+		q := queue(buildqueue.Limits{MaxNamespaceBuilds: 1})
+
+		admitted, message := admit(q, key("busy", "image"), buildapi.BuildPriorityLow)
+		assert.False(t, admitted)
+		assert.Equal(t, "Build is queued, namespace busy has reached the limit of 1 running builds", message)
+
+		admitted, _ = admit(q, key("idle", "image"), buildapi.BuildPriorityLow)
+		assert.True(t, admitted)
+	})
+
+	it("admits waiting builds by priority", func() {
+		running(build("ns", "running", corev1.ConditionUnknown))
+		q := queue(buildqueue.Limits{MaxBuilds: 1})
+
+		admitted, _ := admit(q, key("ns", "low"), buildapi.BuildPriorityLow)
+		assert.False(t, admitted)
+		admitted, _ = admit(q, key("ns", "high"), buildapi.BuildPriorityHigh)
+		assert.False(t, admitted)
+
+		q.BuildUpdated(build("ns", "running", corev1.ConditionTrue))
+
+		admitted, message := admit(q, key("ns", "low"), buildapi.BuildPriorityLow)
+		assert.False(t, admitted)
+		assert.Equal(t, "Build is queued behind builds with a higher priority or from namespaces with fewer running builds", message)
+
+		admitted, _ = admit(q, key("ns", "high"), buildapi.BuildPriorityHigh)
+		assert.True(t, admitted)
+	})
+
+	it("admits waiting builds from the namespace with the fewest running builds", func() {
+		running(
+			build("busy", "running-1", corev1.ConditionUnknown),
+			build("busy", "running-2", corev1.ConditionUnknown),
+			build("idle", "running-1", corev1.ConditionUnknown),
+		)
+		q := queue(buildqueue.Limits{MaxBuilds: 4})
+
+		admitted, _ := admit(q, key("busy", "image"), buildapi.BuildPriorityLow)
+		assert.True(t, admitted)
+
+		admitted, message := admit(q, key("busy", "next"), buildapi.BuildPriorityLow)
+		assert.False(t, admitted)
+		admitted, _ = admit(q, key("idle", "next"), buildapi.BuildPriorityLow)
+		assert.False(t, admitted)
+		assert.Equal(t, "Build is queued, the cluster has reached the limit of 4 running builds", message)
+
+		q.Remove(key("busy", "image"))
+
+		admitted, message = admit(q, key("busy", "next"), buildapi.BuildPriorityLow)
+		assert.False(t, admitted)
+		assert.Equal(t, "Build is queued behind builds with a higher priority or from namespaces with fewer running builds", message)
+
+		admitted, _ = admit(q, key("idle", "next"), buildapi.BuildPriorityLow)
+		assert.True(t, admitted)
+	})
+
+	it("counts admitted images until their build is listed", func() {
+		q := queue(buildqueue.Limits{MaxBuilds: 1})
+
+		admitted, _ := admit(q, key("ns", "first"), buildapi.BuildPriorityLow)
+		assert.True(t, admitted)
+
+		admitted, _ = admit(q, key("ns", "second"), buildapi.BuildPriorityLow)
+		assert.False(t, admitted)
+	})
+
+	it("enqueues waiting images when a running build finishes", func() {
+		running(build("ns", "running", corev1.ConditionUnknown))
+		q := queue(buildqueue.Limits{MaxBuilds: 1})
+
+		admit(q, key("ns", "waiting"), buildapi.BuildPriorityLow)
+		admit(q, key("ns", "removed"), buildapi.BuildPriorityLow)
+		q.Remove(key("ns", "removed"))
+
+		q.BuildUpdated(build("ns", "running", corev1.ConditionUnknown))
+		q.BuildUpdated(build("ns", "other", corev1.ConditionTrue))
+		assert.Empty(t, enqueued)
+
+		q.BuildUpdated(build("ns", "running", corev1.ConditionTrue))
+		assert.Equal(t, []types.NamespacedName{key("ns", "waiting")}, enqueued)
+
+		q.BuildUpdated(build("ns", "running", corev1.ConditionTrue))
+		assert.Len(t, enqueued, 1)
+
+		admitted, _ := admit(q, key("ns", "waiting"), buildapi.BuildPriorityLow)
+		assert.True(t, admitted)
+	})
+
+	it("enqueues waiting images when an admitted image is removed before its build runs", func() {
+		q := queue(buildqueue.Limits{MaxBuilds: 1})
+
+		admitted, _ := admit(q, key("ns", "admitted"), buildapi.BuildPriorityLow)
+		assert.True(t, admitted)
+		admitted, _ = admit(q, key("ns", "waiting"), buildapi.BuildPriorityLow)
+		assert.False(t, admitted)
+
+		q.Remove(key("ns", "admitted"))
+		assert.Equal(t, []types.NamespacedName{key("ns", "waiting")}, enqueued)
+
+		admitted, _ = admit(q, key("ns", "waiting"), buildapi.BuildPriorityLow)
+		assert.True(t, admitted)
+	})
+
+	it("enqueues waiting images when a running build is deleted", func() {
+		running(build("ns", "running", corev1.ConditionUnknown))
+		q := queue(buildqueue.Limits{MaxNamespaceBuilds: 1})
+
+		admit(q, key("ns", "waiting"), buildapi.BuildPriorityLow)
+
+		q.BuildDeleted(build("ns", "running", corev1.ConditionUnknown))
+		assert.Equal(t, []types.NamespacedName{key("ns", "waiting")}, enqueued)
+
+		admitted, _ := admit(q, key("ns", "waiting"), buildapi.BuildPriorityLow)
+		assert.True(t, admitted)
+	})
+
+	it("stops counting an admitted image once its build is observed", func() {
+		q := queue(buildqueue.Limits{MaxBuilds: 1})
+
+		admitted, _ := admit(q, key("ns", "first"), buildapi.BuildPriorityLow)
+		assert.True(t, admitted)
+
+		q.BuildUpdated(build("ns", "first", corev1.ConditionTrue))
+
+		admitted, _ = admit(q, key("ns", "second"), buildapi.BuildPriorityLow)
+		assert.True(t, admitted)
+	})
+
+	it("reports when an image admitted after waiting was queued", func() {
+		running(build("ns", "running", corev1.ConditionUnknown))
+		q := queue(buildqueue.Limits{MaxBuilds: 1})
+
+		admitted, _ := admit(q, key("ns", "waiting"), buildapi.BuildPriorityLow)
+		assert.False(t, admitted)
+		_, ok := q.QueuedAt(key("ns", "waiting"))
+		assert.False(t, ok)
+
+		time.Sleep(time.Millisecond)
+		queuedBefore := time.Now()
+		q.BuildUpdated(build("ns", "running", corev1.ConditionTrue))
+		admitted, _ = admit(q, key("ns", "waiting"), buildapi.BuildPriorityLow)
+		assert.True(t, admitted)
+
+		queuedAt, ok := q.QueuedAt(key("ns", "waiting"))
+		assert.True(t, ok)
+		assert.True(t, queuedAt.Before(queuedBefore))
+	})
+
+	it("does not report a queue time for an image admitted without waiting", func() {
+		q := queue(buildqueue.Limits{MaxBuilds: 1})
+
+		admitted, _ := admit(q, key("ns", "image"), buildapi.BuildPriorityLow)
+		assert.True(t, admitted)
+
+		_, ok := q.QueuedAt(key("ns", "image"))
+		assert.False(t, ok)
+	})
+}
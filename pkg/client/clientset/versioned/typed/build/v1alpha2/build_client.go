@@ -27,11 +27,14 @@ import (
 type KpackV1alpha2Interface interface {
 	RESTClient() rest.Interface
 	BuildsGetter
+	BuildRequestsGetter
 	BuildersGetter
 	ClusterBuildersGetter
 	ClusterStacksGetter
 	ClusterStoresGetter
 	ImagesGetter
+	ImageSetsGetter
+	PreviewImageSetsGetter
 	SourceResolversGetter
 }
 
@@ -44,6 +47,10 @@ func (c *KpackV1alpha2Client) Builds(namespace string) BuildInterface {
 	return newBuilds(c, namespace)
 }
 
+func (c *KpackV1alpha2Client) BuildRequests(namespace string) BuildRequestInterface {
+	return newBuildRequests(c, namespace)
+}
+
 func (c *KpackV1alpha2Client) Builders(namespace string) BuilderInterface {
 	return newBuilders(c, namespace)
 }
@@ -64,6 +71,14 @@ func (c *KpackV1alpha2Client) Images(namespace string) ImageInterface {
 	return newImages(c, namespace)
 }
 
+func (c *KpackV1alpha2Client) ImageSets(namespace string) ImageSetInterface {
+	return newImageSets(c, namespace)
+}
+
+func (c *KpackV1alpha2Client) PreviewImageSets(namespace string) PreviewImageSetInterface {
+	return newPreviewImageSets(c, namespace)
+}
+
 func (c *KpackV1alpha2Client) SourceResolvers(namespace string) SourceResolverInterface {
 	return newSourceResolvers(c, namespace)
 }
@@ -0,0 +1,195 @@
+/*
+ * Copyright 2019 The original author or authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Code generated by client-gen. DO NOT EDIT.
+
+package v1alpha2
+
+import (
+	"context"
+	"time"
+
+	v1alpha2 "github.com/pivotal/kpack/pkg/apis/build/v1alpha2"
+	scheme "github.com/pivotal/kpack/pkg/client/clientset/versioned/scheme"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	types "k8s.io/apimachinery/pkg/types"
+	watch "k8s.io/apimachinery/pkg/watch"
+	rest "k8s.io/client-go/rest"
+)
+
+// BuildRequestsGetter has a method to return a BuildRequestInterface.
+// A group's client should implement this interface.
+type BuildRequestsGetter interface {
+	BuildRequests(namespace string) BuildRequestInterface
+}
+
+// BuildRequestInterface has methods to work with BuildRequest resources.
+type BuildRequestInterface interface {
+	Create(ctx context.Context, buildRequest *v1alpha2.BuildRequest, opts v1.CreateOptions) (*v1alpha2.BuildRequest, error)
+	Update(ctx context.Context, buildRequest *v1alpha2.BuildRequest, opts v1.UpdateOptions) (*v1alpha2.BuildRequest, error)
+	UpdateStatus(ctx context.Context, buildRequest *v1alpha2.BuildRequest, opts v1.UpdateOptions) (*v1alpha2.BuildRequest, error)
+	Delete(ctx context.Context, name string, opts v1.DeleteOptions) error
+	DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error
+	Get(ctx context.Context, name string, opts v1.GetOptions) (*v1alpha2.BuildRequest, error)
+	List(ctx context.Context, opts v1.ListOptions) (*v1alpha2.BuildRequestList, error)
+	Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error)
+	Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *v1alpha2.BuildRequest, err error)
+	BuildRequestExpansion
+}
+
+// buildRequests implements BuildRequestInterface
+type buildRequests struct {
+	client rest.Interface
+	ns     string
+}
+
+// newBuildRequests returns a BuildRequests
+func newBuildRequests(c *KpackV1alpha2Client, namespace string) *buildRequests {
+	return &buildRequests{
+		client: c.RESTClient(),
+		ns:     namespace,
+	}
+}
+
+// Get takes name of the buildRequest, and returns the corresponding buildRequest object, and an error if there is any.
+func (c *buildRequests) Get(ctx context.Context, name string, options v1.GetOptions) (result *v1alpha2.BuildRequest, err error) {
+	result = &v1alpha2.BuildRequest{}
+	err = c.client.Get().
+		Namespace(c.ns).
+		Resource("buildrequests").
+		Name(name).
+		VersionedParams(&options, scheme.ParameterCodec).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// List takes label and field selectors, and returns the list of BuildRequests that match those selectors.
+func (c *buildRequests) List(ctx context.Context, opts v1.ListOptions) (result *v1alpha2.BuildRequestList, err error) {
+	var timeout time.Duration
+	if opts.TimeoutSeconds != nil {
+		timeout = time.Duration(*opts.TimeoutSeconds) * time.Second
+	}
+	result = &v1alpha2.BuildRequestList{}
+	err = c.client.Get().
+		Namespace(c.ns).
+		Resource("buildrequests").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Timeout(timeout).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// Watch returns a watch.Interface that watches the requested buildRequests.
+func (c *buildRequests) Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error) {
+	var timeout time.Duration
+	if opts.TimeoutSeconds != nil {
+		timeout = time.Duration(*opts.TimeoutSeconds) * time.Second
+	}
+	opts.Watch = true
+	return c.client.Get().
+		Namespace(c.ns).
+		Resource("buildrequests").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Timeout(timeout).
+		Watch(ctx)
+}
+
+// Create takes the representation of a buildRequest and creates it.  Returns the server's representation of the buildRequest, and an error, if there is any.
+func (c *buildRequests) Create(ctx context.Context, buildRequest *v1alpha2.BuildRequest, opts v1.CreateOptions) (result *v1alpha2.BuildRequest, err error) {
+	result = &v1alpha2.BuildRequest{}
+	err = c.client.Post().
+		Namespace(c.ns).
+		Resource("buildrequests").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(buildRequest).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// Update takes the representation of a buildRequest and updates it. Returns the server's representation of the buildRequest, and an error, if there is any.
+func (c *buildRequests) Update(ctx context.Context, buildRequest *v1alpha2.BuildRequest, opts v1.UpdateOptions) (result *v1alpha2.BuildRequest, err error) {
+	result = &v1alpha2.BuildRequest{}
+	err = c.client.Put().
+		Namespace(c.ns).
+		Resource("buildrequests").
+		Name(buildRequest.Name).
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(buildRequest).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// UpdateStatus was generated because the type contains a Status member.
+// Add a +genclient:noStatus comment above the type to avoid generating UpdateStatus().
+func (c *buildRequests) UpdateStatus(ctx context.Context, buildRequest *v1alpha2.BuildRequest, opts v1.UpdateOptions) (result *v1alpha2.BuildRequest, err error) {
+	result = &v1alpha2.BuildRequest{}
+	err = c.client.Put().
+		Namespace(c.ns).
+		Resource("buildrequests").
+		Name(buildRequest.Name).
+		SubResource("status").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(buildRequest).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// Delete takes name of the buildRequest and deletes it. Returns an error if one occurs.
+func (c *buildRequests) Delete(ctx context.Context, name string, opts v1.DeleteOptions) error {
+	return c.client.Delete().
+		Namespace(c.ns).
+		Resource("buildrequests").
+		Name(name).
+		Body(&opts).
+		Do(ctx).
+		Error()
+}
+
+// DeleteCollection deletes a collection of objects.
+func (c *buildRequests) DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error {
+	var timeout time.Duration
+	if listOpts.TimeoutSeconds != nil {
+		timeout = time.Duration(*listOpts.TimeoutSeconds) * time.Second
+	}
+	return c.client.Delete().
+		Namespace(c.ns).
+		Resource("buildrequests").
+		VersionedParams(&listOpts, scheme.ParameterCodec).
+		Timeout(timeout).
+		Body(&opts).
+		Do(ctx).
+		Error()
+}
+
+// Patch applies the patch and returns the patched buildRequest.
+func (c *buildRequests) Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *v1alpha2.BuildRequest, err error) {
+	result = &v1alpha2.BuildRequest{}
+	err = c.client.Patch(pt).
+		Namespace(c.ns).
+		Resource("buildrequests").
+		Name(name).
+		SubResource(subresources...).
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(data).
+		Do(ctx).
+		Into(result)
+	return
+}
@@ -32,6 +32,10 @@ func (c *FakeKpackV1alpha2) Builds(namespace string) v1alpha2.BuildInterface {
 	return &FakeBuilds{c, namespace}
 }
 
+func (c *FakeKpackV1alpha2) BuildRequests(namespace string) v1alpha2.BuildRequestInterface {
+	return &FakeBuildRequests{c, namespace}
+}
+
 func (c *FakeKpackV1alpha2) Builders(namespace string) v1alpha2.BuilderInterface {
 	return &FakeBuilders{c, namespace}
 }
@@ -52,6 +56,14 @@ func (c *FakeKpackV1alpha2) Images(namespace string) v1alpha2.ImageInterface {
 	return &FakeImages{c, namespace}
 }
 
+func (c *FakeKpackV1alpha2) ImageSets(namespace string) v1alpha2.ImageSetInterface {
+	return &FakeImageSets{c, namespace}
+}
+
+func (c *FakeKpackV1alpha2) PreviewImageSets(namespace string) v1alpha2.PreviewImageSetInterface {
+	return &FakePreviewImageSets{c, namespace}
+}
+
 func (c *FakeKpackV1alpha2) SourceResolvers(namespace string) v1alpha2.SourceResolverInterface {
 	return &FakeSourceResolvers{c, namespace}
 }
@@ -0,0 +1,142 @@
+/*
+ * Copyright 2019 The original author or authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Code generated by client-gen. DO NOT EDIT.
+
+package fake
+
+import (
+	"context"
+
+	v1alpha2 "github.com/pivotal/kpack/pkg/apis/build/v1alpha2"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	labels "k8s.io/apimachinery/pkg/labels"
+	schema "k8s.io/apimachinery/pkg/runtime/schema"
+	types "k8s.io/apimachinery/pkg/types"
+	watch "k8s.io/apimachinery/pkg/watch"
+	testing "k8s.io/client-go/testing"
+)
+
+// FakeBuildRequests implements BuildRequestInterface
+type FakeBuildRequests struct {
+	Fake *FakeKpackV1alpha2
+	ns   string
+}
+
+var buildrequestsResource = schema.GroupVersionResource{Group: "kpack.io", Version: "v1alpha2", Resource: "buildrequests"}
+
+var buildrequestsKind = schema.GroupVersionKind{Group: "kpack.io", Version: "v1alpha2", Kind: "BuildRequest"}
+
+// Get takes name of the buildRequest, and returns the corresponding buildRequest object, and an error if there is any.
+func (c *FakeBuildRequests) Get(ctx context.Context, name string, options v1.GetOptions) (result *v1alpha2.BuildRequest, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewGetAction(buildrequestsResource, c.ns, name), &v1alpha2.BuildRequest{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha2.BuildRequest), err
+}
+
+// List takes label and field selectors, and returns the list of BuildRequests that match those selectors.
+func (c *FakeBuildRequests) List(ctx context.Context, opts v1.ListOptions) (result *v1alpha2.BuildRequestList, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewListAction(buildrequestsResource, buildrequestsKind, c.ns, opts), &v1alpha2.BuildRequestList{})
+
+	if obj == nil {
+		return nil, err
+	}
+
+	label, _, _ := testing.ExtractFromListOptions(opts)
+	if label == nil {
+		label = labels.Everything()
+	}
+	list := &v1alpha2.BuildRequestList{ListMeta: obj.(*v1alpha2.BuildRequestList).ListMeta}
+	for _, item := range obj.(*v1alpha2.BuildRequestList).Items {
+		if label.Matches(labels.Set(item.Labels)) {
+			list.Items = append(list.Items, item)
+		}
+	}
+	return list, err
+}
+
+// Watch returns a watch.Interface that watches the requested buildRequests.
+func (c *FakeBuildRequests) Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error) {
+	return c.Fake.
+		InvokesWatch(testing.NewWatchAction(buildrequestsResource, c.ns, opts))
+
+}
+
+// Create takes the representation of a buildRequest and creates it.  Returns the server's representation of the buildRequest, and an error, if there is any.
+func (c *FakeBuildRequests) Create(ctx context.Context, buildRequest *v1alpha2.BuildRequest, opts v1.CreateOptions) (result *v1alpha2.BuildRequest, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewCreateAction(buildrequestsResource, c.ns, buildRequest), &v1alpha2.BuildRequest{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha2.BuildRequest), err
+}
+
+// Update takes the representation of a buildRequest and updates it. Returns the server's representation of the buildRequest, and an error, if there is any.
+func (c *FakeBuildRequests) Update(ctx context.Context, buildRequest *v1alpha2.BuildRequest, opts v1.UpdateOptions) (result *v1alpha2.BuildRequest, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewUpdateAction(buildrequestsResource, c.ns, buildRequest), &v1alpha2.BuildRequest{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha2.BuildRequest), err
+}
+
+// UpdateStatus was generated because the type contains a Status member.
+// Add a +genclient:noStatus comment above the type to avoid generating UpdateStatus().
+func (c *FakeBuildRequests) UpdateStatus(ctx context.Context, buildRequest *v1alpha2.BuildRequest, opts v1.UpdateOptions) (*v1alpha2.BuildRequest, error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewUpdateSubresourceAction(buildrequestsResource, "status", c.ns, buildRequest), &v1alpha2.BuildRequest{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha2.BuildRequest), err
+}
+
+// Delete takes name of the buildRequest and deletes it. Returns an error if one occurs.
+func (c *FakeBuildRequests) Delete(ctx context.Context, name string, opts v1.DeleteOptions) error {
+	_, err := c.Fake.
+		Invokes(testing.NewDeleteAction(buildrequestsResource, c.ns, name), &v1alpha2.BuildRequest{})
+
+	return err
+}
+
+// DeleteCollection deletes a collection of objects.
+func (c *FakeBuildRequests) DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error {
+	action := testing.NewDeleteCollectionAction(buildrequestsResource, c.ns, listOpts)
+
+	_, err := c.Fake.Invokes(action, &v1alpha2.BuildRequestList{})
+	return err
+}
+
+// Patch applies the patch and returns the patched buildRequest.
+func (c *FakeBuildRequests) Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *v1alpha2.BuildRequest, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewPatchSubresourceAction(buildrequestsResource, c.ns, name, pt, data, subresources...), &v1alpha2.BuildRequest{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha2.BuildRequest), err
+}
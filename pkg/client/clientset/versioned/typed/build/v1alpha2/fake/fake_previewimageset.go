@@ -0,0 +1,142 @@
+/*
+ * Copyright 2019 The original author or authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Code generated by client-gen. DO NOT EDIT.
+
+package fake
+
+import (
+	"context"
+
+	v1alpha2 "github.com/pivotal/kpack/pkg/apis/build/v1alpha2"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	labels "k8s.io/apimachinery/pkg/labels"
+	schema "k8s.io/apimachinery/pkg/runtime/schema"
+	types "k8s.io/apimachinery/pkg/types"
+	watch "k8s.io/apimachinery/pkg/watch"
+	testing "k8s.io/client-go/testing"
+)
+
+// FakePreviewImageSets implements PreviewImageSetInterface
+type FakePreviewImageSets struct {
+	Fake *FakeKpackV1alpha2
+	ns   string
+}
+
+var previewimagesetsResource = schema.GroupVersionResource{Group: "kpack.io", Version: "v1alpha2", Resource: "previewimagesets"}
+
+var previewimagesetsKind = schema.GroupVersionKind{Group: "kpack.io", Version: "v1alpha2", Kind: "PreviewImageSet"}
+
+// Get takes name of the previewImageSet, and returns the corresponding previewImageSet object, and an error if there is any.
+func (c *FakePreviewImageSets) Get(ctx context.Context, name string, options v1.GetOptions) (result *v1alpha2.PreviewImageSet, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewGetAction(previewimagesetsResource, c.ns, name), &v1alpha2.PreviewImageSet{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha2.PreviewImageSet), err
+}
+
+// List takes label and field selectors, and returns the list of PreviewImageSets that match those selectors.
+func (c *FakePreviewImageSets) List(ctx context.Context, opts v1.ListOptions) (result *v1alpha2.PreviewImageSetList, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewListAction(previewimagesetsResource, previewimagesetsKind, c.ns, opts), &v1alpha2.PreviewImageSetList{})
+
+	if obj == nil {
+		return nil, err
+	}
+
+	label, _, _ := testing.ExtractFromListOptions(opts)
+	if label == nil {
+		label = labels.Everything()
+	}
+	list := &v1alpha2.PreviewImageSetList{ListMeta: obj.(*v1alpha2.PreviewImageSetList).ListMeta}
+	for _, item := range obj.(*v1alpha2.PreviewImageSetList).Items {
+		if label.Matches(labels.Set(item.Labels)) {
+			list.Items = append(list.Items, item)
+		}
+	}
+	return list, err
+}
+
+// Watch returns a watch.Interface that watches the requested previewImageSets.
+func (c *FakePreviewImageSets) Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error) {
+	return c.Fake.
+		InvokesWatch(testing.NewWatchAction(previewimagesetsResource, c.ns, opts))
+
+}
+
+// Create takes the representation of a previewImageSet and creates it.  Returns the server's representation of the previewImageSet, and an error, if there is any.
+func (c *FakePreviewImageSets) Create(ctx context.Context, previewImageSet *v1alpha2.PreviewImageSet, opts v1.CreateOptions) (result *v1alpha2.PreviewImageSet, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewCreateAction(previewimagesetsResource, c.ns, previewImageSet), &v1alpha2.PreviewImageSet{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha2.PreviewImageSet), err
+}
+
+// Update takes the representation of a previewImageSet and updates it. Returns the server's representation of the previewImageSet, and an error, if there is any.
+func (c *FakePreviewImageSets) Update(ctx context.Context, previewImageSet *v1alpha2.PreviewImageSet, opts v1.UpdateOptions) (result *v1alpha2.PreviewImageSet, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewUpdateAction(previewimagesetsResource, c.ns, previewImageSet), &v1alpha2.PreviewImageSet{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha2.PreviewImageSet), err
+}
+
+// UpdateStatus was generated because the type contains a Status member.
+// Add a +genclient:noStatus comment above the type to avoid generating UpdateStatus().
+func (c *FakePreviewImageSets) UpdateStatus(ctx context.Context, previewImageSet *v1alpha2.PreviewImageSet, opts v1.UpdateOptions) (*v1alpha2.PreviewImageSet, error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewUpdateSubresourceAction(previewimagesetsResource, "status", c.ns, previewImageSet), &v1alpha2.PreviewImageSet{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha2.PreviewImageSet), err
+}
+
+// Delete takes name of the previewImageSet and deletes it. Returns an error if one occurs.
+func (c *FakePreviewImageSets) Delete(ctx context.Context, name string, opts v1.DeleteOptions) error {
+	_, err := c.Fake.
+		Invokes(testing.NewDeleteAction(previewimagesetsResource, c.ns, name), &v1alpha2.PreviewImageSet{})
+
+	return err
+}
+
+// DeleteCollection deletes a collection of objects.
+func (c *FakePreviewImageSets) DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error {
+	action := testing.NewDeleteCollectionAction(previewimagesetsResource, c.ns, listOpts)
+
+	_, err := c.Fake.Invokes(action, &v1alpha2.PreviewImageSetList{})
+	return err
+}
+
+// Patch applies the patch and returns the patched previewImageSet.
+func (c *FakePreviewImageSets) Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *v1alpha2.PreviewImageSet, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewPatchSubresourceAction(previewimagesetsResource, c.ns, name, pt, data, subresources...), &v1alpha2.PreviewImageSet{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha2.PreviewImageSet), err
+}
@@ -20,6 +20,8 @@ package v1alpha2
 
 type BuildExpansion interface{}
 
+type BuildRequestExpansion interface{}
+
 type BuilderExpansion interface{}
 
 type ClusterBuilderExpansion interface{}
@@ -30,4 +32,8 @@ type ClusterStoreExpansion interface{}
 
 type ImageExpansion interface{}
 
+type ImageSetExpansion interface{}
+
+type PreviewImageSetExpansion interface{}
+
 type SourceResolverExpansion interface{}
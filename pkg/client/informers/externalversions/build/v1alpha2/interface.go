@@ -26,6 +26,8 @@ import (
 type Interface interface {
 	// Builds returns a BuildInformer.
 	Builds() BuildInformer
+	// BuildRequests returns a BuildRequestInformer.
+	BuildRequests() BuildRequestInformer
 	// Builders returns a BuilderInformer.
 	Builders() BuilderInformer
 	// ClusterBuilders returns a ClusterBuilderInformer.
@@ -36,6 +38,10 @@ type Interface interface {
 	ClusterStores() ClusterStoreInformer
 	// Images returns a ImageInformer.
 	Images() ImageInformer
+	// ImageSets returns a ImageSetInformer.
+	ImageSets() ImageSetInformer
+	// PreviewImageSets returns a PreviewImageSetInformer.
+	PreviewImageSets() PreviewImageSetInformer
 	// SourceResolvers returns a SourceResolverInformer.
 	SourceResolvers() SourceResolverInformer
 }
@@ -56,6 +62,11 @@ func (v *version) Builds() BuildInformer {
 	return &buildInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
 }
 
+// BuildRequests returns a BuildRequestInformer.
+func (v *version) BuildRequests() BuildRequestInformer {
+	return &buildRequestInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
+}
+
 // Builders returns a BuilderInformer.
 func (v *version) Builders() BuilderInformer {
 	return &builderInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
@@ -81,6 +92,16 @@ func (v *version) Images() ImageInformer {
 	return &imageInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
 }
 
+// ImageSets returns a ImageSetInformer.
+func (v *version) ImageSets() ImageSetInformer {
+	return &imageSetInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
+}
+
+// PreviewImageSets returns a PreviewImageSetInformer.
+func (v *version) PreviewImageSets() PreviewImageSetInformer {
+	return &previewImageSetInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
+}
+
 // SourceResolvers returns a SourceResolverInformer.
 func (v *version) SourceResolvers() SourceResolverInformer {
 	return &sourceResolverInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
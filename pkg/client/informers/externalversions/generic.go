@@ -72,6 +72,8 @@ func (f *sharedInformerFactory) ForResource(resource schema.GroupVersionResource
 		// Group=kpack.io, Version=v1alpha2
 	case v1alpha2.SchemeGroupVersion.WithResource("builds"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Kpack().V1alpha2().Builds().Informer()}, nil
+	case v1alpha2.SchemeGroupVersion.WithResource("buildrequests"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Kpack().V1alpha2().BuildRequests().Informer()}, nil
 	case v1alpha2.SchemeGroupVersion.WithResource("builders"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Kpack().V1alpha2().Builders().Informer()}, nil
 	case v1alpha2.SchemeGroupVersion.WithResource("clusterbuilders"):
@@ -82,6 +84,10 @@ func (f *sharedInformerFactory) ForResource(resource schema.GroupVersionResource
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Kpack().V1alpha2().ClusterStores().Informer()}, nil
 	case v1alpha2.SchemeGroupVersion.WithResource("images"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Kpack().V1alpha2().Images().Informer()}, nil
+	case v1alpha2.SchemeGroupVersion.WithResource("imagesets"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Kpack().V1alpha2().ImageSets().Informer()}, nil
+	case v1alpha2.SchemeGroupVersion.WithResource("previewimagesets"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Kpack().V1alpha2().PreviewImageSets().Informer()}, nil
 	case v1alpha2.SchemeGroupVersion.WithResource("sourceresolvers"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Kpack().V1alpha2().SourceResolvers().Informer()}, nil
 
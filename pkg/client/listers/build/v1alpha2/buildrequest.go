@@ -0,0 +1,99 @@
+/*
+ * Copyright 2019 The original author or authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Code generated by lister-gen. DO NOT EDIT.
+
+package v1alpha2
+
+import (
+	v1alpha2 "github.com/pivotal/kpack/pkg/apis/build/v1alpha2"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/tools/cache"
+)
+
+// BuildRequestLister helps list BuildRequests.
+// All objects returned here must be treated as read-only.
+type BuildRequestLister interface {
+	// List lists all BuildRequests in the indexer.
+	// Objects returned here must be treated as read-only.
+	List(selector labels.Selector) (ret []*v1alpha2.BuildRequest, err error)
+	// BuildRequests returns an object that can list and get BuildRequests.
+	BuildRequests(namespace string) BuildRequestNamespaceLister
+	BuildRequestListerExpansion
+}
+
+// buildRequestLister implements the BuildRequestLister interface.
+type buildRequestLister struct {
+	indexer cache.Indexer
+}
+
+// NewBuildRequestLister returns a new BuildRequestLister.
+func NewBuildRequestLister(indexer cache.Indexer) BuildRequestLister {
+	return &buildRequestLister{indexer: indexer}
+}
+
+// List lists all BuildRequests in the indexer.
+func (s *buildRequestLister) List(selector labels.Selector) (ret []*v1alpha2.BuildRequest, err error) {
+	err = cache.ListAll(s.indexer, selector, func(m interface{}) {
+		ret = append(ret, m.(*v1alpha2.BuildRequest))
+	})
+	return ret, err
+}
+
+// BuildRequests returns an object that can list and get BuildRequests.
+func (s *buildRequestLister) BuildRequests(namespace string) BuildRequestNamespaceLister {
+	return buildRequestNamespaceLister{indexer: s.indexer, namespace: namespace}
+}
+
+// BuildRequestNamespaceLister helps list and get BuildRequests.
+// All objects returned here must be treated as read-only.
+type BuildRequestNamespaceLister interface {
+	// List lists all BuildRequests in the indexer for a given namespace.
+	// Objects returned here must be treated as read-only.
+	List(selector labels.Selector) (ret []*v1alpha2.BuildRequest, err error)
+	// Get retrieves the BuildRequest from the indexer for a given namespace and name.
+	// Objects returned here must be treated as read-only.
+	Get(name string) (*v1alpha2.BuildRequest, error)
+	BuildRequestNamespaceListerExpansion
+}
+
+// buildRequestNamespaceLister implements the BuildRequestNamespaceLister
+// interface.
+type buildRequestNamespaceLister struct {
+	indexer   cache.Indexer
+	namespace string
+}
+
+// List lists all BuildRequests in the indexer for a given namespace.
+func (s buildRequestNamespaceLister) List(selector labels.Selector) (ret []*v1alpha2.BuildRequest, err error) {
+	err = cache.ListAllByNamespace(s.indexer, s.namespace, selector, func(m interface{}) {
+		ret = append(ret, m.(*v1alpha2.BuildRequest))
+	})
+	return ret, err
+}
+
+// Get retrieves the BuildRequest from the indexer for a given namespace and name.
+func (s buildRequestNamespaceLister) Get(name string) (*v1alpha2.BuildRequest, error) {
+	obj, exists, err := s.indexer.GetByKey(s.namespace + "/" + name)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, errors.NewNotFound(v1alpha2.Resource("buildrequest"), name)
+	}
+	return obj.(*v1alpha2.BuildRequest), nil
+}
@@ -26,6 +26,14 @@ type BuildListerExpansion interface{}
 // BuildNamespaceLister.
 type BuildNamespaceListerExpansion interface{}
 
+// BuildRequestListerExpansion allows custom methods to be added to
+// BuildRequestLister.
+type BuildRequestListerExpansion interface{}
+
+// BuildRequestNamespaceListerExpansion allows custom methods to be added to
+// BuildRequestNamespaceLister.
+type BuildRequestNamespaceListerExpansion interface{}
+
 // BuilderListerExpansion allows custom methods to be added to
 // BuilderLister.
 type BuilderListerExpansion interface{}
@@ -54,6 +62,22 @@ type ImageListerExpansion interface{}
 // ImageNamespaceLister.
 type ImageNamespaceListerExpansion interface{}
 
+// ImageSetListerExpansion allows custom methods to be added to
+// ImageSetLister.
+type ImageSetListerExpansion interface{}
+
+// ImageSetNamespaceListerExpansion allows custom methods to be added to
+// ImageSetNamespaceLister.
+type ImageSetNamespaceListerExpansion interface{}
+
+// PreviewImageSetListerExpansion allows custom methods to be added to
+// PreviewImageSetLister.
+type PreviewImageSetListerExpansion interface{}
+
+// PreviewImageSetNamespaceListerExpansion allows custom methods to be added to
+// PreviewImageSetNamespaceLister.
+type PreviewImageSetNamespaceListerExpansion interface{}
+
 // SourceResolverListerExpansion allows custom methods to be added to
 // SourceResolverLister.
 type SourceResolverListerExpansion interface{}
@@ -0,0 +1,99 @@
+/*
+ * Copyright 2019 The original author or authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Code generated by lister-gen. DO NOT EDIT.
+
+package v1alpha2
+
+import (
+	v1alpha2 "github.com/pivotal/kpack/pkg/apis/build/v1alpha2"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/tools/cache"
+)
+
+// PreviewImageSetLister helps list PreviewImageSets.
+// All objects returned here must be treated as read-only.
+type PreviewImageSetLister interface {
+	// List lists all PreviewImageSets in the indexer.
+	// Objects returned here must be treated as read-only.
+	List(selector labels.Selector) (ret []*v1alpha2.PreviewImageSet, err error)
+	// PreviewImageSets returns an object that can list and get PreviewImageSets.
+	PreviewImageSets(namespace string) PreviewImageSetNamespaceLister
+	PreviewImageSetListerExpansion
+}
+
+// previewImageSetLister implements the PreviewImageSetLister interface.
+type previewImageSetLister struct {
+	indexer cache.Indexer
+}
+
+// NewPreviewImageSetLister returns a new PreviewImageSetLister.
+func NewPreviewImageSetLister(indexer cache.Indexer) PreviewImageSetLister {
+	return &previewImageSetLister{indexer: indexer}
+}
+
+// List lists all PreviewImageSets in the indexer.
+func (s *previewImageSetLister) List(selector labels.Selector) (ret []*v1alpha2.PreviewImageSet, err error) {
+	err = cache.ListAll(s.indexer, selector, func(m interface{}) {
+		ret = append(ret, m.(*v1alpha2.PreviewImageSet))
+	})
+	return ret, err
+}
+
+// PreviewImageSets returns an object that can list and get PreviewImageSets.
+func (s *previewImageSetLister) PreviewImageSets(namespace string) PreviewImageSetNamespaceLister {
+	return previewImageSetNamespaceLister{indexer: s.indexer, namespace: namespace}
+}
+
+// PreviewImageSetNamespaceLister helps list and get PreviewImageSets.
+// All objects returned here must be treated as read-only.
+type PreviewImageSetNamespaceLister interface {
+	// List lists all PreviewImageSets in the indexer for a given namespace.
+	// Objects returned here must be treated as read-only.
+	List(selector labels.Selector) (ret []*v1alpha2.PreviewImageSet, err error)
+	// Get retrieves the PreviewImageSet from the indexer for a given namespace and name.
+	// Objects returned here must be treated as read-only.
+	Get(name string) (*v1alpha2.PreviewImageSet, error)
+	PreviewImageSetNamespaceListerExpansion
+}
+
+// previewImageSetNamespaceLister implements the PreviewImageSetNamespaceLister
+// interface.
+type previewImageSetNamespaceLister struct {
+	indexer   cache.Indexer
+	namespace string
+}
+
+// List lists all PreviewImageSets in the indexer for a given namespace.
+func (s previewImageSetNamespaceLister) List(selector labels.Selector) (ret []*v1alpha2.PreviewImageSet, err error) {
+	err = cache.ListAllByNamespace(s.indexer, s.namespace, selector, func(m interface{}) {
+		ret = append(ret, m.(*v1alpha2.PreviewImageSet))
+	})
+	return ret, err
+}
+
+// Get retrieves the PreviewImageSet from the indexer for a given namespace and name.
+func (s previewImageSetNamespaceLister) Get(name string) (*v1alpha2.PreviewImageSet, error) {
+	obj, exists, err := s.indexer.GetByKey(s.namespace + "/" + name)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, errors.NewNotFound(v1alpha2.Resource("previewimageset"), name)
+	}
+	return obj.(*v1alpha2.PreviewImageSet), nil
+}
@@ -0,0 +1,48 @@
+package config
+
+import (
+	"sync/atomic"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+const (
+	GitTrustConfigName    = "git-trust"
+	GitTrustKnownHostsKey = "known_hosts"
+	GitTrustCABundleKey   = "ca.crt"
+)
+
+// GitTrustProvider provides the ssh known hosts and certificate authorities
+// trusted for every git source in the cluster.
+type GitTrustProvider struct {
+	trust atomic.Value
+}
+
+func NewGitTrustProvider() *GitTrustProvider {
+	return &GitTrustProvider{}
+}
+
+func (g *GitTrustProvider) UpdateTrust(cm *corev1.ConfigMap) {
+	g.trust.Store(gitTrust{
+		knownHosts: []byte(cm.Data[GitTrustKnownHostsKey]),
+		caBundle:   []byte(cm.Data[GitTrustCABundleKey]),
+	})
+}
+
+func (g *GitTrustProvider) GitKnownHosts() []byte {
+	return g.load().knownHosts
+}
+
+func (g *GitTrustProvider) GitCABundle() []byte {
+	return g.load().caBundle
+}
+
+func (g *GitTrustProvider) load() gitTrust {
+	trust, _ := g.trust.Load().(gitTrust)
+	return trust
+}
+
+type gitTrust struct {
+	knownHosts []byte
+	caBundle   []byte
+}
@@ -0,0 +1,42 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/sclevine/spec"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestGitTrustProvider(t *testing.T) {
+	spec.Run(t, "GitTrustProvider", testGitTrustProvider)
+}
+
+func testGitTrustProvider(t *testing.T, when spec.G, it spec.S) {
+	var p *GitTrustProvider
+
+	it.Before(func() {
+		p = NewGitTrustProvider()
+	})
+
+	it("is empty before the config map is loaded", func() {
+		require.Empty(t, p.GitKnownHosts())
+		require.Empty(t, p.GitCABundle())
+	})
+
+	it("provides the known hosts and ca bundle from the config map", func() {
+		p.UpdateTrust(&corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: GitTrustConfigName,
+			},
+			Data: map[string]string{
+				GitTrustKnownHostsKey: "github.com ssh-ed25519 AAAA",
+				GitTrustCABundleKey:   "some-ca",
+			},
+		})
+
+		require.Equal(t, []byte("github.com ssh-ed25519 AAAA"), p.GitKnownHosts())
+		require.Equal(t, []byte("some-ca"), p.GitCABundle())
+	})
+}
@@ -0,0 +1,229 @@
+package cron
+
+import (
+	"strconv"
+	"strings"
+	"time"
+	// time zones of schedules are loaded without the zoneinfo of the host
+	_ "time/tzdata"
+
+	"github.com/pkg/errors"
+)
+
+// Schedule is a parsed cron expression with minute, hour, day of month, month
+// and day of week fields.
+type Schedule struct {
+	minute, hour, dom, month, dow bits
+	domStar, dowStar              bool
+	location                      *time.Location
+}
+
+var descriptors = map[string]string{
+	"@yearly":   "0 0 1 1 *",
+	"@annually": "0 0 1 1 *",
+	"@monthly":  "0 0 1 * *",
+	"@weekly":   "0 0 * * 0",
+	"@daily":    "0 0 * * *",
+	"@midnight": "0 0 * * *",
+	"@hourly":   "0 * * * *",
+}
+
+var (
+	minutes  = bounds{min: 0, max: 59}
+	hours    = bounds{min: 0, max: 23}
+	days     = bounds{min: 1, max: 31}
+	months   = bounds{min: 1, max: 12, names: []string{"jan", "feb", "mar", "apr", "may", "jun", "jul", "aug", "sep", "oct", "nov", "dec"}}
+	weekdays = bounds{min: 0, max: 7, names: []string{"sun", "mon", "tue", "wed", "thu", "fri", "sat"}}
+)
+
+// Parse parses a five field cron expression, such as "0 3 * * 1", or a
+// descriptor, such as "@weekly", evaluated in location. A nil location is UTC.
+func Parse(expr string, location *time.Location) (*Schedule, error) {
+	if descriptor, ok := descriptors[strings.TrimSpace(expr)]; ok {
+		expr = descriptor
+	}
+
+	fields := strings.Fields(expr)
+	if len(fields) != 5 {
+		return nil, errors.Errorf("expected 5 fields in cron expression %q, found %d", expr, len(fields))
+	}
+
+	var (
+		s   = &Schedule{location: location}
+		err error
+	)
+	if s.minute, err = parseField(fields[0], minutes); err != nil {
+		return nil, errors.Wrap(err, "invalid minute")
+	}
+	if s.hour, err = parseField(fields[1], hours); err != nil {
+		return nil, errors.Wrap(err, "invalid hour")
+	}
+	if s.dom, err = parseField(fields[2], days); err != nil {
+		return nil, errors.Wrap(err, "invalid day of month")
+	}
+	if s.month, err = parseField(fields[3], months); err != nil {
+		return nil, errors.Wrap(err, "invalid month")
+	}
+	if s.dow, err = parseField(fields[4], weekdays); err != nil {
+		return nil, errors.Wrap(err, "invalid day of week")
+	}
+
+	// Sunday is both 0 and 7
+	if s.dow.has(7) {
+		s.dow |= 1
+	}
+	s.domStar = fields[2] == "*" || fields[2] == "?"
+	s.dowStar = fields[4] == "*" || fields[4] == "?"
+	if s.location == nil {
+		s.location = time.UTC
+	}
+	return s, nil
+}
+
+// Next returns the first time after t that matches the schedule, or the zero
+// time if there is none within five years. A wall clock time skipped by a
+// daylight saving time transition matches as the time it normalizes to and a
+// repeated wall clock time matches once.
+func (s *Schedule) Next(t time.Time) time.Time {
+	// The wall clock is walked in UTC, where every minute exists exactly
+	// once, so that daylight saving time transitions cannot move it back.
+	local := t.In(s.location)
+	c := time.Date(local.Year(), local.Month(), local.Day(), local.Hour(), local.Minute()+1, 0, 0, time.UTC)
+	yearLimit := c.Year() + 5
+
+	for c.Year() <= yearLimit {
+		if !s.month.has(int(c.Month())) {
+			c = time.Date(c.Year(), c.Month()+1, 1, 0, 0, 0, 0, time.UTC)
+			continue
+		}
+		if !s.dayMatches(c) {
+			c = time.Date(c.Year(), c.Month(), c.Day()+1, 0, 0, 0, 0, time.UTC)
+			continue
+		}
+		if !s.hour.has(c.Hour()) {
+			c = c.Truncate(time.Hour).Add(time.Hour)
+			continue
+		}
+		if !s.minute.has(c.Minute()) {
+			c = c.Add(time.Minute)
+			continue
+		}
+
+		next := time.Date(c.Year(), c.Month(), c.Day(), c.Hour(), c.Minute(), 0, 0, s.location)
+		if wall := wallClock(next); !wall.Equal(c) {
+			// c is skipped by a transition, move past it by as much as c
+			// is past the start of the transition
+			next = next.Add(c.Sub(wall))
+		}
+		if next.After(t) {
+			return next
+		}
+		c = c.Add(time.Minute)
+	}
+	return time.Time{}
+}
+
+// wallClock returns the wall clock time of t as a time in UTC.
+func wallClock(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), time.UTC)
+}
+
+// dayMatches follows cron in matching either the day of month or the day of
+// week when both are restricted.
+func (s *Schedule) dayMatches(t time.Time) bool {
+	domMatch := s.dom.has(t.Day())
+	dowMatch := s.dow.has(int(t.Weekday()))
+	if s.domStar || s.dowStar {
+		return domMatch && dowMatch
+	}
+	return domMatch || dowMatch
+}
+
+type bits uint64
+
+func (b bits) has(i int) bool {
+	return b&(1<<uint(i)) != 0
+}
+
+type bounds struct {
+	min, max int
+	names    []string
+}
+
+func parseField(field string, b bounds) (bits, error) {
+	var result bits
+	for _, part := range strings.Split(field, ",") {
+		r, err := parseRange(part, b)
+		if err != nil {
+			return 0, err
+		}
+		result |= r
+	}
+	return result, nil
+}
+
+func parseRange(expr string, b bounds) (bits, error) {
+	rangeAndStep := strings.Split(expr, "/")
+	if len(rangeAndStep) > 2 {
+		return 0, errors.Errorf("too many slashes in %q", expr)
+	}
+
+	var start, end int
+	switch rangeExpr := rangeAndStep[0]; {
+	case rangeExpr == "*" || rangeExpr == "?":
+		start, end = b.min, b.max
+	default:
+		lowAndHigh := strings.Split(rangeExpr, "-")
+		if len(lowAndHigh) > 2 {
+			return 0, errors.Errorf("too many hyphens in %q", expr)
+		}
+
+		var err error
+		if start, err = b.value(lowAndHigh[0]); err != nil {
+			return 0, err
+		}
+		end = start
+		if len(lowAndHigh) == 2 {
+			if end, err = b.value(lowAndHigh[1]); err != nil {
+				return 0, err
+			}
+		} else if len(rangeAndStep) == 2 {
+			end = b.max
+		}
+	}
+
+	step := 1
+	if len(rangeAndStep) == 2 {
+		var err error
+		if step, err = strconv.Atoi(rangeAndStep[1]); err != nil || step <= 0 {
+			return 0, errors.Errorf("invalid step in %q", expr)
+		}
+	}
+
+	if start > end {
+		return 0, errors.Errorf("beginning of range is after the end in %q", expr)
+	}
+
+	var result bits
+	for i := start; i <= end; i += step {
+		result |= 1 << uint(i)
+	}
+	return result, nil
+}
+
+func (b bounds) value(expr string) (int, error) {
+	for i, name := range b.names {
+		if strings.EqualFold(expr, name) {
+			return i + b.min, nil
+		}
+	}
+
+	i, err := strconv.Atoi(expr)
+	if err != nil {
+		return 0, errors.Errorf("failed to parse %q", expr)
+	}
+	if i < b.min || i > b.max {
+		return 0, errors.Errorf("%d is out of range [%d, %d]", i, b.min, b.max)
+	}
+	return i, nil
+}
@@ -0,0 +1,126 @@
+package cron_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/sclevine/spec"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/pivotal/kpack/pkg/cron"
+)
+
+func TestSchedule(t *testing.T) {
+	spec.Run(t, "testSchedule", testSchedule)
+}
+
+func testSchedule(t *testing.T, when spec.G, it spec.S) {
+	at := func(value string) time.Time {
+		t.Helper()
+		parsed, err := time.Parse(time.RFC3339, value)
+		require.NoError(t, err)
+		return parsed
+	}
+
+	next := func(expr, from string) time.Time {
+		t.Helper()
+		schedule, err := cron.Parse(expr, nil)
+		require.NoError(t, err)
+		return schedule.Next(at(from))
+	}
+
+	when("#Next", func() {
+		it("returns the next matching minute", func() {
+			assert.Equal(t, at("2021-03-04T10:16:00Z"), next("* * * * *", "2021-03-04T10:15:30Z"))
+			assert.Equal(t, at("2021-03-04T10:30:00Z"), next("*/15 * * * *", "2021-03-04T10:15:00Z"))
+			assert.Equal(t, at("2021-03-04T11:05:00Z"), next("5 * * * *", "2021-03-04T10:05:00Z"))
+		})
+
+		it("rolls over hours, days, months and years", func() {
+			assert.Equal(t, at("2021-03-05T03:00:00Z"), next("0 3 * * *", "2021-03-04T10:15:00Z"))
+			assert.Equal(t, at("2021-04-01T00:00:00Z"), next("@monthly", "2021-03-04T10:15:00Z"))
+			assert.Equal(t, at("2022-01-01T00:00:00Z"), next("@yearly", "2021-03-04T10:15:00Z"))
+			assert.Equal(t, at("2024-02-29T12:00:00Z"), next("0 12 29 feb *", "2021-03-04T10:15:00Z"))
+		})
+
+		it("matches days of the week", func() {
+			assert.Equal(t, at("2021-03-07T00:00:00Z"), next("@weekly", "2021-03-04T10:15:00Z"))
+			assert.Equal(t, at("2021-03-07T00:00:00Z"), next("0 0 * * 7", "2021-03-04T10:15:00Z"))
+			assert.Equal(t, at("2021-03-08T06:30:00Z"), next("30 6 * * mon-fri", "2021-03-05T07:00:00Z"))
+		})
+
+		it("matches either the day of month or the day of week when both are set", func() {
+			assert.Equal(t, at("2021-03-07T00:00:00Z"), next("0 0 15 * sun", "2021-03-04T10:15:00Z"))
+			assert.Equal(t, at("2021-03-15T00:00:00Z"), next("0 0 15 * sun", "2021-03-14T10:15:00Z"))
+		})
+
+		it("evaluates the schedule in its location", func() {
+			berlin, err := time.LoadLocation("Europe/Berlin")
+			require.NoError(t, err)
+			schedule, err := cron.Parse("0 3 * * *", berlin)
+			require.NoError(t, err)
+
+			assert.True(t, at("2021-03-05T02:00:00Z").Equal(schedule.Next(at("2021-03-04T10:15:00Z"))))
+		})
+
+		it("matches a wall clock time skipped by daylight saving time once", func() {
+			newYork, err := time.LoadLocation("America/New_York")
+			require.NoError(t, err)
+			schedule, err := cron.Parse("30 2 * * *", newYork)
+			require.NoError(t, err)
+
+			// 02:30 does not exist on 2026-03-08 and normalizes to 03:30 EDT
+			next := schedule.Next(at("2026-03-08T06:00:00Z"))
+			assert.True(t, at("2026-03-08T07:30:00Z").Equal(next), next.String())
+			assert.True(t, at("2026-03-09T06:30:00Z").Equal(schedule.Next(next)))
+
+			everyMinute, err := cron.Parse("* * * * *", newYork)
+			require.NoError(t, err)
+			assert.True(t, at("2026-03-08T07:00:00Z").Equal(everyMinute.Next(at("2026-03-08T06:59:00Z"))))
+		})
+
+		it("matches a wall clock time repeated by daylight saving time once", func() {
+			newYork, err := time.LoadLocation("America/New_York")
+			require.NoError(t, err)
+			schedule, err := cron.Parse("30 1 * * *", newYork)
+			require.NoError(t, err)
+
+			// 01:30 happens at 05:30Z (EDT) and 06:30Z (EST) on 2026-11-01
+			next := schedule.Next(at("2026-11-01T04:00:00Z"))
+			assert.True(t, at("2026-11-01T05:30:00Z").Equal(next), next.String())
+			assert.True(t, at("2026-11-02T06:30:00Z").Equal(schedule.Next(next)))
+			assert.True(t, at("2026-11-02T06:30:00Z").Equal(schedule.Next(at("2026-11-01T06:10:00Z"))))
+
+			// the repeated hour from 06:00Z to 07:00Z is not matched again
+			everyMinute, err := cron.Parse("* * * * *", newYork)
+			require.NoError(t, err)
+			assert.True(t, at("2026-11-01T07:00:00Z").Equal(everyMinute.Next(at("2026-11-01T05:59:00Z"))))
+		})
+
+		it("returns the zero time for a schedule that never matches", func() {
+			assert.True(t, next("0 0 30 feb *", "2021-03-04T10:15:00Z").IsZero())
+		})
+	})
+
+	when("#Parse", func() {
+		it("rejects invalid expressions", func() {
+			for _, expr := range []string{
+				"",
+				"* * * *",
+				"60 * * * *",
+				"* 24 * * *",
+				"* * 0 * *",
+				"* * * 13 *",
+				"* * * * 8",
+				"*/0 * * * *",
+				"5-1 * * * *",
+				"a * * * *",
+				"@fortnightly",
+			} {
+				_, err := cron.Parse(expr, nil)
+				assert.Error(t, err, expr)
+			}
+		})
+	})
+}
@@ -38,3 +38,7 @@ func (b *DuckBuilder) BuildpackMetadata() corev1alpha1.BuildpackMetadataList {
 func (b *DuckBuilder) RunImage() string {
 	return b.Status.Stack.RunImage
 }
+
+func (b *DuckBuilder) StackID() string {
+	return b.Status.Stack.ID
+}
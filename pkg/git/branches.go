@@ -0,0 +1,62 @@
+package git
+
+import (
+	"io/ioutil"
+	"os"
+	"sort"
+	"strings"
+
+	git2go "github.com/libgit2/git2go/v33"
+	"github.com/pkg/errors"
+
+	corev1alpha1 "github.com/pivotal/kpack/pkg/apis/core/v1alpha1"
+)
+
+const branchRefPrefix = "refs/heads/"
+
+// ListBranches returns the names of the branches of the remote of gitSource.
+func (*remoteGitResolver) ListBranches(keychain GitKeychain, trust Trust, gitSource corev1alpha1.Git) ([]string, error) {
+	dir, err := ioutil.TempDir("", "git-branches")
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(dir)
+
+	repository, err := git2go.InitRepository(dir, true)
+	if err != nil {
+		return nil, errors.Wrap(err, "initializing repo")
+	}
+	defer repository.Free()
+
+	remote, err := repository.Remotes.CreateWithOptions(gitSource.URL, &git2go.RemoteCreateOptions{
+		Name:  defaultRemote,
+		Flags: git2go.RemoteCreateSkipInsteadof,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "create remote")
+	}
+	defer remote.Free()
+
+	err = remote.ConnectFetch(&git2go.RemoteCallbacks{
+		CredentialsCallback:      keychainAsCredentialsCallback(keychain),
+		CertificateCheckCallback: certificateCheckCallback(trust, gitSource.URL),
+	}, &git2go.ProxyOptions{Type: git2go.ProxyTypeAuto}, nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "fetching remote")
+	}
+	defer remote.Disconnect()
+
+	references, err := remote.Ls()
+	if err != nil {
+		return nil, errors.Wrap(err, "remote ls")
+	}
+
+	var branches []string
+	for _, ref := range references {
+		if strings.HasPrefix(ref.Name, branchRefPrefix) && !strings.HasSuffix(ref.Name, "^{}") {
+			branches = append(branches, strings.TrimPrefix(ref.Name, branchRefPrefix))
+		}
+	}
+	sort.Strings(branches)
+	return branches, nil
+}
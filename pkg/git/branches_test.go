@@ -0,0 +1,74 @@
+package git
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	git2go "github.com/libgit2/git2go/v33"
+	"github.com/sclevine/spec"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	corev1alpha1 "github.com/pivotal/kpack/pkg/apis/core/v1alpha1"
+)
+
+func TestListBranches(t *testing.T) {
+	spec.Run(t, "TestListBranches", testListBranches)
+}
+
+func testListBranches(t *testing.T, when spec.G, it spec.S) {
+	var (
+		repoDir    string
+		repository *git2go.Repository
+	)
+
+	it.Before(func() {
+		var err error
+		repoDir, err = ioutil.TempDir("", "git-branches-repo")
+		require.NoError(t, err)
+
+		repository, err = git2go.InitRepository(repoDir, false)
+		require.NoError(t, err)
+
+		require.NoError(t, ioutil.WriteFile(filepath.Join(repoDir, "README.md"), []byte("readme"), 0644))
+		index, err := repository.Index()
+		require.NoError(t, err)
+		defer index.Free()
+		require.NoError(t, index.AddAll([]string{"."}, git2go.IndexAddDefault, nil))
+		treeId, err := index.WriteTree()
+		require.NoError(t, err)
+		tree, err := repository.LookupTree(treeId)
+		require.NoError(t, err)
+		defer tree.Free()
+
+		signature := &git2go.Signature{Name: "kpack", Email: "kpack@example.com", When: time.Now()}
+		commitId, err := repository.CreateCommit("refs/heads/main", signature, signature, "commit", tree)
+		require.NoError(t, err)
+		commit, err := repository.LookupCommit(commitId)
+		require.NoError(t, err)
+		defer commit.Free()
+
+		for _, branch := range []string{"feature/login", "fix"} {
+			b, err := repository.CreateBranch(branch, commit, false)
+			require.NoError(t, err)
+			b.Free()
+		}
+		_, err = repository.Tags.CreateLightweight("v1.0.0", commit, false)
+		require.NoError(t, err)
+	})
+
+	it.After(func() {
+		repository.Free()
+		require.NoError(t, os.RemoveAll(repoDir))
+	})
+
+	it("returns the branches of the remote", func() {
+		branches, err := (&remoteGitResolver{}).ListBranches(&fakeGitKeychain{}, Trust{}, corev1alpha1.Git{URL: repoDir})
+		require.NoError(t, err)
+
+		assert.Equal(t, []string{"feature/login", "fix", "main"}, branches)
+	})
+}
@@ -5,13 +5,29 @@ import (
 	"github.com/pkg/errors"
 )
 
-func certificateCheckCallback() git2go.CertificateCheckCallback {
+func certificateCheckCallback(trust Trust, gitURL string) git2go.CertificateCheckCallback {
 	return func(cert *git2go.Certificate, valid bool, hostname string) error {
+		if cert.Kind == git2go.CertificateHostkey && len(trust.KnownHosts) > 0 {
+			var fingerprint []byte
+			if cert.Hostkey.Kind&git2go.HostkeySHA256 != 0 {
+				fingerprint = cert.Hostkey.HashSHA256[:]
+			}
+
+			if cert.Hostkey.SSHPublicKey == nil && fingerprint == nil {
+				return hostVerificationError(errors.New("no sha256 fingerprint or raw host key available"), "verifying host key")
+			}
+			return trust.verifyHostKey(hostname, sshPort(gitURL), cert.Hostkey.SSHPublicKey, fingerprint)
+		}
+
 		if valid {
 			return nil
 		}
 
 		if cert.Kind == git2go.CertificateX509 {
+			if cert.X509 != nil && len(trust.CABundle) > 0 {
+				return trust.verifyCertificate(hostname, cert.X509)
+			}
+
 			if cert.X509 != nil {
 				err := cert.X509.VerifyHostname(hostname)
 				if err != nil {
@@ -0,0 +1,158 @@
+package git
+
+import (
+	"fmt"
+	"path"
+	"strings"
+
+	git2go "github.com/libgit2/git2go/v33"
+	"github.com/pkg/errors"
+
+	corev1alpha1 "github.com/pivotal/kpack/pkg/apis/core/v1alpha1"
+)
+
+// ListDirectories fetches the revision of gitSource into its cached repository
+// and returns the subdirectories of dir that contain file, or all of them when
+// file is empty.
+func (r *remoteGitResolver) ListDirectories(keychain GitKeychain, trust Trust, gitSource corev1alpha1.Git, dir, file string) ([]string, error) {
+	repository, release, err := r.repositories.open(gitSource.URL)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
+	remote, err := originRemote(repository, gitSource.URL)
+	if err != nil {
+		return nil, err
+	}
+	defer remote.Free()
+
+	fetchOptions := &git2go.FetchOptions{
+		DownloadTags: git2go.DownloadTagsNone,
+		RemoteCallbacks: git2go.RemoteCallbacks{
+			CredentialsCallback:      keychainAsCredentialsCallback(keychain),
+			CertificateCheckCallback: certificateCheckCallback(trust, gitSource.URL),
+		},
+		ProxyOptions: git2go.ProxyOptions{
+			Type: git2go.ProxyTypeAuto,
+		},
+	}
+
+	refspecs, err := revisionRefspecs(remote, gitSource.Revision, fetchOptions)
+	if err != nil {
+		return nil, err
+	}
+
+	err = remote.Fetch(refspecs, fetchOptions, "")
+	if err != nil {
+		return nil, errors.Wrap(err, "fetching remote")
+	}
+
+	oid, err := resolveRevision(repository, gitSource.Revision)
+	if err != nil {
+		return nil, err
+	}
+
+	commit, err := repository.LookupCommit(oid)
+	if err != nil {
+		return nil, errors.Wrap(err, "looking up commit")
+	}
+	defer commit.Free()
+
+	tree, err := commit.Tree()
+	if err != nil {
+		return nil, errors.Wrap(err, "looking up tree")
+	}
+	defer tree.Free()
+
+	dir = strings.Trim(dir, "/")
+	if dir != "" {
+		entry, err := tree.EntryByPath(dir)
+		if err != nil || entry.Type != git2go.ObjectTree {
+			return nil, errors.Errorf("directory %s not found at %s", dir, gitSource.Revision)
+		}
+
+		tree, err = repository.LookupTree(entry.Id)
+		if err != nil {
+			return nil, errors.Wrapf(err, "looking up tree of %s", dir)
+		}
+		defer tree.Free()
+	}
+
+	var directories []string
+	for i := uint64(0); i < tree.EntryCount(); i++ {
+		entry := tree.EntryByIndex(i)
+		if entry.Type != git2go.ObjectTree {
+			continue
+		}
+
+		if file != "" {
+			found, err := containsFile(repository, entry, file)
+			if err != nil {
+				return nil, err
+			}
+			if !found {
+				continue
+			}
+		}
+
+		directories = append(directories, path.Join(dir, entry.Name))
+	}
+	return directories, nil
+}
+
+func containsFile(repository *git2go.Repository, entry *git2go.TreeEntry, file string) (bool, error) {
+	tree, err := repository.LookupTree(entry.Id)
+	if err != nil {
+		return false, errors.Wrapf(err, "looking up tree of %s", entry.Name)
+	}
+	defer tree.Free()
+
+	_, err = tree.EntryByPath(file)
+	return err == nil, nil
+}
+
+// revisionRefspecs returns refspecs for the remote refs that point at or are
+// named by gitRevision. libgit2 cannot fetch a single commit by id, so every
+// ref is fetched when none of them match. A cached repository only downloads
+// the commits it does not have yet either way.
+func revisionRefspecs(remote *git2go.Remote, gitRevision string, fetchOptions *git2go.FetchOptions) ([]string, error) {
+	err := remote.ConnectFetch(&fetchOptions.RemoteCallbacks, &fetchOptions.ProxyOptions, nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "fetching remote")
+	}
+	defer remote.Disconnect()
+
+	references, err := remote.Ls()
+	if err != nil {
+		return nil, errors.Wrap(err, "remote ls")
+	}
+
+	var refspecs []string
+	seen := map[string]bool{}
+	for _, ref := range references {
+		name := strings.TrimSuffix(ref.Name, "^{}")
+		if !strings.HasPrefix(name, "refs/") || seen[name] {
+			continue
+		}
+
+		if ref.Id.String() == gitRevision || matchesRevision(name, gitRevision) {
+			seen[name] = true
+			refspecs = append(refspecs, fmt.Sprintf("+%s:%s", name, name))
+		}
+	}
+
+	if len(refspecs) == 0 {
+		return []string{"+refs/*:refs/*"}, nil
+	}
+	return refspecs, nil
+}
+
+func matchesRevision(refName, gitRevision string) bool {
+	for _, format := range refRevParseRules {
+		if fmt.Sprintf(format, gitRevision) == refName {
+			return true
+		}
+	}
+	return false
+}
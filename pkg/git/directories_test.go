@@ -0,0 +1,125 @@
+package git
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	git2go "github.com/libgit2/git2go/v33"
+	"github.com/sclevine/spec"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	corev1alpha1 "github.com/pivotal/kpack/pkg/apis/core/v1alpha1"
+)
+
+func TestListDirectories(t *testing.T) {
+	spec.Run(t, "TestListDirectories", testListDirectories)
+}
+
+func testListDirectories(t *testing.T, when spec.G, it spec.S) {
+	var (
+		repoDir    string
+		repository *git2go.Repository
+	)
+
+	commitFiles := func(files map[string]string) {
+		for name, contents := range files {
+			file := filepath.Join(repoDir, name)
+			require.NoError(t, os.MkdirAll(filepath.Dir(file), 0755))
+			require.NoError(t, ioutil.WriteFile(file, []byte(contents), 0644))
+		}
+
+		index, err := repository.Index()
+		require.NoError(t, err)
+		defer index.Free()
+		require.NoError(t, index.AddAll([]string{"."}, git2go.IndexAddDefault, nil))
+		require.NoError(t, index.Write())
+
+		treeId, err := index.WriteTree()
+		require.NoError(t, err)
+		tree, err := repository.LookupTree(treeId)
+		require.NoError(t, err)
+		defer tree.Free()
+
+		signature := &git2go.Signature{Name: "kpack", Email: "kpack@example.com", When: time.Now()}
+		_, err = repository.CreateCommit("refs/heads/main", signature, signature, "commit", tree)
+		require.NoError(t, err)
+	}
+
+	source := corev1alpha1.Git{
+		Revision: "main",
+	}
+
+	it.Before(func() {
+		var err error
+		repoDir, err = ioutil.TempDir("", "git-directories-repo")
+		require.NoError(t, err)
+
+		repository, err = git2go.InitRepository(repoDir, false)
+		require.NoError(t, err)
+		source.URL = repoDir
+
+		commitFiles(map[string]string{
+			"README.md":                 "readme",
+			"apps/api/project.toml":     "api",
+			"apps/web/project.toml":     "web",
+			"apps/scripts/build.sh":     "build",
+			"libs/shared/project.toml":  "shared",
+			"apps/web/static/index.htm": "index",
+		})
+	})
+
+	it.After(func() {
+		repository.Free()
+		require.NoError(t, os.RemoveAll(repoDir))
+	})
+
+	it("returns the subdirectories of the path", func() {
+		directories, err := (&remoteGitResolver{}).ListDirectories(&fakeGitKeychain{}, Trust{}, source, "apps", "")
+		require.NoError(t, err)
+
+		assert.Equal(t, []string{"apps/api", "apps/scripts", "apps/web"}, directories)
+	})
+
+	it("returns the subdirectories that contain the file", func() {
+		directories, err := (&remoteGitResolver{}).ListDirectories(&fakeGitKeychain{}, Trust{}, source, "/apps/", "project.toml")
+		require.NoError(t, err)
+
+		assert.Equal(t, []string{"apps/api", "apps/web"}, directories)
+	})
+
+	it("returns the directories of the root without a path", func() {
+		directories, err := (&remoteGitResolver{}).ListDirectories(&fakeGitKeychain{}, Trust{}, source, "", "")
+		require.NoError(t, err)
+
+		assert.Equal(t, []string{"apps", "libs"}, directories)
+	})
+
+	it("lists the directories of the latest revision from a cached repository", func() {
+		cacheDir, err := ioutil.TempDir("", "git-repositories")
+		require.NoError(t, err)
+		defer os.RemoveAll(cacheDir)
+		gitResolver := &remoteGitResolver{repositories: newRepositoryCache(cacheDir, maxCachedRepositories)}
+
+		directories, err := gitResolver.ListDirectories(&fakeGitKeychain{}, Trust{}, source, "libs", "")
+		require.NoError(t, err)
+		assert.Equal(t, []string{"libs/shared"}, directories)
+
+		commitFiles(map[string]string{
+			"libs/shared/project.toml": "shared",
+			"libs/util/project.toml":   "util",
+		})
+
+		directories, err = gitResolver.ListDirectories(&fakeGitKeychain{}, Trust{}, source, "libs", "")
+		require.NoError(t, err)
+		assert.Equal(t, []string{"libs/shared", "libs/util"}, directories)
+	})
+
+	it("returns an error when the path does not exist", func() {
+		_, err := (&remoteGitResolver{}).ListDirectories(&fakeGitKeychain{}, Trust{}, source, "services", "")
+		assert.EqualError(t, err, "directory services not found at main")
+	})
+}
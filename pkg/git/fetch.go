@@ -4,6 +4,8 @@ import (
 	"log"
 	"os"
 	"path"
+	"path/filepath"
+	"strings"
 
 	"github.com/BurntSushi/toml"
 	git2go "github.com/libgit2/git2go/v33"
@@ -13,6 +15,14 @@ import (
 type Fetcher struct {
 	Logger   *log.Logger
 	Keychain GitKeychain
+	// SparsePath limits the checkout to the files below it.
+	SparsePath string
+	// Submodules recursively checks out the submodules of the repository.
+	Submodules bool
+	// LFS replaces Git LFS pointer files with the objects they reference.
+	LFS bool
+	// Trust verifies the git servers when not empty.
+	Trust Trust
 }
 
 func (f Fetcher) Fetch(dir, gitURL, gitRevision, metadataDir string) error {
@@ -33,16 +43,18 @@ func (f Fetcher) Fetch(dir, gitURL, gitRevision, metadataDir string) error {
 	}
 	defer remote.Free()
 
-	err = remote.Fetch([]string{"refs/*:refs/*"}, &git2go.FetchOptions{
+	fetchOptions := &git2go.FetchOptions{
 		DownloadTags: git2go.DownloadTagsAll,
 		RemoteCallbacks: git2go.RemoteCallbacks{
 			CredentialsCallback:      keychainAsCredentialsCallback(f.Keychain),
-			CertificateCheckCallback: certificateCheckCallback(),
+			CertificateCheckCallback: certificateCheckCallback(f.Trust, gitURL),
 		},
 		ProxyOptions: git2go.ProxyOptions{
 			Type: git2go.ProxyTypeAuto,
 		},
-	}, "")
+	}
+
+	err = remote.Fetch([]string{"refs/*:refs/*"}, fetchOptions, "")
 	if err != nil {
 		return errors.Wrap(err, "fetching remote")
 	}
@@ -61,13 +73,32 @@ func (f Fetcher) Fetch(dir, gitURL, gitRevision, metadataDir string) error {
 	if err != nil {
 		return errors.Wrap(err, "setting head detached")
 	}
-	err = repository.CheckoutHead(&git2go.CheckoutOpts{
+	checkoutOptions := &git2go.CheckoutOpts{
 		Strategy: git2go.CheckoutForce,
-	})
+	}
+	if sparsePath := strings.Trim(f.SparsePath, "/"); sparsePath != "" {
+		checkoutOptions.Paths = []string{sparsePath}
+	}
+
+	err = repository.CheckoutHead(checkoutOptions)
 	if err != nil {
 		return errors.Wrap(err, "checkout head")
 	}
 
+	if f.LFS {
+		if err := f.fetchLFSObjects(repository, gitURL); err != nil {
+			return err
+		}
+	}
+
+	var submodules []submodule
+	if f.Submodules {
+		submodules, err = f.updateSubmodules(repository, "", fetchOptions)
+		if err != nil {
+			return err
+		}
+	}
+
 	projectMetadataFile, err := os.Create(path.Join(metadataDir, "project-metadata.toml"))
 	if err != nil {
 		return errors.Wrapf(err, "invalid metadata destination '%s/project-metadata.toml' for git repository: %s", metadataDir, gitURL)
@@ -80,6 +111,7 @@ func (f Fetcher) Fetch(dir, gitURL, gitRevision, metadataDir string) error {
 			Metadata: metadata{
 				Repository: gitURL,
 				Revision:   gitRevision,
+				Submodules: submodules,
 			},
 			Version: version{
 				Commit: commit.Id().String(),
@@ -94,6 +126,77 @@ func (f Fetcher) Fetch(dir, gitURL, gitRevision, metadataDir string) error {
 	return nil
 }
 
+// fetchLFSObjects downloads the Git LFS objects for the pointer files checked
+// out in repository using the same credentials as the Git remote.
+func (f Fetcher) fetchLFSObjects(repository *git2go.Repository, gitURL string) error {
+	index, err := repository.Index()
+	if err != nil {
+		return errors.Wrap(err, "reading index")
+	}
+	defer index.Free()
+
+	var files []string
+	for i := uint(0); i < index.EntryCount(); i++ {
+		entry, err := index.EntryByIndex(i)
+		if err != nil {
+			return errors.Wrap(err, "reading index")
+		}
+
+		if (entry.Mode == git2go.FilemodeBlob || entry.Mode == git2go.FilemodeBlobExecutable) && entry.Size <= lfsPointerMaxSize {
+			files = append(files, entry.Path)
+		}
+	}
+
+	endpoint, err := lfsConfigEndpoint(repository.Workdir())
+	if err != nil {
+		return err
+	}
+	if endpoint == "" {
+		endpoint, err = lfsEndpoint(gitURL)
+		if err != nil {
+			return err
+		}
+	}
+
+	httpClient, err := f.Trust.httpClient()
+	if err != nil {
+		return err
+	}
+
+	client := lfsClient{
+		client:   httpClient,
+		endpoint: endpoint,
+	}
+	if cred, err := f.Keychain.Resolve(endpoint, "", git2go.CredentialTypeUserpassPlaintext); err == nil {
+		if basicAuth, ok := cred.(BasicGit2GoAuth); ok {
+			client.username = basicAuth.Username
+			client.password = basicAuth.Password
+		}
+	}
+
+	return client.smudge(repository.Workdir(), files)
+}
+
+// lfsConfigEndpoint returns the lfs.url configured in the .lfsconfig of dir.
+func lfsConfigEndpoint(dir string) (string, error) {
+	lfsConfigFile := filepath.Join(dir, ".lfsconfig")
+	if _, err := os.Stat(lfsConfigFile); os.IsNotExist(err) {
+		return "", nil
+	}
+
+	config, err := git2go.OpenOndisk(lfsConfigFile)
+	if err != nil {
+		return "", errors.Wrap(err, "reading .lfsconfig")
+	}
+	defer config.Free()
+
+	endpoint, err := config.LookupString("lfs.url")
+	if err != nil {
+		return "", nil
+	}
+	return strings.TrimSuffix(endpoint, "/"), nil
+}
+
 func resolveRevision(repository *git2go.Repository, gitRevision string) (*git2go.Oid, error) {
 	ref, err := repository.References.Dwim(gitRevision)
 	if err != nil {
@@ -122,8 +225,9 @@ type source struct {
 }
 
 type metadata struct {
-	Repository string `toml:"repository"`
-	Revision   string `toml:"revision"`
+	Repository string      `toml:"repository"`
+	Revision   string      `toml:"revision"`
+	Submodules []submodule `toml:"submodules,omitempty"`
 }
 
 type version struct {
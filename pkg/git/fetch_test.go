@@ -87,6 +87,41 @@ func testGitCheckout(t *testing.T, when spec.G, it spec.S) {
 
 		it("fetches a revision", testFetch("https://github.com/git-fixtures/basic", "b029517f6300c2da0f4b651b8642506cd6aaf45d"))
 
+		it("checks out only the sparse path", func() {
+			sparseFetcher := fetcher
+			sparseFetcher.SparsePath = "/go/"
+
+			err := sparseFetcher.Fetch(testDir, "https://github.com/git-fixtures/basic", "master", metadataDir)
+			require.NoError(t, err)
+
+			require.FileExists(t, path.Join(testDir, "go", "example.go"))
+			require.NoFileExists(t, path.Join(testDir, "LICENSE"))
+
+			var projectMetadata project
+			_, err = toml.DecodeFile(path.Join(metadataDir, "project-metadata.toml"), &projectMetadata)
+			require.NoError(t, err)
+			require.Equal(t, "6ecf0ef2c2dffb796033e5a02219af86ec6584e5", projectMetadata.Source.Version.Commit)
+		})
+
+		it("checks out submodules and records their commits", func() {
+			submoduleFetcher := fetcher
+			submoduleFetcher.Submodules = true
+
+			err := submoduleFetcher.Fetch(testDir, "https://github.com/git-fixtures/submodule", "master", metadataDir)
+			require.NoError(t, err)
+
+			require.FileExists(t, path.Join(testDir, "basic", "LICENSE"))
+
+			var projectMetadata project
+			_, err = toml.DecodeFile(path.Join(metadataDir, "project-metadata.toml"), &projectMetadata)
+			require.NoError(t, err)
+
+			require.NotEmpty(t, projectMetadata.Source.Metadata.Submodules)
+			require.Equal(t, "basic", projectMetadata.Source.Metadata.Submodules[0].Path)
+			require.Equal(t, "https://github.com/git-fixtures/basic.git", projectMetadata.Source.Metadata.Submodules[0].Repository)
+			require.Len(t, projectMetadata.Source.Metadata.Submodules[0].Commit, 40)
+		})
+
 		it("returns error on non-existent ref", func() {
 			err := fetcher.Fetch(testDir, "https://github.com/git-fixtures/basic", "doesnotexist", metadataDir)
 			require.EqualError(t, err, "could not find reference: doesnotexist")
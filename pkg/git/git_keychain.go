@@ -54,6 +54,7 @@ func (s SSHGit2GoAuth) Cred() (*git2go.Credential, error) {
 type gitCredential interface {
 	match(host string, allowedTypes git2go.CredentialType) bool
 	git2goCredential(username string) (Git2GoCredential, error)
+	trust() (Trust, error)
 	name() string
 }
 
@@ -87,6 +88,15 @@ func (g gitSshAuthCred) git2goCredential(username string) (Git2GoCredential, err
 	}, nil
 }
 
+func (g gitSshAuthCred) trust() (Trust, error) {
+	sshSecret, err := g.fetchSecret()
+	if err != nil {
+		return Trust{}, err
+	}
+
+	return Trust{KnownHosts: []byte(sshSecret.KnownHosts)}, nil
+}
+
 func (g gitSshAuthCred) name() string {
 	return g.SecretName
 }
@@ -114,6 +124,15 @@ func (c gitBasicAuthCred) git2goCredential(_ string) (Git2GoCredential, error) {
 	return BasicGit2GoAuth{Username: basicAuthSecret.Username, Password: basicAuthSecret.Password}, nil
 }
 
+func (c gitBasicAuthCred) trust() (Trust, error) {
+	basicAuthSecret, err := c.fetchSecret()
+	if err != nil {
+		return Trust{}, err
+	}
+
+	return Trust{CABundle: []byte(basicAuthSecret.CACert)}, nil
+}
+
 func (c gitBasicAuthCred) name() string {
 	return c.SecretName
 }
@@ -170,3 +189,18 @@ func (k *secretGitKeychain) Resolve(url string, username string, allowedTypes gi
 	}
 	return nil, errors.Errorf("no credentials found for %s", url)
 }
+
+// Trust returns the known hosts and certificate authorities of the git secrets.
+func (k *secretGitKeychain) Trust() (Trust, error) {
+	sort.Slice(k.creds, func(i, j int) bool { return k.creds[i].name() < k.creds[j].name() })
+
+	var trust Trust
+	for _, cred := range k.creds {
+		credTrust, err := cred.trust()
+		if err != nil {
+			return Trust{}, err
+		}
+		trust = trust.Merge(credTrust)
+	}
+	return trust, nil
+}
@@ -20,7 +20,7 @@ func newK8sGitKeychainFactory(k8sClient k8sclient.Interface) *k8sGitKeychainFact
 	return &k8sGitKeychainFactory{secretFetcher: secret.Fetcher{Client: k8sClient}}
 }
 
-func (k *k8sGitKeychainFactory) KeychainForServiceAccount(ctx context.Context, namespace, serviceAccount string) (GitKeychain, error) {
+func (k *k8sGitKeychainFactory) KeychainForServiceAccount(ctx context.Context, namespace, serviceAccount string) (*secretGitKeychain, error) {
 	secrets, err := k.secretFetcher.SecretsForServiceAccount(ctx, serviceAccount, namespace)
 	if err != nil && !k8serrors.IsNotFound(err) {
 		return nil, err
@@ -56,13 +56,17 @@ func fetchBasicAuth(s *v1.Secret) func() (secret.BasicAuth, error) {
 		return secret.BasicAuth{
 			Username: string(s.Data[v1.BasicAuthUsernameKey]),
 			Password: string(s.Data[v1.BasicAuthPasswordKey]),
+			CACert:   string(s.Data[secret.CACertKey]),
 		}, nil
 	}
 }
 
 func fetchSshAuth(s *v1.Secret) func() (secret.SSH, error) {
 	return func() (auth secret.SSH, err error) {
-		return secret.SSH{PrivateKey: string(s.Data[v1.SSHAuthPrivateKey])}, nil
+		return secret.SSH{
+			PrivateKey: string(s.Data[v1.SSHAuthPrivateKey]),
+			KnownHosts: string(s.Data[secret.SSHAuthKnownHostsKey]),
+		}, nil
 	}
 }
 
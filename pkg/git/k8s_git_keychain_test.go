@@ -16,6 +16,7 @@ import (
 	"k8s.io/client-go/kubernetes/fake"
 
 	buildapi "github.com/pivotal/kpack/pkg/apis/build/v1alpha2"
+	"github.com/pivotal/kpack/pkg/secret"
 )
 
 func Test(t *testing.T) {
@@ -72,7 +73,8 @@ func (keys gitTest) testK8sGitKeychain(t *testing.T, when spec.G, it spec.S) {
 				},
 				Type: v1.SecretTypeSSHAuth,
 				Data: map[string][]byte{
-					v1.SSHAuthPrivateKey: keys.key1,
+					v1.SSHAuthPrivateKey:        keys.key1,
+					secret.SSHAuthKnownHostsKey: []byte("bitbucket.com ssh-ed25519 AAAA"),
 				},
 			},
 			&v1.Secret{
@@ -100,6 +102,7 @@ func (keys gitTest) testK8sGitKeychain(t *testing.T, when spec.G, it spec.S) {
 				Data: map[string][]byte{
 					v1.BasicAuthUsernameKey: []byte("gitlab-username"),
 					v1.BasicAuthPasswordKey: []byte("gitlab-password"),
+					secret.CACertKey:        []byte("some-ca"),
 				},
 			},
 			&v1.Secret{
@@ -201,6 +204,21 @@ func (keys gitTest) testK8sGitKeychain(t *testing.T, when spec.G, it spec.S) {
 			require.EqualError(t, err, "no credentials found for https://no-creds-github.com/org/repo")
 		})
 	})
+
+	when("Keychain trust", func() {
+		it("returns the known hosts and ca certs of the secrets", func() {
+			keychain, err := keychainFactory.KeychainForServiceAccount(context.Background(), testNamespace, serviceAccount)
+			require.NoError(t, err)
+
+			trust, err := keychain.Trust()
+			require.NoError(t, err)
+
+			require.Equal(t, Trust{
+				KnownHosts: []byte("bitbucket.com ssh-ed25519 AAAA"),
+				CABundle:   []byte("some-ca"),
+			}, trust)
+		})
+	})
 }
 
 func generateRandomPrivateKey(t *testing.T) []byte {
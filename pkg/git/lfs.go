@@ -0,0 +1,246 @@
+package git
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+	giturls "github.com/whilp/git-urls"
+)
+
+const (
+	lfsMediaType      = "application/vnd.git-lfs+json"
+	lfsPointerVersion = "version https://git-lfs.github.com/spec/v1"
+	lfsPointerMaxSize = 1024
+)
+
+type lfsPointer struct {
+	Oid  string `json:"oid"`
+	Size int64  `json:"size"`
+}
+
+// parseLFSPointer returns the object referenced by the contents of a Git LFS
+// pointer file.
+func parseLFSPointer(contents []byte) (lfsPointer, bool) {
+	if len(contents) > lfsPointerMaxSize || !bytes.HasPrefix(contents, []byte(lfsPointerVersion)) {
+		return lfsPointer{}, false
+	}
+
+	var pointer lfsPointer
+	scanner := bufio.NewScanner(bytes.NewReader(contents))
+	for scanner.Scan() {
+		field := strings.SplitN(scanner.Text(), " ", 2)
+		if len(field) != 2 {
+			continue
+		}
+
+		switch field[0] {
+		case "oid":
+			pointer.Oid = strings.TrimPrefix(field[1], "sha256:")
+		case "size":
+			size, err := strconv.ParseInt(field[1], 10, 64)
+			if err != nil {
+				return lfsPointer{}, false
+			}
+			pointer.Size = size
+		}
+	}
+
+	return pointer, pointer.Oid != "" && pointer.Size >= 0
+}
+
+// lfsEndpoint returns the Git LFS server url for a Git remote url.
+func lfsEndpoint(gitURL string) (string, error) {
+	u, err := giturls.Parse(gitURL)
+	if err != nil {
+		return "", errors.Wrapf(err, "parsing git url %s", gitURL)
+	}
+
+	if u.Scheme != "http" {
+		u.Scheme = "https"
+	}
+	u.User = nil
+
+	endpoint := strings.TrimSuffix(u.String(), "/")
+	if strings.HasSuffix(endpoint, ".git") {
+		return endpoint + "/info/lfs", nil
+	}
+	return endpoint + ".git/info/lfs", nil
+}
+
+type lfsClient struct {
+	client   *http.Client
+	endpoint string
+	username string
+	password string
+}
+
+type lfsBatchRequest struct {
+	Operation string       `json:"operation"`
+	Transfers []string     `json:"transfers"`
+	Objects   []lfsPointer `json:"objects"`
+}
+
+type lfsBatchResponse struct {
+	Objects []struct {
+		lfsPointer
+		Actions struct {
+			Download *struct {
+				Href   string            `json:"href"`
+				Header map[string]string `json:"header"`
+			} `json:"download"`
+		} `json:"actions"`
+		Error *struct {
+			Code    int    `json:"code"`
+			Message string `json:"message"`
+		} `json:"error"`
+	} `json:"objects"`
+}
+
+// smudge replaces the Git LFS pointer files among files, relative to dir,
+// with the objects they reference.
+func (c lfsClient) smudge(dir string, files []string) error {
+	pointerFiles := map[string][]string{}
+	var objects []lfsPointer
+	for _, file := range files {
+		contents, err := ioutil.ReadFile(filepath.Join(dir, file))
+		if os.IsNotExist(err) {
+			continue
+		} else if err != nil {
+			return err
+		}
+
+		pointer, ok := parseLFSPointer(contents)
+		if !ok {
+			continue
+		}
+
+		if _, ok := pointerFiles[pointer.Oid]; !ok {
+			objects = append(objects, pointer)
+		}
+		pointerFiles[pointer.Oid] = append(pointerFiles[pointer.Oid], file)
+	}
+
+	if len(objects) == 0 {
+		return nil
+	}
+
+	batch, err := c.batch(objects)
+	if err != nil {
+		return err
+	}
+
+	for _, object := range batch.Objects {
+		if object.Error != nil {
+			return errors.Errorf("fetching lfs object %s: %s", object.Oid, object.Error.Message)
+		}
+		if object.Actions.Download == nil {
+			return errors.Errorf("fetching lfs object %s: no download action", object.Oid)
+		}
+
+		for _, file := range pointerFiles[object.Oid] {
+			err := c.download(object.Actions.Download.Href, object.Actions.Download.Header, object.lfsPointer, filepath.Join(dir, file))
+			if err != nil {
+				return errors.Wrapf(err, "fetching lfs object for %s", file)
+			}
+		}
+	}
+	return nil
+}
+
+func (c lfsClient) batch(objects []lfsPointer) (lfsBatchResponse, error) {
+	body, err := json.Marshal(lfsBatchRequest{
+		Operation: "download",
+		Transfers: []string{"basic"},
+		Objects:   objects,
+	})
+	if err != nil {
+		return lfsBatchResponse{}, err
+	}
+
+	req, err := http.NewRequest(http.MethodPost, c.endpoint+"/objects/batch", bytes.NewReader(body))
+	if err != nil {
+		return lfsBatchResponse{}, err
+	}
+	req.Header.Set("Accept", lfsMediaType)
+	req.Header.Set("Content-Type", lfsMediaType)
+	if c.username != "" || c.password != "" {
+		req.SetBasicAuth(c.username, c.password)
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return lfsBatchResponse{}, errors.Wrap(err, "requesting lfs batch")
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return lfsBatchResponse{}, errors.Errorf("requesting lfs batch: unexpected status %d", resp.StatusCode)
+	}
+
+	var batch lfsBatchResponse
+	if err := json.NewDecoder(resp.Body).Decode(&batch); err != nil {
+		return lfsBatchResponse{}, errors.Wrap(err, "decoding lfs batch")
+	}
+	return batch, nil
+}
+
+func (c lfsClient) download(href string, header map[string]string, pointer lfsPointer, file string) error {
+	req, err := http.NewRequest(http.MethodGet, href, nil)
+	if err != nil {
+		return err
+	}
+	for k, v := range header {
+		req.Header.Set(k, v)
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return errors.Errorf("unexpected status %d", resp.StatusCode)
+	}
+
+	info, err := os.Stat(file)
+	if err != nil {
+		return err
+	}
+
+	tmp, err := ioutil.TempFile(filepath.Dir(file), ".lfs")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	defer tmp.Close()
+
+	hash := sha256.New()
+	size, err := io.Copy(io.MultiWriter(tmp, hash), resp.Body)
+	if err != nil {
+		return err
+	}
+
+	if size != pointer.Size || hex.EncodeToString(hash.Sum(nil)) != pointer.Oid {
+		return errors.Errorf("object does not match oid %s", pointer.Oid)
+	}
+
+	if err := tmp.Chmod(info.Mode()); err != nil {
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), file)
+}
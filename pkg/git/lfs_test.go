@@ -0,0 +1,164 @@
+package git
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/sclevine/spec"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLFS(t *testing.T) {
+	spec.Run(t, "TestLFS", testLFS)
+}
+
+func testLFS(t *testing.T, when spec.G, it spec.S) {
+	when("#parseLFSPointer", func() {
+		it("parses a pointer file", func() {
+			pointer, ok := parseLFSPointer([]byte("version https://git-lfs.github.com/spec/v1\noid sha256:4d7a214614ab2935c943f9e0ff69d22eadbb8f32b1258daaa5e2ca24d17e2393\nsize 12345\n"))
+			require.True(t, ok)
+			assert.Equal(t, lfsPointer{Oid: "4d7a214614ab2935c943f9e0ff69d22eadbb8f32b1258daaa5e2ca24d17e2393", Size: 12345}, pointer)
+		})
+
+		it("ignores other files", func() {
+			_, ok := parseLFSPointer([]byte("package main\n"))
+			assert.False(t, ok)
+		})
+	})
+
+	when("#lfsEndpoint", func() {
+		for _, tc := range []struct {
+			gitURL   string
+			endpoint string
+		}{
+			{"https://github.com/org/repo", "https://github.com/org/repo.git/info/lfs"},
+			{"https://user@github.com/org/repo.git", "https://github.com/org/repo.git/info/lfs"},
+			{"http://git.example.com/org/repo.git", "http://git.example.com/org/repo.git/info/lfs"},
+			{"git@github.com:org/repo.git", "https://github.com/org/repo.git/info/lfs"},
+			{"ssh://git@github.com/org/repo", "https://github.com/org/repo.git/info/lfs"},
+		} {
+			tc := tc
+			it(tc.gitURL, func() {
+				endpoint, err := lfsEndpoint(tc.gitURL)
+				require.NoError(t, err)
+				assert.Equal(t, tc.endpoint, endpoint)
+			})
+		}
+	})
+
+	when("#smudge", func() {
+		const contents = "some large model"
+
+		var (
+			dir      string
+			server   *httptest.Server
+			oid      string
+			username string
+			password string
+		)
+
+		it.Before(func() {
+			var err error
+			dir, err = ioutil.TempDir("", "lfs")
+			require.NoError(t, err)
+
+			sum := sha256.Sum256([]byte(contents))
+			oid = hex.EncodeToString(sum[:])
+
+			mux := http.NewServeMux()
+			mux.HandleFunc("/repo.git/info/lfs/objects/batch", func(w http.ResponseWriter, r *http.Request) {
+				username, password, _ = r.BasicAuth()
+				assert.Equal(t, lfsMediaType, r.Header.Get("Accept"))
+
+				var batch lfsBatchRequest
+				require.NoError(t, json.NewDecoder(r.Body).Decode(&batch))
+				assert.Equal(t, "download", batch.Operation)
+
+				objects := []map[string]interface{}{}
+				for _, object := range batch.Objects {
+					objects = append(objects, map[string]interface{}{
+						"oid":  object.Oid,
+						"size": object.Size,
+						"actions": map[string]interface{}{
+							"download": map[string]interface{}{
+								"href":   server.URL + "/objects/" + object.Oid,
+								"header": map[string]string{"Authorization": "Bearer some-token"},
+							},
+						},
+					})
+				}
+				w.Header().Set("Content-Type", lfsMediaType)
+				require.NoError(t, json.NewEncoder(w).Encode(map[string]interface{}{"objects": objects}))
+			})
+			mux.HandleFunc("/objects/", func(w http.ResponseWriter, r *http.Request) {
+				if r.Header.Get("Authorization") != "Bearer some-token" {
+					w.WriteHeader(http.StatusUnauthorized)
+					return
+				}
+				_, _ = w.Write([]byte(contents))
+			})
+			server = httptest.NewServer(mux)
+		})
+
+		it.After(func() {
+			server.Close()
+			require.NoError(t, os.RemoveAll(dir))
+		})
+
+		writeFile := func(name, data string) {
+			require.NoError(t, os.MkdirAll(filepath.Dir(filepath.Join(dir, name)), 0755))
+			require.NoError(t, ioutil.WriteFile(filepath.Join(dir, name), []byte(data), 0644))
+		}
+
+		pointer := func(oid string, size int) string {
+			return fmt.Sprintf("version https://git-lfs.github.com/spec/v1\noid sha256:%s\nsize %d\n", oid, size)
+		}
+
+		it("replaces pointer files with their objects", func() {
+			writeFile("models/model.bin", pointer(oid, len(contents)))
+			writeFile("models/copy.bin", pointer(oid, len(contents)))
+			writeFile("main.go", "package main\n")
+
+			client := lfsClient{
+				client:   http.DefaultClient,
+				endpoint: server.URL + "/repo.git/info/lfs",
+				username: "some-user",
+				password: "some-password",
+			}
+			require.NoError(t, client.smudge(dir, []string{"models/model.bin", "models/copy.bin", "main.go", "missing.txt"}))
+
+			for _, name := range []string{"models/model.bin", "models/copy.bin"} {
+				data, err := ioutil.ReadFile(filepath.Join(dir, name))
+				require.NoError(t, err)
+				assert.Equal(t, contents, string(data))
+			}
+
+			data, err := ioutil.ReadFile(filepath.Join(dir, "main.go"))
+			require.NoError(t, err)
+			assert.Equal(t, "package main\n", string(data))
+
+			assert.Equal(t, "some-user", username)
+			assert.Equal(t, "some-password", password)
+		})
+
+		it("fails when the object does not match the pointer", func() {
+			writeFile("model.bin", pointer(oid, len(contents)+1))
+
+			client := lfsClient{
+				client:   http.DefaultClient,
+				endpoint: server.URL + "/repo.git/info/lfs",
+			}
+			err := client.smudge(dir, []string{"model.bin"})
+			require.EqualError(t, err, fmt.Sprintf("fetching lfs object for model.bin: object does not match oid %s", oid))
+		})
+	})
+}
@@ -0,0 +1,86 @@
+package git
+
+import (
+	"path"
+	"strings"
+
+	corev1alpha1 "github.com/pivotal/kpack/pkg/apis/core/v1alpha1"
+)
+
+type pathFilter struct {
+	include []string
+	exclude []string
+}
+
+func newPathFilter(sourceConfig corev1alpha1.SourceConfig) pathFilter {
+	include := sourceConfig.Git.IncludePaths
+	if len(include) == 0 && strings.Trim(sourceConfig.SubPath, "/") != "" {
+		include = []string{sourceConfig.SubPath}
+	}
+
+	return pathFilter{
+		include: include,
+		exclude: sourceConfig.Git.ExcludePaths,
+	}
+}
+
+func (f pathFilter) isEmpty() bool {
+	return len(f.include) == 0 && len(f.exclude) == 0
+}
+
+func (f pathFilter) matches(file string) bool {
+	if file == "" {
+		return false
+	}
+
+	included := len(f.include) == 0
+	for _, pattern := range f.include {
+		if matchPath(pattern, file) {
+			included = true
+			break
+		}
+	}
+	if !included {
+		return false
+	}
+
+	for _, pattern := range f.exclude {
+		if matchPath(pattern, file) {
+			return false
+		}
+	}
+	return true
+}
+
+// matchPath reports whether file matches pattern or is inside a directory
+// matched by pattern. A "**" segment matches any number of directories.
+func matchPath(pattern, file string) bool {
+	pattern = strings.Trim(pattern, "/")
+	if pattern == "" {
+		return true
+	}
+	return matchSegments(strings.Split(pattern, "/"), strings.Split(strings.Trim(file, "/"), "/"))
+}
+
+func matchSegments(pattern, file []string) bool {
+	for len(pattern) > 0 {
+		if pattern[0] == "**" {
+			for i := 0; i <= len(file); i++ {
+				if matchSegments(pattern[1:], file[i:]) {
+					return true
+				}
+			}
+			return false
+		}
+
+		if len(file) == 0 {
+			return false
+		}
+
+		if ok, _ := path.Match(pattern[0], file[0]); !ok {
+			return false
+		}
+		pattern, file = pattern[1:], file[1:]
+	}
+	return true
+}
@@ -0,0 +1,87 @@
+package git
+
+import (
+	"testing"
+
+	"github.com/sclevine/spec"
+	"github.com/stretchr/testify/assert"
+
+	corev1alpha1 "github.com/pivotal/kpack/pkg/apis/core/v1alpha1"
+)
+
+func TestPathFilter(t *testing.T) {
+	spec.Run(t, "TestPathFilter", testPathFilter)
+}
+
+func testPathFilter(t *testing.T, when spec.G, it spec.S) {
+	when("#matchPath", func() {
+		for _, tc := range []struct {
+			pattern string
+			file    string
+			match   bool
+		}{
+			{"services/api", "services/api/main.go", true},
+			{"/services/api/", "services/api/pkg/handler.go", true},
+			{"services/api", "services/api-gateway/main.go", false},
+			{"services/*/main.go", "services/api/main.go", true},
+			{"services/*/main.go", "services/api/cmd/main.go", false},
+			{"services/**/main.go", "services/api/cmd/main.go", true},
+			{"services/**/main.go", "services/main.go", true},
+			{"**/*.md", "README.md", true},
+			{"**/*.md", "docs/nested/guide.md", true},
+			{"**/*.md", "docs/guide.txt", false},
+			{"", "anything", true},
+		} {
+			tc := tc
+			it(tc.pattern+" against "+tc.file, func() {
+				assert.Equal(t, tc.match, matchPath(tc.pattern, tc.file))
+			})
+		}
+	})
+
+	when("#newPathFilter", func() {
+		it("defaults include paths to the sub path", func() {
+			filter := newPathFilter(corev1alpha1.SourceConfig{
+				Git:     &corev1alpha1.Git{},
+				SubPath: "/services/api",
+			})
+
+			assert.True(t, filter.matches("services/api/main.go"))
+			assert.False(t, filter.matches("services/web/main.go"))
+		})
+
+		it("is empty without a sub path or globs", func() {
+			filter := newPathFilter(corev1alpha1.SourceConfig{
+				Git:     &corev1alpha1.Git{},
+				SubPath: "/",
+			})
+
+			assert.True(t, filter.isEmpty())
+		})
+
+		it("prefers include paths over the sub path", func() {
+			filter := newPathFilter(corev1alpha1.SourceConfig{
+				Git: &corev1alpha1.Git{
+					IncludePaths: []string{"services/api", "libs/**"},
+				},
+				SubPath: "services/api",
+			})
+
+			assert.True(t, filter.matches("libs/shared/util.go"))
+			assert.False(t, filter.matches("services/web/main.go"))
+		})
+
+		it("excludes paths after including them", func() {
+			filter := newPathFilter(corev1alpha1.SourceConfig{
+				Git: &corev1alpha1.Git{
+					ExcludePaths: []string{"**/*.md"},
+				},
+				SubPath: "services/api",
+			})
+
+			assert.True(t, filter.matches("services/api/main.go"))
+			assert.False(t, filter.matches("services/api/README.md"))
+			assert.False(t, filter.matches("README.md"))
+		})
+	})
+}
@@ -0,0 +1,156 @@
+package git
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/pkg/errors"
+	giturls "github.com/whilp/git-urls"
+
+	buildapi "github.com/pivotal/kpack/pkg/apis/build/v1alpha2"
+)
+
+const (
+	defaultGitHubAPIURL = "https://api.github.com"
+	defaultGitLabAPIURL = "https://gitlab.com"
+
+	pullRequestsPerPage = 100
+)
+
+// knownAPIHosts are the api hosts of git hosts that serve their api from
+// another host.
+var knownAPIHosts = map[string]string{
+	"github.com": "api.github.com",
+}
+
+// pullRequestClient lists the open pull requests of a repository from the
+// api of a git provider.
+type pullRequestClient struct {
+	client *http.Client
+	url    string
+	token  string
+}
+
+type gitHubPullRequest struct {
+	Number int64 `json:"number"`
+	Head   struct {
+		Ref string `json:"ref"`
+	} `json:"head"`
+}
+
+type gitLabMergeRequest struct {
+	IID          int64  `json:"iid"`
+	SourceBranch string `json:"source_branch"`
+}
+
+// gitHubPullRequests returns a preview of each open pull request of the
+// repository of gitURL built from its refs/pull/<number>/head ref.
+func (c pullRequestClient) gitHubPullRequests(ctx context.Context, gitURL string) ([]buildapi.Preview, error) {
+	repository, err := repositoryPath(gitURL)
+	if err != nil {
+		return nil, err
+	}
+
+	var previews []buildapi.Preview
+	for page := 1; ; page++ {
+		var pullRequests []gitHubPullRequest
+		err := c.get(ctx, fmt.Sprintf("%s/repos/%s/pulls?state=open&per_page=%d&page=%d", strings.TrimSuffix(c.url, "/"), repository, pullRequestsPerPage, page), &pullRequests)
+		if err != nil {
+			return nil, errors.Wrapf(err, "listing pull requests of %s", repository)
+		}
+
+		for _, pr := range pullRequests {
+			previews = append(previews, buildapi.PullRequestPreview(pr.Number, pr.Head.Ref, fmt.Sprintf("refs/pull/%d/head", pr.Number)))
+		}
+
+		if len(pullRequests) < pullRequestsPerPage {
+			return previews, nil
+		}
+	}
+}
+
+// gitLabMergeRequests returns a preview of each open merge request of the
+// repository of gitURL built from its refs/merge-requests/<iid>/head ref.
+func (c pullRequestClient) gitLabMergeRequests(ctx context.Context, gitURL string) ([]buildapi.Preview, error) {
+	repository, err := repositoryPath(gitURL)
+	if err != nil {
+		return nil, err
+	}
+
+	var previews []buildapi.Preview
+	for page := 1; ; page++ {
+		var mergeRequests []gitLabMergeRequest
+		err := c.get(ctx, fmt.Sprintf("%s/api/v4/projects/%s/merge_requests?state=opened&per_page=%d&page=%d", strings.TrimSuffix(c.url, "/"), url.PathEscape(repository), pullRequestsPerPage, page), &mergeRequests)
+		if err != nil {
+			return nil, errors.Wrapf(err, "listing merge requests of %s", repository)
+		}
+
+		for _, mr := range mergeRequests {
+			previews = append(previews, buildapi.PullRequestPreview(mr.IID, mr.SourceBranch, fmt.Sprintf("refs/merge-requests/%d/head", mr.IID)))
+		}
+
+		if len(mergeRequests) < pullRequestsPerPage {
+			return previews, nil
+		}
+	}
+}
+
+func (c pullRequestClient) get(ctx context.Context, url string, v interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/json")
+	if c.token != "" {
+		req.Header.Set("Authorization", "Bearer "+c.token)
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return errors.Errorf("unexpected status %d", resp.StatusCode)
+	}
+
+	return json.NewDecoder(resp.Body).Decode(v)
+}
+
+// isGitHostAPI returns true when apiURL is an https url on the host of gitURL
+// or on the known api host of the git host, so the git credentials of gitURL
+// can be sent to it.
+func isGitHostAPI(gitURL, apiURL string) bool {
+	g, err := giturls.Parse(gitURL)
+	if err != nil {
+		return false
+	}
+
+	a, err := url.Parse(apiURL)
+	if err != nil || a.Scheme != "https" {
+		return false
+	}
+
+	gitHost, apiHost := strings.ToLower(g.Hostname()), strings.ToLower(a.Hostname())
+	return gitHost != "" && (apiHost == gitHost || apiHost == knownAPIHosts[gitHost])
+}
+
+// repositoryPath returns the path of the repository of a git url, such as
+// org/repo.
+func repositoryPath(gitURL string) (string, error) {
+	u, err := giturls.Parse(gitURL)
+	if err != nil {
+		return "", errors.Wrapf(err, "parsing git url %s", gitURL)
+	}
+
+	repository := strings.TrimSuffix(strings.Trim(u.Path, "/"), ".git")
+	if repository == "" {
+		return "", errors.Errorf("no repository in git url %s", gitURL)
+	}
+	return repository, nil
+}
@@ -0,0 +1,147 @@
+package git
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/sclevine/spec"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	buildapi "github.com/pivotal/kpack/pkg/apis/build/v1alpha2"
+)
+
+func TestPullRequests(t *testing.T) {
+	spec.Run(t, "TestPullRequests", testPullRequests)
+}
+
+func testPullRequests(t *testing.T, when spec.G, it spec.S) {
+	var (
+		server   *httptest.Server
+		requests []*http.Request
+		pages    map[string]interface{}
+	)
+
+	it.Before(func() {
+		requests = nil
+		pages = map[string]interface{}{}
+		server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			requests = append(requests, r)
+			page, ok := pages[r.URL.RequestURI()]
+			if !ok {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			require.NoError(t, json.NewEncoder(w).Encode(page))
+		}))
+	})
+
+	it.After(func() {
+		server.Close()
+	})
+
+	when("#gitHubPullRequests", func() {
+		it("returns a preview of each open pull request", func() {
+			pages["/repos/org/repo/pulls?state=open&per_page=100&page=1"] = []map[string]interface{}{
+				{"number": 12, "head": map[string]string{"ref": "feature/login"}},
+				{"number": 7, "head": map[string]string{"ref": "fix"}},
+			}
+
+			client := pullRequestClient{client: server.Client(), url: server.URL, token: "some-token"}
+			previews, err := client.gitHubPullRequests(context.TODO(), "https://github.com/org/repo.git")
+			require.NoError(t, err)
+
+			assert.Equal(t, []buildapi.Preview{
+				{Name: "pr-12", Number: 12, Branch: "feature/login", Revision: "refs/pull/12/head"},
+				{Name: "pr-7", Number: 7, Branch: "fix", Revision: "refs/pull/7/head"},
+			}, previews)
+			require.Len(t, requests, 1)
+			assert.Equal(t, "Bearer some-token", requests[0].Header.Get("Authorization"))
+		})
+
+		it("lists every page of pull requests", func() {
+			var firstPage []map[string]interface{}
+			for i := 1; i <= pullRequestsPerPage; i++ {
+				firstPage = append(firstPage, map[string]interface{}{"number": i, "head": map[string]string{"ref": fmt.Sprintf("branch-%d", i)}})
+			}
+			pages["/repos/org/repo/pulls?state=open&per_page=100&page=1"] = firstPage
+			pages["/repos/org/repo/pulls?state=open&per_page=100&page=2"] = []map[string]interface{}{
+				{"number": 101, "head": map[string]string{"ref": "last"}},
+			}
+
+			client := pullRequestClient{client: server.Client(), url: server.URL}
+			previews, err := client.gitHubPullRequests(context.TODO(), "git@github.com:org/repo.git")
+			require.NoError(t, err)
+
+			assert.Len(t, previews, 101)
+			assert.Equal(t, "pr-101", previews[100].Name)
+			assert.Empty(t, requests[0].Header.Get("Authorization"))
+		})
+
+		it("returns an error when the pull requests cannot be listed", func() {
+			client := pullRequestClient{client: server.Client(), url: server.URL}
+			_, err := client.gitHubPullRequests(context.TODO(), "https://github.com/org/missing")
+			assert.EqualError(t, err, "listing pull requests of org/missing: unexpected status 404")
+		})
+	})
+
+	when("#gitLabMergeRequests", func() {
+		it("returns a preview of each open merge request", func() {
+			pages["/api/v4/projects/group%2Fsubgroup%2Frepo/merge_requests?state=opened&per_page=100&page=1"] = []map[string]interface{}{
+				{"iid": 3, "source_branch": "feature"},
+			}
+
+			client := pullRequestClient{client: server.Client(), url: server.URL}
+			previews, err := client.gitLabMergeRequests(context.TODO(), "https://gitlab.com/group/subgroup/repo.git")
+			require.NoError(t, err)
+
+			assert.Equal(t, []buildapi.Preview{
+				{Name: "pr-3", Number: 3, Branch: "feature", Revision: "refs/merge-requests/3/head"},
+			}, previews)
+		})
+	})
+
+	when("#repositoryPath", func() {
+		for _, tc := range []struct {
+			gitURL     string
+			repository string
+		}{
+			{"https://github.com/org/repo", "org/repo"},
+			{"https://github.com/org/repo.git", "org/repo"},
+			{"git@github.com:org/repo.git", "org/repo"},
+			{"ssh://git@gitlab.com/group/subgroup/repo", "group/subgroup/repo"},
+		} {
+			tc := tc
+			it(tc.gitURL, func() {
+				repository, err := repositoryPath(tc.gitURL)
+				require.NoError(t, err)
+				assert.Equal(t, tc.repository, repository)
+			})
+		}
+	})
+	when("#isGitHostAPI", func() {
+		for _, tc := range []struct {
+			gitURL  string
+			apiURL  string
+			gitHost bool
+		}{
+			{"https://github.com/org/repo", "https://api.github.com", true},
+			{"git@github.com:org/repo.git", "https://api.github.com", true},
+			{"https://ghe.example.com/org/repo", "https://ghe.example.com/api/v3", true},
+			{"https://gitlab.com/group/repo", "https://gitlab.com", true},
+			{"https://GitLab.example.com/group/repo", "https://gitlab.example.com:8443", true},
+			{"https://github.com/org/repo", "https://attacker.example.com", false},
+			{"https://gitlab.example.com/group/repo", "https://api.github.com", false},
+			{"https://gitlab.example.com/group/repo", "http://gitlab.example.com", false},
+		} {
+			tc := tc
+			it(tc.gitURL+" "+tc.apiURL, func() {
+				assert.Equal(t, tc.gitHost, isGitHostAPI(tc.gitURL, tc.apiURL))
+			})
+		}
+	})
+}
@@ -13,14 +13,44 @@ import (
 	corev1alpha1 "github.com/pivotal/kpack/pkg/apis/core/v1alpha1"
 )
 
-const defaultRemote = "origin"
+const (
+	defaultRemote = "origin"
+
+	maxSkippedRevisions = 20
+)
 
 var discardLogger = log.New(ioutil.Discard, "", 0)
 
+// FetchFailedReason is reported when the commits of a git source with path
+// filters cannot be fetched.
+const FetchFailedReason = "FetchFailed"
+
+// FetchFailedError is returned when the commits pushed since the last resolved
+// revision of a git source with path filters cannot be fetched to compare
+// their paths. It is temporary, so the last resolved revision is kept.
+type FetchFailedError struct {
+	err error
+}
+
+func (e *FetchFailedError) Error() string {
+	return e.err.Error()
+}
+
+func (e *FetchFailedError) Reason() string {
+	return FetchFailedReason
+}
+
+func (e *FetchFailedError) Temporary() bool {
+	return true
+}
+
 type remoteGitResolver struct {
+	// repositories keeps the history fetched to compare the paths of new
+	// commits, so each poll only fetches the commits pushed since the last.
+	repositories *repositoryCache
 }
 
-func (*remoteGitResolver) Resolve(keychain GitKeychain, sourceConfig corev1alpha1.SourceConfig) (corev1alpha1.ResolvedSourceConfig, error) {
+func (r *remoteGitResolver) Resolve(keychain GitKeychain, trust Trust, sourceConfig corev1alpha1.SourceConfig, lastResolved *corev1alpha1.ResolvedGitSource) (corev1alpha1.ResolvedSourceConfig, error) {
 	dir, err := ioutil.TempDir("", "git-resolve")
 	if err != nil {
 		return corev1alpha1.ResolvedSourceConfig{}, err
@@ -42,19 +72,27 @@ func (*remoteGitResolver) Resolve(keychain GitKeychain, sourceConfig corev1alpha
 	}
 	defer remote.Free()
 
-	err = remote.ConnectFetch(
-		&git2go.RemoteCallbacks{
-			CredentialsCallback:      keychainAsCredentialsCallback(keychain),
-			CertificateCheckCallback: certificateCheckCallback(),
-		},
-		&git2go.ProxyOptions{Type: git2go.ProxyTypeAuto}, nil)
-	if err != nil {
+	callbacks := git2go.RemoteCallbacks{
+		CredentialsCallback:      keychainAsCredentialsCallback(keychain),
+		CertificateCheckCallback: certificateCheckCallback(trust, sourceConfig.Git.URL),
+	}
+	proxyOptions := git2go.ProxyOptions{Type: git2go.ProxyTypeAuto}
+
+	err = remote.ConnectFetch(&callbacks, &proxyOptions, nil)
+	var verificationErr *HostVerificationError
+	if errors.As(err, &verificationErr) {
+		return corev1alpha1.ResolvedSourceConfig{}, err
+	} else if err != nil && sourceConfig.Git.TagConstraint != "" {
+		// there is no revision to report without the tags of the remote
+		return corev1alpha1.ResolvedSourceConfig{}, &TagsUnavailableError{err: errors.Wrapf(err, "listing tags of %s", sourceConfig.Git.URL)}
+	} else if err != nil {
 		return corev1alpha1.ResolvedSourceConfig{
 			Git: &corev1alpha1.ResolvedGitSource{
 				URL:      sourceConfig.Git.URL,
 				Revision: sourceConfig.Git.Revision,
 				Type:     corev1alpha1.Unknown,
 				SubPath:  sourceConfig.SubPath,
+				Fetch:    sourceConfig.Git.Fetch,
 			},
 		}, nil
 	}
@@ -64,17 +102,43 @@ func (*remoteGitResolver) Resolve(keychain GitKeychain, sourceConfig corev1alpha
 		return corev1alpha1.ResolvedSourceConfig{}, errors.Wrap(err, "remote ls")
 	}
 
+	fetchOptions := &git2go.FetchOptions{
+		DownloadTags:    git2go.DownloadTagsNone,
+		RemoteCallbacks: callbacks,
+		ProxyOptions:    proxyOptions,
+	}
+
+	if sourceConfig.Git.TagConstraint != "" {
+		ids := make(map[string]string, len(references))
+		for _, ref := range references {
+			ids[ref.Name] = ref.Id.String()
+		}
+
+		tag, revision, err := newestTag(sourceConfig.Git.TagConstraint, ids)
+		if err != nil {
+			return corev1alpha1.ResolvedSourceConfig{}, err
+		}
+
+		return r.resolveReference(tagRefPrefix+tag, &corev1alpha1.ResolvedGitSource{
+			URL:      sourceConfig.Git.URL,
+			Revision: revision,
+			Tag:      tag,
+			Type:     corev1alpha1.Tag,
+			SubPath:  sourceConfig.SubPath,
+			Fetch:    sourceConfig.Git.Fetch,
+		}, sourceConfig, lastResolved, fetchOptions)
+	}
+
 	for _, ref := range references {
 		for _, format := range refRevParseRules {
 			if fmt.Sprintf(format, sourceConfig.Git.Revision) == ref.Name {
-				return corev1alpha1.ResolvedSourceConfig{
-					Git: &corev1alpha1.ResolvedGitSource{
-						URL:      sourceConfig.Git.URL,
-						Revision: ref.Id.String(),
-						Type:     sourceType(ref),
-						SubPath:  sourceConfig.SubPath,
-					},
-				}, nil
+				return r.resolveReference(ref.Name, &corev1alpha1.ResolvedGitSource{
+					URL:      sourceConfig.Git.URL,
+					Revision: ref.Id.String(),
+					Type:     sourceType(ref),
+					SubPath:  sourceConfig.SubPath,
+					Fetch:    sourceConfig.Git.Fetch,
+				}, sourceConfig, lastResolved, fetchOptions)
 			}
 		}
 	}
@@ -85,22 +149,157 @@ func (*remoteGitResolver) Resolve(keychain GitKeychain, sourceConfig corev1alpha
 			Revision: sourceConfig.Git.Revision,
 			Type:     corev1alpha1.Commit,
 			SubPath:  sourceConfig.SubPath,
+			Fetch:    sourceConfig.Git.Fetch,
 		},
 	}, nil
 }
 
+// resolveReference keeps the last resolved revision of refName when the
+// commits pushed since then did not change any path matched by the filter.
+func (r *remoteGitResolver) resolveReference(refName string, resolved *corev1alpha1.ResolvedGitSource, sourceConfig corev1alpha1.SourceConfig, lastResolved *corev1alpha1.ResolvedGitSource, fetchOptions *git2go.FetchOptions) (corev1alpha1.ResolvedSourceConfig, error) {
+	filter := newPathFilter(sourceConfig)
+	if lastResolved != nil && len(lastResolved.SkippedRevisions) > 0 && lastResolved.SkippedRevisions[0] == resolved.Revision && !filter.isEmpty() {
+		// nothing was pushed since the revisions were last skipped
+		resolved.Revision = lastResolved.Revision
+		resolved.Tag = lastResolved.Tag
+		resolved.SkippedRevisions = lastResolved.SkippedRevisions
+	} else if lastResolved != nil && lastResolved.Revision != resolved.Revision && !filter.isEmpty() {
+		skipped, err := r.skippedRevisions(sourceConfig.Git.URL, refName, lastResolved.Revision, resolved.Revision, filter, fetchOptions)
+		if err != nil {
+			return corev1alpha1.ResolvedSourceConfig{}, err
+		}
+
+		if len(skipped) > 0 {
+			resolved.Revision = lastResolved.Revision
+			resolved.Tag = lastResolved.Tag
+			resolved.SkippedRevisions = skipped
+		}
+	}
+
+	return corev1alpha1.ResolvedSourceConfig{Git: resolved}, nil
+}
+
+// skippedRevisions fetches refName into the cached repository of url and
+// returns the commits between oldRevision and newRevision when none of them
+// changed a path matched by filter.
+func (r *remoteGitResolver) skippedRevisions(url, refName, oldRevision, newRevision string, filter pathFilter, fetchOptions *git2go.FetchOptions) ([]string, error) {
+	repository, release, err := r.repositories.open(url)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
+	remote, err := originRemote(repository, url)
+	if err != nil {
+		return nil, err
+	}
+	defer remote.Free()
+
+	err = remote.Fetch([]string{fmt.Sprintf("+%s:%s", refName, refName)}, fetchOptions, "")
+	if err != nil {
+		return nil, &FetchFailedError{err: errors.Wrapf(err, "fetching %s", refName)}
+	}
+
+	oldCommit, err := lookupCommit(repository, oldRevision)
+	if err != nil {
+		// the previous revision is no longer reachable, e.g. after a force push
+		return nil, nil
+	}
+	defer oldCommit.Free()
+
+	newCommit, err := lookupCommit(repository, newRevision)
+	if err != nil {
+		return nil, err
+	}
+	defer newCommit.Free()
+
+	oldTree, err := oldCommit.Tree()
+	if err != nil {
+		return nil, errors.Wrap(err, "looking up tree")
+	}
+	defer oldTree.Free()
+
+	newTree, err := newCommit.Tree()
+	if err != nil {
+		return nil, errors.Wrap(err, "looking up tree")
+	}
+	defer newTree.Free()
+
+	diff, err := repository.DiffTreeToTree(oldTree, newTree, nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "diffing revisions")
+	}
+	defer diff.Free()
+
+	changed := false
+	err = diff.ForEach(func(delta git2go.DiffDelta, _ float64) (git2go.DiffForEachHunkCallback, error) {
+		if filter.matches(delta.OldFile.Path) || filter.matches(delta.NewFile.Path) {
+			changed = true
+		}
+		return nil, nil
+	}, git2go.DiffDetailFiles)
+	if err != nil {
+		return nil, errors.Wrap(err, "diffing revisions")
+	}
+
+	if changed {
+		return nil, nil
+	}
+
+	walk, err := repository.Walk()
+	if err != nil {
+		return nil, errors.Wrap(err, "walking revisions")
+	}
+	defer walk.Free()
+
+	if err := walk.Push(newCommit.Id()); err != nil {
+		return nil, errors.Wrap(err, "walking revisions")
+	}
+	if err := walk.Hide(oldCommit.Id()); err != nil {
+		return nil, errors.Wrap(err, "walking revisions")
+	}
+
+	var skipped []string
+	err = walk.Iterate(func(commit *git2go.Commit) bool {
+		skipped = append(skipped, commit.Id().String())
+		return len(skipped) < maxSkippedRevisions
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "walking revisions")
+	}
+
+	return skipped, nil
+}
+
+func lookupCommit(repository *git2go.Repository, revision string) (*git2go.Commit, error) {
+	oid, err := git2go.NewOid(revision)
+	if err != nil {
+		return nil, errors.Wrapf(err, "parsing revision %s", revision)
+	}
+
+	commit, err := repository.LookupCommit(oid)
+	if err != nil {
+		return nil, errors.Wrapf(err, "looking up commit %s", revision)
+	}
+	return commit, nil
+}
+
 func sourceType(reference git2go.RemoteHead) corev1alpha1.GitSourceKind {
 	switch {
 	case strings.HasPrefix(reference.Name, "refs/heads"):
 		return corev1alpha1.Branch
 	case strings.HasPrefix(reference.Name, "refs/tags"):
 		return corev1alpha1.Tag
+	case strings.HasPrefix(reference.Name, "refs/pull/"), strings.HasPrefix(reference.Name, "refs/merge-requests/"):
+		// the head of a pull request moves like a branch
+		return corev1alpha1.Branch
 	default:
 		return corev1alpha1.Unknown
 	}
 }
 
 var refRevParseRules = []string{
+	"%s",
 	"refs/%s",
 	"refs/tags/%s",
 	"refs/heads/%s",
@@ -1,8 +1,14 @@
 package git
 
 import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
 	"testing"
+	"time"
 
+	git2go "github.com/libgit2/git2go/v33"
+	"github.com/pkg/errors"
 	"github.com/sclevine/spec"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -24,17 +30,68 @@ func testRemoteGitResolver(t *testing.T, when spec.G, it spec.S) {
 	)
 
 	when("#Resolve", func() {
+		var (
+			repoDir    string
+			repository *git2go.Repository
+		)
+
+		commitFiles := func(files map[string]string) string {
+			for name, contents := range files {
+				file := filepath.Join(repoDir, name)
+				require.NoError(t, os.MkdirAll(filepath.Dir(file), 0755))
+				require.NoError(t, ioutil.WriteFile(file, []byte(contents), 0644))
+			}
+
+			index, err := repository.Index()
+			require.NoError(t, err)
+			defer index.Free()
+			require.NoError(t, index.AddAll([]string{"."}, git2go.IndexAddDefault, nil))
+			require.NoError(t, index.Write())
+
+			treeId, err := index.WriteTree()
+			require.NoError(t, err)
+			tree, err := repository.LookupTree(treeId)
+			require.NoError(t, err)
+			defer tree.Free()
+
+			var parents []*git2go.Commit
+			if main, err := repository.References.Lookup("refs/heads/main"); err == nil {
+				parent, err := repository.LookupCommit(main.Target())
+				require.NoError(t, err)
+				parents = append(parents, parent)
+			}
+
+			signature := &git2go.Signature{Name: "kpack", Email: "kpack@example.com", When: time.Now()}
+			oid, err := repository.CreateCommit("refs/heads/main", signature, signature, "commit", tree, parents...)
+			require.NoError(t, err)
+			return oid.String()
+		}
+
+		it.Before(func() {
+			var err error
+			repoDir, err = ioutil.TempDir("", "git-resolve-repo")
+			require.NoError(t, err)
+
+			repository, err = git2go.InitRepository(repoDir, false)
+			require.NoError(t, err)
+		})
+
+		it.After(func() {
+			repository.Free()
+			require.NoError(t, os.RemoveAll(repoDir))
+		})
+
 		when("source is a commit", func() {
 			it("returns type commit", func() {
 				gitResolver := &remoteGitResolver{}
 
-				resolvedGitSource, err := gitResolver.Resolve(&fakeGitKeychain{}, corev1alpha1.SourceConfig{
+				resolvedGitSource, err := gitResolver.Resolve(&fakeGitKeychain{}, Trust{}, corev1alpha1.SourceConfig{
 					Git: &corev1alpha1.Git{
 						URL:      url,
 						Revision: nonHEADCommit,
 					},
 					SubPath: "/foo/bar",
-				})
+				}, nil)
 				require.NoError(t, err)
 
 				assert.Equal(t, resolvedGitSource, corev1alpha1.ResolvedSourceConfig{
@@ -52,13 +109,40 @@ func testRemoteGitResolver(t *testing.T, when spec.G, it spec.S) {
 			it("returns branch with resolved commit", func() {
 				gitResolver := &remoteGitResolver{}
 
-				resolvedGitSource, err := gitResolver.Resolve(&fakeGitKeychain{}, corev1alpha1.SourceConfig{
+				resolvedGitSource, err := gitResolver.Resolve(&fakeGitKeychain{}, Trust{}, corev1alpha1.SourceConfig{
 					Git: &corev1alpha1.Git{
 						URL:      url,
 						Revision: "master",
 					},
 					SubPath: "/foo/bar",
+				}, nil)
+				require.NoError(t, err)
+
+				assert.Equal(t, resolvedGitSource, corev1alpha1.ResolvedSourceConfig{
+					Git: &corev1alpha1.ResolvedGitSource{
+						URL:      url,
+						Revision: fixtureHEADMasterCommit,
+						Type:     corev1alpha1.Branch,
+						SubPath:  "/foo/bar",
+					},
 				})
+			})
+		})
+
+		when("source has fetch options", func() {
+			it("keeps the fetch options on the resolved source", func() {
+				gitResolver := &remoteGitResolver{}
+
+				resolvedGitSource, err := gitResolver.Resolve(&fakeGitKeychain{}, Trust{}, corev1alpha1.SourceConfig{
+					Git: &corev1alpha1.Git{
+						URL:      url,
+						Revision: "master",
+						Fetch: &corev1alpha1.GitFetchOptions{
+							Sparse: true,
+						},
+					},
+					SubPath: "/foo/bar",
+				}, nil)
 				require.NoError(t, err)
 
 				assert.Equal(t, resolvedGitSource, corev1alpha1.ResolvedSourceConfig{
@@ -67,6 +151,9 @@ func testRemoteGitResolver(t *testing.T, when spec.G, it spec.S) {
 						Revision: fixtureHEADMasterCommit,
 						Type:     corev1alpha1.Branch,
 						SubPath:  "/foo/bar",
+						Fetch: &corev1alpha1.GitFetchOptions{
+							Sparse: true,
+						},
 					},
 				})
 			})
@@ -78,13 +165,13 @@ func testRemoteGitResolver(t *testing.T, when spec.G, it spec.S) {
 
 				gitResolver := &remoteGitResolver{}
 
-				resolvedGitSource, err := gitResolver.Resolve(&fakeGitKeychain{}, corev1alpha1.SourceConfig{
+				resolvedGitSource, err := gitResolver.Resolve(&fakeGitKeychain{}, Trust{}, corev1alpha1.SourceConfig{
 					Git: &corev1alpha1.Git{
 						URL:      tagsUrl,
 						Revision: tag,
 					},
 					SubPath: "/foo/bar",
-				})
+				}, nil)
 				require.NoError(t, err)
 
 				assert.Equal(t, resolvedGitSource, corev1alpha1.ResolvedSourceConfig{
@@ -98,17 +185,44 @@ func testRemoteGitResolver(t *testing.T, when spec.G, it spec.S) {
 			})
 		})
 
+		when("source is a pull request ref", func() {
+			it("returns branch with the resolved head commit", func() {
+				head := commitFiles(map[string]string{"main.go": "pr"})
+				oid, err := git2go.NewOid(head)
+				require.NoError(t, err)
+				_, err = repository.References.Create("refs/pull/12/head", oid, false, "")
+				require.NoError(t, err)
+
+				resolved, err := (&remoteGitResolver{}).Resolve(&fakeGitKeychain{}, Trust{}, corev1alpha1.SourceConfig{
+					Git: &corev1alpha1.Git{
+						URL:      repoDir,
+						Revision: "refs/pull/12/head",
+					},
+				}, nil)
+				require.NoError(t, err)
+
+				assert.Equal(t, corev1alpha1.ResolvedSourceConfig{
+					Git: &corev1alpha1.ResolvedGitSource{
+						URL:      repoDir,
+						Revision: head,
+						Type:     corev1alpha1.Branch,
+					},
+				}, resolved)
+				assert.True(t, resolved.Git.IsPollable())
+			})
+		})
+
 		when("authentication fails", func() {
 			it("returns an unknown type", func() {
 				gitResolver := &remoteGitResolver{}
 
-				resolvedGitSource, err := gitResolver.Resolve(&fakeGitKeychain{}, corev1alpha1.SourceConfig{
+				resolvedGitSource, err := gitResolver.Resolve(&fakeGitKeychain{}, Trust{}, corev1alpha1.SourceConfig{
 					Git: &corev1alpha1.Git{
 						URL:      "git@localhost:org/repo",
 						Revision: tag,
 					},
 					SubPath: "/foo/bar",
-				})
+				}, nil)
 				require.NoError(t, err)
 
 				assert.Equal(t, resolvedGitSource, corev1alpha1.ResolvedSourceConfig{
@@ -121,5 +235,188 @@ func testRemoteGitResolver(t *testing.T, when spec.G, it spec.S) {
 				})
 			})
 		})
+
+		when("source has a tag constraint", func() {
+			createTag := func(name, revision string, annotated bool) {
+				oid, err := git2go.NewOid(revision)
+				require.NoError(t, err)
+				commit, err := repository.LookupCommit(oid)
+				require.NoError(t, err)
+				defer commit.Free()
+
+				if annotated {
+					signature := &git2go.Signature{Name: "kpack", Email: "kpack@example.com", When: time.Now()}
+					_, err = repository.Tags.Create(name, commit, signature, "release "+name)
+				} else {
+					_, err = repository.Tags.CreateLightweight(name, commit, false)
+				}
+				require.NoError(t, err)
+			}
+
+			sourceConfig := func(constraint string) corev1alpha1.SourceConfig {
+				return corev1alpha1.SourceConfig{
+					Git: &corev1alpha1.Git{
+						URL:           repoDir,
+						TagConstraint: constraint,
+					},
+				}
+			}
+
+			it.Before(func() {
+				createTag("v1.0.0", commitFiles(map[string]string{"main.go": "v1.0.0"}), false)
+				createTag("v1.1.0", commitFiles(map[string]string{"main.go": "v1.1.0"}), true)
+				createTag("v2.0.0-rc.1", commitFiles(map[string]string{"main.go": "v2.0.0-rc.1"}), true)
+				createTag("v2.0.0", commitFiles(map[string]string{"main.go": "v2.0.0"}), false)
+			})
+
+			it("resolves the newest tag matching a glob to its commit", func() {
+				head, err := repository.RevparseSingle("v1.1.0^{commit}")
+				require.NoError(t, err)
+				defer head.Free()
+
+				resolved, err := (&remoteGitResolver{}).Resolve(&fakeGitKeychain{}, Trust{}, sourceConfig("v1.*"), nil)
+				require.NoError(t, err)
+
+				assert.Equal(t, corev1alpha1.ResolvedSourceConfig{
+					Git: &corev1alpha1.ResolvedGitSource{
+						URL:      repoDir,
+						Revision: head.Id().String(),
+						Tag:      "v1.1.0",
+						Type:     corev1alpha1.Tag,
+					},
+				}, resolved)
+			})
+
+			it("resolves the newest tag matching a semver constraint", func() {
+				resolved, err := (&remoteGitResolver{}).Resolve(&fakeGitKeychain{}, Trust{}, sourceConfig(">=1.0.0 <3.0.0"), nil)
+				require.NoError(t, err)
+
+				assert.Equal(t, "v2.0.0", resolved.Git.Tag)
+				assert.True(t, resolved.Git.IsPollable())
+			})
+
+			it("returns an error when the tags cannot be listed", func() {
+				_, err := (&remoteGitResolver{}).Resolve(&fakeGitKeychain{}, Trust{}, corev1alpha1.SourceConfig{
+					Git: &corev1alpha1.Git{
+						URL:           "git@localhost:org/repo",
+						TagConstraint: "v1.*",
+					},
+				}, nil)
+
+				var tagsErr *TagsUnavailableError
+				require.True(t, errors.As(err, &tagsErr))
+				assert.Equal(t, TagsUnavailableReason, tagsErr.Reason())
+				assert.True(t, tagsErr.Temporary())
+			})
+
+			it("returns an error when no tag matches", func() {
+				_, err := (&remoteGitResolver{}).Resolve(&fakeGitKeychain{}, Trust{}, sourceConfig(">=3.0.0"), nil)
+				require.EqualError(t, err, `no tag matches ">=3.0.0"`)
+			})
+		})
+
+		when("source has path filters", func() {
+			sourceConfig := func() corev1alpha1.SourceConfig {
+				return corev1alpha1.SourceConfig{
+					Git: &corev1alpha1.Git{
+						URL:      repoDir,
+						Revision: "main",
+					},
+					SubPath: "services/api",
+				}
+			}
+
+			it("keeps the last revision when no matching paths changed", func() {
+				first := commitFiles(map[string]string{"services/api/main.go": "api", "services/web/main.go": "web"})
+				second := commitFiles(map[string]string{"services/web/main.go": "web v2"})
+				third := commitFiles(map[string]string{"README.md": "readme"})
+
+				resolved, err := (&remoteGitResolver{}).Resolve(&fakeGitKeychain{}, Trust{}, sourceConfig(), &corev1alpha1.ResolvedGitSource{
+					URL:      repoDir,
+					Revision: first,
+					Type:     corev1alpha1.Branch,
+				})
+				require.NoError(t, err)
+
+				assert.Equal(t, corev1alpha1.ResolvedSourceConfig{
+					Git: &corev1alpha1.ResolvedGitSource{
+						URL:              repoDir,
+						Revision:         first,
+						Type:             corev1alpha1.Branch,
+						SubPath:          "services/api",
+						SkippedRevisions: []string{third, second},
+					},
+				}, resolved)
+			})
+
+			it("resolves the new revision when matching paths changed", func() {
+				first := commitFiles(map[string]string{"services/api/main.go": "api", "services/web/main.go": "web"})
+				commitFiles(map[string]string{"services/web/main.go": "web v2"})
+				third := commitFiles(map[string]string{"services/api/main.go": "api v2"})
+
+				resolved, err := (&remoteGitResolver{}).Resolve(&fakeGitKeychain{}, Trust{}, sourceConfig(), &corev1alpha1.ResolvedGitSource{
+					URL:      repoDir,
+					Revision: first,
+					Type:     corev1alpha1.Branch,
+				})
+				require.NoError(t, err)
+
+				assert.Equal(t, corev1alpha1.ResolvedSourceConfig{
+					Git: &corev1alpha1.ResolvedGitSource{
+						URL:      repoDir,
+						Revision: third,
+						Type:     corev1alpha1.Branch,
+						SubPath:  "services/api",
+					},
+				}, resolved)
+			})
+
+			it("honors exclude paths", func() {
+				first := commitFiles(map[string]string{"services/api/main.go": "api"})
+				second := commitFiles(map[string]string{"services/api/README.md": "docs"})
+
+				config := sourceConfig()
+				config.Git.ExcludePaths = []string{"**/*.md"}
+				resolved, err := (&remoteGitResolver{}).Resolve(&fakeGitKeychain{}, Trust{}, config, &corev1alpha1.ResolvedGitSource{
+					URL:      repoDir,
+					Revision: first,
+					Type:     corev1alpha1.Branch,
+				})
+				require.NoError(t, err)
+
+				require.Equal(t, first, resolved.Git.Revision)
+				require.Equal(t, []string{second}, resolved.Git.SkippedRevisions)
+			})
+
+			it("polls the commits pushed since the last poll into a cached repository", func() {
+				cacheDir, err := ioutil.TempDir("", "git-repositories")
+				require.NoError(t, err)
+				defer os.RemoveAll(cacheDir)
+				gitResolver := &remoteGitResolver{repositories: newRepositoryCache(cacheDir, maxCachedRepositories)}
+
+				first := commitFiles(map[string]string{"services/api/main.go": "api", "services/web/main.go": "web"})
+				second := commitFiles(map[string]string{"services/web/main.go": "web v2"})
+
+				resolved, err := gitResolver.Resolve(&fakeGitKeychain{}, Trust{}, sourceConfig(), &corev1alpha1.ResolvedGitSource{
+					URL:      repoDir,
+					Revision: first,
+					Type:     corev1alpha1.Branch,
+				})
+				require.NoError(t, err)
+				require.Equal(t, first, resolved.Git.Revision)
+				require.Equal(t, []string{second}, resolved.Git.SkippedRevisions)
+
+				third := commitFiles(map[string]string{"services/api/main.go": "api v2"})
+
+				resolved, err = gitResolver.Resolve(&fakeGitKeychain{}, Trust{}, sourceConfig(), resolved.Git)
+				require.NoError(t, err)
+				require.Equal(t, third, resolved.Git.Revision)
+				require.Empty(t, resolved.Git.SkippedRevisions)
+
+				cached, err := ioutil.ReadDir(cacheDir)
+				require.NoError(t, err)
+				require.Len(t, cached, 1)
+			})
+		})
 	})
 }
@@ -0,0 +1,161 @@
+package git
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	git2go "github.com/libgit2/git2go/v33"
+	"github.com/pkg/errors"
+)
+
+const maxCachedRepositories = 20
+
+// repositoryCache keeps a bare repository per git url on disk, so that a
+// repository that is fetched again only downloads the commits pushed since the
+// previous fetch. Every user of a repository authenticates its own fetch before
+// reading from it. At most size repositories are kept and the least recently
+// used one is removed beyond that.
+type repositoryCache struct {
+	dir  string
+	size int
+
+	lock    sync.Mutex
+	entries map[string]*cachedRepository
+}
+
+type cachedRepository struct {
+	path     string
+	lock     sync.Mutex
+	users    int
+	lastUsed time.Time
+}
+
+func newRepositoryCache(dir string, size int) *repositoryCache {
+	return &repositoryCache{
+		dir:     dir,
+		size:    size,
+		entries: map[string]*cachedRepository{},
+	}
+}
+
+// open returns the repository of url and a function that releases it. The
+// repository is not shared until it is released. A nil cache returns a new
+// repository that is removed when it is released.
+func (c *repositoryCache) open(url string) (*git2go.Repository, func(), error) {
+	if c == nil {
+		dir, err := ioutil.TempDir("", "git-repository")
+		if err != nil {
+			return nil, nil, err
+		}
+
+		repository, err := git2go.InitRepository(dir, true)
+		if err != nil {
+			os.RemoveAll(dir)
+			return nil, nil, errors.Wrap(err, "initializing repo")
+		}
+		return repository, func() {
+			repository.Free()
+			os.RemoveAll(dir)
+		}, nil
+	}
+
+	entry := c.acquire(url)
+	entry.lock.Lock()
+
+	repository, err := openOrInitRepository(entry.path)
+	if err != nil {
+		entry.lock.Unlock()
+		c.releaseEntry(entry)
+		return nil, nil, err
+	}
+	return repository, func() {
+		repository.Free()
+		entry.lock.Unlock()
+		c.releaseEntry(entry)
+	}, nil
+}
+
+func (c *repositoryCache) acquire(url string) *cachedRepository {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	entry, ok := c.entries[url]
+	if !ok {
+		entry = &cachedRepository{path: filepath.Join(c.dir, fmt.Sprintf("%x", sha256.Sum256([]byte(url))))}
+		c.entries[url] = entry
+	}
+	entry.users++
+	entry.lastUsed = time.Now()
+
+	c.evict()
+	return entry
+}
+
+func (c *repositoryCache) releaseEntry(entry *cachedRepository) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	entry.users--
+	c.evict()
+}
+
+// evict removes the least recently used repositories that are not in use
+// while there are more than size of them.
+func (c *repositoryCache) evict() {
+	for len(c.entries) > c.size {
+		var oldestURL string
+		var oldest *cachedRepository
+		for url, entry := range c.entries {
+			if entry.users == 0 && (oldest == nil || entry.lastUsed.Before(oldest.lastUsed)) {
+				oldestURL, oldest = url, entry
+			}
+		}
+		if oldest == nil {
+			return
+		}
+
+		delete(c.entries, oldestURL)
+		os.RemoveAll(oldest.path)
+	}
+}
+
+// openOrInitRepository opens the bare repository at path, and initializes it
+// again when it does not exist or cannot be opened.
+func openOrInitRepository(path string) (*git2go.Repository, error) {
+	repository, err := git2go.OpenRepository(path)
+	if err == nil {
+		return repository, nil
+	}
+
+	if err := os.RemoveAll(path); err != nil {
+		return nil, err
+	}
+	repository, err = git2go.InitRepository(path, true)
+	if err != nil {
+		return nil, errors.Wrap(err, "initializing repo")
+	}
+	return repository, nil
+}
+
+// originRemote returns the remote of url in repository, creating it when the
+// repository is new.
+func originRemote(repository *git2go.Repository, url string) (*git2go.Remote, error) {
+	remote, err := repository.Remotes.Lookup(defaultRemote)
+	if err == nil {
+		return remote, nil
+	}
+
+	remote, err = repository.Remotes.CreateWithOptions(url, &git2go.RemoteCreateOptions{
+		Name:  defaultRemote,
+		Flags: git2go.RemoteCreateSkipInsteadof,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "create remote")
+	}
+	return remote, nil
+}
@@ -0,0 +1,92 @@
+package git
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/sclevine/spec"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRepositoryCache(t *testing.T) {
+	spec.Run(t, "TestRepositoryCache", testRepositoryCache)
+}
+
+func testRepositoryCache(t *testing.T, when spec.G, it spec.S) {
+	var (
+		dir   string
+		cache *repositoryCache
+	)
+
+	it.Before(func() {
+		var err error
+		dir, err = ioutil.TempDir("", "repository-cache")
+		require.NoError(t, err)
+
+		cache = newRepositoryCache(dir, 1)
+	})
+
+	it.After(func() {
+		require.NoError(t, os.RemoveAll(dir))
+	})
+
+	cachedDirs := func() int {
+		entries, err := ioutil.ReadDir(dir)
+		require.NoError(t, err)
+		return len(entries)
+	}
+
+	it("reuses the repository of a url", func() {
+		repository, release, err := cache.open("https://some.git/url")
+		require.NoError(t, err)
+		path := repository.Path()
+		release()
+
+		repository, release, err = cache.open("https://some.git/url")
+		require.NoError(t, err)
+		defer release()
+
+		assert.Equal(t, path, repository.Path())
+		assert.Equal(t, 1, cachedDirs())
+	})
+
+	it("removes the least recently used repository", func() {
+		_, release, err := cache.open("https://some.git/first")
+		require.NoError(t, err)
+		release()
+
+		_, release, err = cache.open("https://some.git/second")
+		require.NoError(t, err)
+		release()
+
+		assert.Equal(t, 1, cachedDirs())
+		assert.Contains(t, cache.entries, "https://some.git/second")
+	})
+
+	it("does not remove a repository in use", func() {
+		_, releaseFirst, err := cache.open("https://some.git/first")
+		require.NoError(t, err)
+
+		_, releaseSecond, err := cache.open("https://some.git/second")
+		require.NoError(t, err)
+		assert.Equal(t, 2, cachedDirs())
+
+		releaseSecond()
+		assert.Contains(t, cache.entries, "https://some.git/first")
+
+		releaseFirst()
+		assert.Len(t, cache.entries, 1)
+	})
+
+	it("returns a new repository without a cache", func() {
+		repository, release, err := (*repositoryCache)(nil).open("https://some.git/url")
+		require.NoError(t, err)
+		path := repository.Path()
+
+		release()
+		_, err = os.Stat(path)
+		assert.True(t, os.IsNotExist(err))
+	})
+}
@@ -2,32 +2,175 @@ package git
 
 import (
 	"context"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
 
+	git2go "github.com/libgit2/git2go/v33"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	k8sclient "k8s.io/client-go/kubernetes"
 
 	buildapi "github.com/pivotal/kpack/pkg/apis/build/v1alpha2"
 	corev1alpha1 "github.com/pivotal/kpack/pkg/apis/core/v1alpha1"
+	"github.com/pivotal/kpack/pkg/secret"
 )
 
+// TrustProvider provides the known hosts and certificate authorities trusted
+// for every git source in the cluster.
+type TrustProvider interface {
+	GitKnownHosts() []byte
+	GitCABundle() []byte
+}
+
 type Resolver struct {
 	remoteGitResolver remoteGitResolver
 	gitKeychain       *k8sGitKeychainFactory
+	trustProvider     TrustProvider
+	k8sClient         k8sclient.Interface
 }
 
-func NewResolver(k8sClient k8sclient.Interface) *Resolver {
+func NewResolver(k8sClient k8sclient.Interface, trustProvider TrustProvider) *Resolver {
 	return &Resolver{
-		remoteGitResolver: remoteGitResolver{},
-		gitKeychain:       newK8sGitKeychainFactory(k8sClient),
+		remoteGitResolver: remoteGitResolver{
+			repositories: newRepositoryCache(filepath.Join(os.TempDir(), "kpack-git-repositories"), maxCachedRepositories),
+		},
+		gitKeychain:   newK8sGitKeychainFactory(k8sClient),
+		trustProvider: trustProvider,
+		k8sClient:     k8sClient,
 	}
 }
 
 func (r *Resolver) Resolve(ctx context.Context, sourceResolver *buildapi.SourceResolver) (corev1alpha1.ResolvedSourceConfig, error) {
-	keychain, err := r.gitKeychain.KeychainForServiceAccount(ctx, sourceResolver.Namespace, sourceResolver.Spec.ServiceAccountName)
+	keychain, trust, err := r.keychainAndTrust(ctx, sourceResolver.Namespace, sourceResolver.Spec.ServiceAccountName)
 	if err != nil {
 		return corev1alpha1.ResolvedSourceConfig{}, err
 	}
 
-	return r.remoteGitResolver.Resolve(keychain, sourceResolver.Spec.Source)
+	return r.remoteGitResolver.Resolve(keychain, trust, sourceResolver.Spec.Source, sourceResolver.LastResolvedGitSource())
+}
+
+// Discover returns the directories of the git source of imageSet that match
+// its discovery.
+func (r *Resolver) Discover(ctx context.Context, imageSet *buildapi.ImageSet) ([]string, error) {
+	keychain, trust, err := r.keychainAndTrust(ctx, imageSet.Namespace, imageSet.Spec.Template.ServiceAccountName)
+	if err != nil {
+		return nil, err
+	}
+
+	discovery := imageSet.Spec.Discovery
+	return r.remoteGitResolver.ListDirectories(keychain, trust, *imageSet.Spec.Template.Source.Git, discovery.Path, discovery.File)
+}
+
+// Previews returns the previews of the git source of previewImageSet listed
+// by its provider.
+func (r *Resolver) Previews(ctx context.Context, previewImageSet *buildapi.PreviewImageSet) ([]buildapi.Preview, error) {
+	keychain, trust, err := r.keychainAndTrust(ctx, previewImageSet.Namespace, previewImageSet.Spec.Template.ServiceAccountName)
+	if err != nil {
+		return nil, err
+	}
+
+	gitSource := *previewImageSet.Spec.Template.Source.Git
+	provider := previewImageSet.Spec.Provider
+	switch {
+	case provider.Branches != nil:
+		branches, err := r.remoteGitResolver.ListBranches(keychain, trust, gitSource)
+		if err != nil {
+			return nil, err
+		}
+
+		var previews []buildapi.Preview
+		for _, branch := range branches {
+			if matched, _ := path.Match(provider.Branches.Pattern, branch); matched {
+				previews = append(previews, buildapi.BranchPreview(branch))
+			}
+		}
+		return previews, nil
+	case provider.GitHub != nil:
+		client, err := r.pullRequestClientFor(ctx, previewImageSet.Namespace, keychain, trust, gitSource.URL, provider.GitHub, defaultGitHubAPIURL)
+		if err != nil {
+			return nil, err
+		}
+		return client.gitHubPullRequests(ctx, gitSource.URL)
+	case provider.GitLab != nil:
+		client, err := r.pullRequestClientFor(ctx, previewImageSet.Namespace, keychain, trust, gitSource.URL, provider.GitLab, defaultGitLabAPIURL)
+		if err != nil {
+			return nil, err
+		}
+		return client.gitLabMergeRequests(ctx, gitSource.URL)
+	default:
+		return nil, errors.New("no preview provider")
+	}
+}
+
+// pullRequestClientFor returns a client for the api of provider, or
+// defaultURL, authenticated with the token of the secret of provider. Without
+// a secret, the password of the basic auth git credentials of gitURL is only
+// sent to an api on the git host. Public repositories are listed without
+// credentials.
+func (r *Resolver) pullRequestClientFor(ctx context.Context, namespace string, keychain GitKeychain, trust Trust, gitURL string, provider *buildapi.PullRequestProvider, defaultURL string) (pullRequestClient, error) {
+	httpClient, err := trust.httpClient()
+	if err != nil {
+		return pullRequestClient{}, err
+	}
+
+	client := pullRequestClient{client: httpClient, url: provider.URL}
+	if client.url == "" {
+		client.url = defaultURL
+	}
+
+	if provider.SecretRef != nil {
+		client.token, err = r.providerToken(ctx, namespace, provider.SecretRef.Name)
+		return client, err
+	}
+
+	if !isGitHostAPI(gitURL, client.url) {
+		return client, nil
+	}
+
+	if cred, err := keychain.Resolve(gitURL, "", git2go.CredentialTypeUserpassPlaintext); err == nil {
+		if basic, ok := cred.(BasicGit2GoAuth); ok {
+			client.token = basic.Password
+		}
+	}
+	return client, nil
+}
+
+// providerToken returns the token, or basic auth password, of the provider
+// secret name.
+func (r *Resolver) providerToken(ctx context.Context, namespace, name string) (string, error) {
+	s, err := r.k8sClient.CoreV1().Secrets(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return "", errors.Wrapf(err, "getting provider secret %s", name)
+	}
+
+	if token := strings.TrimSpace(string(s.Data[secret.BearerTokenKey])); token != "" {
+		return token, nil
+	}
+	if password := string(s.Data[corev1.BasicAuthPasswordKey]); password != "" {
+		return password, nil
+	}
+	return "", errors.Errorf("provider secret %s has no %s or %s", name, secret.BearerTokenKey, corev1.BasicAuthPasswordKey)
+}
+
+func (r *Resolver) keychainAndTrust(ctx context.Context, namespace, serviceAccount string) (GitKeychain, Trust, error) {
+	keychain, err := r.gitKeychain.KeychainForServiceAccount(ctx, namespace, serviceAccount)
+	if err != nil {
+		return nil, Trust{}, err
+	}
+
+	trust, err := keychain.Trust()
+	if err != nil {
+		return nil, Trust{}, err
+	}
+
+	clusterTrust := Trust{
+		KnownHosts: r.trustProvider.GitKnownHosts(),
+		CABundle:   r.trustProvider.GitCABundle(),
+	}
+	return keychain, clusterTrust.Merge(trust), nil
 }
 
 func (*Resolver) CanResolve(sourceResolver *buildapi.SourceResolver) bool {
@@ -0,0 +1,97 @@
+package git
+
+import (
+	"path"
+	"strings"
+
+	git2go "github.com/libgit2/git2go/v33"
+	"github.com/pkg/errors"
+)
+
+type submodule struct {
+	Path       string `toml:"path"`
+	Repository string `toml:"repository"`
+	Commit     string `toml:"commit"`
+}
+
+// updateSubmodules recursively initializes and checks out the submodules of
+// repository, returning the commit checked out for each of them.
+func (f Fetcher) updateSubmodules(repository *git2go.Repository, prefix string, fetchOptions *git2go.FetchOptions) ([]submodule, error) {
+	var names []string
+	err := repository.Submodules.Foreach(func(_ *git2go.Submodule, name string) error {
+		names = append(names, name)
+		return nil
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "listing submodules")
+	}
+
+	var submodules []submodule
+	for _, name := range names {
+		updated, err := f.updateSubmodule(repository, name, prefix, fetchOptions)
+		if err != nil {
+			return nil, err
+		}
+		submodules = append(submodules, updated...)
+	}
+	return submodules, nil
+}
+
+func (f Fetcher) updateSubmodule(repository *git2go.Repository, name, prefix string, fetchOptions *git2go.FetchOptions) ([]submodule, error) {
+	sub, err := repository.Submodules.Lookup(name)
+	if err != nil {
+		return nil, errors.Wrapf(err, "looking up submodule %s", name)
+	}
+	defer sub.Free()
+
+	subPath := path.Join(prefix, sub.Path())
+	if sparsePath := strings.Trim(f.SparsePath, "/"); sparsePath != "" && !matchPath(sparsePath, subPath) && !matchPath(subPath, sparsePath) {
+		return nil, nil
+	}
+
+	f.Logger.Printf("Updating submodule %q...", subPath)
+	err = sub.Update(true, &git2go.SubmoduleUpdateOptions{
+		CheckoutOptions: git2go.CheckoutOptions{
+			Strategy: git2go.CheckoutForce,
+		},
+		FetchOptions: *fetchOptions,
+	})
+	if err != nil {
+		return nil, errors.Wrapf(err, "updating submodule %s", subPath)
+	}
+
+	subRepository, err := sub.Open()
+	if err != nil {
+		return nil, errors.Wrapf(err, "opening submodule %s", subPath)
+	}
+	defer subRepository.Free()
+
+	remote, err := subRepository.Remotes.Lookup(defaultRemote)
+	if err != nil {
+		return nil, errors.Wrapf(err, "looking up remote for submodule %s", subPath)
+	}
+	defer remote.Free()
+
+	head, err := subRepository.Head()
+	if err != nil {
+		return nil, errors.Wrapf(err, "looking up head for submodule %s", subPath)
+	}
+	defer head.Free()
+
+	if f.LFS {
+		if err := f.fetchLFSObjects(subRepository, remote.Url()); err != nil {
+			return nil, err
+		}
+	}
+
+	nested, err := f.updateSubmodules(subRepository, subPath, fetchOptions)
+	if err != nil {
+		return nil, err
+	}
+
+	return append([]submodule{{
+		Path:       subPath,
+		Repository: remote.Url(),
+		Commit:     head.Target().String(),
+	}}, nested...), nil
+}
@@ -0,0 +1,154 @@
+package git
+
+import (
+	"fmt"
+	"path"
+	"strings"
+	"unicode"
+
+	"github.com/Masterminds/semver/v3"
+	"github.com/pkg/errors"
+)
+
+const (
+	NoMatchingTagReason   = "NoMatchingTag"
+	TagsUnavailableReason = "TagsUnavailable"
+
+	tagRefPrefix = "refs/tags/"
+	peeledSuffix = "^{}"
+)
+
+// NoMatchingTagError is returned when no tag in the repository satisfies the
+// tag constraint of a git source.
+type NoMatchingTagError struct {
+	constraint string
+}
+
+func (e *NoMatchingTagError) Error() string {
+	return fmt.Sprintf("no tag matches %q", e.constraint)
+}
+
+func (e *NoMatchingTagError) Reason() string {
+	return NoMatchingTagReason
+}
+
+// TagsUnavailableError is returned when the tags of a git source with a tag
+// constraint cannot be listed, such as when the remote cannot be reached. It
+// is temporary, so the last resolved tag and revision are kept.
+type TagsUnavailableError struct {
+	err error
+}
+
+func (e *TagsUnavailableError) Error() string {
+	return e.err.Error()
+}
+
+func (e *TagsUnavailableError) Reason() string {
+	return TagsUnavailableReason
+}
+
+func (e *TagsUnavailableError) Temporary() bool {
+	return true
+}
+
+// tagConstraint is a glob matched against the tag names, such as v1.*, or
+// a semver constraint, such as >=2.0.0 <3.0.0.
+type tagConstraint struct {
+	constraints *semver.Constraints
+	glob        string
+}
+
+func newTagConstraint(constraint string) (tagConstraint, error) {
+	if isGlob(constraint) {
+		if _, err := path.Match(constraint, ""); err == nil {
+			return tagConstraint{glob: constraint}, nil
+		}
+	}
+
+	constraints, err := semver.NewConstraint(constraint)
+	if err == nil {
+		return tagConstraint{constraints: constraints}, nil
+	}
+
+	if _, globErr := path.Match(constraint, ""); globErr != nil {
+		return tagConstraint{}, errors.Wrapf(err, "parsing tag constraint %q", constraint)
+	}
+	return tagConstraint{glob: constraint}, nil
+}
+
+func isGlob(constraint string) bool {
+	return strings.ContainsAny(constraint, "*?[") && !strings.ContainsAny(constraint, "<>=~^|, ")
+}
+
+// matches returns the version of tag when it satisfies the constraint. Tags
+// that do not contain a semver version never match and pre-release versions
+// only match semver constraints that include a pre-release.
+func (c tagConstraint) matches(tag string) (*semver.Version, bool) {
+	version, ok := tagVersion(tag)
+	if !ok {
+		return nil, false
+	}
+
+	if c.constraints != nil {
+		return version, c.constraints.Check(version)
+	}
+
+	matched, _ := path.Match(c.glob, tag)
+	return version, matched && version.Prerelease() == ""
+}
+
+// tagVersion parses the version of tags such as v1.2.3 or release-1.2.3.
+func tagVersion(tag string) (*semver.Version, bool) {
+	if version, err := semver.NewVersion(tag); err == nil {
+		return version, true
+	}
+
+	i := strings.IndexFunc(tag, unicode.IsDigit)
+	if i <= 0 {
+		return nil, false
+	}
+
+	version, err := semver.NewVersion(tag[i:])
+	return version, err == nil
+}
+
+// newestTag returns the newest tag satisfying constraint and the commit it
+// points at, given the ids of the remote references by name. Annotated tags
+// are resolved to their peeled commit.
+func newestTag(constraint string, references map[string]string) (string, string, error) {
+	c, err := newTagConstraint(constraint)
+	if err != nil {
+		return "", "", err
+	}
+
+	var (
+		newest        string
+		newestVersion *semver.Version
+	)
+	for name := range references {
+		if !strings.HasPrefix(name, tagRefPrefix) || strings.HasSuffix(name, peeledSuffix) {
+			continue
+		}
+
+		tag := strings.TrimPrefix(name, tagRefPrefix)
+		version, ok := c.matches(tag)
+		if !ok {
+			continue
+		}
+
+		if newestVersion == nil || version.GreaterThan(newestVersion) || (version.Equal(newestVersion) && tag > newest) {
+			newest = tag
+			newestVersion = version
+		}
+	}
+
+	if newestVersion == nil {
+		return "", "", &NoMatchingTagError{constraint: constraint}
+	}
+
+	name := tagRefPrefix + newest
+	if peeled, ok := references[name+peeledSuffix]; ok {
+		return newest, peeled, nil
+	}
+	return newest, references[name], nil
+}
@@ -0,0 +1,71 @@
+package git
+
+import (
+	"testing"
+
+	"github.com/sclevine/spec"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTagConstraint(t *testing.T) {
+	spec.Run(t, "TestTagConstraint", testTagConstraint)
+}
+
+func testTagConstraint(t *testing.T, when spec.G, it spec.S) {
+	when("#newestTag", func() {
+		references := map[string]string{
+			"HEAD":                       "head-sha",
+			"refs/heads/main":            "head-sha",
+			"refs/heads/v9.0.0":          "branch-sha",
+			"refs/tags/v1.0.0":           "v1.0.0-sha",
+			"refs/tags/v1.2.0":           "v1.2.0-tag-sha",
+			"refs/tags/v1.2.0^{}":        "v1.2.0-sha",
+			"refs/tags/v1.10.0-rc.1":     "v1.10.0-rc.1-sha",
+			"refs/tags/v2.0.0":           "v2.0.0-sha",
+			"refs/tags/v2.3.1":           "v2.3.1-sha",
+			"refs/tags/v3.0.0":           "v3.0.0-sha",
+			"refs/tags/release-1.4.0":    "release-1.4.0-sha",
+			"refs/tags/release-1.12.0":   "release-1.12.0-sha",
+			"refs/tags/not-a-version":    "not-a-version-sha",
+			"refs/tags/release-latest":   "release-latest-sha",
+			"refs/tags/v4.0.0-beta.1^{}": "v4.0.0-beta.1-sha",
+		}
+
+		for _, tc := range []struct {
+			constraint string
+			tag        string
+			revision   string
+		}{
+			{">=2.0.0 <3.0.0", "v2.3.1", "v2.3.1-sha"},
+			{"~1.2", "v1.2.0", "v1.2.0-sha"},
+			{">=1.10.0-0 <1.11.0-0", "v1.10.0-rc.1", "v1.10.0-rc.1-sha"},
+			{"1.x", "release-1.12.0", "release-1.12.0-sha"},
+			{"v1.*", "v1.2.0", "v1.2.0-sha"},
+			{"release-*", "release-1.12.0", "release-1.12.0-sha"},
+			{"*", "v3.0.0", "v3.0.0-sha"},
+		} {
+			tc := tc
+			it(tc.constraint, func() {
+				tag, revision, err := newestTag(tc.constraint, references)
+				require.NoError(t, err)
+				assert.Equal(t, tc.tag, tag)
+				assert.Equal(t, tc.revision, revision)
+			})
+		}
+
+		it("returns an error when no tag matches", func() {
+			_, _, err := newestTag(">=5.0.0", references)
+			require.EqualError(t, err, `no tag matches ">=5.0.0"`)
+
+			noMatchErr, ok := err.(*NoMatchingTagError)
+			require.True(t, ok)
+			assert.Equal(t, NoMatchingTagReason, noMatchErr.Reason())
+		})
+
+		it("returns an error for an invalid constraint", func() {
+			_, _, err := newestTag("release-[", references)
+			require.Error(t, err)
+		})
+	})
+}
@@ -0,0 +1,174 @@
+package git
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"os"
+
+	"github.com/pkg/errors"
+	giturls "github.com/whilp/git-urls"
+	"golang.org/x/crypto/ssh"
+	"golang.org/x/crypto/ssh/knownhosts"
+)
+
+const (
+	HostVerificationFailedReason = "HostVerificationFailed"
+
+	defaultSSHPort = "22"
+)
+
+// Trust holds the ssh known hosts and the certificate authorities used to
+// verify git servers. Servers are not verified against empty values.
+type Trust struct {
+	KnownHosts []byte
+	CABundle   []byte
+}
+
+func (t Trust) Merge(other Trust) Trust {
+	return Trust{
+		KnownHosts: joinLines(t.KnownHosts, other.KnownHosts),
+		CABundle:   joinLines(t.CABundle, other.CABundle),
+	}
+}
+
+func joinLines(a, b []byte) []byte {
+	if len(a) == 0 {
+		return b
+	} else if len(b) == 0 {
+		return a
+	}
+	return append(append(append([]byte{}, bytes.TrimRight(a, "\n")...), '\n'), b...)
+}
+
+// HostVerificationError is returned when a git server does not match the
+// known hosts or certificate authorities it is verified against.
+type HostVerificationError struct {
+	err error
+}
+
+func (e *HostVerificationError) Error() string {
+	return e.err.Error()
+}
+
+func (e *HostVerificationError) Reason() string {
+	return HostVerificationFailedReason
+}
+
+func hostVerificationError(err error, message string) error {
+	return &HostVerificationError{err: errors.Wrap(err, message)}
+}
+
+// verifyHostKey checks the ssh host key of hostname against the known hosts.
+// The key is compared directly when available and by its SHA256 fingerprint
+// otherwise.
+func (t Trust) verifyHostKey(hostname, port string, key ssh.PublicKey, sha256Fingerprint []byte) error {
+	file, err := ioutil.TempFile("", "known_hosts")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(file.Name())
+
+	_, err = file.Write(t.KnownHosts)
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return err
+	}
+
+	callback, err := knownhosts.New(file.Name())
+	if err != nil {
+		return hostVerificationError(err, "parsing known hosts")
+	}
+
+	address := net.JoinHostPort(hostname, port)
+	if key != nil {
+		err := callback(address, &net.TCPAddr{}, key)
+		if err != nil {
+			return hostVerificationError(err, "verifying host key")
+		}
+		return nil
+	}
+
+	var keyErr *knownhosts.KeyError
+	if err := callback(address, &net.TCPAddr{}, unknownKey{}); !errors.As(err, &keyErr) {
+		return hostVerificationError(err, "verifying host key")
+	}
+
+	for _, known := range keyErr.Want {
+		fingerprint := sha256.Sum256(known.Key.Marshal())
+		if bytes.Equal(fingerprint[:], sha256Fingerprint) {
+			return nil
+		}
+	}
+
+	if len(keyErr.Want) == 0 {
+		return hostVerificationError(errors.Errorf("%s is not a known host", address), "verifying host key")
+	}
+	return hostVerificationError(errors.Errorf("host key for %s does not match the known hosts", address), "verifying host key")
+}
+
+// verifyCertificate checks the certificate of hostname against the
+// certificate authority bundle.
+func (t Trust) verifyCertificate(hostname string, cert *x509.Certificate) error {
+	roots := x509.NewCertPool()
+	if !roots.AppendCertsFromPEM(t.CABundle) {
+		return hostVerificationError(errors.New("no certificates found"), "parsing ca bundle")
+	}
+
+	_, err := cert.Verify(x509.VerifyOptions{
+		DNSName: hostname,
+		Roots:   roots,
+	})
+	if err != nil {
+		return hostVerificationError(err, "verifying certificate")
+	}
+	return nil
+}
+
+// httpClient returns a client trusting the system and bundled certificate authorities.
+func (t Trust) httpClient() (*http.Client, error) {
+	if len(t.CABundle) == 0 {
+		return http.DefaultClient, nil
+	}
+
+	roots, err := x509.SystemCertPool()
+	if err != nil {
+		roots = x509.NewCertPool()
+	}
+	if !roots.AppendCertsFromPEM(t.CABundle) {
+		return nil, errors.New("parsing ca bundle: no certificates found")
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.TLSClientConfig = &tls.Config{RootCAs: roots}
+	return &http.Client{Transport: transport}, nil
+}
+
+func sshPort(gitURL string) string {
+	u, err := giturls.Parse(gitURL)
+	if err != nil || u.Port() == "" {
+		return defaultSSHPort
+	}
+	return u.Port()
+}
+
+// unknownKey never matches a known host so that the known keys are returned.
+type unknownKey struct{}
+
+func (unknownKey) Type() string {
+	return ""
+}
+
+func (unknownKey) Marshal() []byte {
+	return nil
+}
+
+func (unknownKey) Verify([]byte, *ssh.Signature) error {
+	return errors.New("unknown key")
+}
@@ -0,0 +1,157 @@
+package git
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/sclevine/spec"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/ssh"
+	"golang.org/x/crypto/ssh/knownhosts"
+)
+
+func TestTrust(t *testing.T) {
+	spec.Run(t, "TestTrust", testTrust)
+}
+
+func testTrust(t *testing.T, when spec.G, it spec.S) {
+	when("#verifyHostKey", func() {
+		var (
+			hostKey  ssh.PublicKey
+			otherKey ssh.PublicKey
+			trust    Trust
+		)
+
+		it.Before(func() {
+			hostKey = generateHostKey(t)
+			otherKey = generateHostKey(t)
+
+			trust = Trust{
+				KnownHosts: []byte(knownhosts.Line([]string{"github.com"}, hostKey) + "\n" +
+					knownhosts.Line([]string{"[bitbucket.example.com]:7999"}, otherKey) + "\n"),
+			}
+		})
+
+		it("accepts a known host key", func() {
+			require.NoError(t, trust.verifyHostKey("github.com", "22", hostKey, nil))
+		})
+
+		it("accepts a known host key on another port", func() {
+			require.NoError(t, trust.verifyHostKey("bitbucket.example.com", "7999", otherKey, nil))
+		})
+
+		it("accepts a matching sha256 fingerprint", func() {
+			fingerprint := sha256.Sum256(hostKey.Marshal())
+			require.NoError(t, trust.verifyHostKey("github.com", "22", nil, fingerprint[:]))
+		})
+
+		it("rejects a mismatched host key", func() {
+			err := trust.verifyHostKey("github.com", "22", otherKey, nil)
+			require.Error(t, err)
+
+			var verificationErr *HostVerificationError
+			require.True(t, errors.As(err, &verificationErr))
+			assert.Equal(t, HostVerificationFailedReason, verificationErr.Reason())
+		})
+
+		it("rejects a mismatched sha256 fingerprint", func() {
+			fingerprint := sha256.Sum256(otherKey.Marshal())
+			err := trust.verifyHostKey("github.com", "22", nil, fingerprint[:])
+			require.EqualError(t, err, "verifying host key: host key for github.com:22 does not match the known hosts")
+		})
+
+		it("rejects an unknown host", func() {
+			fingerprint := sha256.Sum256(hostKey.Marshal())
+			err := trust.verifyHostKey("gitlab.com", "22", nil, fingerprint[:])
+			require.EqualError(t, err, "verifying host key: gitlab.com:22 is not a known host")
+		})
+	})
+
+	when("#verifyCertificate", func() {
+		it("accepts certificates signed by the ca bundle", func() {
+			caCert, caKey := generateCertificate(t, "some-ca", nil, nil)
+			cert, _ := generateCertificate(t, "git.example.com", caCert, caKey)
+
+			trust := Trust{CABundle: pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: caCert.Raw})}
+			require.NoError(t, trust.verifyCertificate("git.example.com", cert))
+
+			err := trust.verifyCertificate("other.example.com", cert)
+			require.Error(t, err)
+		})
+
+		it("rejects certificates signed by another ca", func() {
+			caCert, _ := generateCertificate(t, "some-ca", nil, nil)
+			otherCACert, otherCAKey := generateCertificate(t, "other-ca", nil, nil)
+			cert, _ := generateCertificate(t, "git.example.com", otherCACert, otherCAKey)
+
+			trust := Trust{CABundle: pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: caCert.Raw})}
+			err := trust.verifyCertificate("git.example.com", cert)
+
+			var verificationErr *HostVerificationError
+			require.True(t, errors.As(err, &verificationErr))
+		})
+	})
+
+	when("#Merge", func() {
+		it("joins known hosts and ca bundles", func() {
+			trust := Trust{KnownHosts: []byte("a"), CABundle: []byte("ca-1\n")}.Merge(Trust{KnownHosts: []byte("b\n"), CABundle: []byte("ca-2")})
+
+			assert.Equal(t, "a\nb\n", string(trust.KnownHosts))
+			assert.Equal(t, "ca-1\nca-2", string(trust.CABundle))
+		})
+	})
+
+	when("#sshPort", func() {
+		it("returns the port of the git url", func() {
+			assert.Equal(t, "7999", sshPort("ssh://git@bitbucket.example.com:7999/org/repo.git"))
+			assert.Equal(t, "22", sshPort("git@github.com:org/repo.git"))
+		})
+	})
+}
+
+func generateHostKey(t *testing.T) ssh.PublicKey {
+	public, _, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	key, err := ssh.NewPublicKey(public)
+	require.NoError(t, err)
+	return key
+}
+
+func generateCertificate(t *testing.T, commonName string, parent *x509.Certificate, parentKey ed25519.PrivateKey) (*x509.Certificate, ed25519.PrivateKey) {
+	public, private, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(time.Now().UnixNano()),
+		Subject:               pkix.Name{CommonName: commonName},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		BasicConstraintsValid: true,
+	}
+	if parent == nil {
+		template.IsCA = true
+		template.KeyUsage = x509.KeyUsageCertSign
+		parent = template
+		parentKey = private
+	} else {
+		template.DNSNames = []string{commonName}
+		template.ExtKeyUsage = []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth}
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, parent, public, parentKey)
+	require.NoError(t, err)
+
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+	return cert, private
+}
@@ -0,0 +1,154 @@
+package gitwebhook
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"fmt"
+	"io/ioutil"
+	"mime"
+	"net/http"
+	"strings"
+
+	"go.uber.org/zap"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/labels"
+	corelisters "k8s.io/client-go/listers/core/v1"
+
+	buildlisters "github.com/pivotal/kpack/pkg/client/listers/build/v1alpha2"
+)
+
+const (
+	// SecretName is the name of the Secret in each namespace that holds the
+	// shared secret used to verify webhook deliveries for that namespace.
+	SecretName = "kpack-git-webhook"
+	SecretKey  = "secret"
+
+	maxPayloadBytes = 25 * 1024 * 1024
+)
+
+// Handler receives push webhooks from GitHub, GitLab, Gitea and Bitbucket on
+// /<namespace> and immediately enqueues the SourceResolvers in that namespace
+// that track the pushed url and revision. The webhook secrets are read from
+// SecretLister, so requests never reach the api server.
+type Handler struct {
+	Logger               *zap.SugaredLogger
+	SecretLister         corelisters.SecretLister
+	SourceResolverLister buildlisters.SourceResolverLister
+	Enqueue              func(obj interface{})
+}
+
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	namespace := strings.Trim(r.URL.Path, "/")
+	if namespace == "" || strings.Contains(namespace, "/") {
+		http.Error(w, "webhook path must be /<namespace>", http.StatusNotFound)
+		return
+	}
+
+	mediaType, _, err := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	if err != nil || mediaType != "application/json" {
+		http.Error(w, "content type must be application/json", http.StatusUnsupportedMediaType)
+		return
+	}
+
+	if r.ContentLength > maxPayloadBytes {
+		http.Error(w, "payload too large", http.StatusRequestEntityTooLarge)
+		return
+	}
+
+	body, err := ioutil.ReadAll(http.MaxBytesReader(w, r.Body, maxPayloadBytes))
+	if err != nil {
+		http.Error(w, "unable to read payload", http.StatusBadRequest)
+		return
+	}
+
+	event, err := parseEvent(r.Header, body)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	secret, err := h.SecretLister.Secrets(namespace).Get(SecretName)
+	if k8serrors.IsNotFound(err) {
+		http.Error(w, fmt.Sprintf("webhooks are not configured for namespace %s", namespace), http.StatusForbidden)
+		return
+	} else if err != nil {
+		h.Logger.Errorw("fetching webhook secret", zap.String("namespace", namespace), zap.Error(err))
+		http.Error(w, "unable to fetch webhook secret", http.StatusInternalServerError)
+		return
+	}
+
+	if !verify(event.provider, r.Header, body, secret.Data[SecretKey]) {
+		http.Error(w, "invalid webhook signature", http.StatusUnauthorized)
+		return
+	}
+
+	if !event.push {
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+
+	sourceResolvers, err := h.SourceResolverLister.SourceResolvers(namespace).List(labels.Everything())
+	if err != nil {
+		http.Error(w, "unable to list source resolvers", http.StatusInternalServerError)
+		return
+	}
+
+	enqueued := 0
+	for _, sourceResolver := range sourceResolvers {
+		if !sourceResolver.IsGit() {
+			continue
+		}
+
+		if event.matches(sourceResolver.Spec.Source.Git) {
+			h.Enqueue(sourceResolver)
+			enqueued++
+		}
+	}
+
+	h.Logger.Infow("received git push webhook",
+		zap.String("provider", string(event.provider)),
+		zap.String("namespace", namespace),
+		zap.Strings("refs", event.refs),
+		zap.Int("enqueued", enqueued))
+
+	w.WriteHeader(http.StatusAccepted)
+	fmt.Fprintf(w, "enqueued %d source resolvers\n", enqueued)
+}
+
+func verify(p provider, header http.Header, body, secret []byte) bool {
+	if len(secret) == 0 {
+		return false
+	}
+
+	switch p {
+	case gitHub:
+		return validHMAC(strings.TrimPrefix(header.Get("X-Hub-Signature-256"), "sha256="), body, secret)
+	case gitea:
+		return validHMAC(header.Get("X-Gitea-Signature"), body, secret)
+	case bitbucket:
+		return validHMAC(strings.TrimPrefix(header.Get("X-Hub-Signature"), "sha256="), body, secret)
+	case gitLab:
+		// GitLab does not sign payloads, it echoes the configured secret token
+		return subtle.ConstantTimeCompare([]byte(header.Get("X-Gitlab-Token")), secret) == 1
+	default:
+		return false
+	}
+}
+
+func validHMAC(signature string, body, secret []byte) bool {
+	decoded, err := hex.DecodeString(signature)
+	if err != nil || len(decoded) == 0 {
+		return false
+	}
+
+	mac := hmac.New(sha256.New, secret)
+	mac.Write(body)
+	return hmac.Equal(decoded, mac.Sum(nil))
+}
@@ -0,0 +1,310 @@
+package gitwebhook_test
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/sclevine/spec"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	buildapi "github.com/pivotal/kpack/pkg/apis/build/v1alpha2"
+	corev1alpha1 "github.com/pivotal/kpack/pkg/apis/core/v1alpha1"
+	"github.com/pivotal/kpack/pkg/gitwebhook"
+	"github.com/pivotal/kpack/pkg/reconciler/testhelpers"
+)
+
+func TestHandler(t *testing.T) {
+	spec.Run(t, "Git Webhook Handler", testHandler)
+}
+
+func testHandler(t *testing.T, when spec.G, it spec.S) {
+	const (
+		namespace = "some-namespace"
+		secret    = "some-secret"
+	)
+
+	var (
+		enqueued []string
+		handler  *gitwebhook.Handler
+	)
+
+	gitSourceResolver := func(name, url, revision string) *buildapi.SourceResolver {
+		return &buildapi.SourceResolver{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: namespace,
+			},
+			Spec: buildapi.SourceResolverSpec{
+				Source: corev1alpha1.SourceConfig{
+					Git: &corev1alpha1.Git{
+						URL:      url,
+						Revision: revision,
+					},
+				},
+			},
+		}
+	}
+
+	objects := []runtime.Object{
+		&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      gitwebhook.SecretName,
+				Namespace: namespace,
+			},
+			Data: map[string][]byte{
+				gitwebhook.SecretKey: []byte(secret),
+			},
+		},
+		gitSourceResolver("https-main", "https://github.com/some-org/some-repo", "main"),
+		gitSourceResolver("ssh-main", "git@github.com:some-org/some-repo.git", "main"),
+		gitSourceResolver("https-other-branch", "https://github.com/some-org/some-repo", "other"),
+		gitSourceResolver("other-repo", "https://github.com/some-org/other-repo", "main"),
+		gitSourceResolver("tag", "https://github.com/some-org/some-repo", "v1.0.0"),
+		&buildapi.SourceResolver{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "tag-constraint",
+				Namespace: namespace,
+			},
+			Spec: buildapi.SourceResolverSpec{
+				Source: corev1alpha1.SourceConfig{
+					Git: &corev1alpha1.Git{
+						URL:           "https://github.com/some-org/some-repo",
+						TagConstraint: "v1.*",
+					},
+				},
+			},
+		},
+		&buildapi.SourceResolver{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "blob",
+				Namespace: namespace,
+			},
+			Spec: buildapi.SourceResolverSpec{
+				Source: corev1alpha1.SourceConfig{
+					Blob: &corev1alpha1.Blob{URL: "https://github.com/some-org/some-repo"},
+				},
+			},
+		},
+	}
+
+	it.Before(func() {
+		enqueued = nil
+		listers := testhelpers.NewListers(objects)
+		handler = &gitwebhook.Handler{
+			Logger:               zap.NewNop().Sugar(),
+			SecretLister:         listers.GetSecretLister(),
+			SourceResolverLister: listers.GetSourceResolverLister(),
+			Enqueue: func(obj interface{}) {
+				enqueued = append(enqueued, obj.(*buildapi.SourceResolver).Name)
+			},
+		}
+	})
+
+	sign := func(body []byte, key string) string {
+		mac := hmac.New(sha256.New, []byte(key))
+		mac.Write(body)
+		return hex.EncodeToString(mac.Sum(nil))
+	}
+
+	serve := func(path string, body []byte, headers map[string]string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, path, bytes.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		for k, v := range headers {
+			req.Header.Set(k, v)
+		}
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		return rec
+	}
+
+	githubPush := []byte(`{
+  "ref": "refs/heads/main",
+  "repository": {
+    "clone_url": "https://github.com/some-org/some-repo.git",
+    "ssh_url": "git@github.com:some-org/some-repo.git",
+    "html_url": "https://github.com/some-org/some-repo"
+  }
+}`)
+
+	when("GitHub", func() {
+		it("enqueues source resolvers matching the url and branch", func() {
+			rec := serve("/"+namespace, githubPush, map[string]string{
+				"X-GitHub-Event":      "push",
+				"X-Hub-Signature-256": "sha256=" + sign(githubPush, secret),
+			})
+
+			require.Equal(t, http.StatusAccepted, rec.Code)
+			require.ElementsMatch(t, []string{"https-main", "ssh-main"}, enqueued)
+		})
+
+		it("enqueues source resolvers tracking a pushed tag", func() {
+			body := []byte(`{"ref": "refs/tags/v1.0.0", "repository": {"clone_url": "https://github.com/some-org/some-repo.git"}}`)
+			rec := serve("/"+namespace, body, map[string]string{
+				"X-GitHub-Event":      "push",
+				"X-Hub-Signature-256": "sha256=" + sign(body, secret),
+			})
+
+			require.Equal(t, http.StatusAccepted, rec.Code)
+			require.ElementsMatch(t, []string{"tag", "tag-constraint"}, enqueued)
+		})
+
+		it("rejects an invalid signature", func() {
+			rec := serve("/"+namespace, githubPush, map[string]string{
+				"X-GitHub-Event":      "push",
+				"X-Hub-Signature-256": "sha256=" + sign(githubPush, "wrong-secret"),
+			})
+
+			require.Equal(t, http.StatusUnauthorized, rec.Code)
+			require.Empty(t, enqueued)
+		})
+
+		it("rejects a missing signature", func() {
+			rec := serve("/"+namespace, githubPush, map[string]string{
+				"X-GitHub-Event": "push",
+			})
+
+			require.Equal(t, http.StatusUnauthorized, rec.Code)
+			require.Empty(t, enqueued)
+		})
+
+		it("acknowledges non push events without enqueuing", func() {
+			body := []byte(`{"zen": "Keep it logically awesome."}`)
+			rec := serve("/"+namespace, body, map[string]string{
+				"X-GitHub-Event":      "ping",
+				"X-Hub-Signature-256": "sha256=" + sign(body, secret),
+			})
+
+			require.Equal(t, http.StatusNoContent, rec.Code)
+			require.Empty(t, enqueued)
+		})
+	})
+
+	when("Gitea", func() {
+		it("verifies the gitea signature", func() {
+			rec := serve("/"+namespace, githubPush, map[string]string{
+				"X-Gitea-Event":     "push",
+				"X-GitHub-Event":    "push",
+				"X-Gitea-Signature": sign(githubPush, secret),
+			})
+
+			require.Equal(t, http.StatusAccepted, rec.Code)
+			require.ElementsMatch(t, []string{"https-main", "ssh-main"}, enqueued)
+		})
+	})
+
+	when("GitLab", func() {
+		body := []byte(`{
+  "ref": "refs/heads/other",
+  "project": {
+    "git_http_url": "https://github.com/some-org/some-repo.git",
+    "git_ssh_url": "git@github.com:some-org/some-repo.git"
+  }
+}`)
+
+		it("verifies the gitlab token", func() {
+			rec := serve("/"+namespace, body, map[string]string{
+				"X-Gitlab-Event": "Push Hook",
+				"X-Gitlab-Token": secret,
+			})
+
+			require.Equal(t, http.StatusAccepted, rec.Code)
+			require.Equal(t, []string{"https-other-branch"}, enqueued)
+		})
+
+		it("rejects an invalid token", func() {
+			rec := serve("/"+namespace, body, map[string]string{
+				"X-Gitlab-Event": "Push Hook",
+				"X-Gitlab-Token": "wrong-secret",
+			})
+
+			require.Equal(t, http.StatusUnauthorized, rec.Code)
+			require.Empty(t, enqueued)
+		})
+	})
+
+	when("Bitbucket", func() {
+		it("handles bitbucket cloud payloads", func() {
+			body := []byte(`{
+  "push": {"changes": [{"new": {"type": "branch", "name": "main"}}]},
+  "repository": {"links": {"html": {"href": "https://github.com/some-org/some-repo"}}}
+}`)
+			rec := serve("/"+namespace, body, map[string]string{
+				"X-Event-Key":     "repo:push",
+				"X-Hub-Signature": "sha256=" + sign(body, secret),
+			})
+
+			require.Equal(t, http.StatusAccepted, rec.Code)
+			require.ElementsMatch(t, []string{"https-main", "ssh-main"}, enqueued)
+		})
+
+		it("handles bitbucket server payloads", func() {
+			body := []byte(`{
+  "changes": [{"refId": "refs/heads/other"}],
+  "repository": {"links": {"clone": [{"href": "ssh://git@github.com:7999/some-org/some-repo.git", "name": "ssh"}]}}
+}`)
+			rec := serve("/"+namespace, body, map[string]string{
+				"X-Event-Key":     "repo:refs_changed",
+				"X-Hub-Signature": "sha256=" + sign(body, secret),
+			})
+
+			require.Equal(t, http.StatusAccepted, rec.Code)
+			require.Equal(t, []string{"https-other-branch"}, enqueued)
+		})
+	})
+
+	it("rejects requests for namespaces without a webhook secret", func() {
+		rec := serve("/other-namespace", githubPush, map[string]string{
+			"X-GitHub-Event":      "push",
+			"X-Hub-Signature-256": "sha256=" + sign(githubPush, secret),
+		})
+
+		require.Equal(t, http.StatusForbidden, rec.Code)
+		require.Empty(t, enqueued)
+	})
+
+	it("rejects unknown providers", func() {
+		rec := serve("/"+namespace, githubPush, nil)
+
+		require.Equal(t, http.StatusBadRequest, rec.Code)
+	})
+
+	it("rejects payloads that are not json", func() {
+		rec := serve("/"+namespace, []byte("payload=%7B%7D"), map[string]string{
+			"Content-Type":        "application/x-www-form-urlencoded",
+			"X-GitHub-Event":      "push",
+			"X-Hub-Signature-256": "sha256=" + sign([]byte("payload=%7B%7D"), secret),
+		})
+
+		require.Equal(t, http.StatusUnsupportedMediaType, rec.Code)
+		require.Empty(t, enqueued)
+	})
+
+	it("rejects payloads that are too large", func() {
+		req := httptest.NewRequest(http.MethodPost, "/"+namespace, bytes.NewReader(githubPush))
+		req.Header.Set("Content-Type", "application/json; charset=utf-8")
+		req.Header.Set("X-GitHub-Event", "push")
+		req.ContentLength = 26 * 1024 * 1024
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+
+		require.Equal(t, http.StatusRequestEntityTooLarge, rec.Code)
+		require.Empty(t, enqueued)
+	})
+
+	it("rejects non POST requests", func() {
+		req := httptest.NewRequest(http.MethodGet, "/"+namespace, nil)
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+
+		require.Equal(t, http.StatusMethodNotAllowed, rec.Code)
+	})
+}
@@ -0,0 +1,232 @@
+package gitwebhook
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	corev1alpha1 "github.com/pivotal/kpack/pkg/apis/core/v1alpha1"
+)
+
+type provider string
+
+const (
+	gitHub    provider = "GitHub"
+	gitLab    provider = "GitLab"
+	gitea     provider = "Gitea"
+	bitbucket provider = "Bitbucket"
+)
+
+type pushEvent struct {
+	provider provider
+	push     bool
+	urls     []string
+	refs     []string
+}
+
+func parseEvent(header http.Header, body []byte) (pushEvent, error) {
+	switch {
+	case header.Get("X-Gitea-Event") != "":
+		return parseGitHubStyleEvent(gitea, header.Get("X-Gitea-Event") == "push", body)
+	case header.Get("X-GitHub-Event") != "":
+		return parseGitHubStyleEvent(gitHub, header.Get("X-GitHub-Event") == "push", body)
+	case header.Get("X-Gitlab-Event") != "":
+		event := header.Get("X-Gitlab-Event")
+		return parseGitLabEvent(event == "Push Hook" || event == "Tag Push Hook", body)
+	case header.Get("X-Event-Key") != "":
+		event := header.Get("X-Event-Key")
+		return parseBitbucketEvent(event == "repo:push" || event == "repo:refs_changed", body)
+	default:
+		return pushEvent{}, errors.New("unsupported webhook provider")
+	}
+}
+
+func parseGitHubStyleEvent(p provider, push bool, body []byte) (pushEvent, error) {
+	event := pushEvent{provider: p, push: push}
+	if !push {
+		return event, nil
+	}
+
+	var payload struct {
+		Ref        string `json:"ref"`
+		Repository struct {
+			CloneURL string `json:"clone_url"`
+			SSHURL   string `json:"ssh_url"`
+			HTMLURL  string `json:"html_url"`
+			GitURL   string `json:"git_url"`
+		} `json:"repository"`
+	}
+	if err := json.Unmarshal(body, &payload); err != nil {
+		return pushEvent{}, errors.Wrapf(err, "parsing %s push payload", p)
+	}
+
+	event.refs = []string{payload.Ref}
+	event.urls = []string{
+		payload.Repository.CloneURL,
+		payload.Repository.SSHURL,
+		payload.Repository.HTMLURL,
+		payload.Repository.GitURL,
+	}
+	return event, nil
+}
+
+func parseGitLabEvent(push bool, body []byte) (pushEvent, error) {
+	event := pushEvent{provider: gitLab, push: push}
+	if !push {
+		return event, nil
+	}
+
+	var payload struct {
+		Ref     string `json:"ref"`
+		Project struct {
+			GitHTTPURL string `json:"git_http_url"`
+			GitSSHURL  string `json:"git_ssh_url"`
+			WebURL     string `json:"web_url"`
+		} `json:"project"`
+	}
+	if err := json.Unmarshal(body, &payload); err != nil {
+		return pushEvent{}, errors.Wrap(err, "parsing GitLab push payload")
+	}
+
+	event.refs = []string{payload.Ref}
+	event.urls = []string{
+		payload.Project.GitHTTPURL,
+		payload.Project.GitSSHURL,
+		payload.Project.WebURL,
+	}
+	return event, nil
+}
+
+func parseBitbucketEvent(push bool, body []byte) (pushEvent, error) {
+	event := pushEvent{provider: bitbucket, push: push}
+	if !push {
+		return event, nil
+	}
+
+	type link struct {
+		Href string `json:"href"`
+	}
+	var payload struct {
+		// Bitbucket Cloud
+		Push struct {
+			Changes []struct {
+				New *struct {
+					Type string `json:"type"`
+					Name string `json:"name"`
+				} `json:"new"`
+			} `json:"changes"`
+		} `json:"push"`
+		// Bitbucket Server and Data Center
+		Changes []struct {
+			RefID string `json:"refId"`
+		} `json:"changes"`
+		Repository struct {
+			Links struct {
+				HTML  link   `json:"html"`
+				Clone []link `json:"clone"`
+			} `json:"links"`
+		} `json:"repository"`
+	}
+	if err := json.Unmarshal(body, &payload); err != nil {
+		return pushEvent{}, errors.Wrap(err, "parsing Bitbucket push payload")
+	}
+
+	for _, change := range payload.Push.Changes {
+		if change.New == nil {
+			continue
+		}
+		switch change.New.Type {
+		case "branch":
+			event.refs = append(event.refs, "refs/heads/"+change.New.Name)
+		case "tag":
+			event.refs = append(event.refs, "refs/tags/"+change.New.Name)
+		}
+	}
+	for _, change := range payload.Changes {
+		event.refs = append(event.refs, change.RefID)
+	}
+
+	event.urls = append(event.urls, payload.Repository.Links.HTML.Href)
+	for _, clone := range payload.Repository.Links.Clone {
+		event.urls = append(event.urls, clone.Href)
+	}
+	return event, nil
+}
+
+func (e pushEvent) matches(git *corev1alpha1.Git) bool {
+	if !e.matchesURL(git.URL) {
+		return false
+	}
+
+	if git.TagConstraint != "" {
+		return e.pushesTag()
+	}
+	return e.matchesRevision(git.Revision)
+}
+
+func (e pushEvent) matchesURL(gitURL string) bool {
+	normalized := normalizeURL(gitURL)
+	for _, u := range e.urls {
+		if u != "" && normalizeURL(u) == normalized {
+			return true
+		}
+	}
+	return false
+}
+
+func (e pushEvent) matchesRevision(revision string) bool {
+	for _, ref := range e.refs {
+		for _, format := range refRevParseRules {
+			if strings.Replace(format, "%s", revision, 1) == ref {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// pushesTag is true when a tag was pushed, which may be selected by the tag
+// constraint of a source.
+func (e pushEvent) pushesTag() bool {
+	for _, ref := range e.refs {
+		if strings.HasPrefix(ref, "refs/tags/") {
+			return true
+		}
+	}
+	return false
+}
+
+var refRevParseRules = []string{
+	"%s",
+	"refs/%s",
+	"refs/tags/%s",
+	"refs/heads/%s",
+}
+
+// normalizeURL reduces https, ssh and scp-like git urls to host/path so that
+// the urls reported by a provider can be compared to a SourceResolver url.
+func normalizeURL(gitURL string) string {
+	gitURL = strings.ToLower(strings.TrimSpace(gitURL))
+
+	var host, path string
+	if strings.Contains(gitURL, "://") {
+		u, err := url.Parse(gitURL)
+		if err != nil {
+			return gitURL
+		}
+		host, path = u.Hostname(), u.Path
+	} else if i := strings.Index(gitURL, ":"); i > 0 {
+		host, path = gitURL[:i], gitURL[i+1:]
+		if at := strings.LastIndex(host, "@"); at >= 0 {
+			host = host[at+1:]
+		}
+	} else {
+		return gitURL
+	}
+
+	path = strings.TrimSuffix(strings.Trim(path, "/"), ".git")
+	return host + "/" + path
+}
@@ -0,0 +1,202 @@
+package objectstore
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+
+	corev1alpha1 "github.com/pivotal/kpack/pkg/apis/core/v1alpha1"
+)
+
+const (
+	DefaultRegion = "us-east-1"
+
+	versionIDHeader = "X-Amz-Version-Id"
+
+	// unversionedID is reported as the version id of objects in buckets
+	// without versioning.
+	unversionedID = "null"
+)
+
+// StatusError is returned for requests the object store did not answer
+// successfully.
+type StatusError struct {
+	Bucket     string
+	Key        string
+	StatusCode int
+}
+
+func (e *StatusError) Error() string {
+	return fmt.Sprintf("object %s/%s: %d %s", e.Bucket, e.Key, e.StatusCode, http.StatusText(e.StatusCode))
+}
+
+func (e *StatusError) Reason() string {
+	switch e.StatusCode {
+	case http.StatusNotFound:
+		return "ObjectNotFound"
+	case http.StatusUnauthorized, http.StatusForbidden:
+		return "ObjectAccessDenied"
+	case http.StatusPreconditionFailed:
+		return "ObjectChanged"
+	default:
+		return "ObjectStoreError"
+	}
+}
+
+// Temporary reports whether the object store failed to respond or throttled
+// the request. The last resolved version is kept for temporary errors.
+func (e *StatusError) Temporary() bool {
+	return e.StatusCode >= http.StatusInternalServerError ||
+		e.StatusCode == http.StatusTooManyRequests ||
+		e.StatusCode == http.StatusRequestTimeout
+}
+
+// UnreachableError is returned when the object store cannot be reached.
+type UnreachableError struct {
+	err error
+}
+
+func (e *UnreachableError) Error() string {
+	return e.err.Error()
+}
+
+func (e *UnreachableError) Reason() string {
+	return "ObjectStoreUnreachable"
+}
+
+func (e *UnreachableError) Temporary() bool {
+	return true
+}
+
+// Client reads objects from an S3 compatible object store using path style
+// requests. Requests are anonymous when Credentials is nil.
+type Client struct {
+	Endpoint    string
+	Region      string
+	Credentials *Credentials
+	HTTPClient  *http.Client
+
+	now func() time.Time
+}
+
+func NewClient(endpoint, region string, creds *Credentials) (*Client, error) {
+	if region == "" {
+		region = DefaultRegion
+	}
+
+	httpClient := http.DefaultClient
+	if creds != nil && creds.CACert != "" {
+		roots, err := x509.SystemCertPool()
+		if err != nil {
+			roots = x509.NewCertPool()
+		}
+		if !roots.AppendCertsFromPEM([]byte(creds.CACert)) {
+			return nil, errors.New("parsing object store ca.crt: no certificates found")
+		}
+
+		transport := http.DefaultTransport.(*http.Transport).Clone()
+		transport.TLSClientConfig = &tls.Config{RootCAs: roots}
+		httpClient = &http.Client{Transport: transport}
+	}
+
+	return &Client{
+		Endpoint:    endpoint,
+		Region:      region,
+		Credentials: creds,
+		HTTPClient:  httpClient,
+		now:         time.Now,
+	}, nil
+}
+
+// Head returns the version id and ETag of the object.
+func (c *Client) Head(ctx context.Context, bucket, key string) (corev1alpha1.ObjectVersion, error) {
+	resp, err := c.do(ctx, http.MethodHead, bucket, key, corev1alpha1.ObjectVersion{})
+	if err != nil {
+		return corev1alpha1.ObjectVersion{}, err
+	}
+	defer resp.Body.Close()
+
+	version := corev1alpha1.ObjectVersion{
+		VersionID: resp.Header.Get(versionIDHeader),
+		ETag:      resp.Header.Get("ETag"),
+	}
+	if version.VersionID == unversionedID {
+		version.VersionID = ""
+	}
+	return version, nil
+}
+
+// Get returns the contents of the object at version. Objects without a version
+// id must still have the resolved ETag, otherwise the object store fails the
+// request.
+func (c *Client) Get(ctx context.Context, bucket, key string, version corev1alpha1.ObjectVersion) (io.ReadCloser, error) {
+	resp, err := c.do(ctx, http.MethodGet, bucket, key, version)
+	if err != nil {
+		return nil, err
+	}
+	return resp.Body, nil
+}
+
+func (c *Client) do(ctx context.Context, method, bucket, key string, version corev1alpha1.ObjectVersion) (*http.Response, error) {
+	u, err := c.objectURL(bucket, key)
+	if err != nil {
+		return nil, err
+	}
+
+	if version.VersionID != "" {
+		u.RawQuery = "versionId=" + uriEncode(version.VersionID, true)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, u.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if version.VersionID == "" && version.ETag != "" {
+		req.Header.Set("If-Match", version.ETag)
+	}
+
+	if c.Credentials != nil {
+		now := c.now
+		if now == nil {
+			now = time.Now
+		}
+		sign(req, *c.Credentials, c.Region, now())
+	}
+
+	httpClient := c.HTTPClient
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return nil, &UnreachableError{err: err}
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		resp.Body.Close()
+		return nil, &StatusError{Bucket: bucket, Key: key, StatusCode: resp.StatusCode}
+	}
+	return resp, nil
+}
+
+func (c *Client) objectURL(bucket, key string) (*url.URL, error) {
+	u, err := url.Parse(strings.TrimSuffix(c.Endpoint, "/"))
+	if err != nil {
+		return nil, errors.Wrapf(err, "parsing object store endpoint %s", c.Endpoint)
+	}
+
+	objectPath := u.Path + "/" + bucket + "/" + strings.TrimPrefix(key, "/")
+	u.Path = objectPath
+	u.RawPath = uriEncode(objectPath, false)
+	return u, nil
+}
@@ -0,0 +1,131 @@
+package objectstore
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/sclevine/spec"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	corev1alpha1 "github.com/pivotal/kpack/pkg/apis/core/v1alpha1"
+)
+
+func TestClient(t *testing.T) {
+	spec.Run(t, "testClient", testClient)
+}
+
+func testClient(t *testing.T, when spec.G, it spec.S) {
+	var (
+		creds = &Credentials{
+			AccessKeyID:     "some-access-key",
+			SecretAccessKey: "some-secret-key",
+		}
+		store  *fakeObjectStore
+		server *httptest.Server
+		client *Client
+	)
+
+	it.Before(func() {
+		store = newFakeObjectStore(t, creds, true)
+		server = httptest.NewServer(store)
+
+		var err error
+		client, err = NewClient(server.URL, "", creds)
+		require.NoError(t, err)
+	})
+
+	it.After(func() {
+		server.Close()
+	})
+
+	when("#Head", func() {
+		it("returns the version id and etag of the latest object", func() {
+			store.put("some-bucket", "dir/app.tar.gz", []byte("first"))
+			latest := store.put("some-bucket", "dir/app.tar.gz", []byte("second"))
+
+			version, err := client.Head(context.Background(), "some-bucket", "dir/app.tar.gz")
+			require.NoError(t, err)
+
+			assert.Equal(t, corev1alpha1.ObjectVersion{VersionID: "version-2", ETag: latest.etag}, version)
+			assert.Equal(t, http.MethodHead, store.requests[0].Method)
+		})
+
+		it("omits the version id of objects in unversioned buckets", func() {
+			store.versioned = false
+			object := store.put("some-bucket", "app.zip", []byte("contents"))
+
+			version, err := client.Head(context.Background(), "some-bucket", "app.zip")
+			require.NoError(t, err)
+
+			assert.Equal(t, corev1alpha1.ObjectVersion{ETag: object.etag}, version)
+		})
+
+		it("signs keys that need to be escaped", func() {
+			store.put("some-bucket", "my app+v1.zip", []byte("contents"))
+
+			_, err := client.Head(context.Background(), "some-bucket", "my app+v1.zip")
+			require.NoError(t, err)
+
+			assert.Equal(t, "/some-bucket/my%20app%2Bv1.zip", store.requests[0].URL.EscapedPath())
+		})
+
+		it("returns a status error with a reason when the object does not exist", func() {
+			_, err := client.Head(context.Background(), "some-bucket", "missing.zip")
+			require.EqualError(t, err, "object some-bucket/missing.zip: 404 Not Found")
+
+			var statusErr *StatusError
+			require.True(t, errors.As(err, &statusErr))
+			assert.Equal(t, "ObjectNotFound", statusErr.Reason())
+		})
+
+		it("is denied with the wrong credentials", func() {
+			store.put("some-bucket", "app.zip", []byte("contents"))
+			client.Credentials = &Credentials{AccessKeyID: "some-access-key", SecretAccessKey: "wrong"}
+
+			_, err := client.Head(context.Background(), "some-bucket", "app.zip")
+			require.EqualError(t, err, "object some-bucket/app.zip: 403 Forbidden")
+			assert.Equal(t, "ObjectAccessDenied", err.(*StatusError).Reason())
+		})
+
+		it("sends anonymous requests without credentials", func() {
+			store.credentials = nil
+			store.put("some-bucket", "app.zip", []byte("contents"))
+			client.Credentials = nil
+
+			_, err := client.Head(context.Background(), "some-bucket", "app.zip")
+			require.NoError(t, err)
+			assert.Empty(t, store.requests[0].Header.Get("Authorization"))
+		})
+	})
+
+	when("#Get", func() {
+		it("gets the requested version", func() {
+			first := store.put("some-bucket", "app.zip", []byte("first"))
+			store.put("some-bucket", "app.zip", []byte("second"))
+
+			body, err := client.Get(context.Background(), "some-bucket", "app.zip", corev1alpha1.ObjectVersion{VersionID: first.versionID, ETag: first.etag})
+			require.NoError(t, err)
+			defer body.Close()
+
+			contents, err := ioutil.ReadAll(body)
+			require.NoError(t, err)
+			assert.Equal(t, "first", string(contents))
+			assert.Equal(t, "versionId=version-1", store.requests[0].URL.RawQuery)
+		})
+
+		it("fails when an unversioned object changed since it was resolved", func() {
+			store.versioned = false
+			resolved := store.put("some-bucket", "app.zip", []byte("first"))
+			store.put("some-bucket", "app.zip", []byte("second"))
+
+			_, err := client.Get(context.Background(), "some-bucket", "app.zip", corev1alpha1.ObjectVersion{ETag: resolved.etag})
+			require.EqualError(t, err, "object some-bucket/app.zip: 412 Precondition Failed")
+			assert.Equal(t, "ObjectChanged", err.(*StatusError).Reason())
+		})
+	})
+}
@@ -0,0 +1,71 @@
+package objectstore
+
+import (
+	"path/filepath"
+	"strings"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/pivotal/kpack/pkg/secret"
+)
+
+// Credentials are the access keys requests to the object store are signed
+// with, and the certificate authority the endpoint is trusted with.
+type Credentials struct {
+	AccessKeyID     string
+	SecretAccessKey string
+	SessionToken    string
+	CACert          string
+}
+
+func CredentialsFromSecret(s *corev1.Secret) (Credentials, error) {
+	creds := Credentials{
+		AccessKeyID:     strings.TrimSpace(string(s.Data[secret.ObjectStoreAccessKeyIDKey])),
+		SecretAccessKey: strings.TrimSpace(string(s.Data[secret.ObjectStoreSecretAccessKeyKey])),
+		SessionToken:    strings.TrimSpace(string(s.Data[secret.ObjectStoreSessionTokenKey])),
+		CACert:          string(s.Data[secret.CACertKey]),
+	}
+
+	if creds.AccessKeyID == "" || creds.SecretAccessKey == "" {
+		return Credentials{}, errors.Errorf("secret %s must contain %s and %s", s.Name, secret.ObjectStoreAccessKeyIDKey, secret.ObjectStoreSecretAccessKeyKey)
+	}
+	return creds, nil
+}
+
+// ReadMountedCredentials reads the credentials of the secret mounted at dir.
+// It returns nil when no secret is mounted and the object is read anonymously.
+func ReadMountedCredentials(dir string) (*Credentials, error) {
+	accessKeyID, err := secret.ReadOptionalFile(filepath.Join(dir, secret.ObjectStoreAccessKeyIDKey))
+	if err != nil {
+		return nil, err
+	}
+	if accessKeyID == "" {
+		return nil, nil
+	}
+
+	secretAccessKey, err := secret.ReadOptionalFile(filepath.Join(dir, secret.ObjectStoreSecretAccessKeyKey))
+	if err != nil {
+		return nil, err
+	}
+	if secretAccessKey == "" {
+		return nil, errors.Errorf("object store secret is missing %s", secret.ObjectStoreSecretAccessKeyKey)
+	}
+
+	sessionToken, err := secret.ReadOptionalFile(filepath.Join(dir, secret.ObjectStoreSessionTokenKey))
+	if err != nil {
+		return nil, err
+	}
+
+	caCert, err := secret.ReadOptionalFile(filepath.Join(dir, secret.CACertKey))
+	if err != nil {
+		return nil, err
+	}
+
+	return &Credentials{
+		AccessKeyID:     strings.TrimSpace(accessKeyID),
+		SecretAccessKey: strings.TrimSpace(secretAccessKey),
+		SessionToken:    strings.TrimSpace(sessionToken),
+		CACert:          caCert,
+	}, nil
+}
@@ -0,0 +1,149 @@
+package objectstore
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"crypto/md5"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+// fakeObjectStore is a minimal stand-in for a MinIO server. It verifies the
+// signature of every request when credentials are configured.
+type fakeObjectStore struct {
+	t           *testing.T
+	credentials *Credentials
+	versioned   bool
+	objects     map[string][]fakeObject
+	requests    []*http.Request
+	// status fails every request with the status code when set
+	status int
+}
+
+type fakeObject struct {
+	versionID string
+	etag      string
+	content   []byte
+}
+
+func newFakeObjectStore(t *testing.T, credentials *Credentials, versioned bool) *fakeObjectStore {
+	return &fakeObjectStore{
+		t:           t,
+		credentials: credentials,
+		versioned:   versioned,
+		objects:     map[string][]fakeObject{},
+	}
+}
+
+func (s *fakeObjectStore) put(bucket, key string, content []byte) fakeObject {
+	sum := md5.Sum(content)
+	path := bucket + "/" + key
+
+	object := fakeObject{
+		versionID: "null",
+		etag:      `"` + hex.EncodeToString(sum[:]) + `"`,
+		content:   content,
+	}
+	if s.versioned {
+		object.versionID = fmt.Sprintf("version-%d", len(s.objects[path])+1)
+		s.objects[path] = append(s.objects[path], object)
+	} else {
+		s.objects[path] = []fakeObject{object}
+	}
+	return object
+}
+
+func (s *fakeObjectStore) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.requests = append(s.requests, r)
+
+	if s.status != 0 {
+		w.WriteHeader(s.status)
+		return
+	}
+
+	if !s.authorized(r) {
+		w.WriteHeader(http.StatusForbidden)
+		return
+	}
+
+	versions := s.objects[strings.TrimPrefix(r.URL.Path, "/")]
+	if len(versions) == 0 {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	object := versions[len(versions)-1]
+	if versionID := r.URL.Query().Get("versionId"); versionID != "" {
+		found := false
+		for _, v := range versions {
+			if v.versionID == versionID {
+				object, found = v, true
+			}
+		}
+		if !found {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+	}
+
+	if ifMatch := r.Header.Get("If-Match"); ifMatch != "" && ifMatch != object.etag {
+		w.WriteHeader(http.StatusPreconditionFailed)
+		return
+	}
+
+	w.Header().Set("ETag", object.etag)
+	w.Header().Set("X-Amz-Version-Id", object.versionID)
+	w.Header().Set("Content-Length", fmt.Sprint(len(object.content)))
+	w.WriteHeader(http.StatusOK)
+	if r.Method == http.MethodGet {
+		_, _ = w.Write(object.content)
+	}
+}
+
+func (s *fakeObjectStore) authorized(r *http.Request) bool {
+	if s.credentials == nil {
+		return true
+	}
+
+	signedAt, err := time.Parse(amzDateFormat, r.Header.Get("X-Amz-Date"))
+	if err != nil {
+		return false
+	}
+
+	expected, err := http.NewRequest(r.Method, "http://"+r.Host+r.URL.RequestURI(), nil)
+	if err != nil {
+		s.t.Fatal(err)
+	}
+	if rangeHeader := r.Header.Get("Range"); rangeHeader != "" {
+		expected.Header.Set("Range", rangeHeader)
+	}
+	sign(expected, *s.credentials, DefaultRegion, signedAt)
+
+	return r.Header.Get("Authorization") == expected.Header.Get("Authorization")
+}
+
+func tarGz(t *testing.T, files map[string]string) []byte {
+	buf := &bytes.Buffer{}
+	gzw := gzip.NewWriter(buf)
+	tw := tar.NewWriter(gzw)
+	for name, contents := range files {
+		if err := tw.WriteHeader(&tar.Header{Name: name, Mode: 0644, Size: int64(len(contents)), Typeflag: tar.TypeReg}); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := tw.Write([]byte(contents)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := tw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err := gzw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
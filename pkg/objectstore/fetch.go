@@ -0,0 +1,69 @@
+package objectstore
+
+import (
+	"context"
+	"io"
+	"io/ioutil"
+	"log"
+	"os"
+
+	"github.com/pkg/errors"
+
+	corev1alpha1 "github.com/pivotal/kpack/pkg/apis/core/v1alpha1"
+	"github.com/pivotal/kpack/pkg/archive"
+)
+
+var unexpectedObjectTypeError = errors.New("unexpected object file type, must be one of .zip, .jar, .tar, .tar.gz, .tar.xz, .tar.bz2, .tar.zst")
+
+type Fetcher struct {
+	Logger *log.Logger
+	Client *Client
+	Limits archive.Limits
+}
+
+// Fetch downloads the resolved version of the object and extracts it into dir.
+func (f *Fetcher) Fetch(dir, bucket, key string, version corev1alpha1.ObjectVersion) error {
+	f.Logger.Printf("Downloading %s/%s from %s...", bucket, key, f.Client.Endpoint)
+
+	file, err := f.downloadObject(bucket, key, version)
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(file.Name())
+
+	err = archive.Extract(file, dir, f.Limits)
+	if err == archive.ErrUnsupportedArchive {
+		return unexpectedObjectTypeError
+	} else if err != nil {
+		return err
+	}
+
+	f.Logger.Printf("Successfully downloaded %s/%s in path %q", bucket, key, dir)
+
+	return nil
+}
+
+func (f *Fetcher) downloadObject(bucket, key string, version corev1alpha1.ObjectVersion) (*os.File, error) {
+	body, err := f.Client.Get(context.Background(), bucket, key, version)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get object")
+	}
+	defer body.Close()
+
+	file, err := ioutil.TempFile("", "")
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = io.Copy(file, body)
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = file.Seek(0, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	return file, nil
+}
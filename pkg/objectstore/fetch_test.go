@@ -0,0 +1,94 @@
+package objectstore
+
+import (
+	"bytes"
+	"io/ioutil"
+	"log"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/sclevine/spec"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	corev1alpha1 "github.com/pivotal/kpack/pkg/apis/core/v1alpha1"
+)
+
+func TestFetcher(t *testing.T) {
+	spec.Run(t, "testFetcher", testFetcher)
+}
+
+func testFetcher(t *testing.T, when spec.G, it spec.S) {
+	var (
+		store   *fakeObjectStore
+		server  *httptest.Server
+		output  = &bytes.Buffer{}
+		fetcher *Fetcher
+		dir     string
+	)
+
+	it.Before(func() {
+		var err error
+		dir, err = ioutil.TempDir("", "objectstore-fetch")
+		require.NoError(t, err)
+
+		secretDir, err := ioutil.TempDir(dir, "secret")
+		require.NoError(t, err)
+		require.NoError(t, ioutil.WriteFile(filepath.Join(secretDir, "accessKeyId"), []byte("some-access-key"), 0600))
+		require.NoError(t, ioutil.WriteFile(filepath.Join(secretDir, "secretAccessKey"), []byte("some-secret-key"), 0600))
+
+		creds, err := ReadMountedCredentials(secretDir)
+		require.NoError(t, err)
+		require.NotNil(t, creds)
+
+		store = newFakeObjectStore(t, creds, true)
+		server = httptest.NewServer(store)
+
+		client, err := NewClient(server.URL, "", creds)
+		require.NoError(t, err)
+
+		fetcher = &Fetcher{
+			Logger: log.New(output, "", 0),
+			Client: client,
+		}
+	})
+
+	it.After(func() {
+		server.Close()
+		require.NoError(t, os.RemoveAll(dir))
+	})
+
+	it("downloads and extracts the resolved version of the object", func() {
+		resolved := store.put("some-bucket", "app.tar.gz", tarGz(t, map[string]string{"testdir/testfile": "resolved contents"}))
+		store.put("some-bucket", "app.tar.gz", tarGz(t, map[string]string{"testdir/testfile": "newer contents"}))
+
+		appDir := filepath.Join(dir, "app")
+		err := fetcher.Fetch(appDir, "some-bucket", "app.tar.gz", corev1alpha1.ObjectVersion{VersionID: resolved.versionID, ETag: resolved.etag})
+		require.NoError(t, err)
+
+		contents, err := ioutil.ReadFile(filepath.Join(appDir, "testdir", "testfile"))
+		require.NoError(t, err)
+		assert.Equal(t, "resolved contents", string(contents))
+		assert.Contains(t, output.String(), "Successfully downloaded some-bucket/app.tar.gz")
+	})
+
+	it("errors when the object is not an archive", func() {
+		object := store.put("some-bucket", "app.txt", []byte("not an archive"))
+
+		err := fetcher.Fetch(dir, "some-bucket", "app.txt", corev1alpha1.ObjectVersion{VersionID: object.versionID})
+		require.EqualError(t, err, "unexpected object file type, must be one of .zip, .jar, .tar, .tar.gz, .tar.xz, .tar.bz2, .tar.zst")
+	})
+
+	it("errors when the object cannot be downloaded", func() {
+		err := fetcher.Fetch(dir, "some-bucket", "missing.tar.gz", corev1alpha1.ObjectVersion{})
+		require.EqualError(t, err, "failed to get object: object some-bucket/missing.tar.gz: 404 Not Found")
+	})
+
+	it("reads no credentials when no secret is mounted", func() {
+		creds, err := ReadMountedCredentials(filepath.Join(dir, "empty"))
+		require.NoError(t, err)
+		assert.Nil(t, creds)
+	})
+}
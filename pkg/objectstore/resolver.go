@@ -0,0 +1,73 @@
+package objectstore
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8sclient "k8s.io/client-go/kubernetes"
+
+	buildapi "github.com/pivotal/kpack/pkg/apis/build/v1alpha2"
+	corev1alpha1 "github.com/pivotal/kpack/pkg/apis/core/v1alpha1"
+)
+
+type Resolver struct {
+	K8sClient k8sclient.Interface
+}
+
+func NewResolver(k8sClient k8sclient.Interface) *Resolver {
+	return &Resolver{K8sClient: k8sClient}
+}
+
+func (r *Resolver) Resolve(ctx context.Context, sourceResolver *buildapi.SourceResolver) (corev1alpha1.ResolvedSourceConfig, error) {
+	objectStore := sourceResolver.Spec.Source.ObjectStore
+
+	creds, err := r.credentials(ctx, sourceResolver.Namespace, objectStore.SecretRef)
+	if err != nil {
+		return corev1alpha1.ResolvedSourceConfig{}, err
+	}
+
+	client, err := NewClient(objectStore.Endpoint, objectStore.Region, creds)
+	if err != nil {
+		return corev1alpha1.ResolvedSourceConfig{}, err
+	}
+
+	version, err := client.Head(ctx, objectStore.Bucket, objectStore.Key)
+	if err != nil {
+		return corev1alpha1.ResolvedSourceConfig{}, err
+	}
+
+	return corev1alpha1.ResolvedSourceConfig{
+		ObjectStore: &corev1alpha1.ResolvedObjectStoreSource{
+			Endpoint:  objectStore.Endpoint,
+			Region:    objectStore.Region,
+			Bucket:    objectStore.Bucket,
+			Key:       objectStore.Key,
+			SecretRef: objectStore.SecretRef,
+			Version:   version,
+			SubPath:   sourceResolver.Spec.Source.SubPath,
+		},
+	}, nil
+}
+
+func (*Resolver) CanResolve(sourceResolver *buildapi.SourceResolver) bool {
+	return sourceResolver.IsObjectStore()
+}
+
+func (r *Resolver) credentials(ctx context.Context, namespace string, secretRef *corev1.LocalObjectReference) (*Credentials, error) {
+	if secretRef == nil || secretRef.Name == "" {
+		return nil, nil
+	}
+
+	secret, err := r.K8sClient.CoreV1().Secrets(namespace).Get(ctx, secretRef.Name, metav1.GetOptions{})
+	if err != nil {
+		return nil, errors.Wrapf(err, "getting object store secret %s", secretRef.Name)
+	}
+
+	creds, err := CredentialsFromSecret(secret)
+	if err != nil {
+		return nil, err
+	}
+	return &creds, nil
+}
@@ -0,0 +1,169 @@
+package objectstore
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/sclevine/spec"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+
+	buildapi "github.com/pivotal/kpack/pkg/apis/build/v1alpha2"
+	corev1alpha1 "github.com/pivotal/kpack/pkg/apis/core/v1alpha1"
+)
+
+func TestResolver(t *testing.T) {
+	spec.Run(t, "testResolver", testResolver)
+}
+
+func testResolver(t *testing.T, when spec.G, it spec.S) {
+	var (
+		store = newFakeObjectStore(t, &Credentials{
+			AccessKeyID:     "some-access-key",
+			SecretAccessKey: "some-secret-key",
+		}, true)
+		server    = httptest.NewServer(store)
+		k8sClient = fake.NewSimpleClientset(&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "minio-creds",
+				Namespace: "some-namespace",
+			},
+			Data: map[string][]byte{
+				"accessKeyId":     []byte("some-access-key"),
+				"secretAccessKey": []byte("some-secret-key\n"),
+			},
+		})
+		resolver = NewResolver(k8sClient)
+	)
+
+	it.After(func() {
+		server.Close()
+	})
+
+	sourceResolver := func(secretName string) *buildapi.SourceResolver {
+		return &buildapi.SourceResolver{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "some-source-resolver",
+				Namespace: "some-namespace",
+			},
+			Spec: buildapi.SourceResolverSpec{
+				Source: corev1alpha1.SourceConfig{
+					ObjectStore: &corev1alpha1.ObjectStore{
+						Endpoint:  server.URL,
+						Bucket:    "some-bucket",
+						Key:       "app.tar.gz",
+						SecretRef: &corev1.LocalObjectReference{Name: secretName},
+					},
+					SubPath: "some-path",
+				},
+			},
+		}
+	}
+
+	when("#Resolve", func() {
+		it("resolves the version of the object with the referenced secret", func() {
+			object := store.put("some-bucket", "app.tar.gz", []byte("contents"))
+
+			resolved, err := resolver.Resolve(context.Background(), sourceResolver("minio-creds"))
+			require.NoError(t, err)
+
+			assert.Equal(t, corev1alpha1.ResolvedSourceConfig{
+				ObjectStore: &corev1alpha1.ResolvedObjectStoreSource{
+					Endpoint:  server.URL,
+					Bucket:    "some-bucket",
+					Key:       "app.tar.gz",
+					SecretRef: &corev1.LocalObjectReference{Name: "minio-creds"},
+					Version: corev1alpha1.ObjectVersion{
+						VersionID: "version-1",
+						ETag:      object.etag,
+					},
+					SubPath: "some-path",
+				},
+			}, resolved)
+			assert.True(t, resolved.ObjectStore.IsPollable())
+		})
+
+		it("returns a condition error when the object does not exist", func() {
+			_, err := resolver.Resolve(context.Background(), sourceResolver("minio-creds"))
+
+			var conditionErr interface{ Reason() string }
+			require.True(t, errors.As(err, &conditionErr))
+			assert.Equal(t, "ObjectNotFound", conditionErr.Reason())
+		})
+
+		it("returns a permanent error when access to the object is denied", func() {
+			store.put("some-bucket", "app.tar.gz", []byte("contents"))
+			store.status = http.StatusForbidden
+
+			_, err := resolver.Resolve(context.Background(), sourceResolver("minio-creds"))
+
+			var statusErr *StatusError
+			require.True(t, errors.As(err, &statusErr))
+			assert.Equal(t, "ObjectAccessDenied", statusErr.Reason())
+			assert.False(t, statusErr.Temporary())
+		})
+
+		it("returns a temporary error when the object store is unavailable", func() {
+			store.status = http.StatusServiceUnavailable
+
+			_, err := resolver.Resolve(context.Background(), sourceResolver("minio-creds"))
+
+			var statusErr *StatusError
+			require.True(t, errors.As(err, &statusErr))
+			assert.Equal(t, "ObjectStoreError", statusErr.Reason())
+			assert.True(t, statusErr.Temporary())
+		})
+
+		it("returns a temporary error when the object store throttles requests", func() {
+			store.status = http.StatusTooManyRequests
+
+			_, err := resolver.Resolve(context.Background(), sourceResolver("minio-creds"))
+
+			var statusErr *StatusError
+			require.True(t, errors.As(err, &statusErr))
+			assert.True(t, statusErr.Temporary())
+		})
+
+		it("returns a temporary error when the object store cannot be reached", func() {
+			server.Close()
+
+			_, err := resolver.Resolve(context.Background(), sourceResolver("minio-creds"))
+
+			var unreachableErr *UnreachableError
+			require.True(t, errors.As(err, &unreachableErr))
+			assert.Equal(t, "ObjectStoreUnreachable", unreachableErr.Reason())
+			assert.True(t, unreachableErr.Temporary())
+		})
+
+		it("returns an error when the secret does not exist", func() {
+			_, err := resolver.Resolve(context.Background(), sourceResolver("missing"))
+			require.EqualError(t, err, `getting object store secret missing: secrets "missing" not found`)
+		})
+
+		it("returns an error when the secret has no access keys", func() {
+			_, err := k8sClient.CoreV1().Secrets("some-namespace").Create(context.Background(), &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "empty",
+					Namespace: "some-namespace",
+				},
+			}, metav1.CreateOptions{})
+			require.NoError(t, err)
+
+			_, err = resolver.Resolve(context.Background(), sourceResolver("empty"))
+			require.EqualError(t, err, "secret empty must contain accessKeyId and secretAccessKey")
+		})
+	})
+
+	when("#CanResolve", func() {
+		it("resolves object store sources", func() {
+			assert.True(t, resolver.CanResolve(sourceResolver("minio-creds")))
+			assert.False(t, resolver.CanResolve(&buildapi.SourceResolver{}))
+		})
+	})
+}
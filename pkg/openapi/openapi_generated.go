@@ -63,14 +63,22 @@ func GetOpenAPIDefinitions(ref common.ReferenceCallback) map[string]common.OpenA
 		"github.com/pivotal/kpack/pkg/apis/build/v1alpha1.SourceResolverList":         schema_pkg_apis_build_v1alpha1_SourceResolverList(ref),
 		"github.com/pivotal/kpack/pkg/apis/build/v1alpha1.SourceResolverSpec":         schema_pkg_apis_build_v1alpha1_SourceResolverSpec(ref),
 		"github.com/pivotal/kpack/pkg/apis/build/v1alpha1.SourceResolverStatus":       schema_pkg_apis_build_v1alpha1_SourceResolverStatus(ref),
+		"github.com/pivotal/kpack/pkg/apis/build/v1alpha2.BranchProvider":             schema_pkg_apis_build_v1alpha2_BranchProvider(ref),
 		"github.com/pivotal/kpack/pkg/apis/build/v1alpha2.Build":                      schema_pkg_apis_build_v1alpha2_Build(ref),
 		"github.com/pivotal/kpack/pkg/apis/build/v1alpha2.BuildCache":                 schema_pkg_apis_build_v1alpha2_BuildCache(ref),
 		"github.com/pivotal/kpack/pkg/apis/build/v1alpha2.BuildCacheConfig":           schema_pkg_apis_build_v1alpha2_BuildCacheConfig(ref),
 		"github.com/pivotal/kpack/pkg/apis/build/v1alpha2.BuildList":                  schema_pkg_apis_build_v1alpha2_BuildList(ref),
 		"github.com/pivotal/kpack/pkg/apis/build/v1alpha2.BuildPersistentVolumeCache": schema_pkg_apis_build_v1alpha2_BuildPersistentVolumeCache(ref),
+		"github.com/pivotal/kpack/pkg/apis/build/v1alpha2.BuildPromotionStatus":       schema_pkg_apis_build_v1alpha2_BuildPromotionStatus(ref),
+		"github.com/pivotal/kpack/pkg/apis/build/v1alpha2.BuildRequest":               schema_pkg_apis_build_v1alpha2_BuildRequest(ref),
+		"github.com/pivotal/kpack/pkg/apis/build/v1alpha2.BuildRequestList":           schema_pkg_apis_build_v1alpha2_BuildRequestList(ref),
+		"github.com/pivotal/kpack/pkg/apis/build/v1alpha2.BuildRequestSpec":           schema_pkg_apis_build_v1alpha2_BuildRequestSpec(ref),
+		"github.com/pivotal/kpack/pkg/apis/build/v1alpha2.BuildRequestStatus":         schema_pkg_apis_build_v1alpha2_BuildRequestStatus(ref),
+		"github.com/pivotal/kpack/pkg/apis/build/v1alpha2.BuildResolvedSource":        schema_pkg_apis_build_v1alpha2_BuildResolvedSource(ref),
 		"github.com/pivotal/kpack/pkg/apis/build/v1alpha2.BuildSpec":                  schema_pkg_apis_build_v1alpha2_BuildSpec(ref),
 		"github.com/pivotal/kpack/pkg/apis/build/v1alpha2.BuildStack":                 schema_pkg_apis_build_v1alpha2_BuildStack(ref),
 		"github.com/pivotal/kpack/pkg/apis/build/v1alpha2.BuildStatus":                schema_pkg_apis_build_v1alpha2_BuildStatus(ref),
+		"github.com/pivotal/kpack/pkg/apis/build/v1alpha2.BuildStepStatus":            schema_pkg_apis_build_v1alpha2_BuildStepStatus(ref),
 		"github.com/pivotal/kpack/pkg/apis/build/v1alpha2.Builder":                    schema_pkg_apis_build_v1alpha2_Builder(ref),
 		"github.com/pivotal/kpack/pkg/apis/build/v1alpha2.BuilderList":                schema_pkg_apis_build_v1alpha2_BuilderList(ref),
 		"github.com/pivotal/kpack/pkg/apis/build/v1alpha2.BuilderSpec":                schema_pkg_apis_build_v1alpha2_BuilderSpec(ref),
@@ -96,10 +104,29 @@ func GetOpenAPIDefinitions(ref common.ReferenceCallback) map[string]common.OpenA
 		"github.com/pivotal/kpack/pkg/apis/build/v1alpha2.ImageCacheConfig":           schema_pkg_apis_build_v1alpha2_ImageCacheConfig(ref),
 		"github.com/pivotal/kpack/pkg/apis/build/v1alpha2.ImageList":                  schema_pkg_apis_build_v1alpha2_ImageList(ref),
 		"github.com/pivotal/kpack/pkg/apis/build/v1alpha2.ImagePersistentVolumeCache": schema_pkg_apis_build_v1alpha2_ImagePersistentVolumeCache(ref),
+		"github.com/pivotal/kpack/pkg/apis/build/v1alpha2.ImagePromotion":             schema_pkg_apis_build_v1alpha2_ImagePromotion(ref),
+		"github.com/pivotal/kpack/pkg/apis/build/v1alpha2.ImageRetryPolicy":           schema_pkg_apis_build_v1alpha2_ImageRetryPolicy(ref),
+		"github.com/pivotal/kpack/pkg/apis/build/v1alpha2.ImageRollback":              schema_pkg_apis_build_v1alpha2_ImageRollback(ref),
+		"github.com/pivotal/kpack/pkg/apis/build/v1alpha2.ImageRollbackStatus":        schema_pkg_apis_build_v1alpha2_ImageRollbackStatus(ref),
+		"github.com/pivotal/kpack/pkg/apis/build/v1alpha2.ImageSchedule":              schema_pkg_apis_build_v1alpha2_ImageSchedule(ref),
+		"github.com/pivotal/kpack/pkg/apis/build/v1alpha2.ImageSet":                   schema_pkg_apis_build_v1alpha2_ImageSet(ref),
+		"github.com/pivotal/kpack/pkg/apis/build/v1alpha2.ImageSetDiscovery":          schema_pkg_apis_build_v1alpha2_ImageSetDiscovery(ref),
+		"github.com/pivotal/kpack/pkg/apis/build/v1alpha2.ImageSetImageStatus":        schema_pkg_apis_build_v1alpha2_ImageSetImageStatus(ref),
+		"github.com/pivotal/kpack/pkg/apis/build/v1alpha2.ImageSetList":               schema_pkg_apis_build_v1alpha2_ImageSetList(ref),
+		"github.com/pivotal/kpack/pkg/apis/build/v1alpha2.ImageSetParameters":         schema_pkg_apis_build_v1alpha2_ImageSetParameters(ref),
+		"github.com/pivotal/kpack/pkg/apis/build/v1alpha2.ImageSetSpec":               schema_pkg_apis_build_v1alpha2_ImageSetSpec(ref),
+		"github.com/pivotal/kpack/pkg/apis/build/v1alpha2.ImageSetStatus":             schema_pkg_apis_build_v1alpha2_ImageSetStatus(ref),
 		"github.com/pivotal/kpack/pkg/apis/build/v1alpha2.ImageSpec":                  schema_pkg_apis_build_v1alpha2_ImageSpec(ref),
 		"github.com/pivotal/kpack/pkg/apis/build/v1alpha2.ImageStatus":                schema_pkg_apis_build_v1alpha2_ImageStatus(ref),
 		"github.com/pivotal/kpack/pkg/apis/build/v1alpha2.LastBuild":                  schema_pkg_apis_build_v1alpha2_LastBuild(ref),
 		"github.com/pivotal/kpack/pkg/apis/build/v1alpha2.NamespacedBuilderSpec":      schema_pkg_apis_build_v1alpha2_NamespacedBuilderSpec(ref),
+		"github.com/pivotal/kpack/pkg/apis/build/v1alpha2.Preview":                    schema_pkg_apis_build_v1alpha2_Preview(ref),
+		"github.com/pivotal/kpack/pkg/apis/build/v1alpha2.PreviewImageSet":            schema_pkg_apis_build_v1alpha2_PreviewImageSet(ref),
+		"github.com/pivotal/kpack/pkg/apis/build/v1alpha2.PreviewImageSetList":        schema_pkg_apis_build_v1alpha2_PreviewImageSetList(ref),
+		"github.com/pivotal/kpack/pkg/apis/build/v1alpha2.PreviewImageSetSpec":        schema_pkg_apis_build_v1alpha2_PreviewImageSetSpec(ref),
+		"github.com/pivotal/kpack/pkg/apis/build/v1alpha2.PreviewImageSetStatus":      schema_pkg_apis_build_v1alpha2_PreviewImageSetStatus(ref),
+		"github.com/pivotal/kpack/pkg/apis/build/v1alpha2.PreviewProvider":            schema_pkg_apis_build_v1alpha2_PreviewProvider(ref),
+		"github.com/pivotal/kpack/pkg/apis/build/v1alpha2.PullRequestProvider":        schema_pkg_apis_build_v1alpha2_PullRequestProvider(ref),
 		"github.com/pivotal/kpack/pkg/apis/build/v1alpha2.RegistryCache":              schema_pkg_apis_build_v1alpha2_RegistryCache(ref),
 		"github.com/pivotal/kpack/pkg/apis/build/v1alpha2.ResolvedClusterStack":       schema_pkg_apis_build_v1alpha2_ResolvedClusterStack(ref),
 		"github.com/pivotal/kpack/pkg/apis/build/v1alpha2.SourceResolver":             schema_pkg_apis_build_v1alpha2_SourceResolver(ref),
@@ -107,6 +134,7 @@ func GetOpenAPIDefinitions(ref common.ReferenceCallback) map[string]common.OpenA
 		"github.com/pivotal/kpack/pkg/apis/build/v1alpha2.SourceResolverSpec":         schema_pkg_apis_build_v1alpha2_SourceResolverSpec(ref),
 		"github.com/pivotal/kpack/pkg/apis/build/v1alpha2.SourceResolverStatus":       schema_pkg_apis_build_v1alpha2_SourceResolverStatus(ref),
 		"github.com/pivotal/kpack/pkg/apis/core/v1alpha1.Blob":                        schema_pkg_apis_core_v1alpha1_Blob(ref),
+		"github.com/pivotal/kpack/pkg/apis/core/v1alpha1.BlobFingerprint":             schema_pkg_apis_core_v1alpha1_BlobFingerprint(ref),
 		"github.com/pivotal/kpack/pkg/apis/core/v1alpha1.BuildBuilderSpec":            schema_pkg_apis_core_v1alpha1_BuildBuilderSpec(ref),
 		"github.com/pivotal/kpack/pkg/apis/core/v1alpha1.BuildStack":                  schema_pkg_apis_core_v1alpha1_BuildStack(ref),
 		"github.com/pivotal/kpack/pkg/apis/core/v1alpha1.BuildpackInfo":               schema_pkg_apis_core_v1alpha1_BuildpackInfo(ref),
@@ -117,13 +145,17 @@ func GetOpenAPIDefinitions(ref common.ReferenceCallback) map[string]common.OpenA
 		"github.com/pivotal/kpack/pkg/apis/core/v1alpha1.CNBBinding":                  schema_pkg_apis_core_v1alpha1_CNBBinding(ref),
 		"github.com/pivotal/kpack/pkg/apis/core/v1alpha1.Condition":                   schema_pkg_apis_core_v1alpha1_Condition(ref),
 		"github.com/pivotal/kpack/pkg/apis/core/v1alpha1.Git":                         schema_pkg_apis_core_v1alpha1_Git(ref),
+		"github.com/pivotal/kpack/pkg/apis/core/v1alpha1.GitFetchOptions":             schema_pkg_apis_core_v1alpha1_GitFetchOptions(ref),
 		"github.com/pivotal/kpack/pkg/apis/core/v1alpha1.NotaryConfig":                schema_pkg_apis_core_v1alpha1_NotaryConfig(ref),
 		"github.com/pivotal/kpack/pkg/apis/core/v1alpha1.NotarySecretRef":             schema_pkg_apis_core_v1alpha1_NotarySecretRef(ref),
 		"github.com/pivotal/kpack/pkg/apis/core/v1alpha1.NotaryV1Config":              schema_pkg_apis_core_v1alpha1_NotaryV1Config(ref),
+		"github.com/pivotal/kpack/pkg/apis/core/v1alpha1.ObjectStore":                 schema_pkg_apis_core_v1alpha1_ObjectStore(ref),
+		"github.com/pivotal/kpack/pkg/apis/core/v1alpha1.ObjectVersion":               schema_pkg_apis_core_v1alpha1_ObjectVersion(ref),
 		"github.com/pivotal/kpack/pkg/apis/core/v1alpha1.OrderEntry":                  schema_pkg_apis_core_v1alpha1_OrderEntry(ref),
 		"github.com/pivotal/kpack/pkg/apis/core/v1alpha1.Registry":                    schema_pkg_apis_core_v1alpha1_Registry(ref),
 		"github.com/pivotal/kpack/pkg/apis/core/v1alpha1.ResolvedBlobSource":          schema_pkg_apis_core_v1alpha1_ResolvedBlobSource(ref),
 		"github.com/pivotal/kpack/pkg/apis/core/v1alpha1.ResolvedGitSource":           schema_pkg_apis_core_v1alpha1_ResolvedGitSource(ref),
+		"github.com/pivotal/kpack/pkg/apis/core/v1alpha1.ResolvedObjectStoreSource":   schema_pkg_apis_core_v1alpha1_ResolvedObjectStoreSource(ref),
 		"github.com/pivotal/kpack/pkg/apis/core/v1alpha1.ResolvedRegistrySource":      schema_pkg_apis_core_v1alpha1_ResolvedRegistrySource(ref),
 		"github.com/pivotal/kpack/pkg/apis/core/v1alpha1.ResolvedSourceConfig":        schema_pkg_apis_core_v1alpha1_ResolvedSourceConfig(ref),
 		"github.com/pivotal/kpack/pkg/apis/core/v1alpha1.SourceConfig":                schema_pkg_apis_core_v1alpha1_SourceConfig(ref),
@@ -1823,6 +1855,26 @@ func schema_pkg_apis_build_v1alpha1_SourceResolverStatus(ref common.ReferenceCal
 	}
 }
 
+func schema_pkg_apis_build_v1alpha2_BranchProvider(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Type: []string{"object"},
+				Properties: map[string]spec.Schema{
+					"pattern": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Pattern is a shell pattern matched against the names of the branches, such as \"feature/*\".",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+				},
+				Required: []string{"pattern"},
+			},
+		},
+	}
+}
+
 func schema_pkg_apis_build_v1alpha2_Build(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
@@ -1973,6 +2025,277 @@ func schema_pkg_apis_build_v1alpha2_BuildPersistentVolumeCache(ref common.Refere
 	}
 }
 
+func schema_pkg_apis_build_v1alpha2_BuildPromotionStatus(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Type: []string{"object"},
+				Properties: map[string]spec.Schema{
+					"repository": {
+						SchemaProps: spec.SchemaProps{
+							Type:   []string{"string"},
+							Format: "",
+						},
+					},
+					"image": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Image is the promoted image with its digest.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"status": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Status is True when the image is promoted, Unknown while a failed promotion is retried and False once it is no longer retried.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"reason": {
+						SchemaProps: spec.SchemaProps{
+							Type:   []string{"string"},
+							Format: "",
+						},
+					},
+					"message": {
+						SchemaProps: spec.SchemaProps{
+							Type:   []string{"string"},
+							Format: "",
+						},
+					},
+					"attempts": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Attempts is how many times the promotion has been attempted.",
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+					"lastAttemptTime": {
+						SchemaProps: spec.SchemaProps{
+							Description: "LastAttemptTime is when the promotion was last attempted.",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Time"),
+						},
+					},
+				},
+				Required: []string{"repository", "status"},
+			},
+		},
+		Dependencies: []string{
+			"k8s.io/apimachinery/pkg/apis/meta/v1.Time"},
+	}
+}
+
+func schema_pkg_apis_build_v1alpha2_BuildRequest(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Type: []string{"object"},
+				Properties: map[string]spec.Schema{
+					"kind": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Kind is a string value representing the REST resource this object represents. Servers may infer this from the endpoint the client submits requests to. Cannot be updated. In CamelCase. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#types-kinds",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"apiVersion": {
+						SchemaProps: spec.SchemaProps{
+							Description: "APIVersion defines the versioned schema of this representation of an object. Servers should convert recognized schemas to the latest internal value, and may reject unrecognized values. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#resources",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"metadata": {
+						SchemaProps: spec.SchemaProps{
+							Ref: ref("k8s.io/apimachinery/pkg/apis/meta/v1.ObjectMeta"),
+						},
+					},
+					"spec": {
+						SchemaProps: spec.SchemaProps{
+							Ref: ref("github.com/pivotal/kpack/pkg/apis/build/v1alpha2.BuildRequestSpec"),
+						},
+					},
+					"status": {
+						SchemaProps: spec.SchemaProps{
+							Ref: ref("github.com/pivotal/kpack/pkg/apis/build/v1alpha2.BuildRequestStatus"),
+						},
+					},
+				},
+				Required: []string{"spec"},
+			},
+		},
+		Dependencies: []string{
+			"github.com/pivotal/kpack/pkg/apis/build/v1alpha2.BuildRequestSpec", "github.com/pivotal/kpack/pkg/apis/build/v1alpha2.BuildRequestStatus", "k8s.io/apimachinery/pkg/apis/meta/v1.ObjectMeta"},
+	}
+}
+
+func schema_pkg_apis_build_v1alpha2_BuildRequestList(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Type: []string{"object"},
+				Properties: map[string]spec.Schema{
+					"kind": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Kind is a string value representing the REST resource this object represents. Servers may infer this from the endpoint the client submits requests to. Cannot be updated. In CamelCase. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#types-kinds",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"apiVersion": {
+						SchemaProps: spec.SchemaProps{
+							Description: "APIVersion defines the versioned schema of this representation of an object. Servers should convert recognized schemas to the latest internal value, and may reject unrecognized values. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#resources",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"metadata": {
+						SchemaProps: spec.SchemaProps{
+							Ref: ref("k8s.io/apimachinery/pkg/apis/meta/v1.ListMeta"),
+						},
+					},
+					"items": {
+						SchemaProps: spec.SchemaProps{
+							Type: []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Ref: ref("github.com/pivotal/kpack/pkg/apis/build/v1alpha2.BuildRequest"),
+									},
+								},
+							},
+						},
+					},
+				},
+				Required: []string{"metadata", "items"},
+			},
+		},
+		Dependencies: []string{
+			"github.com/pivotal/kpack/pkg/apis/build/v1alpha2.BuildRequest", "k8s.io/apimachinery/pkg/apis/meta/v1.ListMeta"},
+	}
+}
+
+func schema_pkg_apis_build_v1alpha2_BuildRequestSpec(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Type: []string{"object"},
+				Properties: map[string]spec.Schema{
+					"imageName": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ImageName is the name of the image in the namespace of the request that is built.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"revision": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Revision is the git revision that is built instead of the revision of the image.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"tag": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Tag is built instead of the tags of the image when set.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+				},
+				Required: []string{"imageName", "revision"},
+			},
+		},
+	}
+}
+
+func schema_pkg_apis_build_v1alpha2_BuildRequestStatus(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Type: []string{"object"},
+				Properties: map[string]spec.Schema{
+					"observedGeneration": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ObservedGeneration is the 'Generation' of the Service that was last processed by the controller.",
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+					"conditions": {
+						VendorExtensible: spec.VendorExtensible{
+							Extensions: spec.Extensions{
+								"x-kubernetes-patch-merge-key": "type",
+								"x-kubernetes-patch-strategy":  "merge",
+							},
+						},
+						SchemaProps: spec.SchemaProps{
+							Description: "Conditions the latest available observations of a resource's current state.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Ref: ref("github.com/pivotal/kpack/pkg/apis/core/v1alpha1.Condition"),
+									},
+								},
+							},
+						},
+					},
+					"buildName": {
+						SchemaProps: spec.SchemaProps{
+							Description: "BuildName is the name of the build of the request.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"latestImage": {
+						SchemaProps: spec.SchemaProps{
+							Type:   []string{"string"},
+							Format: "",
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{
+			"github.com/pivotal/kpack/pkg/apis/core/v1alpha1.Condition"},
+	}
+}
+
+func schema_pkg_apis_build_v1alpha2_BuildResolvedSource(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Type: []string{"object"},
+				Properties: map[string]spec.Schema{
+					"gitTag": {
+						SchemaProps: spec.SchemaProps{
+							Description: "GitTag is the tag selected by the tag constraint of a git source.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"blobFingerprint": {
+						SchemaProps: spec.SchemaProps{
+							Description: "BlobFingerprint identifies the contents of a polled blob source.",
+							Ref:         ref("github.com/pivotal/kpack/pkg/apis/core/v1alpha1.BlobFingerprint"),
+						},
+					},
+					"registryDigest": {
+						SchemaProps: spec.SchemaProps{
+							Description: "RegistryDigest is the digest the source image was resolved to.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{
+			"github.com/pivotal/kpack/pkg/apis/core/v1alpha1.BlobFingerprint"},
+	}
+}
+
 func schema_pkg_apis_build_v1alpha2_BuildSpec(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
@@ -2013,6 +2336,12 @@ func schema_pkg_apis_build_v1alpha2_BuildSpec(ref common.ReferenceCallback) comm
 							Ref: ref("github.com/pivotal/kpack/pkg/apis/core/v1alpha1.SourceConfig"),
 						},
 					},
+					"resolvedSource": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ResolvedSource is what the SourceResolver resolved Source to beyond its revision, such as the tag selected by a tag constraint.",
+							Ref:         ref("github.com/pivotal/kpack/pkg/apis/build/v1alpha2.BuildResolvedSource"),
+						},
+					},
 					"cache": {
 						SchemaProps: spec.SchemaProps{
 							Ref: ref("github.com/pivotal/kpack/pkg/apis/build/v1alpha2.BuildCacheConfig"),
@@ -2155,12 +2484,35 @@ func schema_pkg_apis_build_v1alpha2_BuildSpec(ref common.ReferenceCallback) comm
 							Format: "",
 						},
 					},
-				},
+					"activeDeadlineSeconds": {
+						SchemaProps: spec.SchemaProps{
+							Type:   []string{"integer"},
+							Format: "int64",
+						},
+					},
+					"promotions": {
+						VendorExtensible: spec.VendorExtensible{
+							Extensions: spec.Extensions{
+								"x-kubernetes-list-type": "",
+							},
+						},
+						SchemaProps: spec.SchemaProps{
+							Type: []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Ref: ref("github.com/pivotal/kpack/pkg/apis/build/v1alpha2.ImagePromotion"),
+									},
+								},
+							},
+						},
+					},
+				},
 				Required: []string{"source"},
 			},
 		},
 		Dependencies: []string{
-			"github.com/pivotal/kpack/pkg/apis/build/v1alpha2.BuildCacheConfig", "github.com/pivotal/kpack/pkg/apis/build/v1alpha2.CosignConfig", "github.com/pivotal/kpack/pkg/apis/build/v1alpha2.LastBuild", "github.com/pivotal/kpack/pkg/apis/core/v1alpha1.BuildBuilderSpec", "github.com/pivotal/kpack/pkg/apis/core/v1alpha1.CNBBinding", "github.com/pivotal/kpack/pkg/apis/core/v1alpha1.NotaryConfig", "github.com/pivotal/kpack/pkg/apis/core/v1alpha1.SourceConfig", "k8s.io/api/core/v1.Affinity", "k8s.io/api/core/v1.EnvVar", "k8s.io/api/core/v1.ObjectReference", "k8s.io/api/core/v1.ResourceRequirements", "k8s.io/api/core/v1.Toleration"},
+			"github.com/pivotal/kpack/pkg/apis/build/v1alpha2.BuildCacheConfig", "github.com/pivotal/kpack/pkg/apis/build/v1alpha2.BuildResolvedSource", "github.com/pivotal/kpack/pkg/apis/build/v1alpha2.CosignConfig", "github.com/pivotal/kpack/pkg/apis/build/v1alpha2.ImagePromotion", "github.com/pivotal/kpack/pkg/apis/build/v1alpha2.LastBuild", "github.com/pivotal/kpack/pkg/apis/core/v1alpha1.BuildBuilderSpec", "github.com/pivotal/kpack/pkg/apis/core/v1alpha1.CNBBinding", "github.com/pivotal/kpack/pkg/apis/core/v1alpha1.NotaryConfig", "github.com/pivotal/kpack/pkg/apis/core/v1alpha1.SourceConfig", "k8s.io/api/core/v1.Affinity", "k8s.io/api/core/v1.EnvVar", "k8s.io/api/core/v1.ObjectReference", "k8s.io/api/core/v1.ResourceRequirements", "k8s.io/api/core/v1.Toleration"},
 	}
 }
 
@@ -2290,11 +2642,107 @@ func schema_pkg_apis_build_v1alpha2_BuildStatus(ref common.ReferenceCallback) co
 							},
 						},
 					},
+					"interruptedStep": {
+						SchemaProps: spec.SchemaProps{
+							Description: "InterruptedStep is the step that was running when the build was cancelled.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"promotions": {
+						VendorExtensible: spec.VendorExtensible{
+							Extensions: spec.Extensions{
+								"x-kubernetes-list-type": "",
+							},
+						},
+						SchemaProps: spec.SchemaProps{
+							Type: []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Ref: ref("github.com/pivotal/kpack/pkg/apis/build/v1alpha2.BuildPromotionStatus"),
+									},
+								},
+							},
+						},
+					},
+					"steps": {
+						VendorExtensible: spec.VendorExtensible{
+							Extensions: spec.Extensions{
+								"x-kubernetes-list-type": "",
+							},
+						},
+						SchemaProps: spec.SchemaProps{
+							Description: "Steps are the timings and exit codes of the steps of the build that started, in the order they run.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Ref: ref("github.com/pivotal/kpack/pkg/apis/build/v1alpha2.BuildStepStatus"),
+									},
+								},
+							},
+						},
+					},
+					"queueDuration": {
+						SchemaProps: spec.SchemaProps{
+							Description: "QueueDuration is the time from the creation of the build, or from when its image was queued by the concurrent build limits, until its first step started.",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Duration"),
+						},
+					},
+					"buildDuration": {
+						SchemaProps: spec.SchemaProps{
+							Description: "BuildDuration is the time from the start of the first step of the build until its last step finished.",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Duration"),
+						},
+					},
 				},
 			},
 		},
 		Dependencies: []string{
-			"github.com/pivotal/kpack/pkg/apis/core/v1alpha1.BuildStack", "github.com/pivotal/kpack/pkg/apis/core/v1alpha1.BuildpackMetadata", "github.com/pivotal/kpack/pkg/apis/core/v1alpha1.Condition", "k8s.io/api/core/v1.ContainerState"},
+			"github.com/pivotal/kpack/pkg/apis/build/v1alpha2.BuildPromotionStatus", "github.com/pivotal/kpack/pkg/apis/build/v1alpha2.BuildStepStatus", "github.com/pivotal/kpack/pkg/apis/core/v1alpha1.BuildStack", "github.com/pivotal/kpack/pkg/apis/core/v1alpha1.BuildpackMetadata", "github.com/pivotal/kpack/pkg/apis/core/v1alpha1.Condition", "k8s.io/api/core/v1.ContainerState", "k8s.io/apimachinery/pkg/apis/meta/v1.Duration"},
+	}
+}
+
+func schema_pkg_apis_build_v1alpha2_BuildStepStatus(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Type: []string{"object"},
+				Properties: map[string]spec.Schema{
+					"name": {
+						SchemaProps: spec.SchemaProps{
+							Type:   []string{"string"},
+							Format: "",
+						},
+					},
+					"startedAt": {
+						SchemaProps: spec.SchemaProps{
+							Ref: ref("k8s.io/apimachinery/pkg/apis/meta/v1.Time"),
+						},
+					},
+					"finishedAt": {
+						SchemaProps: spec.SchemaProps{
+							Ref: ref("k8s.io/apimachinery/pkg/apis/meta/v1.Time"),
+						},
+					},
+					"duration": {
+						SchemaProps: spec.SchemaProps{
+							Ref: ref("k8s.io/apimachinery/pkg/apis/meta/v1.Duration"),
+						},
+					},
+					"exitCode": {
+						SchemaProps: spec.SchemaProps{
+							Type:   []string{"integer"},
+							Format: "int32",
+						},
+					},
+				},
+				Required: []string{"name"},
+			},
+		},
+		Dependencies: []string{
+			"k8s.io/apimachinery/pkg/apis/meta/v1.Duration", "k8s.io/apimachinery/pkg/apis/meta/v1.Time"},
 	}
 }
 
@@ -3315,11 +3763,16 @@ func schema_pkg_apis_build_v1alpha2_ImageBuild(ref common.ReferenceCallback) com
 							Format: "",
 						},
 					},
+					"timeout": {
+						SchemaProps: spec.SchemaProps{
+							Ref: ref("k8s.io/apimachinery/pkg/apis/meta/v1.Duration"),
+						},
+					},
 				},
 			},
 		},
 		Dependencies: []string{
-			"github.com/pivotal/kpack/pkg/apis/core/v1alpha1.CNBBinding", "k8s.io/api/core/v1.Affinity", "k8s.io/api/core/v1.EnvVar", "k8s.io/api/core/v1.ObjectReference", "k8s.io/api/core/v1.ResourceRequirements", "k8s.io/api/core/v1.Toleration"},
+			"github.com/pivotal/kpack/pkg/apis/core/v1alpha1.CNBBinding", "k8s.io/api/core/v1.Affinity", "k8s.io/api/core/v1.EnvVar", "k8s.io/api/core/v1.ObjectReference", "k8s.io/api/core/v1.ResourceRequirements", "k8s.io/api/core/v1.Toleration", "k8s.io/apimachinery/pkg/apis/meta/v1.Duration"},
 	}
 }
 
@@ -3445,203 +3898,759 @@ func schema_pkg_apis_build_v1alpha2_ImagePersistentVolumeCache(ref common.Refere
 	}
 }
 
-func schema_pkg_apis_build_v1alpha2_ImageSpec(ref common.ReferenceCallback) common.OpenAPIDefinition {
+func schema_pkg_apis_build_v1alpha2_ImagePromotion(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
 			SchemaProps: spec.SchemaProps{
 				Type: []string{"object"},
 				Properties: map[string]spec.Schema{
-					"tag": {
-						SchemaProps: spec.SchemaProps{
-							Type:   []string{"string"},
-							Format: "",
-						},
-					},
-					"builder": {
-						SchemaProps: spec.SchemaProps{
-							Ref: ref("k8s.io/api/core/v1.ObjectReference"),
-						},
-					},
-					"serviceAccountName": {
-						SchemaProps: spec.SchemaProps{
-							Type:   []string{"string"},
-							Format: "",
-						},
-					},
-					"source": {
-						SchemaProps: spec.SchemaProps{
-							Ref: ref("github.com/pivotal/kpack/pkg/apis/core/v1alpha1.SourceConfig"),
-						},
-					},
-					"cache": {
-						SchemaProps: spec.SchemaProps{
-							Ref: ref("github.com/pivotal/kpack/pkg/apis/build/v1alpha2.ImageCacheConfig"),
-						},
-					},
-					"failedBuildHistoryLimit": {
-						SchemaProps: spec.SchemaProps{
-							Type:   []string{"integer"},
-							Format: "int64",
-						},
-					},
-					"successBuildHistoryLimit": {
-						SchemaProps: spec.SchemaProps{
-							Type:   []string{"integer"},
-							Format: "int64",
-						},
-					},
-					"imageTaggingStrategy": {
-						SchemaProps: spec.SchemaProps{
-							Type:   []string{"string"},
-							Format: "",
-						},
-					},
-					"projectDescriptorPath": {
-						SchemaProps: spec.SchemaProps{
-							Type:   []string{"string"},
-							Format: "",
-						},
-					},
-					"build": {
-						SchemaProps: spec.SchemaProps{
-							Ref: ref("github.com/pivotal/kpack/pkg/apis/build/v1alpha2.ImageBuild"),
-						},
-					},
-					"notary": {
-						SchemaProps: spec.SchemaProps{
-							Ref: ref("github.com/pivotal/kpack/pkg/apis/core/v1alpha1.NotaryConfig"),
-						},
-					},
-					"cosign": {
-						SchemaProps: spec.SchemaProps{
-							Ref: ref("github.com/pivotal/kpack/pkg/apis/build/v1alpha2.CosignConfig"),
-						},
-					},
-					"defaultProcess": {
+					"repository": {
 						SchemaProps: spec.SchemaProps{
-							Type:   []string{"string"},
-							Format: "",
+							Description: "Repository is the repository the built image and its cosign signatures are copied to, such as dr.registry.io/project/app.",
+							Type:        []string{"string"},
+							Format:      "",
 						},
 					},
-					"additionalTags": {
+					"imagePullSecrets": {
 						VendorExtensible: spec.VendorExtensible{
 							Extensions: spec.Extensions{
 								"x-kubernetes-list-type": "",
 							},
 						},
 						SchemaProps: spec.SchemaProps{
-							Type: []string{"array"},
+							Description: "ImagePullSecrets are the credentials of the repository in addition to the secrets of the service account.",
+							Type:        []string{"array"},
 							Items: &spec.SchemaOrArray{
 								Schema: &spec.Schema{
 									SchemaProps: spec.SchemaProps{
-										Type:   []string{"string"},
-										Format: "",
+										Ref: ref("k8s.io/api/core/v1.LocalObjectReference"),
 									},
 								},
 							},
 						},
 					},
 				},
-				Required: []string{"tag", "source"},
+				Required: []string{"repository"},
 			},
 		},
 		Dependencies: []string{
-			"github.com/pivotal/kpack/pkg/apis/build/v1alpha2.CosignConfig", "github.com/pivotal/kpack/pkg/apis/build/v1alpha2.ImageBuild", "github.com/pivotal/kpack/pkg/apis/build/v1alpha2.ImageCacheConfig", "github.com/pivotal/kpack/pkg/apis/core/v1alpha1.NotaryConfig", "github.com/pivotal/kpack/pkg/apis/core/v1alpha1.SourceConfig", "k8s.io/api/core/v1.ObjectReference"},
+			"k8s.io/api/core/v1.LocalObjectReference"},
 	}
 }
 
-func schema_pkg_apis_build_v1alpha2_ImageStatus(ref common.ReferenceCallback) common.OpenAPIDefinition {
+func schema_pkg_apis_build_v1alpha2_ImageRetryPolicy(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
 			SchemaProps: spec.SchemaProps{
 				Type: []string{"object"},
 				Properties: map[string]spec.Schema{
-					"observedGeneration": {
+					"maxAttempts": {
 						SchemaProps: spec.SchemaProps{
-							Description: "ObservedGeneration is the 'Generation' of the Service that was last processed by the controller.",
+							Description: "MaxAttempts is the number of times a build that failed with a retryable failure is retried.",
 							Type:        []string{"integer"},
 							Format:      "int64",
 						},
 					},
-					"conditions": {
-						VendorExtensible: spec.VendorExtensible{
-							Extensions: spec.Extensions{
-								"x-kubernetes-patch-merge-key": "type",
-								"x-kubernetes-patch-strategy":  "merge",
-							},
-						},
+					"backoff": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Conditions the latest available observations of a resource's current state.",
-							Type:        []string{"array"},
-							Items: &spec.SchemaOrArray{
-								Schema: &spec.Schema{
-									SchemaProps: spec.SchemaProps{
-										Ref: ref("github.com/pivotal/kpack/pkg/apis/core/v1alpha1.Condition"),
-									},
-								},
-							},
+							Description: "Backoff is the delay before the first retry. It doubles with every attempt, up to an hour.",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Duration"),
 						},
 					},
-					"latestBuildRef": {
+				},
+				Required: []string{"maxAttempts"},
+			},
+		},
+		Dependencies: []string{
+			"k8s.io/apimachinery/pkg/apis/meta/v1.Duration"},
+	}
+}
+
+func schema_pkg_apis_build_v1alpha2_ImageRollback(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Type: []string{"object"},
+				Properties: map[string]spec.Schema{
+					"buildNumber": {
 						SchemaProps: spec.SchemaProps{
-							Type:   []string{"string"},
-							Format: "",
+							Description: "BuildNumber is the number of the successful build whose image the tags are pointed at.",
+							Type:        []string{"integer"},
+							Format:      "int64",
 						},
 					},
-					"latestBuildImageGeneration": {
+				},
+				Required: []string{"buildNumber"},
+			},
+		},
+	}
+}
+
+func schema_pkg_apis_build_v1alpha2_ImageRollbackStatus(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Type: []string{"object"},
+				Properties: map[string]spec.Schema{
+					"buildNumber": {
 						SchemaProps: spec.SchemaProps{
 							Type:   []string{"integer"},
 							Format: "int64",
 						},
 					},
-					"latestImage": {
+					"buildName": {
 						SchemaProps: spec.SchemaProps{
 							Type:   []string{"string"},
 							Format: "",
 						},
 					},
-					"latestStack": {
+					"image": {
 						SchemaProps: spec.SchemaProps{
-							Type:   []string{"string"},
-							Format: "",
+							Description: "Image is the image, with its digest, the tags were pointed at.",
+							Type:        []string{"string"},
+							Format:      "",
 						},
 					},
-					"buildCounter": {
+					"imageGeneration": {
 						SchemaProps: spec.SchemaProps{
 							Type:   []string{"integer"},
 							Format: "int64",
 						},
 					},
-					"buildCacheName": {
-						SchemaProps: spec.SchemaProps{
-							Type:   []string{"string"},
-							Format: "",
-						},
-					},
-					"latestBuildReason": {
+					"rolledBackAt": {
 						SchemaProps: spec.SchemaProps{
-							Type:   []string{"string"},
-							Format: "",
+							Ref: ref("k8s.io/apimachinery/pkg/apis/meta/v1.Time"),
 						},
 					},
 				},
+				Required: []string{"buildNumber", "buildName", "image", "imageGeneration", "rolledBackAt"},
 			},
 		},
 		Dependencies: []string{
-			"github.com/pivotal/kpack/pkg/apis/core/v1alpha1.Condition"},
+			"k8s.io/apimachinery/pkg/apis/meta/v1.Time"},
 	}
 }
 
-func schema_pkg_apis_build_v1alpha2_LastBuild(ref common.ReferenceCallback) common.OpenAPIDefinition {
+func schema_pkg_apis_build_v1alpha2_ImageSchedule(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
 			SchemaProps: spec.SchemaProps{
 				Type: []string{"object"},
 				Properties: map[string]spec.Schema{
-					"image": {
+					"cron": {
 						SchemaProps: spec.SchemaProps{
-							Type:   []string{"string"},
-							Format: "",
+							Description: "Cron is a cron expression with five fields, such as \"0 3 * * 1\", or a descriptor, such as \"@weekly\", that schedules builds.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"timeZone": {
+						SchemaProps: spec.SchemaProps{
+							Description: "TimeZone is the IANA time zone of the cron expression. Defaults to UTC.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+				},
+				Required: []string{"cron"},
+			},
+		},
+	}
+}
+
+func schema_pkg_apis_build_v1alpha2_ImageSet(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Type: []string{"object"},
+				Properties: map[string]spec.Schema{
+					"kind": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Kind is a string value representing the REST resource this object represents. Servers may infer this from the endpoint the client submits requests to. Cannot be updated. In CamelCase. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#types-kinds",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"apiVersion": {
+						SchemaProps: spec.SchemaProps{
+							Description: "APIVersion defines the versioned schema of this representation of an object. Servers should convert recognized schemas to the latest internal value, and may reject unrecognized values. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#resources",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"metadata": {
+						SchemaProps: spec.SchemaProps{
+							Ref: ref("k8s.io/apimachinery/pkg/apis/meta/v1.ObjectMeta"),
+						},
+					},
+					"spec": {
+						SchemaProps: spec.SchemaProps{
+							Ref: ref("github.com/pivotal/kpack/pkg/apis/build/v1alpha2.ImageSetSpec"),
+						},
+					},
+					"status": {
+						SchemaProps: spec.SchemaProps{
+							Ref: ref("github.com/pivotal/kpack/pkg/apis/build/v1alpha2.ImageSetStatus"),
+						},
+					},
+				},
+				Required: []string{"spec"},
+			},
+		},
+		Dependencies: []string{
+			"github.com/pivotal/kpack/pkg/apis/build/v1alpha2.ImageSetSpec", "github.com/pivotal/kpack/pkg/apis/build/v1alpha2.ImageSetStatus", "k8s.io/apimachinery/pkg/apis/meta/v1.ObjectMeta"},
+	}
+}
+
+func schema_pkg_apis_build_v1alpha2_ImageSetDiscovery(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Type: []string{"object"},
+				Properties: map[string]spec.Schema{
+					"path": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Path is the directory of the git source whose subdirectories are discovered. Defaults to the root of the repository.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"file": {
+						SchemaProps: spec.SchemaProps{
+							Description: "File is a file that a subdirectory must contain to be discovered, such as project.toml.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func schema_pkg_apis_build_v1alpha2_ImageSetImageStatus(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Type: []string{"object"},
+				Properties: map[string]spec.Schema{
+					"name": {
+						SchemaProps: spec.SchemaProps{
+							Type:   []string{"string"},
+							Format: "",
+						},
+					},
+					"ready": {
+						SchemaProps: spec.SchemaProps{
+							Type:   []string{"string"},
+							Format: "",
+						},
+					},
+					"latestImage": {
+						SchemaProps: spec.SchemaProps{
+							Type:   []string{"string"},
+							Format: "",
+						},
+					},
+				},
+				Required: []string{"name", "ready"},
+			},
+		},
+	}
+}
+
+func schema_pkg_apis_build_v1alpha2_ImageSetList(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Type: []string{"object"},
+				Properties: map[string]spec.Schema{
+					"kind": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Kind is a string value representing the REST resource this object represents. Servers may infer this from the endpoint the client submits requests to. Cannot be updated. In CamelCase. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#types-kinds",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"apiVersion": {
+						SchemaProps: spec.SchemaProps{
+							Description: "APIVersion defines the versioned schema of this representation of an object. Servers should convert recognized schemas to the latest internal value, and may reject unrecognized values. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#resources",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"metadata": {
+						SchemaProps: spec.SchemaProps{
+							Ref: ref("k8s.io/apimachinery/pkg/apis/meta/v1.ListMeta"),
+						},
+					},
+					"items": {
+						SchemaProps: spec.SchemaProps{
+							Type: []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Ref: ref("github.com/pivotal/kpack/pkg/apis/build/v1alpha2.ImageSet"),
+									},
+								},
+							},
+						},
+					},
+				},
+				Required: []string{"metadata", "items"},
+			},
+		},
+		Dependencies: []string{
+			"github.com/pivotal/kpack/pkg/apis/build/v1alpha2.ImageSet", "k8s.io/apimachinery/pkg/apis/meta/v1.ListMeta"},
+	}
+}
+
+func schema_pkg_apis_build_v1alpha2_ImageSetParameters(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Type: []string{"object"},
+				Properties: map[string]spec.Schema{
+					"name": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Name is appended to the name of the image set to name the image.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"subPath": {
+						SchemaProps: spec.SchemaProps{
+							Description: "SubPath is the source subPath of the image. Defaults to the subPath of the template.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"tag": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Tag is the tag of the image. Defaults to the rendered tag of the template.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+				},
+				Required: []string{"name"},
+			},
+		},
+	}
+}
+
+func schema_pkg_apis_build_v1alpha2_ImageSetSpec(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Type: []string{"object"},
+				Properties: map[string]spec.Schema{
+					"template": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Template is the spec of every image of the set. Its tag is a Go template rendered with the Name and SubPath of each image, such as \"registry.io/apps/{{.Name}}\".",
+							Ref:         ref("github.com/pivotal/kpack/pkg/apis/build/v1alpha2.ImageSpec"),
+						},
+					},
+					"parameters": {
+						VendorExtensible: spec.VendorExtensible{
+							Extensions: spec.Extensions{
+								"x-kubernetes-list-type": "",
+							},
+						},
+						SchemaProps: spec.SchemaProps{
+							Description: "Parameters create an image for each entry.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Ref: ref("github.com/pivotal/kpack/pkg/apis/build/v1alpha2.ImageSetParameters"),
+									},
+								},
+							},
+						},
+					},
+					"discovery": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Discovery creates an image for each directory of the git source of the template that it matches.",
+							Ref:         ref("github.com/pivotal/kpack/pkg/apis/build/v1alpha2.ImageSetDiscovery"),
+						},
+					},
+				},
+				Required: []string{"template"},
+			},
+		},
+		Dependencies: []string{
+			"github.com/pivotal/kpack/pkg/apis/build/v1alpha2.ImageSetDiscovery", "github.com/pivotal/kpack/pkg/apis/build/v1alpha2.ImageSetParameters", "github.com/pivotal/kpack/pkg/apis/build/v1alpha2.ImageSpec"},
+	}
+}
+
+func schema_pkg_apis_build_v1alpha2_ImageSetStatus(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Type: []string{"object"},
+				Properties: map[string]spec.Schema{
+					"observedGeneration": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ObservedGeneration is the 'Generation' of the Service that was last processed by the controller.",
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+					"conditions": {
+						VendorExtensible: spec.VendorExtensible{
+							Extensions: spec.Extensions{
+								"x-kubernetes-patch-merge-key": "type",
+								"x-kubernetes-patch-strategy":  "merge",
+							},
+						},
+						SchemaProps: spec.SchemaProps{
+							Description: "Conditions the latest available observations of a resource's current state.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Ref: ref("github.com/pivotal/kpack/pkg/apis/core/v1alpha1.Condition"),
+									},
+								},
+							},
+						},
+					},
+					"images": {
+						VendorExtensible: spec.VendorExtensible{
+							Extensions: spec.Extensions{
+								"x-kubernetes-list-type": "",
+							},
+						},
+						SchemaProps: spec.SchemaProps{
+							Type: []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Ref: ref("github.com/pivotal/kpack/pkg/apis/build/v1alpha2.ImageSetImageStatus"),
+									},
+								},
+							},
+						},
+					},
+					"discoveredPaths": {
+						VendorExtensible: spec.VendorExtensible{
+							Extensions: spec.Extensions{
+								"x-kubernetes-list-type": "",
+							},
+						},
+						SchemaProps: spec.SchemaProps{
+							Description: "DiscoveredPaths are the directories found by the discovery of the image set.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Type:   []string{"string"},
+										Format: "",
+									},
+								},
+							},
+						},
+					},
+					"lastDiscoveryTime": {
+						SchemaProps: spec.SchemaProps{
+							Ref: ref("k8s.io/apimachinery/pkg/apis/meta/v1.Time"),
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{
+			"github.com/pivotal/kpack/pkg/apis/build/v1alpha2.ImageSetImageStatus", "github.com/pivotal/kpack/pkg/apis/core/v1alpha1.Condition", "k8s.io/apimachinery/pkg/apis/meta/v1.Time"},
+	}
+}
+
+func schema_pkg_apis_build_v1alpha2_ImageSpec(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Type: []string{"object"},
+				Properties: map[string]spec.Schema{
+					"tag": {
+						SchemaProps: spec.SchemaProps{
+							Type:   []string{"string"},
+							Format: "",
+						},
+					},
+					"builder": {
+						SchemaProps: spec.SchemaProps{
+							Ref: ref("k8s.io/api/core/v1.ObjectReference"),
+						},
+					},
+					"serviceAccountName": {
+						SchemaProps: spec.SchemaProps{
+							Type:   []string{"string"},
+							Format: "",
+						},
+					},
+					"source": {
+						SchemaProps: spec.SchemaProps{
+							Ref: ref("github.com/pivotal/kpack/pkg/apis/core/v1alpha1.SourceConfig"),
+						},
+					},
+					"cache": {
+						SchemaProps: spec.SchemaProps{
+							Ref: ref("github.com/pivotal/kpack/pkg/apis/build/v1alpha2.ImageCacheConfig"),
+						},
+					},
+					"failedBuildHistoryLimit": {
+						SchemaProps: spec.SchemaProps{
+							Type:   []string{"integer"},
+							Format: "int64",
+						},
+					},
+					"successBuildHistoryLimit": {
+						SchemaProps: spec.SchemaProps{
+							Type:   []string{"integer"},
+							Format: "int64",
+						},
+					},
+					"imageTaggingStrategy": {
+						SchemaProps: spec.SchemaProps{
+							Type:   []string{"string"},
+							Format: "",
+						},
+					},
+					"projectDescriptorPath": {
+						SchemaProps: spec.SchemaProps{
+							Type:   []string{"string"},
+							Format: "",
+						},
+					},
+					"build": {
+						SchemaProps: spec.SchemaProps{
+							Ref: ref("github.com/pivotal/kpack/pkg/apis/build/v1alpha2.ImageBuild"),
+						},
+					},
+					"notary": {
+						SchemaProps: spec.SchemaProps{
+							Ref: ref("github.com/pivotal/kpack/pkg/apis/core/v1alpha1.NotaryConfig"),
+						},
+					},
+					"cosign": {
+						SchemaProps: spec.SchemaProps{
+							Ref: ref("github.com/pivotal/kpack/pkg/apis/build/v1alpha2.CosignConfig"),
+						},
+					},
+					"defaultProcess": {
+						SchemaProps: spec.SchemaProps{
+							Type:   []string{"string"},
+							Format: "",
+						},
+					},
+					"additionalTags": {
+						VendorExtensible: spec.VendorExtensible{
+							Extensions: spec.Extensions{
+								"x-kubernetes-list-type": "",
+							},
+						},
+						SchemaProps: spec.SchemaProps{
+							Type: []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Type:   []string{"string"},
+										Format: "",
+									},
+								},
+							},
+						},
+					},
+					"retryPolicy": {
+						SchemaProps: spec.SchemaProps{
+							Ref: ref("github.com/pivotal/kpack/pkg/apis/build/v1alpha2.ImageRetryPolicy"),
+						},
+					},
+					"paused": {
+						SchemaProps: spec.SchemaProps{
+							Type:   []string{"boolean"},
+							Format: "",
+						},
+					},
+					"schedule": {
+						SchemaProps: spec.SchemaProps{
+							Ref: ref("github.com/pivotal/kpack/pkg/apis/build/v1alpha2.ImageSchedule"),
+						},
+					},
+					"tagTemplates": {
+						VendorExtensible: spec.VendorExtensible{
+							Extensions: spec.Extensions{
+								"x-kubernetes-list-type": "",
+							},
+						},
+						SchemaProps: spec.SchemaProps{
+							Description: "TagTemplates are Go templates of additional tags of the built image, such as \"{{.Branch}}-{{.ShortSHA}}\".",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Type:   []string{"string"},
+										Format: "",
+									},
+								},
+							},
+						},
+					},
+					"promotions": {
+						VendorExtensible: spec.VendorExtensible{
+							Extensions: spec.Extensions{
+								"x-kubernetes-list-type": "",
+							},
+						},
+						SchemaProps: spec.SchemaProps{
+							Description: "Promotions are repositories, outside the registry of the tag, that successfully built images are copied to.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Ref: ref("github.com/pivotal/kpack/pkg/apis/build/v1alpha2.ImagePromotion"),
+									},
+								},
+							},
+						},
+					},
+					"buildHistoryMaxAge": {
+						SchemaProps: spec.SchemaProps{
+							Description: "BuildHistoryMaxAge is the age after which finished builds are deleted regardless of the build history limits.",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Duration"),
+						},
+					},
+					"rollback": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Rollback points the tag and additional tags of the image back at the image of an earlier build. No builds are created until it is removed.",
+							Ref:         ref("github.com/pivotal/kpack/pkg/apis/build/v1alpha2.ImageRollback"),
+						},
+					},
+				},
+				Required: []string{"tag", "source"},
+			},
+		},
+		Dependencies: []string{
+			"github.com/pivotal/kpack/pkg/apis/build/v1alpha2.CosignConfig", "github.com/pivotal/kpack/pkg/apis/build/v1alpha2.ImageBuild", "github.com/pivotal/kpack/pkg/apis/build/v1alpha2.ImageCacheConfig", "github.com/pivotal/kpack/pkg/apis/build/v1alpha2.ImagePromotion", "github.com/pivotal/kpack/pkg/apis/build/v1alpha2.ImageRetryPolicy", "github.com/pivotal/kpack/pkg/apis/build/v1alpha2.ImageRollback", "github.com/pivotal/kpack/pkg/apis/build/v1alpha2.ImageSchedule", "github.com/pivotal/kpack/pkg/apis/core/v1alpha1.NotaryConfig", "github.com/pivotal/kpack/pkg/apis/core/v1alpha1.SourceConfig", "k8s.io/api/core/v1.ObjectReference", "k8s.io/apimachinery/pkg/apis/meta/v1.Duration"},
+	}
+}
+
+func schema_pkg_apis_build_v1alpha2_ImageStatus(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Type: []string{"object"},
+				Properties: map[string]spec.Schema{
+					"observedGeneration": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ObservedGeneration is the 'Generation' of the Service that was last processed by the controller.",
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+					"conditions": {
+						VendorExtensible: spec.VendorExtensible{
+							Extensions: spec.Extensions{
+								"x-kubernetes-patch-merge-key": "type",
+								"x-kubernetes-patch-strategy":  "merge",
+							},
+						},
+						SchemaProps: spec.SchemaProps{
+							Description: "Conditions the latest available observations of a resource's current state.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Ref: ref("github.com/pivotal/kpack/pkg/apis/core/v1alpha1.Condition"),
+									},
+								},
+							},
+						},
+					},
+					"latestBuildRef": {
+						SchemaProps: spec.SchemaProps{
+							Type:   []string{"string"},
+							Format: "",
+						},
+					},
+					"latestBuildImageGeneration": {
+						SchemaProps: spec.SchemaProps{
+							Type:   []string{"integer"},
+							Format: "int64",
+						},
+					},
+					"latestImage": {
+						SchemaProps: spec.SchemaProps{
+							Type:   []string{"string"},
+							Format: "",
+						},
+					},
+					"latestStack": {
+						SchemaProps: spec.SchemaProps{
+							Type:   []string{"string"},
+							Format: "",
+						},
+					},
+					"buildCounter": {
+						SchemaProps: spec.SchemaProps{
+							Type:   []string{"integer"},
+							Format: "int64",
+						},
+					},
+					"buildCacheName": {
+						SchemaProps: spec.SchemaProps{
+							Type:   []string{"string"},
+							Format: "",
+						},
+					},
+					"latestBuildReason": {
+						SchemaProps: spec.SchemaProps{
+							Type:   []string{"string"},
+							Format: "",
+						},
+					},
+					"latestBuildRetryAttempt": {
+						SchemaProps: spec.SchemaProps{
+							Type:   []string{"integer"},
+							Format: "int64",
+						},
+					},
+					"nextScheduledBuild": {
+						SchemaProps: spec.SchemaProps{
+							Description: "NextScheduledBuild is when the schedule of the image next creates a build.",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Time"),
+						},
+					},
+					"rollback": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Rollback is the rollback the tags of the image point at. It is cleared once a build succeeds after the rollback is removed.",
+							Ref:         ref("github.com/pivotal/kpack/pkg/apis/build/v1alpha2.ImageRollbackStatus"),
+						},
+					},
+					"averageBuildDuration": {
+						SchemaProps: spec.SchemaProps{
+							Description: "AverageBuildDuration is the average build duration of the latest successful builds of the image.",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Duration"),
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{
+			"github.com/pivotal/kpack/pkg/apis/build/v1alpha2.ImageRollbackStatus", "github.com/pivotal/kpack/pkg/apis/core/v1alpha1.Condition", "k8s.io/apimachinery/pkg/apis/meta/v1.Duration", "k8s.io/apimachinery/pkg/apis/meta/v1.Time"},
+	}
+}
+
+func schema_pkg_apis_build_v1alpha2_LastBuild(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Type: []string{"object"},
+				Properties: map[string]spec.Schema{
+					"image": {
+						SchemaProps: spec.SchemaProps{
+							Type:   []string{"string"},
+							Format: "",
 						},
 					},
 					"cache": {
@@ -3722,6 +4731,303 @@ func schema_pkg_apis_build_v1alpha2_NamespacedBuilderSpec(ref common.ReferenceCa
 	}
 }
 
+func schema_pkg_apis_build_v1alpha2_Preview(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Type: []string{"object"},
+				Properties: map[string]spec.Schema{
+					"name": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Name is appended to the name of the preview image set to name the image of the preview.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"number": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Number is the number of the pull request of the preview.",
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+					"branch": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Branch is the head branch of the preview.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"revision": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Revision is the git revision the image of the preview is built from.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+				},
+				Required: []string{"name", "revision"},
+			},
+		},
+	}
+}
+
+func schema_pkg_apis_build_v1alpha2_PreviewImageSet(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Type: []string{"object"},
+				Properties: map[string]spec.Schema{
+					"kind": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Kind is a string value representing the REST resource this object represents. Servers may infer this from the endpoint the client submits requests to. Cannot be updated. In CamelCase. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#types-kinds",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"apiVersion": {
+						SchemaProps: spec.SchemaProps{
+							Description: "APIVersion defines the versioned schema of this representation of an object. Servers should convert recognized schemas to the latest internal value, and may reject unrecognized values. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#resources",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"metadata": {
+						SchemaProps: spec.SchemaProps{
+							Ref: ref("k8s.io/apimachinery/pkg/apis/meta/v1.ObjectMeta"),
+						},
+					},
+					"spec": {
+						SchemaProps: spec.SchemaProps{
+							Ref: ref("github.com/pivotal/kpack/pkg/apis/build/v1alpha2.PreviewImageSetSpec"),
+						},
+					},
+					"status": {
+						SchemaProps: spec.SchemaProps{
+							Ref: ref("github.com/pivotal/kpack/pkg/apis/build/v1alpha2.PreviewImageSetStatus"),
+						},
+					},
+				},
+				Required: []string{"spec"},
+			},
+		},
+		Dependencies: []string{
+			"github.com/pivotal/kpack/pkg/apis/build/v1alpha2.PreviewImageSetSpec", "github.com/pivotal/kpack/pkg/apis/build/v1alpha2.PreviewImageSetStatus", "k8s.io/apimachinery/pkg/apis/meta/v1.ObjectMeta"},
+	}
+}
+
+func schema_pkg_apis_build_v1alpha2_PreviewImageSetList(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Type: []string{"object"},
+				Properties: map[string]spec.Schema{
+					"kind": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Kind is a string value representing the REST resource this object represents. Servers may infer this from the endpoint the client submits requests to. Cannot be updated. In CamelCase. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#types-kinds",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"apiVersion": {
+						SchemaProps: spec.SchemaProps{
+							Description: "APIVersion defines the versioned schema of this representation of an object. Servers should convert recognized schemas to the latest internal value, and may reject unrecognized values. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#resources",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"metadata": {
+						SchemaProps: spec.SchemaProps{
+							Ref: ref("k8s.io/apimachinery/pkg/apis/meta/v1.ListMeta"),
+						},
+					},
+					"items": {
+						SchemaProps: spec.SchemaProps{
+							Type: []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Ref: ref("github.com/pivotal/kpack/pkg/apis/build/v1alpha2.PreviewImageSet"),
+									},
+								},
+							},
+						},
+					},
+				},
+				Required: []string{"metadata", "items"},
+			},
+		},
+		Dependencies: []string{
+			"github.com/pivotal/kpack/pkg/apis/build/v1alpha2.PreviewImageSet", "k8s.io/apimachinery/pkg/apis/meta/v1.ListMeta"},
+	}
+}
+
+func schema_pkg_apis_build_v1alpha2_PreviewImageSetSpec(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Type: []string{"object"},
+				Properties: map[string]spec.Schema{
+					"template": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Template is the spec of the image of every preview. Its source must be a git repository and its tag is a Go template rendered with the Name, Number and Branch of each preview, such as \"registry.io/app:pr-{{.Number}}\".",
+							Ref:         ref("github.com/pivotal/kpack/pkg/apis/build/v1alpha2.ImageSpec"),
+						},
+					},
+					"provider": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Provider lists the previews of the git repository of the template.",
+							Ref:         ref("github.com/pivotal/kpack/pkg/apis/build/v1alpha2.PreviewProvider"),
+						},
+					},
+				},
+				Required: []string{"template", "provider"},
+			},
+		},
+		Dependencies: []string{
+			"github.com/pivotal/kpack/pkg/apis/build/v1alpha2.ImageSpec", "github.com/pivotal/kpack/pkg/apis/build/v1alpha2.PreviewProvider"},
+	}
+}
+
+func schema_pkg_apis_build_v1alpha2_PreviewImageSetStatus(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Type: []string{"object"},
+				Properties: map[string]spec.Schema{
+					"observedGeneration": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ObservedGeneration is the 'Generation' of the Service that was last processed by the controller.",
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+					"conditions": {
+						VendorExtensible: spec.VendorExtensible{
+							Extensions: spec.Extensions{
+								"x-kubernetes-patch-merge-key": "type",
+								"x-kubernetes-patch-strategy":  "merge",
+							},
+						},
+						SchemaProps: spec.SchemaProps{
+							Description: "Conditions the latest available observations of a resource's current state.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Ref: ref("github.com/pivotal/kpack/pkg/apis/core/v1alpha1.Condition"),
+									},
+								},
+							},
+						},
+					},
+					"images": {
+						VendorExtensible: spec.VendorExtensible{
+							Extensions: spec.Extensions{
+								"x-kubernetes-list-type": "",
+							},
+						},
+						SchemaProps: spec.SchemaProps{
+							Type: []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Ref: ref("github.com/pivotal/kpack/pkg/apis/build/v1alpha2.ImageSetImageStatus"),
+									},
+								},
+							},
+						},
+					},
+					"previews": {
+						VendorExtensible: spec.VendorExtensible{
+							Extensions: spec.Extensions{
+								"x-kubernetes-list-type": "",
+							},
+						},
+						SchemaProps: spec.SchemaProps{
+							Description: "Previews are the previews last listed by the provider.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Ref: ref("github.com/pivotal/kpack/pkg/apis/build/v1alpha2.Preview"),
+									},
+								},
+							},
+						},
+					},
+					"lastPollTime": {
+						SchemaProps: spec.SchemaProps{
+							Ref: ref("k8s.io/apimachinery/pkg/apis/meta/v1.Time"),
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{
+			"github.com/pivotal/kpack/pkg/apis/build/v1alpha2.ImageSetImageStatus", "github.com/pivotal/kpack/pkg/apis/build/v1alpha2.Preview", "github.com/pivotal/kpack/pkg/apis/core/v1alpha1.Condition", "k8s.io/apimachinery/pkg/apis/meta/v1.Time"},
+	}
+}
+
+func schema_pkg_apis_build_v1alpha2_PreviewProvider(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Type: []string{"object"},
+				Properties: map[string]spec.Schema{
+					"github": {
+						SchemaProps: spec.SchemaProps{
+							Description: "GitHub creates a preview for each open pull request.",
+							Ref:         ref("github.com/pivotal/kpack/pkg/apis/build/v1alpha2.PullRequestProvider"),
+						},
+					},
+					"gitlab": {
+						SchemaProps: spec.SchemaProps{
+							Description: "GitLab creates a preview for each open merge request.",
+							Ref:         ref("github.com/pivotal/kpack/pkg/apis/build/v1alpha2.PullRequestProvider"),
+						},
+					},
+					"branches": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Branches creates a preview for each branch that matches a pattern.",
+							Ref:         ref("github.com/pivotal/kpack/pkg/apis/build/v1alpha2.BranchProvider"),
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{
+			"github.com/pivotal/kpack/pkg/apis/build/v1alpha2.BranchProvider", "github.com/pivotal/kpack/pkg/apis/build/v1alpha2.PullRequestProvider"},
+	}
+}
+
+func schema_pkg_apis_build_v1alpha2_PullRequestProvider(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Type: []string{"object"},
+				Properties: map[string]spec.Schema{
+					"url": {
+						SchemaProps: spec.SchemaProps{
+							Description: "URL is the https base url of the api of the provider. Defaults to the public api of the provider.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"secretRef": {
+						SchemaProps: spec.SchemaProps{
+							Description: "SecretRef is a secret in the namespace of the preview image set with the api token in its token key or basic auth password. It is required to authenticate to an api outside the host of the git repository.",
+							Ref:         ref("k8s.io/api/core/v1.LocalObjectReference"),
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{
+			"k8s.io/api/core/v1.LocalObjectReference"},
+	}
+}
+
 func schema_pkg_apis_build_v1alpha2_RegistryCache(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
@@ -3974,6 +5280,13 @@ func schema_pkg_apis_core_v1alpha1_Blob(ref common.ReferenceCallback) common.Ope
 							Format: "",
 						},
 					},
+					"digest": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Digest, of the form sha256:<hex>, that the downloaded blob must match.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
 				},
 				Required: []string{"url"},
 			},
@@ -3981,6 +5294,37 @@ func schema_pkg_apis_core_v1alpha1_Blob(ref common.ReferenceCallback) common.Ope
 	}
 }
 
+func schema_pkg_apis_core_v1alpha1_BlobFingerprint(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "BlobFingerprint is reported by the blob server in response to a HEAD request and changes when the blob is replaced.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"etag": {
+						SchemaProps: spec.SchemaProps{
+							Type:   []string{"string"},
+							Format: "",
+						},
+					},
+					"lastModified": {
+						SchemaProps: spec.SchemaProps{
+							Type:   []string{"string"},
+							Format: "",
+						},
+					},
+					"contentLength": {
+						SchemaProps: spec.SchemaProps{
+							Type:   []string{"integer"},
+							Format: "int64",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
 func schema_pkg_apis_core_v1alpha1_BuildBuilderSpec(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
@@ -4303,10 +5647,95 @@ func schema_pkg_apis_core_v1alpha1_Git(ref common.ReferenceCallback) common.Open
 							Format: "",
 						},
 					},
+					"tagConstraint": {
+						SchemaProps: spec.SchemaProps{
+							Description: "TagConstraint follows the newest tag matching a glob, such as v1.*, or a semver constraint, such as >=2.0.0 <3.0.0, instead of Revision.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"includePaths": {
+						VendorExtensible: spec.VendorExtensible{
+							Extensions: spec.Extensions{
+								"x-kubernetes-list-type": "",
+							},
+						},
+						SchemaProps: spec.SchemaProps{
+							Description: "IncludePaths are globs, relative to the repository root, of the paths that trigger a new revision. Defaults to the SubPath when empty.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Type:   []string{"string"},
+										Format: "",
+									},
+								},
+							},
+						},
+					},
+					"excludePaths": {
+						VendorExtensible: spec.VendorExtensible{
+							Extensions: spec.Extensions{
+								"x-kubernetes-list-type": "",
+							},
+						},
+						SchemaProps: spec.SchemaProps{
+							Description: "ExcludePaths are globs of paths that never trigger a new revision.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Type:   []string{"string"},
+										Format: "",
+									},
+								},
+							},
+						},
+					},
+					"fetch": {
+						SchemaProps: spec.SchemaProps{
+							Ref: ref("github.com/pivotal/kpack/pkg/apis/core/v1alpha1.GitFetchOptions"),
+						},
+					},
 				},
 				Required: []string{"url", "revision"},
 			},
 		},
+		Dependencies: []string{
+			"github.com/pivotal/kpack/pkg/apis/core/v1alpha1.GitFetchOptions"},
+	}
+}
+
+func schema_pkg_apis_core_v1alpha1_GitFetchOptions(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Type: []string{"object"},
+				Properties: map[string]spec.Schema{
+					"sparse": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Sparse checks out only the files below the SubPath.",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
+					"submodules": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Submodules recursively checks out the submodules of the repository.",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
+					"lfs": {
+						SchemaProps: spec.SchemaProps{
+							Description: "LFS downloads the Git LFS objects referenced by the checked out files.",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
+				},
+			},
+		},
 	}
 }
 
@@ -4374,6 +5803,83 @@ func schema_pkg_apis_core_v1alpha1_NotaryV1Config(ref common.ReferenceCallback)
 	}
 }
 
+func schema_pkg_apis_core_v1alpha1_ObjectStore(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Type: []string{"object"},
+				Properties: map[string]spec.Schema{
+					"endpoint": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Endpoint of the S3 compatible object storage, such as https://minio.example.com:9000. Objects are addressed path style.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"region": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Region the requests are signed for. Defaults to us-east-1.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"bucket": {
+						SchemaProps: spec.SchemaProps{
+							Type:   []string{"string"},
+							Format: "",
+						},
+					},
+					"key": {
+						SchemaProps: spec.SchemaProps{
+							Type:   []string{"string"},
+							Format: "",
+						},
+					},
+					"secretRef": {
+						SchemaProps: spec.SchemaProps{
+							Description: "SecretRef references a Secret with the accessKeyId, secretAccessKey and optional sessionToken used to access the object.",
+							Ref:         ref("k8s.io/api/core/v1.LocalObjectReference"),
+						},
+					},
+					"version": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Version identifies the object when the source was resolved.",
+							Ref:         ref("github.com/pivotal/kpack/pkg/apis/core/v1alpha1.ObjectVersion"),
+						},
+					},
+				},
+				Required: []string{"endpoint", "bucket", "key"},
+			},
+		},
+		Dependencies: []string{
+			"github.com/pivotal/kpack/pkg/apis/core/v1alpha1.ObjectVersion", "k8s.io/api/core/v1.LocalObjectReference"},
+	}
+}
+
+func schema_pkg_apis_core_v1alpha1_ObjectVersion(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Type: []string{"object"},
+				Properties: map[string]spec.Schema{
+					"versionId": {
+						SchemaProps: spec.SchemaProps{
+							Type:   []string{"string"},
+							Format: "",
+						},
+					},
+					"etag": {
+						SchemaProps: spec.SchemaProps{
+							Type:   []string{"string"},
+							Format: "",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
 func schema_pkg_apis_core_v1alpha1_OrderEntry(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
@@ -4457,6 +5963,17 @@ func schema_pkg_apis_core_v1alpha1_ResolvedBlobSource(ref common.ReferenceCallba
 							Format: "",
 						},
 					},
+					"digest": {
+						SchemaProps: spec.SchemaProps{
+							Type:   []string{"string"},
+							Format: "",
+						},
+					},
+					"fingerprint": {
+						SchemaProps: spec.SchemaProps{
+							Ref: ref("github.com/pivotal/kpack/pkg/apis/core/v1alpha1.BlobFingerprint"),
+						},
+					},
 					"subPath": {
 						SchemaProps: spec.SchemaProps{
 							Type:   []string{"string"},
@@ -4467,6 +5984,8 @@ func schema_pkg_apis_core_v1alpha1_ResolvedBlobSource(ref common.ReferenceCallba
 				Required: []string{"url"},
 			},
 		},
+		Dependencies: []string{
+			"github.com/pivotal/kpack/pkg/apis/core/v1alpha1.BlobFingerprint"},
 	}
 }
 
@@ -4488,6 +6007,12 @@ func schema_pkg_apis_core_v1alpha1_ResolvedGitSource(ref common.ReferenceCallbac
 							Format: "",
 						},
 					},
+					"tag": {
+						SchemaProps: spec.SchemaProps{
+							Type:   []string{"string"},
+							Format: "",
+						},
+					},
 					"subPath": {
 						SchemaProps: spec.SchemaProps{
 							Type:   []string{"string"},
@@ -4500,10 +6025,91 @@ func schema_pkg_apis_core_v1alpha1_ResolvedGitSource(ref common.ReferenceCallbac
 							Format: "",
 						},
 					},
+					"fetch": {
+						SchemaProps: spec.SchemaProps{
+							Ref: ref("github.com/pivotal/kpack/pkg/apis/core/v1alpha1.GitFetchOptions"),
+						},
+					},
+					"skippedRevisions": {
+						VendorExtensible: spec.VendorExtensible{
+							Extensions: spec.Extensions{
+								"x-kubernetes-list-type": "",
+							},
+						},
+						SchemaProps: spec.SchemaProps{
+							Description: "SkippedRevisions are the most recent commits after Revision that did not change any path matched by the include and exclude paths.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Type:   []string{"string"},
+										Format: "",
+									},
+								},
+							},
+						},
+					},
 				},
 				Required: []string{"url", "revision", "type"},
 			},
 		},
+		Dependencies: []string{
+			"github.com/pivotal/kpack/pkg/apis/core/v1alpha1.GitFetchOptions"},
+	}
+}
+
+func schema_pkg_apis_core_v1alpha1_ResolvedObjectStoreSource(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Type: []string{"object"},
+				Properties: map[string]spec.Schema{
+					"endpoint": {
+						SchemaProps: spec.SchemaProps{
+							Type:   []string{"string"},
+							Format: "",
+						},
+					},
+					"region": {
+						SchemaProps: spec.SchemaProps{
+							Type:   []string{"string"},
+							Format: "",
+						},
+					},
+					"bucket": {
+						SchemaProps: spec.SchemaProps{
+							Type:   []string{"string"},
+							Format: "",
+						},
+					},
+					"key": {
+						SchemaProps: spec.SchemaProps{
+							Type:   []string{"string"},
+							Format: "",
+						},
+					},
+					"secretRef": {
+						SchemaProps: spec.SchemaProps{
+							Ref: ref("k8s.io/api/core/v1.LocalObjectReference"),
+						},
+					},
+					"version": {
+						SchemaProps: spec.SchemaProps{
+							Ref: ref("github.com/pivotal/kpack/pkg/apis/core/v1alpha1.ObjectVersion"),
+						},
+					},
+					"subPath": {
+						SchemaProps: spec.SchemaProps{
+							Type:   []string{"string"},
+							Format: "",
+						},
+					},
+				},
+				Required: []string{"endpoint", "bucket", "key", "version"},
+			},
+		},
+		Dependencies: []string{
+			"github.com/pivotal/kpack/pkg/apis/core/v1alpha1.ObjectVersion", "k8s.io/api/core/v1.LocalObjectReference"},
 	}
 }
 
@@ -4519,6 +6125,12 @@ func schema_pkg_apis_core_v1alpha1_ResolvedRegistrySource(ref common.ReferenceCa
 							Format: "",
 						},
 					},
+					"digest": {
+						SchemaProps: spec.SchemaProps{
+							Type:   []string{"string"},
+							Format: "",
+						},
+					},
 					"subPath": {
 						SchemaProps: spec.SchemaProps{
 							Type:   []string{"string"},
@@ -4574,11 +6186,16 @@ func schema_pkg_apis_core_v1alpha1_ResolvedSourceConfig(ref common.ReferenceCall
 							Ref: ref("github.com/pivotal/kpack/pkg/apis/core/v1alpha1.ResolvedRegistrySource"),
 						},
 					},
+					"objectStore": {
+						SchemaProps: spec.SchemaProps{
+							Ref: ref("github.com/pivotal/kpack/pkg/apis/core/v1alpha1.ResolvedObjectStoreSource"),
+						},
+					},
 				},
 			},
 		},
 		Dependencies: []string{
-			"github.com/pivotal/kpack/pkg/apis/core/v1alpha1.ResolvedBlobSource", "github.com/pivotal/kpack/pkg/apis/core/v1alpha1.ResolvedGitSource", "github.com/pivotal/kpack/pkg/apis/core/v1alpha1.ResolvedRegistrySource"},
+			"github.com/pivotal/kpack/pkg/apis/core/v1alpha1.ResolvedBlobSource", "github.com/pivotal/kpack/pkg/apis/core/v1alpha1.ResolvedGitSource", "github.com/pivotal/kpack/pkg/apis/core/v1alpha1.ResolvedObjectStoreSource", "github.com/pivotal/kpack/pkg/apis/core/v1alpha1.ResolvedRegistrySource"},
 	}
 }
 
@@ -4603,6 +6220,11 @@ func schema_pkg_apis_core_v1alpha1_SourceConfig(ref common.ReferenceCallback) co
 							Ref: ref("github.com/pivotal/kpack/pkg/apis/core/v1alpha1.Registry"),
 						},
 					},
+					"objectStore": {
+						SchemaProps: spec.SchemaProps{
+							Ref: ref("github.com/pivotal/kpack/pkg/apis/core/v1alpha1.ObjectStore"),
+						},
+					},
 					"subPath": {
 						SchemaProps: spec.SchemaProps{
 							Type:   []string{"string"},
@@ -4613,7 +6235,7 @@ func schema_pkg_apis_core_v1alpha1_SourceConfig(ref common.ReferenceCallback) co
 			},
 		},
 		Dependencies: []string{
-			"github.com/pivotal/kpack/pkg/apis/core/v1alpha1.Blob", "github.com/pivotal/kpack/pkg/apis/core/v1alpha1.Git", "github.com/pivotal/kpack/pkg/apis/core/v1alpha1.Registry"},
+			"github.com/pivotal/kpack/pkg/apis/core/v1alpha1.Blob", "github.com/pivotal/kpack/pkg/apis/core/v1alpha1.Git", "github.com/pivotal/kpack/pkg/apis/core/v1alpha1.ObjectStore", "github.com/pivotal/kpack/pkg/apis/core/v1alpha1.Registry"},
 	}
 }
 
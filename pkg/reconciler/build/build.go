@@ -2,6 +2,9 @@ package build
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"time"
 
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/equality"
@@ -26,6 +29,16 @@ import (
 const (
 	ReconcilerName = "Builds"
 	Kind           = "Build"
+
+	// podDeadlineExceeded is the reason of a pod failed by its activeDeadlineSeconds
+	podDeadlineExceeded = "DeadlineExceeded"
+
+	// promotionRetryInterval is how long to wait before retrying a failed
+	// promotion the first time. The wait doubles with every failed attempt.
+	promotionRetryInterval = time.Minute
+	// maxPromotionAttempts is how many times a promotion is attempted before
+	// it is no longer retried.
+	maxPromotionAttempts = 6
 )
 
 //go:generate counterfeiter . MetadataRetriever
@@ -38,7 +51,19 @@ type PodGenerator interface {
 	Generate(context.Context, buildpod.BuildPodable) (*corev1.Pod, error)
 }
 
-func NewController(opt reconciler.Options, k8sClient k8sclient.Interface, informer buildinformers.BuildInformer, podInformer corev1Informers.PodInformer, metadataRetriever MetadataRetriever, podGenerator PodGenerator) *controller.Impl {
+type Promoter interface {
+	Promote(context.Context, *buildapi.Build, buildapi.ImagePromotion) (string, error)
+}
+
+// PromotionConditionError is returned by a Promoter when a promotion cannot
+// succeed by retrying it, such as when the signature of a signed image is
+// missing. The promotion is reported with its Reason and is not retried.
+type PromotionConditionError interface {
+	error
+	Reason() string
+}
+
+func NewController(opt reconciler.Options, k8sClient k8sclient.Interface, informer buildinformers.BuildInformer, podInformer corev1Informers.PodInformer, metadataRetriever MetadataRetriever, podGenerator PodGenerator, promoter Promoter) *controller.Impl {
 	c := &Reconciler{
 		Client:            opt.Client,
 		K8sClient:         k8sClient,
@@ -46,9 +71,12 @@ func NewController(opt reconciler.Options, k8sClient k8sclient.Interface, inform
 		Lister:            informer.Lister(),
 		PodLister:         podInformer.Lister(),
 		PodGenerator:      podGenerator,
+		Promoter:          promoter,
+		Now:               time.Now,
 	}
 
 	impl := controller.NewImpl(c, opt.Logger, ReconcilerName)
+	c.EnqueueAfter = impl.EnqueueAfter
 
 	informer.Informer().AddEventHandler(reconciler.Handler(impl.Enqueue))
 
@@ -67,6 +95,9 @@ type Reconciler struct {
 	K8sClient         k8sclient.Interface
 	PodLister         v1Listers.PodLister
 	PodGenerator      PodGenerator
+	Promoter          Promoter
+	EnqueueAfter      func(obj interface{}, after time.Duration)
+	Now               func() time.Time
 }
 
 func (c *Reconciler) Reconcile(ctx context.Context, key string) error {
@@ -97,7 +128,14 @@ func (c *Reconciler) Reconcile(ctx context.Context, key string) error {
 
 func (c *Reconciler) reconcile(ctx context.Context, build *buildapi.Build) error {
 	if build.Finished() {
-		return nil
+		return c.reconcilePromotions(ctx, build)
+	}
+
+	if build.CancelRequested() {
+		cancelled, err := c.reconcileCancel(ctx, build)
+		if err != nil || cancelled {
+			return err
+		}
 	}
 
 	pod, err := c.reconcileBuildPod(ctx, build)
@@ -126,10 +164,100 @@ func (c *Reconciler) reconcile(ctx context.Context, build *buildapi.Build) error
 	build.Status.PodName = pod.Name
 	build.Status.StepStates = stepStates(pod)
 	build.Status.StepsCompleted = stepCompleted(pod)
+	reportStepTimings(build, pod)
 	build.Status.Conditions = conditionForPod(pod)
+	return c.reconcilePromotions(ctx, build)
+}
+
+// reconcilePromotions copies the image of a successful build to each of its
+// promotions that has not yet succeeded. Failed promotions are retried with an
+// exponential backoff starting at promotionRetryInterval until they have been
+// attempted maxPromotionAttempts times. A PromotionConditionError is not
+// retried.
+func (c *Reconciler) reconcilePromotions(ctx context.Context, build *buildapi.Build) error {
+	if !build.IsSuccess() || len(build.Spec.Promotions) == 0 {
+		return nil
+	}
+
+	previous := map[string]buildapi.BuildPromotionStatus{}
+	for _, status := range build.Status.Promotions {
+		previous[status.Repository] = status
+	}
+
+	now := c.Now()
+	var retryAfter time.Duration
+	retryIn := func(wait time.Duration) {
+		if retryAfter == 0 || wait < retryAfter {
+			retryAfter = wait
+		}
+	}
+
+	statuses := make([]buildapi.BuildPromotionStatus, 0, len(build.Spec.Promotions))
+	for _, promotion := range build.Spec.Promotions {
+		status, ok := previous[promotion.Repository]
+		if ok && status.Status != corev1.ConditionUnknown {
+			statuses = append(statuses, status)
+			continue
+		}
+
+		if ok && status.LastAttemptTime != nil {
+			if wait := status.LastAttemptTime.Add(promotionBackoff(status.Attempts)).Sub(now); wait > 0 {
+				retryIn(wait)
+				statuses = append(statuses, status)
+				continue
+			}
+		}
+
+		attempts := status.Attempts + 1
+		image, err := c.Promoter.Promote(ctx, build, promotion)
+		if err != nil {
+			status = buildapi.BuildPromotionStatus{
+				Repository:      promotion.Repository,
+				Status:          corev1.ConditionUnknown,
+				Reason:          buildapi.PromotionRetrying,
+				Message:         err.Error(),
+				Attempts:        attempts,
+				LastAttemptTime: &metav1.Time{Time: now},
+			}
+			var conditionErr PromotionConditionError
+			if errors.As(err, &conditionErr) {
+				status.Status = corev1.ConditionFalse
+				status.Reason = conditionErr.Reason()
+			} else if attempts >= maxPromotionAttempts {
+				status.Status = corev1.ConditionFalse
+				status.Reason = buildapi.PromotionFailed
+			} else {
+				retryIn(promotionBackoff(attempts))
+			}
+			statuses = append(statuses, status)
+			continue
+		}
+
+		statuses = append(statuses, buildapi.BuildPromotionStatus{
+			Repository:      promotion.Repository,
+			Image:           image,
+			Status:          corev1.ConditionTrue,
+			Attempts:        attempts,
+			LastAttemptTime: &metav1.Time{Time: now},
+		})
+	}
+	build.Status.Promotions = statuses
+
+	if retryAfter > 0 && c.EnqueueAfter != nil {
+		c.EnqueueAfter(build, retryAfter)
+	}
 	return nil
 }
 
+// promotionBackoff is how long to wait after the last of attempts failed
+// promotion attempts before attempting it again.
+func promotionBackoff(attempts int64) time.Duration {
+	if attempts < 1 {
+		return 0
+	}
+	return promotionRetryInterval << (attempts - 1)
+}
+
 func (c *Reconciler) reconcileBuildPod(ctx context.Context, build *buildapi.Build) (*corev1.Pod, error) {
 	pod, err := c.PodLister.Pods(build.Namespace).Get(build.PodName())
 	if err != nil && !k8s_errors.IsNotFound(err) {
@@ -145,6 +273,51 @@ func (c *Reconciler) reconcileBuildPod(ctx context.Context, build *buildapi.Buil
 	return c.K8sClient.CoreV1().Pods(build.Namespace).Create(ctx, podConfig, metav1.CreateOptions{})
 }
 
+// reconcileCancel deletes the pod of a build that is requested to be
+// cancelled and fails the build with the step that was interrupted. Builds
+// whose pod already completed are not cancelled.
+func (c *Reconciler) reconcileCancel(ctx context.Context, build *buildapi.Build) (bool, error) {
+	pod, err := c.PodLister.Pods(build.Namespace).Get(build.PodName())
+	if err != nil && !k8s_errors.IsNotFound(err) {
+		return false, err
+	}
+
+	if pod != nil {
+		if pod.Status.Phase == corev1.PodSucceeded || pod.Status.Phase == corev1.PodFailed {
+			return false, nil
+		}
+
+		err := c.K8sClient.CoreV1().Pods(pod.Namespace).Delete(ctx, pod.Name, metav1.DeleteOptions{})
+		if err != nil && !k8s_errors.IsNotFound(err) {
+			return false, err
+		}
+
+		build.Status.PodName = pod.Name
+		build.Status.StepStates = stepStates(pod)
+		build.Status.StepsCompleted = stepCompleted(pod)
+		build.Status.InterruptedStep = runningStep(pod)
+		reportStepTimings(build, pod)
+	}
+
+	build.Status.Conditions = corev1alpha1.Conditions{
+		{
+			Type:               corev1alpha1.ConditionSucceeded,
+			Status:             corev1.ConditionFalse,
+			Reason:             buildapi.BuildCancelled,
+			Message:            cancelledMessage(build.Status.InterruptedStep),
+			LastTransitionTime: corev1alpha1.VolatileTime{Inner: metav1.Now()},
+		},
+	}
+	return true, nil
+}
+
+func cancelledMessage(step string) string {
+	if step == "" {
+		return "Build cancelled before a step started"
+	}
+	return fmt.Sprintf("Build cancelled while running step %s", step)
+}
+
 func conditionForPod(pod *corev1.Pod) corev1alpha1.Conditions {
 	switch pod.Status.Phase {
 	case corev1.PodSucceeded:
@@ -156,6 +329,17 @@ func conditionForPod(pod *corev1.Pod) corev1alpha1.Conditions {
 			},
 		}
 	case corev1.PodFailed:
+		if pod.Status.Reason == podDeadlineExceeded {
+			return corev1alpha1.Conditions{
+				{
+					Type:               corev1alpha1.ConditionSucceeded,
+					Status:             corev1.ConditionFalse,
+					Reason:             buildapi.BuildTimedOut,
+					Message:            timedOutMessage(pod),
+					LastTransitionTime: corev1alpha1.VolatileTime{Inner: metav1.Now()},
+				},
+			}
+		}
 		return corev1alpha1.Conditions{
 			{
 				Type:               corev1alpha1.ConditionSucceeded,
@@ -189,6 +373,31 @@ func conditionForPod(pod *corev1.Pod) corev1alpha1.Conditions {
 	}
 }
 
+func timedOutMessage(pod *corev1.Pod) string {
+	timeout := "its deadline"
+	if pod.Spec.ActiveDeadlineSeconds != nil {
+		timeout = (time.Duration(*pod.Spec.ActiveDeadlineSeconds) * time.Second).String()
+	}
+
+	step := runningStep(pod)
+	if step == "" {
+		return fmt.Sprintf("Build timed out after %s before a step started", timeout)
+	}
+	return fmt.Sprintf("Build timed out after %s while running step %s", timeout, step)
+}
+
+// runningStep is the first step that did not complete successfully.
+func runningStep(pod *corev1.Pod) string {
+	statuses := append(append([]corev1.ContainerStatus{}, pod.Status.InitContainerStatuses...), pod.Status.ContainerStatuses...)
+	for _, s := range statuses {
+		if s.State.Terminated != nil && s.State.Terminated.ExitCode == 0 {
+			continue
+		}
+		return s.Name
+	}
+	return ""
+}
+
 func stepStates(pod *corev1.Pod) []corev1.ContainerState {
 	states := make([]corev1.ContainerState, 0, len(pod.Status.InitContainerStatuses))
 	for _, s := range pod.Status.InitContainerStatuses {
@@ -207,6 +416,81 @@ func stepCompleted(pod *corev1.Pod) []string {
 	return completed
 }
 
+// reportStepTimings reports the steps of pod that started on build, the time
+// the build and its image were queued before its first step and, once pod
+// finished, the duration of the build.
+func reportStepTimings(build *buildapi.Build, pod *corev1.Pod) {
+	build.Status.Steps = buildSteps(pod)
+	build.Status.QueueDuration = nil
+	build.Status.BuildDuration = nil
+
+	var started time.Time
+	for _, step := range build.Status.Steps {
+		if step.StartedAt != nil {
+			started = step.StartedAt.Time
+			break
+		}
+	}
+	if started.IsZero() {
+		return
+	}
+
+	queuedAt := build.CreationTimestamp.Time
+	if q := build.QueuedAt(); !q.IsZero() && (queuedAt.IsZero() || q.Before(queuedAt)) {
+		queuedAt = q
+	}
+	if !queuedAt.IsZero() {
+		build.Status.QueueDuration = &metav1.Duration{Duration: started.Sub(queuedAt)}
+	}
+
+	if pod.Status.Phase != corev1.PodSucceeded && pod.Status.Phase != corev1.PodFailed {
+		return
+	}
+
+	var finished time.Time
+	for _, step := range build.Status.Steps {
+		if step.FinishedAt != nil && step.FinishedAt.After(finished) {
+			finished = step.FinishedAt.Time
+		}
+	}
+	if !finished.IsZero() {
+		build.Status.BuildDuration = &metav1.Duration{Duration: finished.Sub(started)}
+	}
+}
+
+// buildSteps returns the timing of each step of pod that started.
+func buildSteps(pod *corev1.Pod) []buildapi.BuildStepStatus {
+	statuses := append(append([]corev1.ContainerStatus{}, pod.Status.InitContainerStatuses...), pod.Status.ContainerStatuses...)
+	steps := make([]buildapi.BuildStepStatus, 0, len(statuses))
+	for _, s := range statuses {
+		switch {
+		case s.State.Terminated != nil:
+			terminated := s.State.Terminated.DeepCopy()
+			step := buildapi.BuildStepStatus{
+				Name:     s.Name,
+				ExitCode: &terminated.ExitCode,
+			}
+			if !terminated.StartedAt.IsZero() {
+				step.StartedAt = &terminated.StartedAt
+			}
+			if !terminated.FinishedAt.IsZero() {
+				step.FinishedAt = &terminated.FinishedAt
+			}
+			if step.StartedAt != nil && step.FinishedAt != nil {
+				step.Duration = &metav1.Duration{Duration: terminated.FinishedAt.Sub(terminated.StartedAt.Time)}
+			}
+			steps = append(steps, step)
+		case s.State.Running != nil:
+			step := buildapi.BuildStepStatus{Name: s.Name}
+			if running := s.State.Running.DeepCopy(); !running.StartedAt.IsZero() {
+				step.StartedAt = &running.StartedAt
+			}
+			steps = append(steps, step)
+		}
+	}
+	return steps
+}
+
 func (c *Reconciler) updateStatus(ctx context.Context, desired *buildapi.Build) error {
 	desired.Status.ObservedGeneration = desired.Generation
 	original, err := c.Lister.Builds(desired.Namespace).Get(desired.Name)
@@ -14,6 +14,7 @@ import (
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	k8sfake "k8s.io/client-go/kubernetes/fake"
 	clientgotesting "k8s.io/client-go/testing"
 	"k8s.io/client-go/tools/record"
@@ -48,6 +49,9 @@ func testBuildReconciler(t *testing.T, when spec.G, it spec.S) {
 	var (
 		fakeMetadataRetriever = &buildfakes.FakeMetadataRetriever{}
 		podGenerator          = &testPodGenerator{}
+		promoter              = &testPromoter{}
+		enqueuedAfter         time.Duration
+		now                   = time.Date(2022, time.March, 1, 12, 0, 0, 0, time.UTC)
 		ctx                   = context.Background()
 	)
 
@@ -69,6 +73,13 @@ func testBuildReconciler(t *testing.T, when spec.G, it spec.S) {
 				PodLister:         listers.GetPodLister(),
 				MetadataRetriever: fakeMetadataRetriever,
 				PodGenerator:      podGenerator,
+				Promoter:          promoter,
+				EnqueueAfter: func(obj interface{}, after time.Duration) {
+					enqueuedAfter = after
+				},
+				Now: func() time.Time {
+					return now
+				},
 			}
 
 			rtesting.PrependGenerateNameReactor(&fakeClient.Fake)
@@ -329,6 +340,7 @@ func testBuildReconciler(t *testing.T, when spec.G, it spec.S) {
 				require.NoError(t, err)
 
 				startTime := time.Now()
+				build.CreationTimestamp = metav1.NewTime(startTime.Add(-time.Minute))
 				pod.Status.InitContainerStatuses = []corev1.ContainerStatus{
 					{
 						Name: "step-1",
@@ -338,6 +350,8 @@ func testBuildReconciler(t *testing.T, when spec.G, it spec.S) {
 								Reason:      "Terminated",
 								Message:     "Message",
 								ContainerID: "container.ID",
+								StartedAt:   metav1.Time{Time: startTime.Add(-50 * time.Second)},
+								FinishedAt:  metav1.Time{Time: startTime},
 							},
 						},
 					},
@@ -391,6 +405,8 @@ func testBuildReconciler(t *testing.T, when spec.G, it spec.S) {
 												Reason:      "Terminated",
 												Message:     "Message",
 												ContainerID: "container.ID",
+												StartedAt:   metav1.Time{Time: startTime.Add(-50 * time.Second)},
+												FinishedAt:  metav1.Time{Time: startTime},
 											},
 										},
 										{
@@ -408,6 +424,20 @@ func testBuildReconciler(t *testing.T, when spec.G, it spec.S) {
 									StepsCompleted: []string{
 										"step-1",
 									},
+									Steps: []buildapi.BuildStepStatus{
+										{
+											Name:       "step-1",
+											StartedAt:  &metav1.Time{Time: startTime.Add(-50 * time.Second)},
+											FinishedAt: &metav1.Time{Time: startTime},
+											Duration:   &metav1.Duration{Duration: 50 * time.Second},
+											ExitCode:   exitCode(0),
+										},
+										{
+											Name:      "step-2",
+											StartedAt: &metav1.Time{Time: startTime},
+										},
+									},
+									QueueDuration: &metav1.Duration{Duration: 10 * time.Second},
 								},
 							},
 						},
@@ -488,6 +518,9 @@ func testBuildReconciler(t *testing.T, when spec.G, it spec.S) {
 									StepsCompleted: []string{
 										"step-1",
 									},
+									Steps: []buildapi.BuildStepStatus{
+										{Name: "step-1", ExitCode: exitCode(0)},
+									},
 								},
 							},
 						},
@@ -597,6 +630,10 @@ func testBuildReconciler(t *testing.T, when spec.G, it spec.S) {
 										"step-1",
 										"step-2",
 									},
+									Steps: []buildapi.BuildStepStatus{
+										{Name: "step-1", ExitCode: exitCode(0)},
+										{Name: "step-2", ExitCode: exitCode(0)},
+									},
 								},
 							},
 						},
@@ -606,6 +643,185 @@ func testBuildReconciler(t *testing.T, when spec.G, it spec.S) {
 				assert.Equal(t, fakeMetadataRetriever.GetBuiltImageCallCount(), 1)
 			})
 
+			it("reports the timing of each step and the duration of the build", func() {
+				createdAt := time.Now().Add(-time.Hour).Truncate(time.Second)
+				build.CreationTimestamp = metav1.NewTime(createdAt)
+				at := func(seconds int) metav1.Time {
+					return metav1.NewTime(createdAt.Add(time.Duration(seconds) * time.Second))
+				}
+
+				pod, err := podGenerator.Generate(ctx, build)
+				require.NoError(t, err)
+				pod.Status.Phase = corev1.PodSucceeded
+				pod.Status.InitContainerStatuses = []corev1.ContainerStatus{
+					{
+						Name: "prepare",
+						State: corev1.ContainerState{
+							Terminated: &corev1.ContainerStateTerminated{StartedAt: at(30), FinishedAt: at(35)},
+						},
+					},
+					{
+						Name: "build",
+						State: corev1.ContainerState{
+							Terminated: &corev1.ContainerStateTerminated{StartedAt: at(36), FinishedAt: at(156)},
+						},
+					},
+				}
+				pod.Status.ContainerStatuses = []corev1.ContainerStatus{
+					{
+						Name: "completion",
+						State: corev1.ContainerState{
+							Terminated: &corev1.ContainerStateTerminated{StartedAt: at(157), FinishedAt: at(160)},
+						},
+					},
+				}
+
+				rt.Test(rtesting.TableRow{
+					Key: key,
+					Objects: []runtime.Object{
+						build,
+						pod,
+					},
+					WantErr: false,
+					WantStatusUpdates: []clientgotesting.UpdateActionImpl{
+						{
+							Object: &buildapi.Build{
+								ObjectMeta: build.ObjectMeta,
+								Spec:       build.Spec,
+								Status: buildapi.BuildStatus{
+									Status: corev1alpha1.Status{
+										ObservedGeneration: originalGeneration,
+										Conditions: corev1alpha1.Conditions{
+											{
+												Type:   corev1alpha1.ConditionSucceeded,
+												Status: corev1.ConditionTrue,
+											},
+										},
+									},
+									PodName: "build-name-build-pod",
+									BuildMetadata: corev1alpha1.BuildpackMetadataList{{
+										Id:       "io.buildpack.executed",
+										Version:  "1.1",
+										Homepage: "mysupercoolsite.com",
+									}},
+									LatestImage: identifier,
+									Stack: corev1alpha1.BuildStack{
+										RunImage: "somerun/123@sha256:12334563ad",
+										ID:       "io.buildpacks.stacks.bionic",
+									},
+									StepStates: []corev1.ContainerState{
+										{Terminated: &corev1.ContainerStateTerminated{StartedAt: at(30), FinishedAt: at(35)}},
+										{Terminated: &corev1.ContainerStateTerminated{StartedAt: at(36), FinishedAt: at(156)}},
+									},
+									StepsCompleted: []string{
+										"prepare",
+										"build",
+									},
+									Steps: []buildapi.BuildStepStatus{
+										{Name: "prepare", StartedAt: timePtr(at(30)), FinishedAt: timePtr(at(35)), Duration: &metav1.Duration{Duration: 5 * time.Second}, ExitCode: exitCode(0)},
+										{Name: "build", StartedAt: timePtr(at(36)), FinishedAt: timePtr(at(156)), Duration: &metav1.Duration{Duration: 2 * time.Minute}, ExitCode: exitCode(0)},
+										{Name: "completion", StartedAt: timePtr(at(157)), FinishedAt: timePtr(at(160)), Duration: &metav1.Duration{Duration: 3 * time.Second}, ExitCode: exitCode(0)},
+									},
+									QueueDuration: &metav1.Duration{Duration: 30 * time.Second},
+									BuildDuration: &metav1.Duration{Duration: 130 * time.Second},
+								},
+							},
+						},
+					},
+				})
+			})
+
+			it("includes the time the image was queued before the build was created in the queue duration", func() {
+				createdAt := time.Now().Add(-time.Hour).Truncate(time.Second)
+				build.CreationTimestamp = metav1.NewTime(createdAt)
+				build.Annotations = map[string]string{
+					buildapi.BuildQueuedAtAnnotation: createdAt.Add(-time.Minute).UTC().Format(time.RFC3339),
+				}
+				at := func(seconds int) metav1.Time {
+					return metav1.NewTime(createdAt.Add(time.Duration(seconds) * time.Second))
+				}
+
+				pod, err := podGenerator.Generate(ctx, build)
+				require.NoError(t, err)
+				pod.Status.Phase = corev1.PodSucceeded
+				pod.Status.InitContainerStatuses = []corev1.ContainerStatus{
+					{
+						Name: "prepare",
+						State: corev1.ContainerState{
+							Terminated: &corev1.ContainerStateTerminated{StartedAt: at(30), FinishedAt: at(35)},
+						},
+					},
+					{
+						Name: "build",
+						State: corev1.ContainerState{
+							Terminated: &corev1.ContainerStateTerminated{StartedAt: at(36), FinishedAt: at(156)},
+						},
+					},
+				}
+				pod.Status.ContainerStatuses = []corev1.ContainerStatus{
+					{
+						Name: "completion",
+						State: corev1.ContainerState{
+							Terminated: &corev1.ContainerStateTerminated{StartedAt: at(157), FinishedAt: at(160)},
+						},
+					},
+				}
+
+				rt.Test(rtesting.TableRow{
+					Key: key,
+					Objects: []runtime.Object{
+						build,
+						pod,
+					},
+					WantErr: false,
+					WantStatusUpdates: []clientgotesting.UpdateActionImpl{
+						{
+							Object: &buildapi.Build{
+								ObjectMeta: build.ObjectMeta,
+								Spec:       build.Spec,
+								Status: buildapi.BuildStatus{
+									Status: corev1alpha1.Status{
+										ObservedGeneration: originalGeneration,
+										Conditions: corev1alpha1.Conditions{
+											{
+												Type:   corev1alpha1.ConditionSucceeded,
+												Status: corev1.ConditionTrue,
+											},
+										},
+									},
+									PodName: "build-name-build-pod",
+									BuildMetadata: corev1alpha1.BuildpackMetadataList{{
+										Id:       "io.buildpack.executed",
+										Version:  "1.1",
+										Homepage: "mysupercoolsite.com",
+									}},
+									LatestImage: identifier,
+									Stack: corev1alpha1.BuildStack{
+										RunImage: "somerun/123@sha256:12334563ad",
+										ID:       "io.buildpacks.stacks.bionic",
+									},
+									StepStates: []corev1.ContainerState{
+										{Terminated: &corev1.ContainerStateTerminated{StartedAt: at(30), FinishedAt: at(35)}},
+										{Terminated: &corev1.ContainerStateTerminated{StartedAt: at(36), FinishedAt: at(156)}},
+									},
+									StepsCompleted: []string{
+										"prepare",
+										"build",
+									},
+									Steps: []buildapi.BuildStepStatus{
+										{Name: "prepare", StartedAt: timePtr(at(30)), FinishedAt: timePtr(at(35)), Duration: &metav1.Duration{Duration: 5 * time.Second}, ExitCode: exitCode(0)},
+										{Name: "build", StartedAt: timePtr(at(36)), FinishedAt: timePtr(at(156)), Duration: &metav1.Duration{Duration: 2 * time.Minute}, ExitCode: exitCode(0)},
+										{Name: "completion", StartedAt: timePtr(at(157)), FinishedAt: timePtr(at(160)), Duration: &metav1.Duration{Duration: 3 * time.Second}, ExitCode: exitCode(0)},
+									},
+									QueueDuration: &metav1.Duration{Duration: 90 * time.Second},
+									BuildDuration: &metav1.Duration{Duration: 130 * time.Second},
+								},
+							},
+						},
+					},
+				})
+			})
+
 			it("does not fetch metadata if already retrieved", func() {
 				pod, err := podGenerator.Generate(ctx, build)
 				require.NoError(t, err)
@@ -814,6 +1030,82 @@ func testBuildReconciler(t *testing.T, when spec.G, it spec.S) {
 									StepsCompleted: []string{
 										"step-1",
 									},
+									Steps: []buildapi.BuildStepStatus{
+										{Name: "step-1", ExitCode: exitCode(1)},
+									},
+								},
+							},
+						},
+					},
+				})
+			})
+
+			it("sets the build status to BuildTimedOut when the pod deadline is exceeded", func() {
+				pod, err := podGenerator.Generate(ctx, build)
+				require.NoError(t, err)
+				deadline := int64(3600)
+				pod.Spec.ActiveDeadlineSeconds = &deadline
+				pod.Status.Phase = corev1.PodFailed
+				pod.Status.Reason = "DeadlineExceeded"
+				pod.Status.InitContainerStatuses = []corev1.ContainerStatus{
+					{
+						Name: "prepare",
+						State: corev1.ContainerState{
+							Terminated: &corev1.ContainerStateTerminated{ExitCode: 0},
+						},
+					},
+					{
+						Name: "detect",
+						State: corev1.ContainerState{
+							Terminated: &corev1.ContainerStateTerminated{ExitCode: 137, Reason: "Error"},
+						},
+					},
+					{
+						Name: "export",
+						State: corev1.ContainerState{
+							Waiting: &corev1.ContainerStateWaiting{Reason: "PodInitializing"},
+						},
+					},
+				}
+
+				rt.Test(rtesting.TableRow{
+					Key: key,
+					Objects: []runtime.Object{
+						build,
+						pod,
+					},
+					WantErr: false,
+					WantStatusUpdates: []clientgotesting.UpdateActionImpl{
+						{
+							Object: &buildapi.Build{
+								ObjectMeta: build.ObjectMeta,
+								Spec:       build.Spec,
+								Status: buildapi.BuildStatus{
+									Status: corev1alpha1.Status{
+										ObservedGeneration: originalGeneration,
+										Conditions: corev1alpha1.Conditions{
+											{
+												Type:    corev1alpha1.ConditionSucceeded,
+												Status:  corev1.ConditionFalse,
+												Reason:  buildapi.BuildTimedOut,
+												Message: "Build timed out after 1h0m0s while running step detect",
+											},
+										},
+									},
+									PodName: "build-name-build-pod",
+									StepStates: []corev1.ContainerState{
+										{Terminated: &corev1.ContainerStateTerminated{ExitCode: 0}},
+										{Terminated: &corev1.ContainerStateTerminated{ExitCode: 137, Reason: "Error"}},
+										{Waiting: &corev1.ContainerStateWaiting{Reason: "PodInitializing"}},
+									},
+									StepsCompleted: []string{
+										"prepare",
+										"detect",
+									},
+									Steps: []buildapi.BuildStepStatus{
+										{Name: "prepare", ExitCode: exitCode(0)},
+										{Name: "detect", ExitCode: exitCode(137)},
+									},
 								},
 							},
 						},
@@ -866,6 +1158,441 @@ func testBuildReconciler(t *testing.T, when spec.G, it spec.S) {
 			})
 		})
 
+		when("build is requested to be cancelled", func() {
+			cancelledBuild := build.DeepCopy()
+			cancelledBuild.Annotations = map[string]string{buildapi.BuildCancelAnnotation: "true"}
+
+			it("deletes the pod and fails the build with the interrupted step", func() {
+				pod, err := podGenerator.Generate(ctx, build)
+				require.NoError(t, err)
+				pod.Status.Phase = corev1.PodPending
+				pod.Status.InitContainerStatuses = []corev1.ContainerStatus{
+					{
+						Name: "prepare",
+						State: corev1.ContainerState{
+							Terminated: &corev1.ContainerStateTerminated{ExitCode: 0},
+						},
+					},
+					{
+						Name: "build",
+						State: corev1.ContainerState{
+							Running: &corev1.ContainerStateRunning{},
+						},
+					},
+				}
+
+				rt.Test(rtesting.TableRow{
+					Key: key,
+					Objects: []runtime.Object{
+						cancelledBuild,
+						pod,
+					},
+					WantErr: false,
+					WantDeletes: []clientgotesting.DeleteActionImpl{
+						{
+							ActionImpl: clientgotesting.ActionImpl{
+								Namespace: namespace,
+								Resource: schema.GroupVersionResource{
+									Resource: "pods",
+								},
+							},
+							Name: "build-name-build-pod",
+						},
+					},
+					WantStatusUpdates: []clientgotesting.UpdateActionImpl{
+						{
+							Object: &buildapi.Build{
+								ObjectMeta: cancelledBuild.ObjectMeta,
+								Spec:       cancelledBuild.Spec,
+								Status: buildapi.BuildStatus{
+									Status: corev1alpha1.Status{
+										ObservedGeneration: originalGeneration,
+										Conditions: corev1alpha1.Conditions{
+											{
+												Type:    corev1alpha1.ConditionSucceeded,
+												Status:  corev1.ConditionFalse,
+												Reason:  buildapi.BuildCancelled,
+												Message: "Build cancelled while running step build",
+											},
+										},
+									},
+									PodName: "build-name-build-pod",
+									StepStates: []corev1.ContainerState{
+										{Terminated: &corev1.ContainerStateTerminated{ExitCode: 0}},
+										{Running: &corev1.ContainerStateRunning{}},
+									},
+									StepsCompleted:  []string{"prepare"},
+									InterruptedStep: "build",
+									Steps: []buildapi.BuildStepStatus{
+										{Name: "prepare", ExitCode: exitCode(0)},
+										{Name: "build"},
+									},
+								},
+							},
+						},
+					},
+				})
+			})
+
+			it("fails the build without creating a pod when the build has not started", func() {
+				rt.Test(rtesting.TableRow{
+					Key: key,
+					Objects: []runtime.Object{
+						cancelledBuild,
+					},
+					WantErr: false,
+					WantStatusUpdates: []clientgotesting.UpdateActionImpl{
+						{
+							Object: &buildapi.Build{
+								ObjectMeta: cancelledBuild.ObjectMeta,
+								Spec:       cancelledBuild.Spec,
+								Status: buildapi.BuildStatus{
+									Status: corev1alpha1.Status{
+										ObservedGeneration: originalGeneration,
+										Conditions: corev1alpha1.Conditions{
+											{
+												Type:    corev1alpha1.ConditionSucceeded,
+												Status:  corev1.ConditionFalse,
+												Reason:  buildapi.BuildCancelled,
+												Message: "Build cancelled before a step started",
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+				})
+			})
+
+			it("does not cancel a build whose pod already completed", func() {
+				pod, err := podGenerator.Generate(ctx, build)
+				require.NoError(t, err)
+				pod.Status.Phase = corev1.PodFailed
+
+				rt.Test(rtesting.TableRow{
+					Key: key,
+					Objects: []runtime.Object{
+						cancelledBuild,
+						pod,
+					},
+					WantErr: false,
+					WantStatusUpdates: []clientgotesting.UpdateActionImpl{
+						{
+							Object: &buildapi.Build{
+								ObjectMeta: cancelledBuild.ObjectMeta,
+								Spec:       cancelledBuild.Spec,
+								Status: buildapi.BuildStatus{
+									Status: corev1alpha1.Status{
+										ObservedGeneration: originalGeneration,
+										Conditions: corev1alpha1.Conditions{
+											{
+												Type:   corev1alpha1.ConditionSucceeded,
+												Status: corev1.ConditionFalse,
+											},
+										},
+									},
+									PodName:        "build-name-build-pod",
+									StepStates:     []corev1.ContainerState{},
+									StepsCompleted: []string{},
+								},
+							},
+						},
+					},
+				})
+			})
+		})
+
+		when("build has promotions", func() {
+			promotions := []buildapi.ImagePromotion{
+				{Repository: "dr.registry.io/app"},
+				{Repository: "other.registry.io/app"},
+			}
+
+			succeededBuild := func(promotionStatuses []buildapi.BuildPromotionStatus) *buildapi.Build {
+				b := build.DeepCopy()
+				b.Spec.Promotions = promotions
+				b.Status = buildapi.BuildStatus{
+					Status: corev1alpha1.Status{
+						ObservedGeneration: originalGeneration,
+						Conditions: corev1alpha1.Conditions{
+							{
+								Type:   corev1alpha1.ConditionSucceeded,
+								Status: corev1.ConditionTrue,
+							},
+						},
+					},
+					PodName:     "build-name-build-pod",
+					LatestImage: "someimage/name@sha256:1234567",
+					Promotions:  promotionStatuses,
+				}
+				return b
+			}
+
+			it("promotes the built image to each repository", func() {
+				promoter.promoted = map[string]string{
+					"dr.registry.io/app":    "dr.registry.io/app@sha256:1234567",
+					"other.registry.io/app": "other.registry.io/app@sha256:1234567",
+				}
+
+				rt.Test(rtesting.TableRow{
+					Key: key,
+					Objects: []runtime.Object{
+						succeededBuild(nil),
+					},
+					WantErr: false,
+					WantStatusUpdates: []clientgotesting.UpdateActionImpl{
+						{
+							Object: succeededBuild([]buildapi.BuildPromotionStatus{
+								{
+									Repository:      "dr.registry.io/app",
+									Image:           "dr.registry.io/app@sha256:1234567",
+									Status:          corev1.ConditionTrue,
+									Attempts:        1,
+									LastAttemptTime: &metav1.Time{Time: now},
+								},
+								{
+									Repository:      "other.registry.io/app",
+									Image:           "other.registry.io/app@sha256:1234567",
+									Status:          corev1.ConditionTrue,
+									Attempts:        1,
+									LastAttemptTime: &metav1.Time{Time: now},
+								},
+							}),
+						},
+					},
+				})
+
+				assert.Equal(t, []string{"dr.registry.io/app", "other.registry.io/app"}, promoter.calls)
+				assert.Equal(t, time.Duration(0), enqueuedAfter)
+			})
+
+			it("records failed promotions and retries them", func() {
+				promoter.promoted = map[string]string{
+					"dr.registry.io/app": "dr.registry.io/app@sha256:1234567",
+				}
+
+				rt.Test(rtesting.TableRow{
+					Key: key,
+					Objects: []runtime.Object{
+						succeededBuild(nil),
+					},
+					WantErr: false,
+					WantStatusUpdates: []clientgotesting.UpdateActionImpl{
+						{
+							Object: succeededBuild([]buildapi.BuildPromotionStatus{
+								{
+									Repository:      "dr.registry.io/app",
+									Image:           "dr.registry.io/app@sha256:1234567",
+									Status:          corev1.ConditionTrue,
+									Attempts:        1,
+									LastAttemptTime: &metav1.Time{Time: now},
+								},
+								{
+									Repository:      "other.registry.io/app",
+									Status:          corev1.ConditionUnknown,
+									Reason:          buildapi.PromotionRetrying,
+									Message:         "unauthorized to push to other.registry.io/app",
+									Attempts:        1,
+									LastAttemptTime: &metav1.Time{Time: now},
+								},
+							}),
+						},
+					},
+				})
+
+				assert.Equal(t, time.Minute, enqueuedAfter)
+			})
+
+			it("does not repeat successful promotions", func() {
+				promoter.promoted = map[string]string{
+					"other.registry.io/app": "other.registry.io/app@sha256:1234567",
+				}
+
+				rt.Test(rtesting.TableRow{
+					Key: key,
+					Objects: []runtime.Object{
+						succeededBuild([]buildapi.BuildPromotionStatus{
+							{
+								Repository:      "dr.registry.io/app",
+								Image:           "dr.registry.io/app@sha256:1234567",
+								Status:          corev1.ConditionTrue,
+								Attempts:        1,
+								LastAttemptTime: &metav1.Time{Time: now.Add(-time.Hour)},
+							},
+							{
+								Repository:      "other.registry.io/app",
+								Status:          corev1.ConditionUnknown,
+								Reason:          buildapi.PromotionRetrying,
+								Message:         "unauthorized to push to other.registry.io/app",
+								Attempts:        1,
+								LastAttemptTime: &metav1.Time{Time: now.Add(-time.Minute)},
+							},
+						}),
+					},
+					WantErr: false,
+					WantStatusUpdates: []clientgotesting.UpdateActionImpl{
+						{
+							Object: succeededBuild([]buildapi.BuildPromotionStatus{
+								{
+									Repository:      "dr.registry.io/app",
+									Image:           "dr.registry.io/app@sha256:1234567",
+									Status:          corev1.ConditionTrue,
+									Attempts:        1,
+									LastAttemptTime: &metav1.Time{Time: now.Add(-time.Hour)},
+								},
+								{
+									Repository:      "other.registry.io/app",
+									Image:           "other.registry.io/app@sha256:1234567",
+									Status:          corev1.ConditionTrue,
+									Attempts:        2,
+									LastAttemptTime: &metav1.Time{Time: now},
+								},
+							}),
+						},
+					},
+				})
+
+				assert.Equal(t, []string{"other.registry.io/app"}, promoter.calls)
+			})
+
+			it("waits longer after each failed attempt before retrying", func() {
+				rt.Test(rtesting.TableRow{
+					Key: key,
+					Objects: []runtime.Object{
+						succeededBuild([]buildapi.BuildPromotionStatus{
+							{
+								Repository:      "dr.registry.io/app",
+								Status:          corev1.ConditionUnknown,
+								Reason:          buildapi.PromotionRetrying,
+								Message:         "unauthorized to push to dr.registry.io/app",
+								Attempts:        3,
+								LastAttemptTime: &metav1.Time{Time: now.Add(-3 * time.Minute)},
+							},
+							{
+								Repository:      "other.registry.io/app",
+								Status:          corev1.ConditionUnknown,
+								Reason:          buildapi.PromotionRetrying,
+								Message:         "unauthorized to push to other.registry.io/app",
+								Attempts:        2,
+								LastAttemptTime: &metav1.Time{Time: now.Add(-time.Minute)},
+							},
+						}),
+					},
+					WantErr: false,
+				})
+
+				assert.Empty(t, promoter.calls)
+				assert.Equal(t, time.Minute, enqueuedAfter)
+			})
+
+			it("stops retrying a promotion after the maximum attempts", func() {
+				rt.Test(rtesting.TableRow{
+					Key: key,
+					Objects: []runtime.Object{
+						succeededBuild([]buildapi.BuildPromotionStatus{
+							{
+								Repository:      "dr.registry.io/app",
+								Status:          corev1.ConditionFalse,
+								Reason:          buildapi.PromotionFailed,
+								Message:         "unauthorized to push to dr.registry.io/app",
+								Attempts:        6,
+								LastAttemptTime: &metav1.Time{Time: now.Add(-time.Hour)},
+							},
+							{
+								Repository:      "other.registry.io/app",
+								Status:          corev1.ConditionUnknown,
+								Reason:          buildapi.PromotionRetrying,
+								Message:         "unauthorized to push to other.registry.io/app",
+								Attempts:        5,
+								LastAttemptTime: &metav1.Time{Time: now.Add(-16 * time.Minute)},
+							},
+						}),
+					},
+					WantErr: false,
+					WantStatusUpdates: []clientgotesting.UpdateActionImpl{
+						{
+							Object: succeededBuild([]buildapi.BuildPromotionStatus{
+								{
+									Repository:      "dr.registry.io/app",
+									Status:          corev1.ConditionFalse,
+									Reason:          buildapi.PromotionFailed,
+									Message:         "unauthorized to push to dr.registry.io/app",
+									Attempts:        6,
+									LastAttemptTime: &metav1.Time{Time: now.Add(-time.Hour)},
+								},
+								{
+									Repository:      "other.registry.io/app",
+									Status:          corev1.ConditionFalse,
+									Reason:          buildapi.PromotionFailed,
+									Message:         "unauthorized to push to other.registry.io/app",
+									Attempts:        6,
+									LastAttemptTime: &metav1.Time{Time: now},
+								},
+							}),
+						},
+					},
+				})
+
+				assert.Equal(t, []string{"other.registry.io/app"}, promoter.calls)
+				assert.Equal(t, time.Duration(0), enqueuedAfter)
+			})
+
+			it("does not retry promotions that fail with a reason", func() {
+				promoter.promoted = map[string]string{
+					"dr.registry.io/app": "dr.registry.io/app@sha256:1234567",
+				}
+				promoter.errs = map[string]error{
+					"other.registry.io/app": testPromotionConditionError{reason: "SignatureNotFound"},
+				}
+
+				rt.Test(rtesting.TableRow{
+					Key: key,
+					Objects: []runtime.Object{
+						succeededBuild(nil),
+					},
+					WantErr: false,
+					WantStatusUpdates: []clientgotesting.UpdateActionImpl{
+						{
+							Object: succeededBuild([]buildapi.BuildPromotionStatus{
+								{
+									Repository:      "dr.registry.io/app",
+									Image:           "dr.registry.io/app@sha256:1234567",
+									Status:          corev1.ConditionTrue,
+									Attempts:        1,
+									LastAttemptTime: &metav1.Time{Time: now},
+								},
+								{
+									Repository:      "other.registry.io/app",
+									Status:          corev1.ConditionFalse,
+									Reason:          "SignatureNotFound",
+									Message:         "promotion failed with SignatureNotFound",
+									Attempts:        1,
+									LastAttemptTime: &metav1.Time{Time: now},
+								},
+							}),
+						},
+					},
+				})
+
+				assert.Equal(t, time.Duration(0), enqueuedAfter)
+			})
+
+			it("does not promote failed builds", func() {
+				failedBuild := succeededBuild(nil)
+				failedBuild.Status.Conditions[0].Status = corev1.ConditionFalse
+
+				rt.Test(rtesting.TableRow{
+					Key: key,
+					Objects: []runtime.Object{
+						failedBuild,
+					},
+					WantErr: false,
+				})
+
+				assert.Empty(t, promoter.calls)
+			})
+		})
 	})
 }
 
@@ -900,3 +1627,41 @@ func (tpg testPodGenerator) Generate(ctx context.Context, build buildpod.BuildPo
 		},
 	}, nil
 }
+
+type testPromoter struct {
+	promoted map[string]string
+	errs     map[string]error
+	calls    []string
+}
+
+func (p *testPromoter) Promote(_ context.Context, _ *buildapi.Build, promotion buildapi.ImagePromotion) (string, error) {
+	p.calls = append(p.calls, promotion.Repository)
+	if err, ok := p.errs[promotion.Repository]; ok {
+		return "", err
+	}
+	image, ok := p.promoted[promotion.Repository]
+	if !ok {
+		return "", errors.New("unauthorized to push to " + promotion.Repository)
+	}
+	return image, nil
+}
+
+func exitCode(code int32) *int32 {
+	return &code
+}
+
+func timePtr(t metav1.Time) *metav1.Time {
+	return &t
+}
+
+type testPromotionConditionError struct {
+	reason string
+}
+
+func (e testPromotionConditionError) Error() string {
+	return "promotion failed with " + e.reason
+}
+
+func (e testPromotionConditionError) Reason() string {
+	return e.reason
+}
@@ -2,24 +2,41 @@ package image
 
 import (
 	"sort"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	v1alpha1build "github.com/pivotal/kpack/pkg/reconciler/build"
 
 	buildapi "github.com/pivotal/kpack/pkg/apis/build/v1alpha2"
 )
 
+// averageBuildDurationWindow is the number of latest successful builds the
+// average build duration of an image is calculated from.
+const averageBuildDurationWindow = 10
+
 type buildList struct {
 	successfulBuilds []*buildapi.Build
 	failedBuilds     []*buildapi.Build
 	lastBuild        *buildapi.Build
+	// lastImageBuild is the last build that is not a manual build of a
+	// build request.
+	lastImageBuild *buildapi.Build
+	requestBuilds  map[string]*buildapi.Build
 }
 
 func newBuildList(builds []*buildapi.Build) (buildList, error) {
 	sort.Sort(v1alpha1build.ByCreationTimestamp(builds)) //nobody enforcing this
 
-	buildList := buildList{}
+	buildList := buildList{requestBuilds: map[string]*buildapi.Build{}}
 
 	for _, build := range builds {
+		if request, ok := build.Labels[buildapi.BuildRequestLabel]; ok {
+			buildList.requestBuilds[request] = build
+		} else {
+			buildList.lastImageBuild = build
+		}
+
 		if build.IsSuccess() {
 			buildList.successfulBuilds = append(buildList.successfulBuilds, build)
 		} else if build.IsFailure() {
@@ -34,18 +51,71 @@ func newBuildList(builds []*buildapi.Build) (buildList, error) {
 	return buildList, nil
 }
 
-func (l buildList) NumberFailedBuilds() int64 {
-	return int64(len(l.failedBuilds))
-}
+// buildsToPrune returns the failed and successful builds beyond the build
+// history limits of image and, with a buildHistoryMaxAge, the builds that are
+// older than it. The latest build, the latest build of the image status and
+// the latest successful build are always kept. It also returns when the
+// oldest kept build expires, or the zero time if none does.
+func (l buildList) buildsToPrune(image *buildapi.Image, now time.Time) ([]*buildapi.Build, time.Time) {
+	keep := map[string]bool{image.Status.LatestBuildRef: true}
+	if l.lastBuild != nil {
+		keep[l.lastBuild.Name] = true
+	}
+	if len(l.successfulBuilds) > 0 {
+		keep[l.successfulBuilds[len(l.successfulBuilds)-1].Name] = true
+	}
 
-func (l buildList) OldestFailure() *buildapi.Build {
-	return l.failedBuilds[0]
-}
+	var maxAge time.Duration
+	if image.Spec.BuildHistoryMaxAge != nil {
+		maxAge = image.Spec.BuildHistoryMaxAge.Duration
+	}
 
-func (l buildList) NumberSuccessfulBuilds() int64 {
-	return int64(len(l.successfulBuilds))
+	var (
+		pruned     []*buildapi.Build
+		nextExpiry time.Time
+	)
+	prune := func(builds []*buildapi.Build, limit int64) {
+		// kept builds count towards the limit but are never pruned, so the
+		// excess is taken from the oldest builds that are not kept
+		var candidates []*buildapi.Build
+		for _, build := range builds {
+			if !keep[build.Name] {
+				candidates = append(candidates, build)
+			}
+		}
+
+		excess := int64(len(builds)) - limit
+		for i, build := range candidates {
+			expiry := build.CreationTimestamp.Add(maxAge)
+			if int64(i) < excess || (maxAge > 0 && !expiry.After(now)) {
+				pruned = append(pruned, build)
+			} else if maxAge > 0 && (nextExpiry.IsZero() || expiry.Before(nextExpiry)) {
+				nextExpiry = expiry
+			}
+		}
+	}
+	prune(l.failedBuilds, *image.Spec.FailedBuildHistoryLimit)
+	prune(l.successfulBuilds, *image.Spec.SuccessBuildHistoryLimit)
+
+	return pruned, nextExpiry
 }
 
-func (l buildList) OldestSuccess() *buildapi.Build {
-	return l.successfulBuilds[0]
+// averageBuildDuration returns the average build duration of the latest
+// successful builds that report one, or nil if none does.
+func (l buildList) averageBuildDuration() *metav1.Duration {
+	var (
+		total time.Duration
+		count int64
+	)
+	for i := len(l.successfulBuilds) - 1; i >= 0 && count < averageBuildDurationWindow; i-- {
+		if duration := l.successfulBuilds[i].Status.BuildDuration; duration != nil {
+			total += duration.Duration
+			count++
+		}
+	}
+
+	if count == 0 {
+		return nil
+	}
+	return &metav1.Duration{Duration: total / time.Duration(count)}
 }
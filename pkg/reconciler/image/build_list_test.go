@@ -0,0 +1,90 @@
+package image
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/sclevine/spec"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	buildapi "github.com/pivotal/kpack/pkg/apis/build/v1alpha2"
+	corev1alpha1 "github.com/pivotal/kpack/pkg/apis/core/v1alpha1"
+)
+
+func TestBuildList(t *testing.T) {
+	spec.Run(t, "Build List", testBuildList)
+}
+
+func testBuildList(t *testing.T, when spec.G, it spec.S) {
+	var (
+		now   = time.Now()
+		limit = func(l int64) *int64 { return &l }
+		image = &buildapi.Image{
+			Spec: buildapi.ImageSpec{
+				SuccessBuildHistoryLimit: limit(2),
+				FailedBuildHistoryLimit:  limit(2),
+			},
+		}
+	)
+
+	build := func(number int, status corev1.ConditionStatus) *buildapi.Build {
+		return &buildapi.Build{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:              fmt.Sprintf("image-name-build-%d", number),
+				CreationTimestamp: metav1.NewTime(now.Add(time.Duration(number-10) * time.Minute)),
+			},
+			Status: buildapi.BuildStatus{
+				Status: corev1alpha1.Status{
+					Conditions: corev1alpha1.Conditions{
+						{Type: corev1alpha1.ConditionSucceeded, Status: status},
+					},
+				},
+			},
+		}
+	}
+
+	names := func(builds []*buildapi.Build) []string {
+		var names []string
+		for _, build := range builds {
+			names = append(names, build.Name)
+		}
+		return names
+	}
+
+	when("#buildsToPrune", func() {
+		it("prunes the oldest builds beyond the limits", func() {
+			list, err := newBuildList([]*buildapi.Build{
+				build(1, corev1.ConditionFalse),
+				build(2, corev1.ConditionTrue),
+				build(3, corev1.ConditionFalse),
+				build(4, corev1.ConditionTrue),
+				build(5, corev1.ConditionFalse),
+				build(6, corev1.ConditionTrue),
+			})
+			require.NoError(t, err)
+			image.Status.LatestBuildRef = "image-name-build-6"
+
+			pruned, _ := list.buildsToPrune(image, now)
+			assert.Equal(t, []string{"image-name-build-1", "image-name-build-2"}, names(pruned))
+		})
+
+		it("takes the excess from the builds that are not kept", func() {
+			list, err := newBuildList([]*buildapi.Build{
+				build(1, corev1.ConditionTrue),
+				build(2, corev1.ConditionTrue),
+				build(3, corev1.ConditionTrue),
+				build(4, corev1.ConditionTrue),
+				build(5, corev1.ConditionTrue),
+			})
+			require.NoError(t, err)
+			image.Status.LatestBuildRef = "image-name-build-1"
+
+			pruned, _ := list.buildsToPrune(image, now)
+			assert.Equal(t, []string{"image-name-build-2", "image-name-build-3", "image-name-build-4"}, names(pruned))
+		})
+	})
+}
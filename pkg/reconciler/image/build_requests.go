@@ -0,0 +1,199 @@
+package image
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"sort"
+
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/api/equality"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+
+	buildapi "github.com/pivotal/kpack/pkg/apis/build/v1alpha2"
+	corev1alpha1 "github.com/pivotal/kpack/pkg/apis/core/v1alpha1"
+	"github.com/pivotal/kpack/pkg/buildchange"
+)
+
+// commitSHA matches the full commit SHAs a revision that is not a branch or a
+// tag must be.
+var commitSHA = regexp.MustCompile(`^[0-9a-fA-F]{40}$`)
+
+// conditionError is returned by a GitResolver when the revision cannot be
+// resolved until the image or its credentials change.
+type conditionError interface {
+	error
+	Reason() string
+}
+
+// reconcileBuildRequests reports the builds of the build requests of image on
+// the requests and returns the requests that are pending, oldest first.
+func (c *Reconciler) reconcileBuildRequests(ctx context.Context, image *buildapi.Image, builds buildList) ([]*buildapi.BuildRequest, error) {
+	requests, err := c.BuildRequestLister.BuildRequests(image.Namespace).List(labels.Everything())
+	if err != nil {
+		return nil, errors.Wrap(err, "cannot list build requests")
+	}
+
+	var pending []*buildapi.BuildRequest
+	for _, request := range requests {
+		if request.Spec.ImageName != image.Name {
+			continue
+		}
+
+		build, ok := builds.requestBuilds[request.Name]
+		if !ok {
+			if request.Pending() {
+				pending = append(pending, request)
+			}
+			continue
+		}
+
+		request = request.DeepCopy()
+		request.BuildCreated(build)
+		if err := c.updateBuildRequestStatus(ctx, request); err != nil {
+			return nil, err
+		}
+	}
+
+	sort.Slice(pending, func(i, j int) bool {
+		if !pending[i].CreationTimestamp.Equal(&pending[j].CreationTimestamp) {
+			return pending[i].CreationTimestamp.Before(&pending[j].CreationTimestamp)
+		}
+		return pending[i].Name < pending[j].Name
+	})
+	return pending, nil
+}
+
+// reconcileManualBuild creates the build of the commit of the revision of the
+// oldest pending build request once the builder is ready and the build is
+// admitted. The requests of a paused image stay queued until it is resumed. It
+// reports whether the build of a request was created or queued.
+func (c *Reconciler) reconcileManualBuild(ctx context.Context, image *buildapi.Image, builds buildList, pending []*buildapi.BuildRequest, builder buildapi.BuilderResource) (bool, error) {
+	if len(pending) == 0 {
+		return false, nil
+	}
+
+	if image.Spec.Source.Git == nil {
+		for _, request := range pending {
+			request = request.DeepCopy()
+			request.Failed(buildapi.BuildRequestSourceNotGit, fmt.Sprintf("Image %s does not build from a git source", image.Name))
+			if err := c.updateBuildRequestStatus(ctx, request); err != nil {
+				return false, err
+			}
+		}
+		return false, nil
+	}
+
+	if image.Spec.Paused {
+		return false, c.queueBuildRequests(ctx, pending, buildapi.BuildQueued, fmt.Sprintf("Image %s is paused", image.Name))
+	}
+
+	if !builder.Ready() {
+		return false, nil
+	}
+
+	request := pending[0].DeepCopy()
+	resolved, err := c.resolveRequestedRevision(ctx, image, request)
+	if err != nil {
+		return false, err
+	}
+	if resolved == nil {
+		return false, c.updateBuildRequestStatus(ctx, request)
+	}
+
+	summary, err := buildchange.NewChangeProcessor().
+		Process(buildchange.NewManualChange(gitRevision(builds.lastImageBuild), resolved.Git.Revision)).
+		Summarize()
+	if err != nil {
+		return false, err
+	}
+
+	admitted, message, err := c.BuildQueue.Admit(image.NamespacedName(), summary.Priority)
+	if err != nil {
+		return false, errors.Wrap(err, "error admitting image build")
+	}
+	if !admitted {
+		request.Queued(buildapi.BuildQueued, message)
+		return true, c.updateBuildRequestStatus(ctx, request)
+	}
+
+	currentBuildNumber, err := buildCounter(builds.lastBuild)
+	if err != nil {
+		return false, err
+	}
+
+	priorityClass := ""
+	if c.EnablePriorityClasses {
+		priorityClass = summary.Priority.PriorityClass()
+	}
+
+	build := image.ManualBuild(builder, builds.lastImageBuild, request, *resolved, summary.ChangesStr, currentBuildNumber+1, priorityClass)
+	c.annotateQueuedAt(image, build)
+	build, err = c.Client.KpackV1alpha2().Builds(build.Namespace).Create(ctx, build, metav1.CreateOptions{})
+	if err != nil {
+		c.BuildQueue.Remove(image.NamespacedName())
+		return false, err
+	}
+
+	request.BuildCreated(build)
+	return true, c.updateBuildRequestStatus(ctx, request)
+}
+
+// resolveRequestedRevision resolves the revision of request to the commit it
+// points at. It fails request and returns nil when the revision cannot be
+// resolved, and returns an error to retry when the remote cannot be reached.
+func (c *Reconciler) resolveRequestedRevision(ctx context.Context, image *buildapi.Image, request *buildapi.BuildRequest) (*corev1alpha1.ResolvedSourceConfig, error) {
+	resolved, err := c.GitResolver.Resolve(ctx, image.RequestedSourceResolver(request))
+	var conditionErr conditionError
+	if errors.As(err, &conditionErr) {
+		request.Failed(conditionErr.Reason(), conditionErr.Error())
+		return nil, nil
+	} else if err != nil {
+		return nil, errors.Wrapf(err, "cannot resolve revision %s", request.Spec.Revision)
+	}
+
+	switch {
+	case resolved.Git == nil || resolved.Git.Type == corev1alpha1.Unknown:
+		return nil, errors.Errorf("cannot reach %s to resolve revision %s", image.Spec.Source.Git.URL, request.Spec.Revision)
+	case resolved.Git.Type == corev1alpha1.Commit && !commitSHA.MatchString(resolved.Git.Revision):
+		request.Failed(buildapi.BuildRequestRevisionNotFound, fmt.Sprintf("Revision %s is not a branch, tag or commit of %s", request.Spec.Revision, image.Spec.Source.Git.URL))
+		return nil, nil
+	}
+	return &resolved, nil
+}
+
+// queueBuildRequests reports on each of the pending requests that its build
+// waits for reason.
+func (c *Reconciler) queueBuildRequests(ctx context.Context, pending []*buildapi.BuildRequest, reason, message string) error {
+	for _, request := range pending {
+		request = request.DeepCopy()
+		request.Queued(reason, message)
+		if err := c.updateBuildRequestStatus(ctx, request); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (c *Reconciler) updateBuildRequestStatus(ctx context.Context, desired *buildapi.BuildRequest) error {
+	desired.Status.ObservedGeneration = desired.Generation
+	original, err := c.BuildRequestLister.BuildRequests(desired.Namespace).Get(desired.Name)
+	if err != nil {
+		return err
+	}
+
+	if equality.Semantic.DeepEqual(original.Status, desired.Status) {
+		return nil
+	}
+
+	_, err = c.Client.KpackV1alpha2().BuildRequests(desired.Namespace).UpdateStatus(ctx, desired, metav1.UpdateOptions{})
+	return err
+}
+
+func gitRevision(build *buildapi.Build) string {
+	if build == nil || build.Spec.Source.Git == nil {
+		return ""
+	}
+	return build.Spec.Source.Git.Revision
+}
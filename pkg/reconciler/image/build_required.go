@@ -3,6 +3,7 @@ package image
 import (
 	"time"
 
+	"github.com/pkg/errors"
 	corev1 "k8s.io/api/core/v1"
 
 	buildapi "github.com/pivotal/kpack/pkg/apis/build/v1alpha2"
@@ -15,6 +16,10 @@ type buildRequiredResult struct {
 	ReasonsStr      string
 	ChangesStr      string
 	PriorityClass   string
+	Priority        buildapi.BuildPriority
+	Retry           bool
+	RetryAfter      time.Duration
+	NextScheduled   time.Time
 }
 
 func newBuildRequiredResult(summary buildchange.ChangeSummary) buildRequiredResult {
@@ -27,6 +32,7 @@ func newBuildRequiredResult(summary buildchange.ChangeSummary) buildRequiredResu
 	result.ReasonsStr = summary.ReasonsStr
 	result.ChangesStr = summary.ChangesStr
 	result.PriorityClass = summary.Priority.PriorityClass()
+	result.Priority = summary.Priority
 	return result
 }
 
@@ -40,18 +46,101 @@ func isBuildRequired(img *buildapi.Image,
 		return result, nil
 	}
 
+	now := time.Now()
+	scheduled, nextScheduled, err := scheduledChange(img, lastBuild, now)
+	if err != nil {
+		return result, err
+	}
+
 	changeSummary, err := buildchange.NewChangeProcessor().
 		Process(triggerChange(lastBuild)).
 		Process(commitChange(lastBuild, srcResolver)).
+		Process(blobChange(lastBuild, srcResolver)).
+		Process(objectChange(lastBuild, srcResolver)).
+		Process(registryChange(lastBuild, srcResolver)).
 		Process(configChange(img, lastBuild, srcResolver)).
 		Process(buildpackChange(lastBuild, builder)).
 		Process(stackChange(lastBuild, builder)).
+		Process(scheduled).
+		Summarize()
+	if err != nil {
+		return result, err
+	}
+
+	if changeSummary.HasChanges {
+		result = newBuildRequiredResult(changeSummary)
+		result.NextScheduled = nextScheduled
+		return result, nil
+	}
+
+	retry, retryAfter := retryChange(img, lastBuild, now)
+	changeSummary, err = buildchange.NewChangeProcessor().
+		Process(retry).
 		Summarize()
 	if err != nil {
 		return result, err
 	}
 
-	return newBuildRequiredResult(changeSummary), nil
+	result = newBuildRequiredResult(changeSummary)
+	result.Retry = changeSummary.HasChanges
+	result.RetryAfter = retryAfter
+	result.NextScheduled = nextScheduled
+	return result, nil
+}
+
+// scheduledChange builds the image when its schedule fired since the last
+// build was created. Schedules missed while the controller was down result in
+// a single build. It also returns the next time the schedule fires.
+func scheduledChange(img *buildapi.Image, lastBuild *buildapi.Build, now time.Time) (buildchange.Change, time.Time, error) {
+	if img.Spec.Schedule == nil {
+		return nil, time.Time{}, nil
+	}
+
+	schedule, err := img.Spec.Schedule.CronSchedule()
+	if err != nil {
+		return nil, time.Time{}, errors.Wrapf(err, "invalid schedule %q", img.Spec.Schedule.Cron)
+	}
+
+	if lastBuild == nil || lastBuild.CreationTimestamp.IsZero() {
+		return nil, schedule.Next(now), nil
+	}
+
+	lastBuildTime := lastBuild.CreationTimestamp.Time
+	fired := schedule.Next(lastBuildTime)
+	if fired.IsZero() || fired.After(now) {
+		return nil, fired, nil
+	}
+	return buildchange.NewScheduledChange(lastBuildTime.UTC().Format(time.RFC3339), fired.UTC().Format(time.RFC3339)), schedule.Next(now), nil
+}
+
+// maxRetryBackoff caps the doubling delay between retries of a failed build.
+const maxRetryBackoff = time.Hour
+
+// retryChange retries a build with a retryable failure once the backoff of the
+// attempt has elapsed. Until then, it returns the time left.
+func retryChange(img *buildapi.Image, lastBuild *buildapi.Build, now time.Time) (buildchange.Change, time.Duration) {
+	policy := img.Spec.RetryPolicy
+	if policy == nil || policy.Backoff == nil || !lastBuild.IsRetryable() {
+		return nil, 0
+	}
+
+	attempt := lastBuild.RetryAttempt()
+	if attempt >= policy.MaxAttempts {
+		return nil, 0
+	}
+
+	backoff := policy.Backoff.Duration
+	for i := int64(0); i < attempt && backoff < maxRetryBackoff; i++ {
+		backoff *= 2
+	}
+	if backoff > maxRetryBackoff {
+		backoff = maxRetryBackoff
+	}
+
+	if remaining := lastBuild.FinishedAt().Add(backoff).Sub(now); remaining > 0 {
+		return nil, remaining
+	}
+	return buildchange.NewRetryChange(attempt, attempt+1), 0
 }
 
 func triggerChange(lastBuild *buildapi.Build) buildchange.Change {
@@ -76,9 +165,55 @@ func commitChange(lastBuild *buildapi.Build, srcResolver *buildapi.SourceResolve
 
 	oldRevision := lastBuild.Spec.Source.Git.Revision
 	newRevision := srcResolver.Status.Source.Git.Revision
+
+	var oldTag string
+	if resolved := lastBuild.Spec.ResolvedSource; resolved != nil {
+		oldTag = resolved.GitTag
+	}
+	newTag := srcResolver.Status.Source.Git.Tag
+	if oldTag != "" || newTag != "" {
+		return buildchange.NewTaggedCommitChange(oldRevision, oldTag, newRevision, newTag)
+	}
 	return buildchange.NewCommitChange(oldRevision, newRevision)
 }
 
+func blobChange(lastBuild *buildapi.Build, srcResolver *buildapi.SourceResolver) buildchange.Change {
+	if lastBuild == nil || lastBuild.Spec.Source.Blob == nil || srcResolver.Status.Source.Blob == nil {
+		return nil
+	}
+
+	var oldFingerprint *corev1alpha1.BlobFingerprint
+	if resolved := lastBuild.Spec.ResolvedSource; resolved != nil {
+		oldFingerprint = resolved.BlobFingerprint
+	}
+	newBlob := srcResolver.Status.Source.Blob
+	return buildchange.NewBlobChange(
+		lastBuild.Spec.Source.Blob, oldFingerprint,
+		newBlob.SourceConfig().Blob, newBlob.Fingerprint,
+	)
+}
+
+func objectChange(lastBuild *buildapi.Build, srcResolver *buildapi.SourceResolver) buildchange.Change {
+	if lastBuild == nil || lastBuild.Spec.Source.ObjectStore == nil || srcResolver.Status.Source.ObjectStore == nil {
+		return nil
+	}
+
+	newVersion := srcResolver.Status.Source.ObjectStore.Version
+	return buildchange.NewObjectChange(lastBuild.Spec.Source.ObjectStore.Version, &newVersion)
+}
+
+func registryChange(lastBuild *buildapi.Build, srcResolver *buildapi.SourceResolver) buildchange.Change {
+	if lastBuild == nil || lastBuild.Spec.Source.Registry == nil || srcResolver.Status.Source.Registry == nil {
+		return nil
+	}
+
+	var oldDigest string
+	if resolved := lastBuild.Spec.ResolvedSource; resolved != nil {
+		oldDigest = resolved.RegistryDigest
+	}
+	return buildchange.NewRegistryChange(oldDigest, srcResolver.Status.Source.Registry.Digest)
+}
+
 func configChange(img *buildapi.Image, lastBuild *buildapi.Build, srcResolver *buildapi.SourceResolver) buildchange.Change {
 	var old buildchange.Config
 	var new buildchange.Config
@@ -2,8 +2,10 @@ package image
 
 import (
 	"encoding/json"
+	"fmt"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/sclevine/spec"
 	"github.com/stretchr/testify/assert"
@@ -491,6 +493,33 @@ func testImageBuilds(t *testing.T, when spec.G, it spec.S) {
 				assert.Equal(t, expectedChanges, result.ChangesStr)
 			})
 
+			it("true for a different tag with the selected tags in the COMMIT change", func() {
+				latestBuild.Spec.ResolvedSource = &buildapi.BuildResolvedSource{GitTag: "v1.0.0"}
+				sourceResolver.Status.Source.Git.Revision = "different"
+				sourceResolver.Status.Source.Git.Tag = "v1.1.0"
+
+				expectedChanges := testhelpers.CompactJSON(`
+[
+  {
+    "reason": "COMMIT",
+    "old": {
+      "revision": "revision",
+      "tag": "v1.0.0"
+    },
+    "new": {
+      "revision": "different",
+      "tag": "v1.1.0"
+    }
+  }
+]`)
+
+				result, err := isBuildRequired(image, latestBuild, sourceResolver, builder)
+				assert.NoError(t, err)
+				assert.Equal(t, corev1.ConditionTrue, result.ConditionStatus)
+				assert.Equal(t, buildapi.BuildReasonCommit, result.ReasonsStr)
+				assert.Equal(t, expectedChanges, result.ChangesStr)
+			})
+
 			it("false if source resolver is not ready", func() {
 				sourceResolver.Status.Source.Git.Revision = "different"
 				sourceResolver.Status.Conditions = []corev1alpha1.Condition{
@@ -678,6 +707,66 @@ func testImageBuilds(t *testing.T, when spec.G, it spec.S) {
 				assert.Equal(t, expectedChanges, result.ChangesStr)
 			})
 
+			it("true for a different blob fingerprint", func() {
+				sourceResolver.Status.Source.Blob.URL = "some-url"
+				sourceResolver.Status.Source.Blob.Fingerprint = &corev1alpha1.BlobFingerprint{ETag: `"new-etag"`}
+				latestBuild.Spec.ResolvedSource = &buildapi.BuildResolvedSource{
+					BlobFingerprint: &corev1alpha1.BlobFingerprint{ETag: `"old-etag"`},
+				}
+
+				expectedChanges := testhelpers.CompactJSON(`
+[
+  {
+    "reason": "BLOB",
+    "old": {
+      "etag": "\"old-etag\""
+    },
+    "new": {
+      "etag": "\"new-etag\""
+    }
+  }
+]`)
+
+				result, err := isBuildRequired(image, latestBuild, sourceResolver, builder)
+				assert.NoError(t, err)
+				assert.Equal(t, corev1.ConditionTrue, result.ConditionStatus)
+				assert.Equal(t, buildapi.BuildReasonBlob, result.ReasonsStr)
+				assert.Equal(t, buildapi.BuildPriorityClassHigh, result.PriorityClass)
+				assert.Equal(t, expectedChanges, result.ChangesStr)
+			})
+
+			it("true when the last build has no blob fingerprint", func() {
+				sourceResolver.Status.Source.Blob.URL = "some-url"
+				sourceResolver.Status.Source.Blob.Fingerprint = &corev1alpha1.BlobFingerprint{ETag: `"new-etag"`}
+
+				expectedChanges := testhelpers.CompactJSON(`
+[
+  {
+    "reason": "BLOB",
+    "new": {
+      "etag": "\"new-etag\""
+    }
+  }
+]`)
+
+				result, err := isBuildRequired(image, latestBuild, sourceResolver, builder)
+				assert.NoError(t, err)
+				assert.Equal(t, corev1.ConditionTrue, result.ConditionStatus)
+				assert.Equal(t, buildapi.BuildReasonBlob, result.ReasonsStr)
+				assert.Equal(t, expectedChanges, result.ChangesStr)
+			})
+
+			it("false when the last build has no blob fingerprint and downloaded the same digest", func() {
+				latestBuild.Spec.Source.Blob.Digest = "sha256:some-digest"
+				sourceResolver.Status.Source.Blob.URL = "some-url"
+				sourceResolver.Status.Source.Blob.Digest = "sha256:some-digest"
+				sourceResolver.Status.Source.Blob.Fingerprint = &corev1alpha1.BlobFingerprint{ETag: `"new-etag"`}
+
+				result, err := isBuildRequired(image, latestBuild, sourceResolver, builder)
+				assert.NoError(t, err)
+				assert.Equal(t, corev1.ConditionFalse, result.ConditionStatus)
+			})
+
 			it("true for different Blob SubPath", func() {
 				sourceResolver.Status.Source.Blob.SubPath = "different"
 
@@ -759,6 +848,37 @@ func testImageBuilds(t *testing.T, when spec.G, it spec.S) {
 				assert.Equal(t, expectedChanges, result.ChangesStr)
 			})
 
+			it("true for a new digest of the same image", func() {
+				sourceResolver.Status.Source.Registry.Image = "some-image"
+				sourceResolver.Status.Source.Registry.Digest = "sha256:new"
+				latestBuild.Spec.ResolvedSource = &buildapi.BuildResolvedSource{RegistryDigest: "sha256:old"}
+
+				expectedChanges := testhelpers.CompactJSON(`
+[
+  {
+    "reason": "REGISTRY",
+    "old": "sha256:old",
+    "new": "sha256:new"
+  }
+]`)
+
+				result, err := isBuildRequired(image, latestBuild, sourceResolver, builder)
+				assert.NoError(t, err)
+				assert.Equal(t, corev1.ConditionTrue, result.ConditionStatus)
+				assert.Equal(t, buildapi.BuildReasonRegistry, result.ReasonsStr)
+				assert.Equal(t, buildapi.BuildPriorityClassHigh, result.PriorityClass)
+				assert.Equal(t, expectedChanges, result.ChangesStr)
+			})
+
+			it("false when the last build was not resolved to a digest", func() {
+				sourceResolver.Status.Source.Registry.Image = "some-image"
+				sourceResolver.Status.Source.Registry.Digest = "sha256:new"
+
+				result, err := isBuildRequired(image, latestBuild, sourceResolver, builder)
+				assert.NoError(t, err)
+				assert.Equal(t, corev1.ConditionFalse, result.ConditionStatus)
+			})
+
 			it("true for different Registry SubPath", func() {
 				sourceResolver.Status.Source.Registry.SubPath = "different"
 				expectedChanges := testhelpers.CompactJSON(`
@@ -793,6 +913,202 @@ func testImageBuilds(t *testing.T, when spec.G, it spec.S) {
 				assert.Equal(t, expectedChanges, result.ChangesStr)
 			})
 		})
+
+		when("ObjectStore", func() {
+			sourceResolver.Status.Source = corev1alpha1.ResolvedSourceConfig{
+				ObjectStore: &corev1alpha1.ResolvedObjectStoreSource{
+					Endpoint: "https://minio.example.com",
+					Bucket:   "some-bucket",
+					Key:      "app.tar.gz",
+					Version:  corev1alpha1.ObjectVersion{VersionID: "new-version", ETag: `"new-etag"`},
+				},
+			}
+
+			latestBuild.Spec.Source = corev1alpha1.SourceConfig{
+				ObjectStore: &corev1alpha1.ObjectStore{
+					Endpoint: "https://minio.example.com",
+					Bucket:   "some-bucket",
+					Key:      "app.tar.gz",
+					Version:  &corev1alpha1.ObjectVersion{VersionID: "old-version", ETag: `"old-etag"`},
+				},
+			}
+
+			it("true for a different object version", func() {
+				expectedChanges := testhelpers.CompactJSON(`
+[
+  {
+    "reason": "OBJECT",
+    "old": {
+      "versionId": "old-version",
+      "etag": "\"old-etag\""
+    },
+    "new": {
+      "versionId": "new-version",
+      "etag": "\"new-etag\""
+    }
+  }
+]`)
+
+				result, err := isBuildRequired(image, latestBuild, sourceResolver, builder)
+				assert.NoError(t, err)
+				assert.Equal(t, corev1.ConditionTrue, result.ConditionStatus)
+				assert.Equal(t, buildapi.BuildReasonObject, result.ReasonsStr)
+				assert.Equal(t, buildapi.BuildPriorityClassHigh, result.PriorityClass)
+				assert.Equal(t, expectedChanges, result.ChangesStr)
+			})
+
+			it("false for the same object version", func() {
+				sourceResolver.Status.Source.ObjectStore.Version = corev1alpha1.ObjectVersion{VersionID: "old-version", ETag: `"old-etag"`}
+
+				result, err := isBuildRequired(image, latestBuild, sourceResolver, builder)
+				assert.NoError(t, err)
+				assert.Equal(t, corev1.ConditionFalse, result.ConditionStatus)
+			})
+
+			it("true for a different object key", func() {
+				sourceResolver.Status.Source.ObjectStore.Key = "other.tar.gz"
+				sourceResolver.Status.Source.ObjectStore.Version = corev1alpha1.ObjectVersion{VersionID: "old-version", ETag: `"old-etag"`}
+
+				result, err := isBuildRequired(image, latestBuild, sourceResolver, builder)
+				assert.NoError(t, err)
+				assert.Equal(t, corev1.ConditionTrue, result.ConditionStatus)
+				assert.Equal(t, buildapi.BuildReasonConfig, result.ReasonsStr)
+			})
+		})
+
+		when("Schedule", func() {
+			it("true if the schedule fired since the last build", func() {
+				now := time.Now().UTC()
+				latestBuild.CreationTimestamp = metav1.NewTime(now.Add(-30 * 24 * time.Hour))
+				image.Spec.Schedule = &buildapi.ImageSchedule{Cron: "@daily"}
+
+				result, err := isBuildRequired(image, latestBuild, sourceResolver, builder)
+				assert.NoError(t, err)
+				assert.Equal(t, corev1.ConditionTrue, result.ConditionStatus)
+				assert.Equal(t, buildapi.BuildReasonScheduled, result.ReasonsStr)
+				assert.Equal(t, buildapi.BuildPriorityClassLow, result.PriorityClass)
+				assert.True(t, result.NextScheduled.After(now))
+
+				var changes []buildchange.GenericChange
+				err = json.Unmarshal([]byte(result.ChangesStr), &changes)
+				assert.NoError(t, err)
+				assert.Len(t, changes, 1)
+				assert.Equal(t, latestBuild.CreationTimestamp.UTC().Format(time.RFC3339), changes[0].Old)
+				assert.Equal(t, latestBuild.CreationTimestamp.UTC().Truncate(24*time.Hour).Add(24*time.Hour).Format(time.RFC3339), changes[0].New)
+			})
+
+			it("false until the schedule fires", func() {
+				now := time.Now().UTC()
+				next := now.Add(30 * time.Minute).Truncate(time.Minute)
+				latestBuild.CreationTimestamp = metav1.NewTime(now.Add(-time.Minute))
+				image.Spec.Schedule = &buildapi.ImageSchedule{Cron: fmt.Sprintf("%d %d * * *", next.Minute(), next.Hour())}
+
+				result, err := isBuildRequired(image, latestBuild, sourceResolver, builder)
+				assert.NoError(t, err)
+				assert.Equal(t, corev1.ConditionFalse, result.ConditionStatus)
+				assert.True(t, next.Equal(result.NextScheduled))
+			})
+
+			it("evaluates the schedule in its time zone", func() {
+				image.Spec.Schedule = &buildapi.ImageSchedule{Cron: "0 3 * * *", TimeZone: "Asia/Kolkata"}
+
+				result, err := isBuildRequired(image, latestBuild, sourceResolver, builder)
+				assert.NoError(t, err)
+				assert.Equal(t, 30, result.NextScheduled.UTC().Minute())
+				assert.Equal(t, 21, result.NextScheduled.UTC().Hour())
+			})
+		})
+
+		when("Retry", func() {
+			failedIn := func(step string, finishedAt time.Time) {
+				latestBuild.Status.Conditions = corev1alpha1.Conditions{
+					{
+						Type:               corev1alpha1.ConditionSucceeded,
+						Status:             corev1.ConditionFalse,
+						LastTransitionTime: corev1alpha1.VolatileTime{Inner: metav1.NewTime(finishedAt)},
+					},
+				}
+				latestBuild.Status.StepsCompleted = []string{"prepare", step}
+				latestBuild.Status.StepStates = []corev1.ContainerState{
+					{Terminated: &corev1.ContainerStateTerminated{ExitCode: 0}},
+					{Terminated: &corev1.ContainerStateTerminated{ExitCode: 1}},
+				}
+			}
+
+			image.Spec.RetryPolicy = &buildapi.ImageRetryPolicy{
+				MaxAttempts: 2,
+				Backoff:     &metav1.Duration{Duration: time.Minute},
+			}
+
+			it("true if the last build failed with a retryable step after the backoff", func() {
+				failedIn("export", time.Now().Add(-2*time.Minute))
+
+				result, err := isBuildRequired(image, latestBuild, sourceResolver, builder)
+				assert.NoError(t, err)
+				assert.Equal(t, corev1.ConditionTrue, result.ConditionStatus)
+				assert.Equal(t, buildapi.BuildReasonRetry, result.ReasonsStr)
+				assert.Equal(t, buildapi.BuildPriorityClassLow, result.PriorityClass)
+				assert.True(t, result.Retry)
+
+				expectedChanges := testhelpers.CompactJSON(`
+[
+  {
+    "reason": "RETRY",
+    "old": 0,
+    "new": 1
+  }
+]`)
+				assert.Equal(t, expectedChanges, result.ChangesStr)
+			})
+
+			it("doubles the backoff for each attempt", func() {
+				failedIn("export", time.Now().Add(-90*time.Second))
+				latestBuild.Annotations = map[string]string{buildapi.BuildRetryAttemptAnnotation: "1"}
+
+				result, err := isBuildRequired(image, latestBuild, sourceResolver, builder)
+				assert.NoError(t, err)
+				assert.Equal(t, corev1.ConditionFalse, result.ConditionStatus)
+				assert.False(t, result.Retry)
+				assert.InDelta(t, 30*time.Second, result.RetryAfter, float64(5*time.Second))
+			})
+
+			it("false if the last build failed to build the app", func() {
+				failedIn("build", time.Now().Add(-2*time.Minute))
+
+				result, err := isBuildRequired(image, latestBuild, sourceResolver, builder)
+				assert.NoError(t, err)
+				assert.Equal(t, corev1.ConditionFalse, result.ConditionStatus)
+				assert.Zero(t, result.RetryAfter)
+			})
+
+			it("false if the last build timed out", func() {
+				failedIn("export", time.Now().Add(-2*time.Minute))
+				latestBuild.Status.Conditions[0].Reason = buildapi.BuildTimedOut
+
+				result, err := isBuildRequired(image, latestBuild, sourceResolver, builder)
+				assert.NoError(t, err)
+				assert.Equal(t, corev1.ConditionFalse, result.ConditionStatus)
+			})
+
+			it("false if the max attempts are reached", func() {
+				failedIn("export", time.Now().Add(-2*time.Hour))
+				latestBuild.Annotations = map[string]string{buildapi.BuildRetryAttemptAnnotation: "2"}
+
+				result, err := isBuildRequired(image, latestBuild, sourceResolver, builder)
+				assert.NoError(t, err)
+				assert.Equal(t, corev1.ConditionFalse, result.ConditionStatus)
+				assert.Zero(t, result.RetryAfter)
+			})
+
+			it("false without a retry policy", func() {
+				failedIn("export", time.Now().Add(-2*time.Minute))
+				image.Spec.RetryPolicy = nil
+
+				result, err := isBuildRequired(image, latestBuild, sourceResolver, builder)
+				assert.NoError(t, err)
+				assert.Equal(t, corev1.ConditionFalse, result.ConditionStatus)
+			})
+		})
 	})
 }
 
@@ -802,6 +1118,7 @@ type TestBuilderResource struct {
 	ImagePullSecrets []corev1.LocalObjectReference
 	LatestImage      string
 	LatestRunImage   string
+	LatestStackID    string
 	Name             string
 }
 
@@ -824,6 +1141,10 @@ func (t TestBuilderResource) RunImage() string {
 	return t.LatestRunImage
 }
 
+func (t TestBuilderResource) StackID() string {
+	return t.LatestStackID
+}
+
 func (t TestBuilderResource) GetName() string {
 	return t.Name
 }
@@ -3,6 +3,7 @@ package image
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/pkg/errors"
 	corev1 "k8s.io/api/core/v1"
@@ -11,6 +12,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/selection"
+	"k8s.io/apimachinery/pkg/types"
 	coreinformers "k8s.io/client-go/informers/core/v1"
 	k8sclient "k8s.io/client-go/kubernetes"
 	corelisters "k8s.io/client-go/listers/core/v1"
@@ -18,6 +20,8 @@ import (
 	"knative.dev/pkg/controller"
 
 	buildapi "github.com/pivotal/kpack/pkg/apis/build/v1alpha2"
+	corev1alpha1 "github.com/pivotal/kpack/pkg/apis/core/v1alpha1"
+	"github.com/pivotal/kpack/pkg/buildqueue"
 	"github.com/pivotal/kpack/pkg/client/clientset/versioned"
 	buildinformers "github.com/pivotal/kpack/pkg/client/informers/externalversions/build/v1alpha2"
 	buildlisters "github.com/pivotal/kpack/pkg/client/listers/build/v1alpha2"
@@ -31,6 +35,16 @@ const (
 	Kind           = "Image"
 )
 
+type Retagger interface {
+	Retag(context.Context, *buildapi.Image, *buildapi.Build) (string, error)
+}
+
+// GitResolver resolves the revision of the git source of a source resolver to
+// the commit it points at.
+type GitResolver interface {
+	Resolve(context.Context, *buildapi.SourceResolver) (corev1alpha1.ResolvedSourceConfig, error)
+}
+
 func NewController(
 	opt reconciler.Options,
 	k8sClient k8sclient.Interface,
@@ -38,8 +52,12 @@ func NewController(
 	buildInformer buildinformers.BuildInformer,
 	duckbuilderInformer *duckbuilder.DuckBuilderInformer,
 	sourceResolverInformer buildinformers.SourceResolverInformer,
+	buildRequestInformer buildinformers.BuildRequestInformer,
 	pvcInformer coreinformers.PersistentVolumeClaimInformer,
+	retagger Retagger,
+	gitResolver GitResolver,
 	enablePriorityClasses bool,
+	buildLimits buildqueue.Limits,
 ) *controller.Impl {
 	c := &Reconciler{
 		Client:                opt.Client,
@@ -48,11 +66,15 @@ func NewController(
 		BuildLister:           buildInformer.Lister(),
 		DuckBuilderLister:     duckbuilderInformer.Lister(),
 		SourceResolverLister:  sourceResolverInformer.Lister(),
+		BuildRequestLister:    buildRequestInformer.Lister(),
 		PvcLister:             pvcInformer.Lister(),
+		Retagger:              retagger,
+		GitResolver:           gitResolver,
 		EnablePriorityClasses: enablePriorityClasses,
 	}
 
 	impl := controller.NewImpl(c, opt.Logger, ReconcilerName)
+	c.EnqueueAfter = impl.EnqueueAfter
 
 	imageInformer.Informer().AddEventHandler(reconciler.Handler(impl.Enqueue))
 
@@ -61,11 +83,39 @@ func NewController(
 		Handler:    reconciler.Handler(impl.EnqueueControllerOf),
 	})
 
+	c.BuildQueue = buildqueue.New(buildLimits, impl.EnqueueKey)
+	buildInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc: func(obj interface{}) {
+			if build, ok := obj.(*buildapi.Build); ok {
+				c.BuildQueue.BuildUpdated(build)
+			}
+		},
+		UpdateFunc: controller.PassNew(func(obj interface{}) {
+			if build, ok := obj.(*buildapi.Build); ok {
+				c.BuildQueue.BuildUpdated(build)
+			}
+		}),
+		DeleteFunc: func(obj interface{}) {
+			if tombstone, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+				obj = tombstone.Obj
+			}
+			if build, ok := obj.(*buildapi.Build); ok {
+				c.BuildQueue.BuildDeleted(build)
+			}
+		},
+	})
+
 	sourceResolverInformer.Informer().AddEventHandler(cache.FilteringResourceEventHandler{
 		FilterFunc: controller.FilterControllerGK(buildapi.SchemeGroupVersion.WithKind(Kind).GroupKind()),
 		Handler:    reconciler.Handler(impl.EnqueueControllerOf),
 	})
 
+	buildRequestInformer.Informer().AddEventHandler(reconciler.Handler(func(obj interface{}) {
+		if request, ok := obj.(*buildapi.BuildRequest); ok {
+			impl.EnqueueKey(types.NamespacedName{Namespace: request.Namespace, Name: request.Spec.ImageName})
+		}
+	}))
+
 	pvcInformer.Informer().AddEventHandler(cache.FilteringResourceEventHandler{
 		FilterFunc: controller.FilterControllerGK(buildapi.SchemeGroupVersion.WithKind(Kind).GroupKind()),
 		Handler:    reconciler.Handler(impl.EnqueueControllerOf),
@@ -84,10 +134,15 @@ type Reconciler struct {
 	ImageLister           buildlisters.ImageLister
 	BuildLister           buildlisters.BuildLister
 	SourceResolverLister  buildlisters.SourceResolverLister
+	BuildRequestLister    buildlisters.BuildRequestLister
 	PvcLister             corelisters.PersistentVolumeClaimLister
 	Tracker               reconciler.Tracker
 	K8sClient             k8sclient.Interface
+	Retagger              Retagger
+	GitResolver           GitResolver
 	EnablePriorityClasses bool
+	BuildQueue            *buildqueue.Queue
+	EnqueueAfter          func(obj interface{}, after time.Duration)
 }
 
 func (c *Reconciler) Reconcile(ctx context.Context, key string) error {
@@ -98,6 +153,7 @@ func (c *Reconciler) Reconcile(ctx context.Context, key string) error {
 
 	image, err := c.ImageLister.Images(namespace).Get(imageName)
 	if k8serrors.IsNotFound(err) {
+		c.BuildQueue.Remove(types.NamespacedName{Namespace: namespace, Name: imageName})
 		return nil
 	} else if err != nil {
 		return err
@@ -129,15 +185,28 @@ func (c *Reconciler) reconcileImage(ctx context.Context, image *buildapi.Image)
 		return nil, err
 	}
 
-	lastBuild, err := c.fetchLastBuild(image)
+	builds, err := c.fetchAllBuilds(image)
 	if err != nil {
 		return nil, err
 	}
 
-	if lastBuild.IsRunning() {
+	pendingRequests, err := c.reconcileBuildRequests(ctx, image, builds)
+	if err != nil {
+		return nil, err
+	}
+
+	if builds.lastBuild.IsRunning() {
 		return image, nil
 	}
 
+	if image.Spec.Rollback != nil {
+		err := c.queueBuildRequests(ctx, pendingRequests, buildapi.RolledBack, fmt.Sprintf("Image %s is rolled back", image.Name))
+		if err != nil {
+			return nil, err
+		}
+		return c.reconcileRollback(ctx, image, builds, builder)
+	}
+
 	buildCacheName, err := c.reconcileBuildCache(ctx, image)
 	if err != nil {
 		return nil, err
@@ -148,10 +217,24 @@ func (c *Reconciler) reconcileImage(ctx context.Context, image *buildapi.Image)
 		return nil, err
 	}
 
-	image.Status, err = c.reconcileBuild(ctx, image, lastBuild, sourceResolver, builder, buildCacheName)
+	manual, err := c.reconcileManualBuild(ctx, image, builds, pendingRequests, builder)
+	if err != nil || manual {
+		return image, err
+	}
+
+	// The tags keep pointing at the image of a removed rollback until a
+	// build succeeds after it.
+	rollback := image.Status.Rollback
+	if !image.RolledBackPast(builds.lastImageBuild) && builds.lastImageBuild.IsSuccess() {
+		rollback = nil
+	}
+
+	image.Status, err = c.reconcileBuild(ctx, image, builds, sourceResolver, builder, buildCacheName)
 	if err != nil {
 		return nil, err
 	}
+	image.Status.Rollback = rollback
+	image.Status.AverageBuildDuration = builds.averageBuildDuration()
 
 	return image, c.deleteOldBuilds(ctx, image)
 }
@@ -222,24 +305,18 @@ func (c *Reconciler) deleteOldBuilds(ctx context.Context, image *buildapi.Image)
 		return fmt.Errorf("failed fetching all builds for image: %s", err)
 	}
 
-	if builds.NumberFailedBuilds() > *image.Spec.FailedBuildHistoryLimit {
-		oldestFailedBuild := builds.OldestFailure()
-
-		err := c.Client.KpackV1alpha2().Builds(image.Namespace).Delete(ctx, oldestFailedBuild.Name, metav1.DeleteOptions{})
-		if err != nil {
-			return fmt.Errorf("failed deleting failed build: %s", err)
+	now := time.Now()
+	pruned, nextExpiry := builds.buildsToPrune(image, now)
+	for _, build := range pruned {
+		err := c.Client.KpackV1alpha2().Builds(image.Namespace).Delete(ctx, build.Name, metav1.DeleteOptions{})
+		if err != nil && !k8serrors.IsNotFound(err) {
+			return fmt.Errorf("failed deleting build %s: %s", build.Name, err)
 		}
 	}
 
-	if builds.NumberSuccessfulBuilds() > *image.Spec.SuccessBuildHistoryLimit {
-		oldestSuccess := builds.OldestSuccess()
-
-		err := c.Client.KpackV1alpha2().Builds(image.Namespace).Delete(ctx, oldestSuccess.Name, metav1.DeleteOptions{})
-		if err != nil {
-			return fmt.Errorf("failed deleting successful build: %s", err)
-		}
+	if !nextExpiry.IsZero() && c.EnqueueAfter != nil {
+		c.EnqueueAfter(image, nextExpiry.Sub(now))
 	}
-
 	return nil
 }
 
@@ -258,14 +335,6 @@ func (c *Reconciler) fetchAllBuilds(image *buildapi.Image) (buildList, error) {
 	return newBuildList(builds)
 }
 
-func (c *Reconciler) fetchLastBuild(image *buildapi.Image) (*buildapi.Build, error) {
-	builds, err := c.fetchAllBuilds(image)
-	if err != nil {
-		return nil, err
-	}
-	return builds.lastBuild, nil
-}
-
 func (c *Reconciler) updateStatus(ctx context.Context, desired *buildapi.Image) error {
 	desired.Status.ObservedGeneration = desired.Generation
 	original, err := c.ImageLister.Images(desired.Namespace).Get(desired.Name)
@@ -1,19 +1,25 @@
 package image_test
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"strconv"
 	"strings"
 	"testing"
 	"time"
 
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
 	"github.com/sclevine/spec"
 	"github.com/stretchr/testify/require"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
 	k8sfake "k8s.io/client-go/kubernetes/fake"
 	clientgotesting "k8s.io/client-go/testing"
 	"k8s.io/client-go/tools/record"
@@ -23,6 +29,7 @@ import (
 
 	buildapi "github.com/pivotal/kpack/pkg/apis/build/v1alpha2"
 	corev1alpha1 "github.com/pivotal/kpack/pkg/apis/core/v1alpha1"
+	"github.com/pivotal/kpack/pkg/buildqueue"
 	"github.com/pivotal/kpack/pkg/client/clientset/versioned/fake"
 	"github.com/pivotal/kpack/pkg/reconciler/image"
 	"github.com/pivotal/kpack/pkg/reconciler/testhelpers"
@@ -47,6 +54,9 @@ func testImageReconciler(t *testing.T, when spec.G, it spec.S) {
 	)
 	var (
 		fakeTracker = testhelpers.FakeTracker{}
+		buildLimits = buildqueue.Limits{}
+		retagger    = &testRetagger{}
+		gitResolver = &testGitResolver{}
 	)
 
 	rt := testhelpers.ReconcilerTester(t,
@@ -66,9 +76,19 @@ func testImageReconciler(t *testing.T, when spec.G, it spec.S) {
 				BuildLister:          listers.GetBuildLister(),
 				DuckBuilderLister:    listers.GetDuckBuilderLister(),
 				SourceResolverLister: listers.GetSourceResolverLister(),
+				BuildRequestLister:   listers.GetBuildRequestLister(),
 				PvcLister:            listers.GetPersistentVolumeClaimLister(),
 				Tracker:              fakeTracker,
 				K8sClient:            k8sfakeClient,
+				Retagger:             retagger,
+				GitResolver:          gitResolver,
+				BuildQueue:           buildqueue.New(buildLimits, func(types.NamespacedName) {}),
+			}
+
+			builds, err := listers.GetBuildLister().List(labels.Everything())
+			require.NoError(t, err)
+			for _, build := range builds {
+				r.BuildQueue.BuildUpdated(build)
 			}
 
 			rtesting.PrependGenerateNameReactor(&fakeClient.Fake)
@@ -748,6 +768,156 @@ func testImageReconciler(t *testing.T, when spec.G, it spec.S) {
 				})
 			})
 
+			it("queues a build when the concurrent build limit is reached", func() {
+				buildLimits = buildqueue.Limits{MaxBuilds: 1}
+
+				rt.Test(rtesting.TableRow{
+					Key: key,
+					Objects: []runtime.Object{
+						image,
+						builder,
+						resolvedSourceResolver(image),
+						&buildapi.Build{
+							ObjectMeta: metav1.ObjectMeta{
+								Name:      "other-image-build-1",
+								Namespace: namespace,
+								Labels: map[string]string{
+									buildapi.BuildNumberLabel: "1",
+									buildapi.ImageLabel:       "other-image",
+								},
+							},
+							Status: buildapi.BuildStatus{
+								Status: corev1alpha1.Status{
+									Conditions: corev1alpha1.Conditions{
+										{
+											Type:   corev1alpha1.ConditionSucceeded,
+											Status: corev1.ConditionUnknown,
+										},
+									},
+								},
+							},
+						},
+					},
+					WantErr: false,
+					WantStatusUpdates: []clientgotesting.UpdateActionImpl{
+						{
+							Object: &buildapi.Image{
+								ObjectMeta: image.ObjectMeta,
+								Spec:       image.Spec,
+								Status: buildapi.ImageStatus{
+									Status: corev1alpha1.Status{
+										ObservedGeneration: originalGeneration,
+										Conditions: corev1alpha1.Conditions{
+											{
+												Type:    corev1alpha1.ConditionReady,
+												Status:  corev1.ConditionUnknown,
+												Reason:  buildapi.BuildQueued,
+												Message: "Build is queued, the cluster has reached the limit of 1 running builds",
+											},
+											{
+												Type:   buildapi.ConditionBuilderReady,
+												Status: corev1.ConditionTrue,
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+				})
+			})
+
+			it("does not schedule a build while the image is paused", func() {
+				image.Spec.Paused = true
+				image.Status.BuildCounter = 1
+				image.Status.LatestBuildRef = "image-name-build-1"
+				image.Status.LatestImage = "some/image@sha256:ad3f454c"
+
+				rt.Test(rtesting.TableRow{
+					Key: key,
+					Objects: []runtime.Object{
+						image,
+						builder,
+						resolvedSourceResolver(image),
+						&buildapi.Build{
+							ObjectMeta: metav1.ObjectMeta{
+								Name:      image.Status.LatestBuildRef,
+								Namespace: namespace,
+								OwnerReferences: []metav1.OwnerReference{
+									*kmeta.NewControllerRef(image),
+								},
+								Labels: map[string]string{
+									buildapi.BuildNumberLabel: "1",
+									buildapi.ImageLabel:       imageName,
+								},
+							},
+							Spec: buildapi.BuildSpec{
+								Tags: []string{image.Spec.Tag},
+								Builder: corev1alpha1.BuildBuilderSpec{
+									Image: builder.Status.LatestImage,
+								},
+								ServiceAccountName: image.Spec.ServiceAccountName,
+								Source: corev1alpha1.SourceConfig{
+									Git: &corev1alpha1.Git{
+										URL:      "https://some.git/url-resolved",
+										Revision: "out-of-date-git-revision",
+									},
+								},
+							},
+							Status: buildapi.BuildStatus{
+								LatestImage: image.Status.LatestImage,
+								Stack: corev1alpha1.BuildStack{
+									RunImage: "some/run@sha256:67e3de2af270bf09c02e9a644aeb7e87e6b3c049abe6766bf6b6c3728a83e7fb",
+									ID:       "io.buildpacks.stacks.bionic",
+								},
+								Status: corev1alpha1.Status{
+									Conditions: corev1alpha1.Conditions{
+										{
+											Type:   corev1alpha1.ConditionSucceeded,
+											Status: corev1.ConditionTrue,
+										},
+									},
+								},
+							},
+						},
+					},
+					WantErr: false,
+					WantStatusUpdates: []clientgotesting.UpdateActionImpl{
+						{
+							Object: &buildapi.Image{
+								ObjectMeta: image.ObjectMeta,
+								Spec:       image.Spec,
+								Status: buildapi.ImageStatus{
+									Status: corev1alpha1.Status{
+										ObservedGeneration: originalGeneration,
+										Conditions: corev1alpha1.Conditions{
+											{
+												Type:   corev1alpha1.ConditionReady,
+												Status: corev1.ConditionTrue,
+											},
+											{
+												Type:   buildapi.ConditionBuilderReady,
+												Status: corev1.ConditionTrue,
+											},
+											{
+												Type:    buildapi.ConditionPaused,
+												Status:  corev1.ConditionTrue,
+												Reason:  buildapi.BuildPending,
+												Message: "Image is paused, a build is pending for COMMIT",
+											},
+										},
+									},
+									LatestBuildRef: "image-name-build-1",
+									LatestImage:    "some/image@sha256:ad3f454c",
+									LatestStack:    "io.buildpacks.stacks.bionic",
+									BuildCounter:   1,
+								},
+							},
+						},
+					},
+				})
+			})
+
 			it("schedules a build with a cluster builder", func() {
 				image.Spec.Builder = corev1.ObjectReference{
 					Kind: buildapi.ClusterBuilderKind,
@@ -1848,9 +2018,9 @@ func testImageReconciler(t *testing.T, when spec.G, it spec.S) {
 				})
 			})
 
-			it("does not schedule a build if the previous build is running", func() {
-				image.Status.BuildCounter = 1
-				image.Status.LatestBuildRef = "image-name-build-1"
+			it("schedules a build once the previous build is cancelled", func() {
+				image.Status.BuildCounter = 2
+				image.Status.LatestBuildRef = "image-name-build200001"
 
 				sourceResolver := resolvedSourceResolver(image)
 				rt.Test(rtesting.TableRow{
@@ -1861,15 +2031,18 @@ func testImageReconciler(t *testing.T, when spec.G, it spec.S) {
 						sourceResolver,
 						&buildapi.Build{
 							ObjectMeta: metav1.ObjectMeta{
-								Name:      "image-name-build-100001",
+								Name:      "image-name-build-1",
 								Namespace: namespace,
 								OwnerReferences: []metav1.OwnerReference{
 									*kmeta.NewControllerRef(image),
 								},
 								Labels: map[string]string{
-									buildapi.BuildNumberLabel: "1",
+									buildapi.BuildNumberLabel: "2",
 									buildapi.ImageLabel:       imageName,
 								},
+								Annotations: map[string]string{
+									buildapi.BuildCancelAnnotation: "true",
+								},
 							},
 							Spec: buildapi.BuildSpec{
 								Tags: []string{image.Spec.Tag},
@@ -1883,13 +2056,18 @@ func testImageReconciler(t *testing.T, when spec.G, it spec.S) {
 										Revision: "out-of-date-git-revision",
 									},
 								},
+								LastBuild: &buildapi.LastBuild{
+									Image:   image.Spec.Tag + "@sha256:from-build-before-this-build",
+									StackId: "io.buildpacks.stacks.bionic",
+								},
 							},
 							Status: buildapi.BuildStatus{
 								Status: corev1alpha1.Status{
 									Conditions: corev1alpha1.Conditions{
 										{
 											Type:   corev1alpha1.ConditionSucceeded,
-											Status: corev1.ConditionUnknown,
+											Status: corev1.ConditionFalse,
+											Reason: buildapi.BuildCancelled,
 										},
 									},
 								},
@@ -1897,33 +2075,54 @@ func testImageReconciler(t *testing.T, when spec.G, it spec.S) {
 						},
 					},
 					WantErr: false,
-				})
-			})
-
-			it("does not schedule a build if the previous build spec matches the current desired spec", func() {
-				image.Status.BuildCounter = 1
-				image.Status.LatestBuildRef = "image-name-build-1"
-				image.Status.LatestImage = "some/image@sha256:ad3f454c"
-				image.Status.Conditions = conditionReady()
-				image.Status.LatestStack = "io.buildpacks.stacks.bionic"
-
-				sourceResolver := resolvedSourceResolver(image)
-				rt.Test(rtesting.TableRow{
-					Key: key,
-					Objects: []runtime.Object{
-						image,
-						builder,
-						sourceResolver,
+					WantCreates: []runtime.Object{
 						&buildapi.Build{
 							ObjectMeta: metav1.ObjectMeta{
-								Name:      image.Status.LatestBuildRef,
+								Name:      imageName + "-build-3",
 								Namespace: namespace,
 								OwnerReferences: []metav1.OwnerReference{
 									*kmeta.NewControllerRef(image),
 								},
 								Labels: map[string]string{
-									buildapi.BuildNumberLabel: "1",
-									buildapi.ImageLabel:       imageName,
+									buildapi.BuildNumberLabel:     "3",
+									buildapi.ImageLabel:           imageName,
+									buildapi.ImageGenerationLabel: generation(image),
+									someLabelKey:                  someValueToPassThrough,
+								},
+								Annotations: map[string]string{
+									buildapi.BuildReasonAnnotation: strings.Join([]string{
+										buildapi.BuildReasonCommit,
+										buildapi.BuildReasonConfig,
+									}, ","),
+									buildapi.BuildChangesAnnotation: testhelpers.CompactJSON(`
+[
+  {
+    "reason": "COMMIT",
+    "old": "out-of-date-git-revision",
+    "new": "1234567-resolved"
+  },
+  {
+    "reason": "CONFIG",
+    "old": {
+      "resources": {},
+      "source": {
+        "git": {
+          "url": "out-of-date-git-url",
+          "revision": "out-of-date-git-revision"
+        }
+      }
+    },
+    "new": {
+      "resources": {},
+      "source": {
+        "git": {
+          "url": "https://some.git/url-resolved",
+          "revision": "1234567-resolved"
+        }
+      }
+    }
+  }
+]`),
 								},
 							},
 							Spec: buildapi.BuildSpec{
@@ -1938,49 +2137,1024 @@ func testImageReconciler(t *testing.T, when spec.G, it spec.S) {
 										Revision: sourceResolver.Status.Source.Git.Revision,
 									},
 								},
-							},
-							Status: buildapi.BuildStatus{
-								LatestImage: image.Status.LatestImage,
-								Stack: corev1alpha1.BuildStack{
-									RunImage: "some/run@sha256:67e3de2af270bf09c02e9a644aeb7e87e6b3c049abe6766bf6b6c3728a83e7fb",
-									ID:       "io.buildpacks.stacks.bionic",
+								Cache: &buildapi.BuildCacheConfig{},
+								LastBuild: &buildapi.LastBuild{
+									Image:   "some/image@sha256:from-build-before-this-build",
+									StackId: "io.buildpacks.stacks.bionic",
 								},
-								Status: corev1alpha1.Status{
-									Conditions: corev1alpha1.Conditions{
-										{
-											Type:   corev1alpha1.ConditionSucceeded,
-											Status: corev1.ConditionTrue,
-										},
-										{
-											Type:   buildapi.ConditionBuilderReady,
-											Status: corev1.ConditionTrue,
-										},
+							},
+						},
+					},
+					WantStatusUpdates: []clientgotesting.UpdateActionImpl{
+						{
+							Object: &buildapi.Image{
+								ObjectMeta: image.ObjectMeta,
+								Spec:       image.Spec,
+								Status: buildapi.ImageStatus{
+									Status: corev1alpha1.Status{
+										ObservedGeneration: originalGeneration,
+										Conditions:         conditionBuildExecuting("image-name-build-3"),
 									},
+									LatestBuildRef:             "image-name-build-3",
+									LatestBuildReason:          "COMMIT,CONFIG",
+									LatestBuildImageGeneration: originalGeneration,
+									BuildCounter:               3,
 								},
 							},
 						},
 					},
-					WantErr: false,
 				})
 			})
 
-			it("reports the last successful build on the image when the last build is successful", func() {
+			it("retries a build that failed in a retryable step", func() {
+				image.Spec.RetryPolicy = &buildapi.ImageRetryPolicy{
+					MaxAttempts: 3,
+					Backoff:     &metav1.Duration{Duration: time.Minute},
+				}
 				image.Status.BuildCounter = 1
 				image.Status.LatestBuildRef = "image-name-build-1"
-				image.Status.LatestImage = "some/image@some-old-sha"
-				image.Status.LatestStack = "io.buildpacks.stacks.bionic"
 
 				sourceResolver := resolvedSourceResolver(image)
-				rt.Test(rtesting.TableRow{
+				failedBuildSource := corev1alpha1.SourceConfig{
+					Git: &corev1alpha1.Git{
+						URL:      sourceResolver.Status.Source.Git.URL,
+						Revision: sourceResolver.Status.Source.Git.Revision,
+					},
+				}
+				rt.Test(rtesting.TableRow{
+					Key: key,
+					Objects: []runtime.Object{
+						image,
+						builder,
+						sourceResolver,
+						&buildapi.Build{
+							ObjectMeta: metav1.ObjectMeta{
+								Name:      image.Status.LatestBuildRef,
+								Namespace: namespace,
+								OwnerReferences: []metav1.OwnerReference{
+									*kmeta.NewControllerRef(image),
+								},
+								Labels: map[string]string{
+									buildapi.BuildNumberLabel: "1",
+									buildapi.ImageLabel:       imageName,
+								},
+							},
+							Spec: buildapi.BuildSpec{
+								Tags: []string{image.Spec.Tag},
+								Builder: corev1alpha1.BuildBuilderSpec{
+									Image: builder.Status.LatestImage,
+								},
+								ServiceAccountName: image.Spec.ServiceAccountName,
+								Source:             failedBuildSource,
+							},
+							Status: buildapi.BuildStatus{
+								Status: corev1alpha1.Status{
+									Conditions: corev1alpha1.Conditions{
+										{
+											Type:               corev1alpha1.ConditionSucceeded,
+											Status:             corev1.ConditionFalse,
+											LastTransitionTime: corev1alpha1.VolatileTime{Inner: metav1.NewTime(time.Now().Add(-time.Hour))},
+										},
+									},
+								},
+								StepsCompleted: []string{"prepare", "analyze"},
+								StepStates: []corev1.ContainerState{
+									{Terminated: &corev1.ContainerStateTerminated{ExitCode: 0}},
+									{Terminated: &corev1.ContainerStateTerminated{ExitCode: 1}},
+								},
+							},
+						},
+					},
+					WantErr: false,
+					WantCreates: []runtime.Object{
+						&buildapi.Build{
+							ObjectMeta: metav1.ObjectMeta{
+								Name:      imageName + "-build-2",
+								Namespace: namespace,
+								OwnerReferences: []metav1.OwnerReference{
+									*kmeta.NewControllerRef(image),
+								},
+								Labels: map[string]string{
+									buildapi.BuildNumberLabel:     "2",
+									buildapi.ImageLabel:           imageName,
+									buildapi.ImageGenerationLabel: generation(image),
+									someLabelKey:                  someValueToPassThrough,
+								},
+								Annotations: map[string]string{
+									buildapi.BuildReasonAnnotation:       buildapi.BuildReasonRetry,
+									buildapi.BuildRetryAttemptAnnotation: "1",
+									buildapi.BuildChangesAnnotation: testhelpers.CompactJSON(`
+[
+  {
+    "reason": "RETRY",
+    "old": 0,
+    "new": 1
+  }
+]`),
+								},
+							},
+							Spec: buildapi.BuildSpec{
+								Tags: []string{image.Spec.Tag},
+								Builder: corev1alpha1.BuildBuilderSpec{
+									Image: builder.Status.LatestImage,
+								},
+								ServiceAccountName: image.Spec.ServiceAccountName,
+								Source:             failedBuildSource,
+								Cache:              &buildapi.BuildCacheConfig{},
+							},
+						},
+					},
+					WantStatusUpdates: []clientgotesting.UpdateActionImpl{
+						{
+							Object: &buildapi.Image{
+								ObjectMeta: image.ObjectMeta,
+								Spec:       image.Spec,
+								Status: buildapi.ImageStatus{
+									Status: corev1alpha1.Status{
+										ObservedGeneration: originalGeneration,
+										Conditions:         conditionBuildExecuting("image-name-build-2"),
+									},
+									LatestBuildRef:             "image-name-build-2",
+									LatestBuildReason:          buildapi.BuildReasonRetry,
+									LatestBuildImageGeneration: originalGeneration,
+									LatestBuildRetryAttempt:    1,
+									BuildCounter:               2,
+								},
+							},
+						},
+					},
+				})
+			})
+
+			it("does not schedule a build if the previous build is running", func() {
+				image.Status.BuildCounter = 1
+				image.Status.LatestBuildRef = "image-name-build-1"
+
+				sourceResolver := resolvedSourceResolver(image)
+				rt.Test(rtesting.TableRow{
+					Key: key,
+					Objects: []runtime.Object{
+						image,
+						builder,
+						sourceResolver,
+						&buildapi.Build{
+							ObjectMeta: metav1.ObjectMeta{
+								Name:      "image-name-build-100001",
+								Namespace: namespace,
+								OwnerReferences: []metav1.OwnerReference{
+									*kmeta.NewControllerRef(image),
+								},
+								Labels: map[string]string{
+									buildapi.BuildNumberLabel: "1",
+									buildapi.ImageLabel:       imageName,
+								},
+							},
+							Spec: buildapi.BuildSpec{
+								Tags: []string{image.Spec.Tag},
+								Builder: corev1alpha1.BuildBuilderSpec{
+									Image: builder.Status.LatestImage,
+								},
+								ServiceAccountName: "old-service-account",
+								Source: corev1alpha1.SourceConfig{
+									Git: &corev1alpha1.Git{
+										URL:      "out-of-date-git-url",
+										Revision: "out-of-date-git-revision",
+									},
+								},
+							},
+							Status: buildapi.BuildStatus{
+								Status: corev1alpha1.Status{
+									Conditions: corev1alpha1.Conditions{
+										{
+											Type:   corev1alpha1.ConditionSucceeded,
+											Status: corev1.ConditionUnknown,
+										},
+									},
+								},
+							},
+						},
+					},
+					WantErr: false,
+				})
+			})
+
+			it("does not schedule a build while the previous build is being cancelled", func() {
+				image.Status.BuildCounter = 1
+				image.Status.LatestBuildRef = "image-name-build-1"
+
+				sourceResolver := resolvedSourceResolver(image)
+				rt.Test(rtesting.TableRow{
+					Key: key,
+					Objects: []runtime.Object{
+						image,
+						builder,
+						sourceResolver,
+						&buildapi.Build{
+							ObjectMeta: metav1.ObjectMeta{
+								Name:      "image-name-build-1",
+								Namespace: namespace,
+								OwnerReferences: []metav1.OwnerReference{
+									*kmeta.NewControllerRef(image),
+								},
+								Labels: map[string]string{
+									buildapi.BuildNumberLabel: "1",
+									buildapi.ImageLabel:       imageName,
+								},
+								Annotations: map[string]string{
+									buildapi.BuildCancelAnnotation: "true",
+								},
+							},
+							Spec: buildapi.BuildSpec{
+								Tags: []string{image.Spec.Tag},
+								Builder: corev1alpha1.BuildBuilderSpec{
+									Image: builder.Status.LatestImage,
+								},
+								ServiceAccountName: "old-service-account",
+								Source: corev1alpha1.SourceConfig{
+									Git: &corev1alpha1.Git{
+										URL:      "out-of-date-git-url",
+										Revision: "out-of-date-git-revision",
+									},
+								},
+							},
+							Status: buildapi.BuildStatus{
+								Status: corev1alpha1.Status{
+									Conditions: corev1alpha1.Conditions{
+										{
+											Type:   corev1alpha1.ConditionSucceeded,
+											Status: corev1.ConditionUnknown,
+										},
+									},
+								},
+							},
+						},
+					},
+					WantErr: false,
+				})
+			})
+
+			it("does not schedule a build if the previous build spec matches the current desired spec", func() {
+				image.Status.BuildCounter = 1
+				image.Status.LatestBuildRef = "image-name-build-1"
+				image.Status.LatestImage = "some/image@sha256:ad3f454c"
+				image.Status.Conditions = conditionReady()
+				image.Status.LatestStack = "io.buildpacks.stacks.bionic"
+
+				sourceResolver := resolvedSourceResolver(image)
+				rt.Test(rtesting.TableRow{
+					Key: key,
+					Objects: []runtime.Object{
+						image,
+						builder,
+						sourceResolver,
+						&buildapi.Build{
+							ObjectMeta: metav1.ObjectMeta{
+								Name:      image.Status.LatestBuildRef,
+								Namespace: namespace,
+								OwnerReferences: []metav1.OwnerReference{
+									*kmeta.NewControllerRef(image),
+								},
+								Labels: map[string]string{
+									buildapi.BuildNumberLabel: "1",
+									buildapi.ImageLabel:       imageName,
+								},
+							},
+							Spec: buildapi.BuildSpec{
+								Tags: []string{image.Spec.Tag},
+								Builder: corev1alpha1.BuildBuilderSpec{
+									Image: builder.Status.LatestImage,
+								},
+								ServiceAccountName: image.Spec.ServiceAccountName,
+								Source: corev1alpha1.SourceConfig{
+									Git: &corev1alpha1.Git{
+										URL:      sourceResolver.Status.Source.Git.URL,
+										Revision: sourceResolver.Status.Source.Git.Revision,
+									},
+								},
+							},
+							Status: buildapi.BuildStatus{
+								LatestImage: image.Status.LatestImage,
+								Stack: corev1alpha1.BuildStack{
+									RunImage: "some/run@sha256:67e3de2af270bf09c02e9a644aeb7e87e6b3c049abe6766bf6b6c3728a83e7fb",
+									ID:       "io.buildpacks.stacks.bionic",
+								},
+								Status: corev1alpha1.Status{
+									Conditions: corev1alpha1.Conditions{
+										{
+											Type:   corev1alpha1.ConditionSucceeded,
+											Status: corev1.ConditionTrue,
+										},
+										{
+											Type:   buildapi.ConditionBuilderReady,
+											Status: corev1.ConditionTrue,
+										},
+									},
+								},
+							},
+						},
+					},
+					WantErr: false,
+				})
+			})
+
+			it("reports the last successful build on the image when the last build is successful", func() {
+				image.Status.BuildCounter = 1
+				image.Status.LatestBuildRef = "image-name-build-1"
+				image.Status.LatestImage = "some/image@some-old-sha"
+				image.Status.LatestStack = "io.buildpacks.stacks.bionic"
+
+				sourceResolver := resolvedSourceResolver(image)
+				rt.Test(rtesting.TableRow{
+					Key: key,
+					Objects: runtimeObjects(
+						successfulBuilds(image, sourceResolver, 1),
+						image,
+						builder,
+						sourceResolver,
+					),
+					WantErr: false,
+					WantStatusUpdates: []clientgotesting.UpdateActionImpl{
+						{
+							Object: &buildapi.Image{
+								ObjectMeta: image.ObjectMeta,
+								Spec:       image.Spec,
+								Status: buildapi.ImageStatus{
+									Status: corev1alpha1.Status{
+										ObservedGeneration: originalGeneration,
+										Conditions: corev1alpha1.Conditions{
+											{
+												Type:   corev1alpha1.ConditionReady,
+												Status: corev1.ConditionTrue,
+											},
+											{
+												Type:   buildapi.ConditionBuilderReady,
+												Status: corev1.ConditionTrue,
+											},
+										},
+									},
+									LatestBuildRef: "image-name-build-1",
+									LatestImage:    "some/image@sha256:build-1",
+									BuildCounter:   1,
+									LatestStack:    "io.buildpacks.stacks.bionic",
+								},
+							},
+						},
+					},
+				})
+			})
+
+			it("reports the average build duration of the latest successful builds", func() {
+				image.Spec.SuccessBuildHistoryLimit = limit(20)
+				image.Status.BuildCounter = 12
+				image.Status.LatestBuildRef = "image-name-build-12"
+				image.Status.LatestImage = "some/image@sha256:build-12"
+				image.Status.LatestStack = "io.buildpacks.stacks.bionic"
+				image.Status.Conditions = conditionReady()
+
+				sourceResolver := resolvedSourceResolver(image)
+				builds := successfulBuilds(image, sourceResolver, 12)
+				for i, build := range builds {
+					if i != 5 {
+						build.(*buildapi.Build).Status.BuildDuration = &metav1.Duration{Duration: time.Duration(i+1) * time.Minute}
+					}
+				}
+
+				rt.Test(rtesting.TableRow{
+					Key: key,
+					Objects: runtimeObjects(
+						builds,
+						image,
+						builder,
+						sourceResolver,
+					),
+					WantErr: false,
+					WantStatusUpdates: []clientgotesting.UpdateActionImpl{
+						{
+							Object: &buildapi.Image{
+								ObjectMeta: image.ObjectMeta,
+								Spec:       image.Spec,
+								Status: buildapi.ImageStatus{
+									Status: corev1alpha1.Status{
+										ObservedGeneration: originalGeneration,
+										Conditions:         conditionReady(),
+									},
+									LatestBuildRef:       "image-name-build-12",
+									LatestImage:          "some/image@sha256:build-12",
+									BuildCounter:         12,
+									LatestStack:          "io.buildpacks.stacks.bionic",
+									AverageBuildDuration: &metav1.Duration{Duration: 71 * time.Minute / 10},
+								},
+							},
+						},
+					},
+				})
+			})
+
+			it("reports unknown when last build was successful and source resolver is unknown", func() {
+				image.Status.BuildCounter = 1
+				image.Status.LatestBuildRef = "image-name-build-1"
+				image.Status.LatestImage = "some/image@some-old-sha"
+				image.Status.LatestStack = "io.buildpacks.stacks.bionic"
+
+				sourceResolver := resolvedSourceResolver(image)
+				rt.Test(rtesting.TableRow{
+					Key: key,
+					Objects: runtimeObjects(
+						successfulBuilds(image, sourceResolver, 1),
+						image,
+						builder,
+						unresolvedSourceResolver(image),
+					),
+					WantErr: false,
+					WantStatusUpdates: []clientgotesting.UpdateActionImpl{
+						{
+							Object: &buildapi.Image{
+								ObjectMeta: image.ObjectMeta,
+								Spec:       image.Spec,
+								Status: buildapi.ImageStatus{
+									Status: corev1alpha1.Status{
+										ObservedGeneration: originalGeneration,
+										Conditions: corev1alpha1.Conditions{
+											{
+												Type:   corev1alpha1.ConditionReady,
+												Status: corev1.ConditionUnknown,
+											},
+											{
+												Type:   buildapi.ConditionBuilderReady,
+												Status: corev1.ConditionTrue,
+											},
+										},
+									},
+									LatestBuildRef: "image-name-build-1",
+									LatestImage:    "some/image@sha256:build-1",
+									BuildCounter:   1,
+									LatestStack:    "io.buildpacks.stacks.bionic",
+								},
+							},
+						},
+					},
+				})
+
+			})
+
+			it("reports unknown when last build was successful and builder is not ready", func() {
+				image.Status.BuildCounter = 1
+				image.Status.LatestBuildRef = "image-name-build-1"
+				image.Status.LatestImage = "some/image@some-old-sha"
+				image.Status.LatestStack = "io.buildpacks.stacks.bionic"
+
+				sourceResolver := resolvedSourceResolver(image)
+				rt.Test(rtesting.TableRow{
+					Key: key,
+					Objects: runtimeObjects(
+						successfulBuilds(image, sourceResolver, 1),
+						image,
+						sourceResolver,
+						notReadyBuilder(builder),
+					),
+					WantErr: false,
+					WantStatusUpdates: []clientgotesting.UpdateActionImpl{
+						{
+							Object: &buildapi.Image{
+								ObjectMeta: image.ObjectMeta,
+								Spec:       image.Spec,
+								Status: buildapi.ImageStatus{
+									Status: corev1alpha1.Status{
+										ObservedGeneration: originalGeneration,
+										Conditions: corev1alpha1.Conditions{
+											{
+												Type:   corev1alpha1.ConditionReady,
+												Status: corev1.ConditionUnknown,
+											},
+											{
+												Type:    buildapi.ConditionBuilderReady,
+												Status:  corev1.ConditionFalse,
+												Reason:  buildapi.BuilderNotReady,
+												Message: "Builder builder-name is not ready",
+											},
+										},
+									},
+									LatestBuildRef: "image-name-build-1",
+									LatestImage:    "some/image@sha256:build-1",
+									BuildCounter:   1,
+									LatestStack:    "io.buildpacks.stacks.bionic",
+								},
+							},
+						},
+					},
+				})
+
+			})
+
+			when("reconciling old builds", func() {
+
+				it("deletes a failed build if more than the limit", func() {
+					image.Spec.FailedBuildHistoryLimit = limit(4)
+					image.Status.LatestBuildRef = "image-name-build-5"
+					image.Status.Conditions = conditionNotReady()
+					image.Status.BuildCounter = 5
+					sourceResolver := resolvedSourceResolver(image)
+
+					rt.Test(rtesting.TableRow{
+						Key: key,
+						Objects: runtimeObjects(
+							failedBuilds(image, sourceResolver, 5),
+							image,
+							builder,
+							sourceResolver,
+						),
+						WantErr: false,
+						WantDeletes: []clientgotesting.DeleteActionImpl{
+							{
+								ActionImpl: clientgotesting.ActionImpl{
+									Namespace: "some-namespace",
+									Verb:      "",
+									Resource: schema.GroupVersionResource{
+										Resource: "builds",
+									},
+									Subresource: "",
+								},
+								Name: image.Name + "-build-1", // first-build
+							},
+						},
+					})
+				})
+
+				it("deletes a successful build if more than the limit", func() {
+					image.Spec.SuccessBuildHistoryLimit = limit(4)
+					image.Status.LatestBuildRef = "image-name-build-5"
+					image.Status.LatestImage = "some/image@sha256:build-5"
+					image.Status.LatestStack = "io.buildpacks.stacks.bionic"
+					image.Status.Conditions = conditionReady()
+					image.Status.BuildCounter = 5
+					sourceResolver := resolvedSourceResolver(image)
+
+					rt.Test(rtesting.TableRow{
+						Key: key,
+						Objects: runtimeObjects(
+							successfulBuilds(image, sourceResolver, 5),
+							image,
+							builder,
+							sourceResolver,
+						),
+						WantErr: false,
+						WantDeletes: []clientgotesting.DeleteActionImpl{
+							{
+								ActionImpl: clientgotesting.ActionImpl{
+									Namespace: "some-namespace",
+									Verb:      "",
+									Resource: schema.GroupVersionResource{
+										Resource: "builds",
+									},
+									Subresource: "",
+								},
+								Name: image.Name + "-build-1", // first-build
+							},
+						},
+					})
+				})
+
+				it("deletes all builds beyond a lowered limit", func() {
+					image.Spec.FailedBuildHistoryLimit = limit(3)
+					image.Status.LatestBuildRef = "image-name-build-8"
+					image.Status.Conditions = conditionNotReady()
+					image.Status.BuildCounter = 8
+					sourceResolver := resolvedSourceResolver(image)
+
+					rt.Test(rtesting.TableRow{
+						Key: key,
+						Objects: runtimeObjects(
+							failedBuilds(image, sourceResolver, 8),
+							image,
+							builder,
+							sourceResolver,
+						),
+						WantErr:     false,
+						WantDeletes: buildDeletes(image, 1, 2, 3, 4, 5),
+					})
+				})
+
+				it("deletes builds older than the max age", func() {
+					image.Spec.BuildHistoryMaxAge = &metav1.Duration{Duration: time.Hour}
+					image.Status.LatestBuildRef = "image-name-build-5"
+					image.Status.LatestImage = "some/image@sha256:build-5"
+					image.Status.LatestStack = "io.buildpacks.stacks.bionic"
+					image.Status.Conditions = conditionReady()
+					image.Status.BuildCounter = 5
+					sourceResolver := resolvedSourceResolver(image)
+
+					builds := successfulBuilds(image, sourceResolver, 5)
+					for _, build := range builds[:2] {
+						build.(*buildapi.Build).CreationTimestamp = metav1.NewTime(time.Now().Add(-2 * time.Hour))
+					}
+
+					rt.Test(rtesting.TableRow{
+						Key: key,
+						Objects: runtimeObjects(
+							builds,
+							image,
+							builder,
+							sourceResolver,
+						),
+						WantErr:     false,
+						WantDeletes: buildDeletes(image, 1, 2),
+					})
+				})
+
+				it("keeps the latest build and latest successful build older than the max age", func() {
+					image.Spec.BuildHistoryMaxAge = &metav1.Duration{Duration: time.Hour}
+					image.Status.LatestBuildRef = "image-name-build-3"
+					image.Status.Conditions = conditionNotReady()
+					image.Status.BuildCounter = 3
+					sourceResolver := resolvedSourceResolver(image)
+
+					builds := runtimeObjects(
+						successfulBuilds(image, sourceResolver, 1),
+						failedBuilds(image, sourceResolver, 3)[1:]...,
+					)
+					for _, build := range builds {
+						build.(*buildapi.Build).CreationTimestamp = metav1.NewTime(time.Now().Add(-2 * time.Hour))
+					}
+					image.Status.LatestImage = "some/image@sha256:build-1"
+
+					rt.Test(rtesting.TableRow{
+						Key: key,
+						Objects: runtimeObjects(
+							builds,
+							image,
+							builder,
+							sourceResolver,
+						),
+						WantErr:     false,
+						WantDeletes: buildDeletes(image, 2),
+					})
+				})
+			})
+		})
+
+		when("build requests", func() {
+			request := &buildapi.BuildRequest{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "hotfix",
+					Namespace: namespace,
+				},
+				Spec: buildapi.BuildRequestSpec{
+					ImageName: imageName,
+					Revision:  "v1.2.3",
+					Tag:       "some/image:hotfix",
+				},
+			}
+
+			withRequestStatus := func(status buildapi.BuildRequestStatus) *buildapi.BuildRequest {
+				request := request.DeepCopy()
+				request.Status = status
+				return request
+			}
+
+			currentBuild := func(sourceResolver *buildapi.SourceResolver) *buildapi.Build {
+				return &buildapi.Build{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "image-name-build-1",
+						Namespace: namespace,
+						OwnerReferences: []metav1.OwnerReference{
+							*kmeta.NewControllerRef(image),
+						},
+						Labels: map[string]string{
+							buildapi.BuildNumberLabel: "1",
+							buildapi.ImageLabel:       imageName,
+						},
+						CreationTimestamp: metav1.NewTime(time.Now().Add(-time.Hour)),
+					},
+					Spec: buildapi.BuildSpec{
+						Tags: []string{image.Spec.Tag},
+						Builder: corev1alpha1.BuildBuilderSpec{
+							Image: builder.Status.LatestImage,
+						},
+						ServiceAccountName: image.Spec.ServiceAccountName,
+						Source: corev1alpha1.SourceConfig{
+							Git: &corev1alpha1.Git{
+								URL:      sourceResolver.Status.Source.Git.URL,
+								Revision: sourceResolver.Status.Source.Git.Revision,
+							},
+						},
+					},
+					Status: buildapi.BuildStatus{
+						LatestImage: "some/image@sha256:ad3f454c",
+						Stack: corev1alpha1.BuildStack{
+							RunImage: "some/run@sha256:67e3de2af270bf09c02e9a644aeb7e87e6b3c049abe6766bf6b6c3728a83e7fb",
+							ID:       "io.buildpacks.stacks.bionic",
+						},
+						Status: corev1alpha1.Status{
+							Conditions: corev1alpha1.Conditions{
+								{
+									Type:   corev1alpha1.ConditionSucceeded,
+									Status: corev1.ConditionTrue,
+								},
+							},
+						},
+					},
+				}
+			}
+
+			manualBuild := func() *buildapi.Build {
+				return &buildapi.Build{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "image-name-build-2",
+						Namespace: namespace,
+						OwnerReferences: []metav1.OwnerReference{
+							*kmeta.NewControllerRef(image),
+						},
+						Labels: map[string]string{
+							buildapi.BuildNumberLabel:     "2",
+							buildapi.ImageLabel:           imageName,
+							buildapi.ImageGenerationLabel: generation(image),
+							buildapi.BuildRequestLabel:    "hotfix",
+							someLabelKey:                  someValueToPassThrough,
+						},
+						Annotations: map[string]string{
+							buildapi.BuildReasonAnnotation: buildapi.BuildReasonManual,
+							buildapi.BuildChangesAnnotation: testhelpers.CompactJSON(`
+[
+  {
+    "reason": "MANUAL",
+    "old": "1234567-resolved",
+    "new": "0f4b1a6a3b2c1d4e5f60718293a4b5c6d7e8f901"
+  }
+]`),
+						},
+					},
+					Spec: buildapi.BuildSpec{
+						Tags: []string{"some/image:hotfix"},
+						Builder: corev1alpha1.BuildBuilderSpec{
+							Image: builder.Status.LatestImage,
+						},
+						ServiceAccountName: image.Spec.ServiceAccountName,
+						Source: corev1alpha1.SourceConfig{
+							Git: &corev1alpha1.Git{
+								URL:      image.Spec.Source.Git.URL,
+								Revision: "0f4b1a6a3b2c1d4e5f60718293a4b5c6d7e8f901",
+							},
+						},
+						Cache: &buildapi.BuildCacheConfig{},
+						LastBuild: &buildapi.LastBuild{
+							Image:   "some/image@sha256:ad3f454c",
+							StackId: "io.buildpacks.stacks.bionic",
+						},
+					},
+				}
+			}
+
+			it.Before(func() {
+				gitResolver.resolved = corev1alpha1.ResolvedGitSource{
+					Revision: "0f4b1a6a3b2c1d4e5f60718293a4b5c6d7e8f901",
+					Type:     corev1alpha1.Tag,
+				}
+				gitResolver.err = nil
+				gitResolver.requested = nil
+
+				image.Status.BuildCounter = 1
+				image.Status.LatestBuildRef = "image-name-build-1"
+				image.Status.LatestImage = "some/image@sha256:ad3f454c"
+				image.Status.LatestStack = "io.buildpacks.stacks.bionic"
+				image.Status.Conditions = conditionReady()
+			})
+
+			it("creates a manual build of the requested revision and tag", func() {
+				sourceResolver := resolvedSourceResolver(image)
+
+				rt.Test(rtesting.TableRow{
+					Key: key,
+					Objects: []runtime.Object{
+						image,
+						builder,
+						sourceResolver,
+						currentBuild(sourceResolver),
+						request,
+					},
+					WantErr:     false,
+					WantCreates: []runtime.Object{manualBuild()},
+					WantStatusUpdates: []clientgotesting.UpdateActionImpl{
+						{
+							Object: withRequestStatus(buildapi.BuildRequestStatus{
+								Status: corev1alpha1.Status{
+									Conditions: corev1alpha1.Conditions{
+										{
+											Type:    corev1alpha1.ConditionSucceeded,
+											Status:  corev1.ConditionUnknown,
+											Message: "image-name-build-2 is executing",
+										},
+									},
+								},
+								BuildName: "image-name-build-2",
+							}),
+						},
+					},
+				})
+
+				require.Len(t, gitResolver.requested, 1)
+				require.Equal(t, "v1.2.3", gitResolver.requested[0].Spec.Source.Git.Revision)
+				require.Equal(t, serviceAccount, gitResolver.requested[0].Spec.ServiceAccountName)
+			})
+
+			it("fails a request of a revision that is not a branch, tag or commit", func() {
+				gitResolver.resolved = corev1alpha1.ResolvedGitSource{
+					Revision: "v1.2.3",
+					Type:     corev1alpha1.Commit,
+				}
+				sourceResolver := resolvedSourceResolver(image)
+
+				rt.Test(rtesting.TableRow{
+					Key: key,
+					Objects: []runtime.Object{
+						image,
+						builder,
+						sourceResolver,
+						currentBuild(sourceResolver),
+						request,
+					},
+					WantErr: false,
+					WantStatusUpdates: []clientgotesting.UpdateActionImpl{
+						{
+							Object: withRequestStatus(buildapi.BuildRequestStatus{
+								Status: corev1alpha1.Status{
+									Conditions: corev1alpha1.Conditions{
+										{
+											Type:    corev1alpha1.ConditionSucceeded,
+											Status:  corev1.ConditionFalse,
+											Reason:  buildapi.BuildRequestRevisionNotFound,
+											Message: "Revision v1.2.3 is not a branch, tag or commit of https://some.git/url",
+										},
+									},
+								},
+							}),
+						},
+					},
+				})
+			})
+
+			it("fails a request whose revision cannot be resolved", func() {
+				gitResolver.err = &testConditionError{reason: "HostVerificationFailed", message: "unknown host some.git"}
+				sourceResolver := resolvedSourceResolver(image)
+
+				rt.Test(rtesting.TableRow{
+					Key: key,
+					Objects: []runtime.Object{
+						image,
+						builder,
+						sourceResolver,
+						currentBuild(sourceResolver),
+						request,
+					},
+					WantErr: false,
+					WantStatusUpdates: []clientgotesting.UpdateActionImpl{
+						{
+							Object: withRequestStatus(buildapi.BuildRequestStatus{
+								Status: corev1alpha1.Status{
+									Conditions: corev1alpha1.Conditions{
+										{
+											Type:    corev1alpha1.ConditionSucceeded,
+											Status:  corev1.ConditionFalse,
+											Reason:  "HostVerificationFailed",
+											Message: "unknown host some.git",
+										},
+									},
+								},
+							}),
+						},
+					},
+				})
+			})
+
+			it("retries a request while the git source cannot be reached", func() {
+				gitResolver.resolved = corev1alpha1.ResolvedGitSource{
+					Revision: "v1.2.3",
+					Type:     corev1alpha1.Unknown,
+				}
+				sourceResolver := resolvedSourceResolver(image)
+
+				rt.Test(rtesting.TableRow{
+					Key: key,
+					Objects: []runtime.Object{
+						image,
+						builder,
+						sourceResolver,
+						currentBuild(sourceResolver),
+						request,
+					},
+					WantErr: true,
+				})
+			})
+
+			it("reports the result of the manual build on the request without changing the image", func() {
+				image.Status.BuildCounter = 2
+				sourceResolver := resolvedSourceResolver(image)
+				build := manualBuild()
+				build.CreationTimestamp = metav1.Now()
+				build.Status = buildapi.BuildStatus{
+					LatestImage: "some/image:hotfix@sha256:f00d",
+					Status: corev1alpha1.Status{
+						Conditions: corev1alpha1.Conditions{
+							{
+								Type:   corev1alpha1.ConditionSucceeded,
+								Status: corev1.ConditionTrue,
+							},
+						},
+					},
+				}
+
+				rt.Test(rtesting.TableRow{
+					Key: key,
+					Objects: []runtime.Object{
+						image,
+						builder,
+						sourceResolver,
+						currentBuild(sourceResolver),
+						build,
+						withRequestStatus(buildapi.BuildRequestStatus{
+							Status: corev1alpha1.Status{
+								Conditions: corev1alpha1.Conditions{
+									{
+										Type:    corev1alpha1.ConditionSucceeded,
+										Status:  corev1.ConditionUnknown,
+										Message: "image-name-build-2 is executing",
+									},
+								},
+							},
+							BuildName: "image-name-build-2",
+						}),
+					},
+					WantErr: false,
+					WantStatusUpdates: []clientgotesting.UpdateActionImpl{
+						{
+							Object: withRequestStatus(buildapi.BuildRequestStatus{
+								Status: corev1alpha1.Status{
+									Conditions: corev1alpha1.Conditions{
+										{
+											Type:   corev1alpha1.ConditionSucceeded,
+											Status: corev1.ConditionTrue,
+										},
+									},
+								},
+								BuildName:   "image-name-build-2",
+								LatestImage: "some/image:hotfix@sha256:f00d",
+							}),
+						},
+					},
+				})
+			})
+
+			it("does not create a manual build while a build is running", func() {
+				sourceResolver := resolvedSourceResolver(image)
+				running := currentBuild(sourceResolver)
+				running.Status.Conditions = corev1alpha1.Conditions{
+					{
+						Type:   corev1alpha1.ConditionSucceeded,
+						Status: corev1.ConditionUnknown,
+					},
+				}
+
+				rt.Test(rtesting.TableRow{
 					Key: key,
-					Objects: runtimeObjects(
-						successfulBuilds(image, sourceResolver, 1),
+					Objects: []runtime.Object{
 						image,
 						builder,
 						sourceResolver,
-					),
+						running,
+						request,
+					},
+					WantErr: false,
+				})
+			})
+
+			it("queues the requests of a paused image without creating a build", func() {
+				image.Spec.Paused = true
+				sourceResolver := resolvedSourceResolver(image)
+
+				rt.Test(rtesting.TableRow{
+					Key: key,
+					Objects: []runtime.Object{
+						image,
+						builder,
+						sourceResolver,
+						currentBuild(sourceResolver),
+						request,
+					},
 					WantErr: false,
 					WantStatusUpdates: []clientgotesting.UpdateActionImpl{
+						{
+							Object: withRequestStatus(buildapi.BuildRequestStatus{
+								Status: corev1alpha1.Status{
+									Conditions: corev1alpha1.Conditions{
+										{
+											Type:    corev1alpha1.ConditionSucceeded,
+											Status:  corev1.ConditionUnknown,
+											Reason:  buildapi.BuildQueued,
+											Message: "Image image-name is paused",
+										},
+									},
+								},
+							}),
+						},
 						{
 							Object: &buildapi.Image{
 								ObjectMeta: image.ObjectMeta,
@@ -1997,12 +3171,17 @@ func testImageReconciler(t *testing.T, when spec.G, it spec.S) {
 												Type:   buildapi.ConditionBuilderReady,
 												Status: corev1.ConditionTrue,
 											},
+											{
+												Type:    buildapi.ConditionPaused,
+												Status:  corev1.ConditionTrue,
+												Message: "Image is paused",
+											},
 										},
 									},
 									LatestBuildRef: "image-name-build-1",
-									LatestImage:    "some/image@sha256:build-1",
-									BuildCounter:   1,
+									LatestImage:    "some/image@sha256:ad3f454c",
 									LatestStack:    "io.buildpacks.stacks.bionic",
+									BuildCounter:   1,
 								},
 							},
 						},
@@ -2010,21 +3189,96 @@ func testImageReconciler(t *testing.T, when spec.G, it spec.S) {
 				})
 			})
 
-			it("reports unknown when last build was successful and source resolver is unknown", func() {
-				image.Status.BuildCounter = 1
-				image.Status.LatestBuildRef = "image-name-build-1"
-				image.Status.LatestImage = "some/image@some-old-sha"
+			it("fails the requests of an image without a git source", func() {
+				image.Spec.Source = corev1alpha1.SourceConfig{
+					Blob: &corev1alpha1.Blob{URL: "https://some-blobstore.example.com/some-blob"},
+				}
+				image.Status = buildapi.ImageStatus{
+					Status: corev1alpha1.Status{
+						ObservedGeneration: originalGeneration,
+						Conditions:         conditionReadyUnknown(),
+					},
+				}
+
+				rt.Test(rtesting.TableRow{
+					Key: key,
+					Objects: []runtime.Object{
+						image,
+						builder,
+						unresolvedSourceResolver(image),
+						request,
+					},
+					WantErr: false,
+					WantStatusUpdates: []clientgotesting.UpdateActionImpl{
+						{
+							Object: withRequestStatus(buildapi.BuildRequestStatus{
+								Status: corev1alpha1.Status{
+									Conditions: corev1alpha1.Conditions{
+										{
+											Type:    corev1alpha1.ConditionSucceeded,
+											Status:  corev1.ConditionFalse,
+											Reason:  buildapi.BuildRequestSourceNotGit,
+											Message: "Image image-name does not build from a git source",
+										},
+									},
+								},
+							}),
+						},
+					},
+				})
+			})
+		})
+
+		when("rollback", func() {
+			ignoreRolledBackAt := cmpopts.IgnoreFields(buildapi.ImageRollbackStatus{}, "RolledBackAt")
+
+			rollbackBuilds := func(sourceResolver *buildapi.SourceResolver) []runtime.Object {
+				builds := successfulBuilds(image, sourceResolver, 3)
+				for _, build := range builds {
+					build.(*buildapi.Build).Spec.Builder.Image = builder.Status.LatestImage
+				}
+				return builds
+			}
+
+			rolledBackConditions := func() corev1alpha1.Conditions {
+				return corev1alpha1.Conditions{
+					{
+						Type:    corev1alpha1.ConditionReady,
+						Status:  corev1.ConditionTrue,
+						Reason:  buildapi.RolledBack,
+						Message: "Rolled back to image-name-build-2, builds are suspended until the rollback is removed",
+					},
+					{
+						Type:   buildapi.ConditionBuilderReady,
+						Status: corev1.ConditionTrue,
+					},
+				}
+			}
+
+			it.Before(func() {
+				retagger.retagged = map[string]string{"image-name-build-2": "some/image@sha256:build-2"}
+				retagger.calls = nil
+
+				image.Status.BuildCounter = 3
+				image.Status.LatestBuildRef = "image-name-build-3"
+				image.Status.LatestImage = "some/image@sha256:build-3"
 				image.Status.LatestStack = "io.buildpacks.stacks.bionic"
+				image.Status.Conditions = conditionReady()
+			})
 
+			it("points the tags at the image of the build and suspends builds", func() {
+				image.Spec.Rollback = &buildapi.ImageRollback{BuildNumber: 2}
 				sourceResolver := resolvedSourceResolver(image)
+
 				rt.Test(rtesting.TableRow{
 					Key: key,
 					Objects: runtimeObjects(
-						successfulBuilds(image, sourceResolver, 1),
+						successfulBuilds(image, sourceResolver, 3),
 						image,
 						builder,
-						unresolvedSourceResolver(image),
+						sourceResolver,
 					),
+					CmpOpts: []cmp.Option{ignoreRolledBackAt},
 					WantErr: false,
 					WantStatusUpdates: []clientgotesting.UpdateActionImpl{
 						{
@@ -2036,8 +3290,10 @@ func testImageReconciler(t *testing.T, when spec.G, it spec.S) {
 										ObservedGeneration: originalGeneration,
 										Conditions: corev1alpha1.Conditions{
 											{
-												Type:   corev1alpha1.ConditionReady,
-												Status: corev1.ConditionUnknown,
+												Type:    corev1alpha1.ConditionReady,
+												Status:  corev1.ConditionTrue,
+												Reason:  buildapi.RolledBack,
+												Message: "Rolled back to image-name-build-2, builds are suspended until the rollback is removed",
 											},
 											{
 												Type:   buildapi.ConditionBuilderReady,
@@ -2045,32 +3301,120 @@ func testImageReconciler(t *testing.T, when spec.G, it spec.S) {
 											},
 										},
 									},
-									LatestBuildRef: "image-name-build-1",
-									LatestImage:    "some/image@sha256:build-1",
-									BuildCounter:   1,
-									LatestStack:    "io.buildpacks.stacks.bionic",
+									LatestBuildRef:    "image-name-build-2",
+									LatestBuildReason: buildapi.BuildReasonRollback,
+									LatestImage:       "some/image@sha256:build-2",
+									LatestStack:       "io.buildpacks.stacks.bionic",
+									BuildCounter:      3,
+									Rollback: &buildapi.ImageRollbackStatus{
+										BuildNumber:     2,
+										BuildName:       "image-name-build-2",
+										Image:           "some/image@sha256:build-2",
+										ImageGeneration: originalGeneration,
+									},
 								},
 							},
 						},
 					},
 				})
 
+				require.Equal(t, []string{"image-name-build-2"}, retagger.calls)
 			})
 
-			it("reports unknown when last build was successful and builder is not ready", func() {
-				image.Status.BuildCounter = 1
-				image.Status.LatestBuildRef = "image-name-build-1"
-				image.Status.LatestImage = "some/image@some-old-sha"
-				image.Status.LatestStack = "io.buildpacks.stacks.bionic"
+			it("queues the requests of a rolled back image without creating a build", func() {
+				image.Spec.Rollback = &buildapi.ImageRollback{BuildNumber: 2}
+				image.Status.LatestBuildRef = "image-name-build-2"
+				image.Status.LatestBuildReason = buildapi.BuildReasonRollback
+				image.Status.LatestImage = "some/image@sha256:build-2"
+				image.Status.Conditions = rolledBackConditions()
+				image.Status.Rollback = &buildapi.ImageRollbackStatus{
+					BuildNumber:     2,
+					BuildName:       "image-name-build-2",
+					Image:           "some/image@sha256:build-2",
+					ImageGeneration: originalGeneration,
+					RolledBackAt:    metav1.Now(),
+				}
+				sourceResolver := resolvedSourceResolver(image)
+				request := &buildapi.BuildRequest{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "hotfix",
+						Namespace: namespace,
+					},
+					Spec: buildapi.BuildRequestSpec{
+						ImageName: imageName,
+						Revision:  "v1.2.3",
+					},
+				}
+				queued := request.DeepCopy()
+				queued.Status.Conditions = corev1alpha1.Conditions{
+					{
+						Type:    corev1alpha1.ConditionSucceeded,
+						Status:  corev1.ConditionUnknown,
+						Reason:  buildapi.RolledBack,
+						Message: "Image image-name is rolled back",
+					},
+				}
+
+				rt.Test(rtesting.TableRow{
+					Key: key,
+					Objects: runtimeObjects(
+						rollbackBuilds(sourceResolver),
+						image,
+						builder,
+						sourceResolver,
+						request,
+					),
+					WantErr: false,
+					WantStatusUpdates: []clientgotesting.UpdateActionImpl{
+						{
+							Object: queued,
+						},
+					},
+				})
+
+				require.Empty(t, retagger.calls)
+			})
 
+			it("does not point the tags at the image again once rolled back", func() {
+				image.Spec.Rollback = &buildapi.ImageRollback{BuildNumber: 2}
+				image.Status.LatestBuildRef = "image-name-build-2"
+				image.Status.LatestBuildReason = buildapi.BuildReasonRollback
+				image.Status.LatestImage = "some/image@sha256:build-2"
+				image.Status.Conditions = rolledBackConditions()
+				image.Status.Rollback = &buildapi.ImageRollbackStatus{
+					BuildNumber:     2,
+					BuildName:       "image-name-build-2",
+					Image:           "some/image@sha256:build-2",
+					ImageGeneration: originalGeneration,
+					RolledBackAt:    metav1.Now(),
+				}
 				sourceResolver := resolvedSourceResolver(image)
+
 				rt.Test(rtesting.TableRow{
 					Key: key,
 					Objects: runtimeObjects(
-						successfulBuilds(image, sourceResolver, 1),
+						rollbackBuilds(sourceResolver),
 						image,
+						builder,
+						sourceResolver,
+					),
+					WantErr: false,
+				})
+
+				require.Empty(t, retagger.calls)
+			})
+
+			it("reports a rollback to a build that did not succeed", func() {
+				image.Spec.Rollback = &buildapi.ImageRollback{BuildNumber: 4}
+				sourceResolver := resolvedSourceResolver(image)
+
+				rt.Test(rtesting.TableRow{
+					Key: key,
+					Objects: runtimeObjects(
+						rollbackBuilds(sourceResolver),
+						image,
+						builder,
 						sourceResolver,
-						notReadyBuilder(builder),
 					),
 					WantErr: false,
 					WantStatusUpdates: []clientgotesting.UpdateActionImpl{
@@ -2083,92 +3427,159 @@ func testImageReconciler(t *testing.T, when spec.G, it spec.S) {
 										ObservedGeneration: originalGeneration,
 										Conditions: corev1alpha1.Conditions{
 											{
-												Type:   corev1alpha1.ConditionReady,
-												Status: corev1.ConditionUnknown,
+												Type:    corev1alpha1.ConditionReady,
+												Status:  corev1.ConditionFalse,
+												Reason:  buildapi.RollbackFailed,
+												Message: "Image image-name has no successful build 4",
 											},
 											{
-												Type:    buildapi.ConditionBuilderReady,
-												Status:  corev1.ConditionFalse,
-												Reason:  buildapi.BuilderNotReady,
-												Message: "Builder builder-name is not ready",
+												Type:   buildapi.ConditionBuilderReady,
+												Status: corev1.ConditionTrue,
 											},
 										},
 									},
-									LatestBuildRef: "image-name-build-1",
-									LatestImage:    "some/image@sha256:build-1",
-									BuildCounter:   1,
+									LatestBuildRef: "image-name-build-3",
+									LatestImage:    "some/image@sha256:build-3",
 									LatestStack:    "io.buildpacks.stacks.bionic",
+									BuildCounter:   3,
 								},
 							},
 						},
 					},
 				})
 
+				require.Empty(t, retagger.calls)
 			})
 
-			when("reconciling old builds", func() {
+			it("reports a rollback that could not point the tags at the image", func() {
+				image.Spec.Rollback = &buildapi.ImageRollback{BuildNumber: 1}
+				sourceResolver := resolvedSourceResolver(image)
 
-				it("deletes a failed build if more than the limit", func() {
-					image.Spec.FailedBuildHistoryLimit = limit(4)
-					image.Status.LatestBuildRef = "image-name-build-5"
-					image.Status.Conditions = conditionNotReady()
-					image.Status.BuildCounter = 5
+				rt.Test(rtesting.TableRow{
+					Key: key,
+					Objects: runtimeObjects(
+						rollbackBuilds(sourceResolver),
+						image,
+						builder,
+						sourceResolver,
+					),
+					WantErr: false,
+					WantStatusUpdates: []clientgotesting.UpdateActionImpl{
+						{
+							Object: &buildapi.Image{
+								ObjectMeta: image.ObjectMeta,
+								Spec:       image.Spec,
+								Status: buildapi.ImageStatus{
+									Status: corev1alpha1.Status{
+										ObservedGeneration: originalGeneration,
+										Conditions: corev1alpha1.Conditions{
+											{
+												Type:    corev1alpha1.ConditionReady,
+												Status:  corev1.ConditionFalse,
+												Reason:  buildapi.RollbackFailed,
+												Message: "Unable to roll back to image-name-build-1: unauthorized to push to some/image",
+											},
+											{
+												Type:   buildapi.ConditionBuilderReady,
+												Status: corev1.ConditionTrue,
+											},
+										},
+									},
+									LatestBuildRef: "image-name-build-3",
+									LatestImage:    "some/image@sha256:build-3",
+									LatestStack:    "io.buildpacks.stacks.bionic",
+									BuildCounter:   3,
+								},
+							},
+						},
+					},
+				})
+			})
+
+			when("the rollback is removed", func() {
+				it.Before(func() {
+					image.Status.LatestBuildRef = "image-name-build-2"
+					image.Status.LatestBuildReason = buildapi.BuildReasonRollback
+					image.Status.LatestImage = "some/image@sha256:build-2"
+					image.Status.Conditions = rolledBackConditions()
+				})
+
+				it("keeps reporting the rolled back image until a build succeeds", func() {
+					image.Status.Rollback = &buildapi.ImageRollbackStatus{
+						BuildNumber:     2,
+						BuildName:       "image-name-build-2",
+						Image:           "some/image@sha256:build-2",
+						ImageGeneration: originalGeneration,
+						RolledBackAt:    metav1.NewTime(time.Now().Add(time.Hour)),
+					}
 					sourceResolver := resolvedSourceResolver(image)
 
 					rt.Test(rtesting.TableRow{
 						Key: key,
 						Objects: runtimeObjects(
-							failedBuilds(image, sourceResolver, 5),
+							rollbackBuilds(sourceResolver),
 							image,
 							builder,
 							sourceResolver,
 						),
 						WantErr: false,
-						WantDeletes: []clientgotesting.DeleteActionImpl{
+						WantStatusUpdates: []clientgotesting.UpdateActionImpl{
 							{
-								ActionImpl: clientgotesting.ActionImpl{
-									Namespace: "some-namespace",
-									Verb:      "",
-									Resource: schema.GroupVersionResource{
-										Resource: "builds",
+								Object: &buildapi.Image{
+									ObjectMeta: image.ObjectMeta,
+									Spec:       image.Spec,
+									Status: buildapi.ImageStatus{
+										Status: corev1alpha1.Status{
+											ObservedGeneration: originalGeneration,
+											Conditions:         conditionReady(),
+										},
+										LatestBuildRef: "image-name-build-3",
+										LatestImage:    "some/image@sha256:build-2",
+										LatestStack:    "io.buildpacks.stacks.bionic",
+										BuildCounter:   3,
+										Rollback:       image.Status.Rollback,
 									},
-									Subresource: "",
 								},
-								Name: image.Name + "-build-1", // first-build
 							},
 						},
 					})
 				})
 
-				it("deletes a successful build if more than the limit", func() {
-					image.Spec.SuccessBuildHistoryLimit = limit(4)
-					image.Status.LatestBuildRef = "image-name-build-5"
-					image.Status.LatestImage = "some/image@sha256:build-5"
-					image.Status.LatestStack = "io.buildpacks.stacks.bionic"
-					image.Status.Conditions = conditionReady()
-					image.Status.BuildCounter = 5
+				it("reports the built image once a build succeeds after the rollback", func() {
+					image.Status.Rollback = &buildapi.ImageRollbackStatus{
+						BuildNumber:     2,
+						BuildName:       "image-name-build-2",
+						Image:           "some/image@sha256:build-2",
+						ImageGeneration: originalGeneration,
+						RolledBackAt:    metav1.NewTime(time.Now().Add(150 * time.Second)),
+					}
 					sourceResolver := resolvedSourceResolver(image)
 
 					rt.Test(rtesting.TableRow{
 						Key: key,
 						Objects: runtimeObjects(
-							successfulBuilds(image, sourceResolver, 5),
+							rollbackBuilds(sourceResolver),
 							image,
 							builder,
 							sourceResolver,
 						),
 						WantErr: false,
-						WantDeletes: []clientgotesting.DeleteActionImpl{
+						WantStatusUpdates: []clientgotesting.UpdateActionImpl{
 							{
-								ActionImpl: clientgotesting.ActionImpl{
-									Namespace: "some-namespace",
-									Verb:      "",
-									Resource: schema.GroupVersionResource{
-										Resource: "builds",
+								Object: &buildapi.Image{
+									ObjectMeta: image.ObjectMeta,
+									Spec:       image.Spec,
+									Status: buildapi.ImageStatus{
+										Status: corev1alpha1.Status{
+											ObservedGeneration: originalGeneration,
+											Conditions:         conditionReady(),
+										},
+										LatestBuildRef: "image-name-build-3",
+										LatestImage:    "some/image@sha256:build-3",
+										LatestStack:    "io.buildpacks.stacks.bionic",
+										BuildCounter:   3,
 									},
-									Subresource: "",
 								},
-								Name: image.Name + "-build-1", // first-build
 							},
 						},
 					})
@@ -2221,6 +3632,57 @@ func notReadyBuilder(builder *buildapi.Builder) runtime.Object {
 	return builder
 }
 
+type testGitResolver struct {
+	resolved  corev1alpha1.ResolvedGitSource
+	err       error
+	requested []*buildapi.SourceResolver
+}
+
+func (r *testGitResolver) Resolve(_ context.Context, sourceResolver *buildapi.SourceResolver) (corev1alpha1.ResolvedSourceConfig, error) {
+	r.requested = append(r.requested, sourceResolver)
+	if r.err != nil {
+		return corev1alpha1.ResolvedSourceConfig{}, r.err
+	}
+
+	git := sourceResolver.Spec.Source.Git
+	return corev1alpha1.ResolvedSourceConfig{
+		Git: &corev1alpha1.ResolvedGitSource{
+			URL:      git.URL,
+			Revision: r.resolved.Revision,
+			Type:     r.resolved.Type,
+			SubPath:  sourceResolver.Spec.Source.SubPath,
+			Fetch:    git.Fetch,
+		},
+	}, nil
+}
+
+type testConditionError struct {
+	reason  string
+	message string
+}
+
+func (e *testConditionError) Error() string {
+	return e.message
+}
+
+func (e *testConditionError) Reason() string {
+	return e.reason
+}
+
+type testRetagger struct {
+	retagged map[string]string
+	calls    []string
+}
+
+func (r *testRetagger) Retag(_ context.Context, image *buildapi.Image, build *buildapi.Build) (string, error) {
+	r.calls = append(r.calls, build.Name)
+	retagged, ok := r.retagged[build.Name]
+	if !ok {
+		return "", errors.New("unauthorized to push to " + image.Spec.Tag)
+	}
+	return retagged, nil
+}
+
 func failedBuilds(image *buildapi.Image, sourceResolver *buildapi.SourceResolver, count int) []runtime.Object {
 	return builds(image, sourceResolver, count, corev1alpha1.Condition{
 		Type:   corev1alpha1.ConditionSucceeded,
@@ -2284,6 +3746,22 @@ func builds(image *buildapi.Image, sourceResolver *buildapi.SourceResolver, coun
 	return builds
 }
 
+func buildDeletes(image *buildapi.Image, buildNumbers ...int) []clientgotesting.DeleteActionImpl {
+	var deletes []clientgotesting.DeleteActionImpl
+	for _, n := range buildNumbers {
+		deletes = append(deletes, clientgotesting.DeleteActionImpl{
+			ActionImpl: clientgotesting.ActionImpl{
+				Namespace: image.Namespace,
+				Resource: schema.GroupVersionResource{
+					Resource: "builds",
+				},
+			},
+			Name: fmt.Sprintf("%s-build-%d", image.Name, n),
+		})
+	}
+	return deletes
+}
+
 func runtimeObjects(objects []runtime.Object, additional ...runtime.Object) []runtime.Object {
 	return append(objects, additional...)
 }
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"strconv"
+	"time"
 
 	"github.com/pkg/errors"
 	corev1 "k8s.io/api/core/v1"
@@ -13,8 +14,9 @@ import (
 	corev1alpha1 "github.com/pivotal/kpack/pkg/apis/core/v1alpha1"
 )
 
-func (c *Reconciler) reconcileBuild(ctx context.Context, image *buildapi.Image, latestBuild *buildapi.Build, sourceResolver *buildapi.SourceResolver, builder buildapi.BuilderResource, buildCacheName string) (buildapi.ImageStatus, error) {
-	currentBuildNumber, err := buildCounter(latestBuild)
+func (c *Reconciler) reconcileBuild(ctx context.Context, image *buildapi.Image, builds buildList, sourceResolver *buildapi.SourceResolver, builder buildapi.BuilderResource, buildCacheName string) (buildapi.ImageStatus, error) {
+	latestBuild := builds.lastImageBuild
+	currentBuildNumber, err := buildCounter(builds.lastBuild)
 	if err != nil {
 		return buildapi.ImageStatus{}, err
 	}
@@ -27,12 +29,64 @@ func (c *Reconciler) reconcileBuild(ctx context.Context, image *buildapi.Image,
 	if c.EnablePriorityClasses {
 		priorityClass = result.PriorityClass
 	}
+	if !result.NextScheduled.IsZero() && c.EnqueueAfter != nil {
+		c.EnqueueAfter(image, time.Until(result.NextScheduled))
+	}
+	if image.Spec.Paused {
+		c.BuildQueue.Remove(image.NamespacedName())
+		return buildapi.ImageStatus{
+			Status: corev1alpha1.Status{
+				Conditions: pausedConditions(result, builder, latestBuild),
+			},
+			LatestBuildRef:             latestBuild.BuildRef(),
+			LatestBuildReason:          latestBuild.BuildReason(),
+			LatestBuildImageGeneration: latestBuild.ImageGeneration(),
+			LatestImage:                image.LatestForImage(latestBuild),
+			LatestStack:                latestBuild.Stack(),
+			LatestBuildRetryAttempt:    latestBuild.RetryAttempt(),
+			BuildCounter:               currentBuildNumber,
+			BuildCacheName:             buildCacheName,
+			NextScheduledBuild:         nextScheduledBuild(result),
+		}, nil
+	}
+	if result.ConditionStatus == corev1.ConditionTrue {
+		admitted, message, err := c.BuildQueue.Admit(image.NamespacedName(), result.Priority)
+		if err != nil {
+			return buildapi.ImageStatus{}, errors.Wrap(err, "error admitting image build")
+		}
+		if !admitted {
+			return buildapi.ImageStatus{
+				Status: corev1alpha1.Status{
+					Conditions: queuedBuildCondition(message, builder),
+				},
+				LatestBuildRef:             latestBuild.BuildRef(),
+				LatestBuildReason:          latestBuild.BuildReason(),
+				LatestBuildImageGeneration: latestBuild.ImageGeneration(),
+				LatestImage:                image.LatestForImage(latestBuild),
+				LatestStack:                latestBuild.Stack(),
+				LatestBuildRetryAttempt:    latestBuild.RetryAttempt(),
+				BuildCounter:               currentBuildNumber,
+				BuildCacheName:             buildCacheName,
+				NextScheduledBuild:         nextScheduledBuild(result),
+			}, nil
+		}
+	} else {
+		c.BuildQueue.Remove(image.NamespacedName())
+	}
+
 	switch result.ConditionStatus {
 	case corev1.ConditionTrue:
 		nextBuildNumber := currentBuildNumber + 1
-		build := image.Build(sourceResolver, builder, latestBuild, result.ReasonsStr, result.ChangesStr, nextBuildNumber, priorityClass)
+		var build *buildapi.Build
+		if result.Retry {
+			build = image.RetryBuild(sourceResolver, builder, latestBuild, result.ChangesStr, nextBuildNumber, priorityClass)
+		} else {
+			build = image.Build(sourceResolver, builder, latestBuild, result.ReasonsStr, result.ChangesStr, nextBuildNumber, priorityClass)
+		}
+		c.annotateQueuedAt(image, build)
 		build, err = c.Client.KpackV1alpha2().Builds(build.Namespace).Create(ctx, build, metav1.CreateOptions{})
 		if err != nil {
+			c.BuildQueue.Remove(image.NamespacedName())
 			return buildapi.ImageStatus{}, err
 		}
 
@@ -47,10 +101,16 @@ func (c *Reconciler) reconcileBuild(ctx context.Context, image *buildapi.Image,
 			LatestImage:                image.LatestForImage(latestBuild),
 			LatestStack:                build.Stack(),
 			LatestBuildImageGeneration: build.ImageGeneration(),
+			LatestBuildRetryAttempt:    build.RetryAttempt(),
+			NextScheduledBuild:         nextScheduledBuild(result),
 		}, nil
 	case corev1.ConditionUnknown:
 		fallthrough
 	case corev1.ConditionFalse:
+		if result.RetryAfter > 0 && c.EnqueueAfter != nil {
+			c.EnqueueAfter(image, result.RetryAfter)
+		}
+
 		return buildapi.ImageStatus{
 			Status: corev1alpha1.Status{
 				Conditions: noScheduledBuild(result.ConditionStatus, builder, latestBuild),
@@ -60,8 +120,10 @@ func (c *Reconciler) reconcileBuild(ctx context.Context, image *buildapi.Image,
 			LatestBuildImageGeneration: latestBuild.ImageGeneration(),
 			LatestImage:                image.LatestForImage(latestBuild),
 			LatestStack:                latestBuild.Stack(),
+			LatestBuildRetryAttempt:    latestBuild.RetryAttempt(),
 			BuildCounter:               currentBuildNumber,
 			BuildCacheName:             buildCacheName,
+			NextScheduledBuild:         nextScheduledBuild(result),
 		}, nil
 	default:
 		return buildapi.ImageStatus{}, errors.Errorf("unexpected build needed condition %s", result.ConditionStatus)
@@ -131,6 +193,56 @@ func scheduledBuildCondition(build *buildapi.Build) corev1alpha1.Conditions {
 	}
 }
 
+func queuedBuildCondition(message string, builder buildapi.BuilderResource) corev1alpha1.Conditions {
+	return corev1alpha1.Conditions{
+		{
+			Type:               corev1alpha1.ConditionReady,
+			Status:             corev1.ConditionUnknown,
+			Reason:             buildapi.BuildQueued,
+			Message:            message,
+			LastTransitionTime: corev1alpha1.VolatileTime{Inner: metav1.Now()},
+		},
+		builderCondition(builder),
+	}
+}
+
+// annotateQueuedAt records on build when its image was queued by the
+// concurrent build limits so the wait counts towards its queue duration.
+func (c *Reconciler) annotateQueuedAt(image *buildapi.Image, build *buildapi.Build) {
+	if queuedAt, ok := c.BuildQueue.QueuedAt(image.NamespacedName()); ok {
+		build.Annotations[buildapi.BuildQueuedAtAnnotation] = queuedAt.UTC().Format(time.RFC3339)
+	}
+}
+
+// pausedConditions report the latest build and, while a build is required,
+// the reasons of the build that runs once the image is resumed.
+func pausedConditions(result buildRequiredResult, builder buildapi.BuilderResource, latestBuild *buildapi.Build) corev1alpha1.Conditions {
+	buildNeeded := result.ConditionStatus
+	if latestBuild == nil {
+		buildNeeded = corev1.ConditionUnknown
+	}
+
+	paused := corev1alpha1.Condition{
+		Type:               buildapi.ConditionPaused,
+		Status:             corev1.ConditionTrue,
+		Message:            "Image is paused",
+		LastTransitionTime: corev1alpha1.VolatileTime{Inner: metav1.Now()},
+	}
+	if result.ConditionStatus == corev1.ConditionTrue {
+		paused.Reason = buildapi.BuildPending
+		paused.Message = fmt.Sprintf("Image is paused, a build is pending for %s", result.ReasonsStr)
+	}
+	return append(noScheduledBuild(buildNeeded, builder, latestBuild), paused)
+}
+
+func nextScheduledBuild(result buildRequiredResult) *metav1.Time {
+	if result.NextScheduled.IsZero() {
+		return nil
+	}
+	next := metav1.NewTime(result.NextScheduled)
+	return &next
+}
+
 func buildCounter(build *buildapi.Build) (int64, error) {
 	if build == nil {
 		return 0, nil
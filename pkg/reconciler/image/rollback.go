@@ -0,0 +1,104 @@
+package image
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	buildapi "github.com/pivotal/kpack/pkg/apis/build/v1alpha2"
+	corev1alpha1 "github.com/pivotal/kpack/pkg/apis/core/v1alpha1"
+)
+
+const rollbackRetryInterval = time.Minute
+
+// reconcileRollback points the tags of image at the image of the build of its
+// rollback unless they already were for the current generation of image. No
+// builds are created while the image is rolled back.
+func (c *Reconciler) reconcileRollback(ctx context.Context, image *buildapi.Image, builds buildList, builder buildapi.BuilderResource) (*buildapi.Image, error) {
+	c.BuildQueue.Remove(image.NamespacedName())
+
+	buildNumber := image.Spec.Rollback.BuildNumber
+	if rollback := image.Status.Rollback; rollback != nil && rollback.BuildNumber == buildNumber && rollback.ImageGeneration == image.Generation {
+		image.Status.Conditions = rolledBackConditions(rollback, builder)
+		return image, nil
+	}
+
+	target := builds.successfulBuild(buildNumber)
+	if target == nil {
+		image.Status.Conditions = rollbackFailedConditions(fmt.Sprintf("Image %s has no successful build %d", image.Name, buildNumber), builder)
+		return image, nil
+	}
+
+	retagged, err := c.Retagger.Retag(ctx, image, target)
+	if err != nil {
+		image.Status.Conditions = rollbackFailedConditions(fmt.Sprintf("Unable to roll back to %s: %s", target.Name, err), builder)
+		if c.EnqueueAfter != nil {
+			c.EnqueueAfter(image, rollbackRetryInterval)
+		}
+		return image, nil
+	}
+
+	currentBuildNumber, err := buildCounter(builds.lastBuild)
+	if err != nil {
+		return nil, err
+	}
+
+	image.Status.Rollback = &buildapi.ImageRollbackStatus{
+		BuildNumber:     buildNumber,
+		BuildName:       target.Name,
+		Image:           retagged,
+		ImageGeneration: image.Generation,
+		RolledBackAt:    metav1.Now(),
+	}
+	image.Status.Conditions = rolledBackConditions(image.Status.Rollback, builder)
+	image.Status.LatestBuildRef = target.BuildRef()
+	image.Status.LatestBuildReason = buildapi.BuildReasonRollback
+	image.Status.LatestBuildImageGeneration = target.ImageGeneration()
+	image.Status.LatestImage = retagged
+	image.Status.LatestStack = target.Stack()
+	image.Status.LatestBuildRetryAttempt = target.RetryAttempt()
+	image.Status.BuildCounter = currentBuildNumber
+	image.Status.NextScheduledBuild = nil
+	return image, nil
+}
+
+// successfulBuild returns the successful build with number, or nil if there
+// is none.
+func (l buildList) successfulBuild(number int64) *buildapi.Build {
+	for _, build := range l.successfulBuilds {
+		if build.Labels[buildapi.BuildNumberLabel] == strconv.FormatInt(number, 10) {
+			return build
+		}
+	}
+	return nil
+}
+
+func rolledBackConditions(rollback *buildapi.ImageRollbackStatus, builder buildapi.BuilderResource) corev1alpha1.Conditions {
+	return corev1alpha1.Conditions{
+		{
+			Type:               corev1alpha1.ConditionReady,
+			Status:             corev1.ConditionTrue,
+			Reason:             buildapi.RolledBack,
+			Message:            fmt.Sprintf("Rolled back to %s, builds are suspended until the rollback is removed", rollback.BuildName),
+			LastTransitionTime: corev1alpha1.VolatileTime{Inner: metav1.Now()},
+		},
+		builderCondition(builder),
+	}
+}
+
+func rollbackFailedConditions(message string, builder buildapi.BuilderResource) corev1alpha1.Conditions {
+	return corev1alpha1.Conditions{
+		{
+			Type:               corev1alpha1.ConditionReady,
+			Status:             corev1.ConditionFalse,
+			Reason:             buildapi.RollbackFailed,
+			Message:            message,
+			LastTransitionTime: corev1alpha1.VolatileTime{Inner: metav1.Now()},
+		},
+		builderCondition(builder),
+	}
+}
@@ -0,0 +1,185 @@
+package imageset
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+
+	buildapi "github.com/pivotal/kpack/pkg/apis/build/v1alpha2"
+	corev1alpha1 "github.com/pivotal/kpack/pkg/apis/core/v1alpha1"
+	"github.com/pivotal/kpack/pkg/client/clientset/versioned"
+	buildlisters "github.com/pivotal/kpack/pkg/client/listers/build/v1alpha2"
+)
+
+// ChildImages manages the images controlled by an owner, such as an image set.
+type ChildImages struct {
+	Client      versioned.Interface
+	ImageLister buildlisters.ImageLister
+}
+
+// ImageConflictError is returned by ChildImages.Reconcile, along with the
+// images it reconciled, when images that the owner does not control already
+// have the names of desired images.
+type ImageConflictError struct {
+	Names []string
+}
+
+func (e *ImageConflictError) Error() string {
+	return fmt.Sprintf("images not controlled by this resource already exist: %s", strings.Join(e.Names, ", "))
+}
+
+// Reconcile creates the desired images, updates the images whose spec or
+// labels differ from the desired images and deletes the images of the owner
+// that are not desired along with their builds. Desired images whose name is
+// taken by an image that the owner does not control are left alone and
+// reported in an ImageConflictError.
+func (c ChildImages) Reconcile(ctx context.Context, owner metav1.Object, desired []*buildapi.Image) ([]*buildapi.Image, error) {
+	existing, err := c.ImageLister.Images(owner.GetNamespace()).List(labels.Everything())
+	if err != nil {
+		return nil, err
+	}
+
+	children := map[string]*buildapi.Image{}
+	others := map[string]bool{}
+	for _, image := range existing {
+		if metav1.IsControlledBy(image, owner) {
+			children[image.Name] = image
+		} else {
+			others[image.Name] = true
+		}
+	}
+
+	images := make([]*buildapi.Image, 0, len(desired))
+	var conflicts []string
+	for _, desiredImage := range desired {
+		if others[desiredImage.Name] {
+			conflicts = append(conflicts, desiredImage.Name)
+			continue
+		}
+
+		image, err := c.reconcileImage(ctx, desiredImage, children[desiredImage.Name])
+		if err != nil {
+			return nil, err
+		}
+		images = append(images, image)
+		delete(children, desiredImage.Name)
+	}
+
+	propagationPolicy := metav1.DeletePropagationBackground
+	for _, image := range children {
+		err := c.Client.KpackV1alpha2().Images(image.Namespace).Delete(ctx, image.Name, metav1.DeleteOptions{
+			PropagationPolicy: &propagationPolicy,
+		})
+		if err != nil && !k8serrors.IsNotFound(err) {
+			return nil, err
+		}
+	}
+
+	if len(conflicts) > 0 {
+		return images, &ImageConflictError{Names: conflicts}
+	}
+	return images, nil
+}
+
+func (c ChildImages) reconcileImage(ctx context.Context, desired, image *buildapi.Image) (*buildapi.Image, error) {
+	if image == nil {
+		return c.Client.KpackV1alpha2().Images(desired.Namespace).Create(ctx, desired, metav1.CreateOptions{})
+	}
+
+	if imagesEqual(desired, image) {
+		return image, nil
+	}
+
+	image = image.DeepCopy()
+	image.Spec = desired.Spec
+	image.Labels = desired.Labels
+	return c.Client.KpackV1alpha2().Images(image.Namespace).Update(ctx, image, metav1.UpdateOptions{})
+}
+
+func imagesEqual(desired, image *buildapi.Image) bool {
+	return equality.Semantic.DeepEqual(desired.Spec, image.Spec) &&
+		equality.Semantic.DeepEqual(desired.Labels, image.Labels)
+}
+
+// ImageStatuses returns the readiness and latest image of images. The
+// readiness of an image is unknown until its current generation is observed.
+func ImageStatuses(images []*buildapi.Image) []buildapi.ImageSetImageStatus {
+	statuses := make([]buildapi.ImageSetImageStatus, 0, len(images))
+	for _, image := range images {
+		ready := corev1.ConditionUnknown
+		if condition := image.Status.GetCondition(corev1alpha1.ConditionReady); condition != nil && image.Status.ObservedGeneration == image.Generation {
+			ready = condition.Status
+		}
+
+		statuses = append(statuses, buildapi.ImageSetImageStatus{
+			Name:        image.Name,
+			Ready:       ready,
+			LatestImage: image.Status.LatestImage,
+		})
+	}
+	return statuses
+}
+
+// ReadyConditions aggregate the readiness of images. The owner is ready when
+// all of its images are ready and it is not ready with reason when err is not
+// nil or any of its images is not ready.
+func ReadyConditions(images []buildapi.ImageSetImageStatus, reason string, err error) corev1alpha1.Conditions {
+	if err != nil {
+		return corev1alpha1.Conditions{
+			{
+				Type:               corev1alpha1.ConditionReady,
+				Status:             corev1.ConditionFalse,
+				Reason:             reason,
+				Message:            err.Error(),
+				LastTransitionTime: corev1alpha1.VolatileTime{Inner: metav1.Now()},
+			},
+		}
+	}
+
+	var notReady, unknown []string
+	for _, image := range images {
+		switch image.Ready {
+		case corev1.ConditionTrue:
+		case corev1.ConditionFalse:
+			notReady = append(notReady, image.Name)
+		default:
+			unknown = append(unknown, image.Name)
+		}
+	}
+
+	switch {
+	case len(notReady) > 0:
+		return corev1alpha1.Conditions{
+			{
+				Type:               corev1alpha1.ConditionReady,
+				Status:             corev1.ConditionFalse,
+				Reason:             buildapi.ImagesNotReady,
+				Message:            fmt.Sprintf("%d of %d images are not ready: %s", len(notReady), len(images), strings.Join(notReady, ", ")),
+				LastTransitionTime: corev1alpha1.VolatileTime{Inner: metav1.Now()},
+			},
+		}
+	case len(unknown) > 0:
+		return corev1alpha1.Conditions{
+			{
+				Type:               corev1alpha1.ConditionReady,
+				Status:             corev1.ConditionUnknown,
+				Message:            fmt.Sprintf("%d of %d images are ready", len(images)-len(unknown), len(images)),
+				LastTransitionTime: corev1alpha1.VolatileTime{Inner: metav1.Now()},
+			},
+		}
+	default:
+		return corev1alpha1.Conditions{
+			{
+				Type:               corev1alpha1.ConditionReady,
+				Status:             corev1.ConditionTrue,
+				LastTransitionTime: corev1alpha1.VolatileTime{Inner: metav1.Now()},
+			},
+		}
+	}
+}
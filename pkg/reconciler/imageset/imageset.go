@@ -0,0 +1,147 @@
+package imageset
+
+import (
+	"context"
+	"time"
+
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/api/equality"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/cache"
+	"knative.dev/pkg/controller"
+
+	buildapi "github.com/pivotal/kpack/pkg/apis/build/v1alpha2"
+	"github.com/pivotal/kpack/pkg/client/clientset/versioned"
+	buildinformers "github.com/pivotal/kpack/pkg/client/informers/externalversions/build/v1alpha2"
+	buildlisters "github.com/pivotal/kpack/pkg/client/listers/build/v1alpha2"
+	"github.com/pivotal/kpack/pkg/reconciler"
+)
+
+const (
+	ReconcilerName = "ImageSets"
+	Kind           = "ImageSet"
+)
+
+type Discoverer interface {
+	Discover(context.Context, *buildapi.ImageSet) ([]string, error)
+}
+
+func NewController(opt reconciler.Options, imageSetInformer buildinformers.ImageSetInformer, imageInformer buildinformers.ImageInformer, discoverer Discoverer) *controller.Impl {
+	c := &Reconciler{
+		Client:            opt.Client,
+		ImageSetLister:    imageSetInformer.Lister(),
+		ImageLister:       imageInformer.Lister(),
+		Discoverer:        discoverer,
+		DiscoveryInterval: opt.SourcePollingFrequency,
+	}
+
+	impl := controller.NewImpl(c, opt.Logger, ReconcilerName)
+	c.EnqueueAfter = impl.EnqueueAfter
+
+	imageSetInformer.Informer().AddEventHandler(reconciler.Handler(impl.Enqueue))
+
+	imageInformer.Informer().AddEventHandler(cache.FilteringResourceEventHandler{
+		FilterFunc: controller.FilterControllerGK(buildapi.SchemeGroupVersion.WithKind(Kind).GroupKind()),
+		Handler:    reconciler.Handler(impl.EnqueueControllerOf),
+	})
+
+	return impl
+}
+
+type Reconciler struct {
+	Client            versioned.Interface
+	ImageSetLister    buildlisters.ImageSetLister
+	ImageLister       buildlisters.ImageLister
+	Discoverer        Discoverer
+	DiscoveryInterval time.Duration
+	EnqueueAfter      func(obj interface{}, after time.Duration)
+}
+
+func (c *Reconciler) Reconcile(ctx context.Context, key string) error {
+	namespace, imageSetName, err := cache.SplitMetaNamespaceKey(key)
+	if err != nil {
+		return err
+	}
+
+	imageSet, err := c.ImageSetLister.ImageSets(namespace).Get(imageSetName)
+	if k8serrors.IsNotFound(err) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+
+	imageSet = imageSet.DeepCopy()
+	imageSet.SetDefaults(ctx)
+
+	discoveryErr := c.reconcileDiscovery(ctx, imageSet)
+
+	desired, err := imageSet.Images(imageSet.Status.DiscoveredPaths)
+	if err != nil {
+		return controller.NewPermanentError(err)
+	}
+
+	reason, readyErr := buildapi.DiscoveryFailed, discoveryErr
+	images, err := ChildImages{Client: c.Client, ImageLister: c.ImageLister}.Reconcile(ctx, imageSet, desired)
+	var conflictErr *ImageConflictError
+	if errors.As(err, &conflictErr) {
+		// images of other owners do not enqueue the set when they are deleted
+		c.enqueueAfter(imageSet, c.DiscoveryInterval)
+		reason, readyErr = buildapi.ImageConflict, conflictErr
+	} else if err != nil {
+		return err
+	}
+
+	imageSet.Status.Images = ImageStatuses(images)
+	imageSet.Status.Conditions = ReadyConditions(imageSet.Status.Images, reason, readyErr)
+	imageSet.Status.ObservedGeneration = imageSet.Generation
+	return c.updateStatus(ctx, imageSet)
+}
+
+// reconcileDiscovery records the discovered paths of imageSet when its spec
+// changed or the discovery interval has passed since they were last
+// discovered. The previously discovered paths are kept when discovery fails.
+func (c *Reconciler) reconcileDiscovery(ctx context.Context, imageSet *buildapi.ImageSet) error {
+	if imageSet.Spec.Discovery == nil {
+		imageSet.Status.DiscoveredPaths = nil
+		imageSet.Status.LastDiscoveryTime = nil
+		return nil
+	}
+
+	now := time.Now()
+	last := imageSet.Status.LastDiscoveryTime
+	if last != nil && imageSet.Status.ObservedGeneration == imageSet.Generation && now.Sub(last.Time) < c.DiscoveryInterval {
+		c.enqueueAfter(imageSet, c.DiscoveryInterval-now.Sub(last.Time))
+		return nil
+	}
+
+	c.enqueueAfter(imageSet, c.DiscoveryInterval)
+	paths, err := c.Discoverer.Discover(ctx, imageSet)
+	if err != nil {
+		return err
+	}
+
+	imageSet.Status.DiscoveredPaths = paths
+	imageSet.Status.LastDiscoveryTime = &metav1.Time{Time: now}
+	return nil
+}
+
+func (c *Reconciler) enqueueAfter(imageSet *buildapi.ImageSet, after time.Duration) {
+	if c.EnqueueAfter != nil {
+		c.EnqueueAfter(imageSet, after)
+	}
+}
+
+func (c *Reconciler) updateStatus(ctx context.Context, desired *buildapi.ImageSet) error {
+	original, err := c.ImageSetLister.ImageSets(desired.Namespace).Get(desired.Name)
+	if err != nil {
+		return err
+	}
+
+	if equality.Semantic.DeepEqual(desired.Status, original.Status) {
+		return nil
+	}
+
+	_, err = c.Client.KpackV1alpha2().ImageSets(desired.Namespace).UpdateStatus(ctx, desired, metav1.UpdateOptions{})
+	return err
+}
@@ -0,0 +1,500 @@
+package imageset_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/sclevine/spec"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	clientgotesting "k8s.io/client-go/testing"
+	"k8s.io/client-go/tools/record"
+	"knative.dev/pkg/controller"
+	rtesting "knative.dev/pkg/reconciler/testing"
+
+	buildapi "github.com/pivotal/kpack/pkg/apis/build/v1alpha2"
+	corev1alpha1 "github.com/pivotal/kpack/pkg/apis/core/v1alpha1"
+	"github.com/pivotal/kpack/pkg/client/clientset/versioned/fake"
+	"github.com/pivotal/kpack/pkg/reconciler/imageset"
+	"github.com/pivotal/kpack/pkg/reconciler/testhelpers"
+)
+
+func TestImageSetReconciler(t *testing.T) {
+	spec.Run(t, "Image Set Reconciler", testImageSetReconciler)
+}
+
+func testImageSetReconciler(t *testing.T, when spec.G, it spec.S) {
+	const (
+		imageSetName           = "apps"
+		namespace              = "some-namespace"
+		key                    = "some-namespace/apps"
+		originalGeneration     = 1
+		discoveryInterval      = 5 * time.Minute
+		someLabelKey           = "some/label"
+		someValueToPassThrough = "to-pass-through"
+	)
+
+	var (
+		discoverer    = &testDiscoverer{}
+		enqueuedAfter time.Duration
+	)
+
+	rt := testhelpers.ReconcilerTester(t,
+		func(t *testing.T, row *rtesting.TableRow) (reconciler controller.Reconciler, lists rtesting.ActionRecorderList, list rtesting.EventList) {
+			listers := testhelpers.NewListers(row.Objects)
+			fakeClient := fake.NewSimpleClientset(listers.BuildServiceObjects()...)
+			r := &imageset.Reconciler{
+				Client:            fakeClient,
+				ImageSetLister:    listers.GetImageSetLister(),
+				ImageLister:       listers.GetImageLister(),
+				Discoverer:        discoverer,
+				DiscoveryInterval: discoveryInterval,
+				EnqueueAfter: func(_ interface{}, after time.Duration) {
+					enqueuedAfter = after
+				},
+			}
+			eventRecorder := record.NewFakeRecorder(10)
+			return r, rtesting.ActionRecorderList{fakeClient}, rtesting.EventList{Recorder: eventRecorder}
+		})
+
+	it.Before(func() {
+		discoverer = &testDiscoverer{}
+		enqueuedAfter = 0
+	})
+
+	imageSet := &buildapi.ImageSet{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:       imageSetName,
+			Namespace:  namespace,
+			UID:        "some-uid",
+			Generation: originalGeneration,
+			Labels: map[string]string{
+				someLabelKey: someValueToPassThrough,
+			},
+		},
+		Spec: buildapi.ImageSetSpec{
+			Template: buildapi.ImageSpec{
+				Tag: "registry.io/apps/{{.Name}}",
+				Builder: corev1.ObjectReference{
+					Kind: "ClusterBuilder",
+					Name: "builder-name",
+				},
+				ServiceAccountName: "service-account",
+				Source: corev1alpha1.SourceConfig{
+					Git: &corev1alpha1.Git{
+						URL:      "https://github.com/some/monorepo",
+						Revision: "main",
+					},
+				},
+			},
+			Parameters: []buildapi.ImageSetParameters{
+				{Name: "api", SubPath: "services/api"},
+				{Name: "web", SubPath: "services/web"},
+			},
+		},
+	}
+	imageSet.SetDefaults(context.TODO())
+
+	desiredImages := func(imageSet *buildapi.ImageSet, paths ...string) []*buildapi.Image {
+		images, err := imageSet.Images(paths)
+		require.NoError(t, err)
+		return images
+	}
+
+	withReady := func(image *buildapi.Image, status corev1.ConditionStatus) *buildapi.Image {
+		image.Status.Conditions = corev1alpha1.Conditions{
+			{
+				Type:   corev1alpha1.ConditionReady,
+				Status: status,
+			},
+		}
+		image.Status.LatestImage = "registry.io/" + image.Name + "@sha256:abc"
+		return image
+	}
+
+	imageSetWithStatus := func(imageSet *buildapi.ImageSet, status buildapi.ImageSetStatus) *buildapi.ImageSet {
+		imageSet = imageSet.DeepCopy()
+		imageSet.Status = status
+		return imageSet
+	}
+
+	when("#Reconcile", func() {
+		it("creates an image for each parameter", func() {
+			images := desiredImages(imageSet)
+
+			rt.Test(rtesting.TableRow{
+				Key:     key,
+				Objects: []runtime.Object{imageSet},
+				WantCreates: []runtime.Object{
+					images[0],
+					images[1],
+				},
+				WantStatusUpdates: []clientgotesting.UpdateActionImpl{
+					{
+						Object: imageSetWithStatus(imageSet, buildapi.ImageSetStatus{
+							Status: corev1alpha1.Status{
+								ObservedGeneration: originalGeneration,
+								Conditions: corev1alpha1.Conditions{
+									{
+										Type:    corev1alpha1.ConditionReady,
+										Status:  corev1.ConditionUnknown,
+										Message: "0 of 2 images are ready",
+									},
+								},
+							},
+							Images: []buildapi.ImageSetImageStatus{
+								{Name: "apps-api", Ready: corev1.ConditionUnknown},
+								{Name: "apps-web", Ready: corev1.ConditionUnknown},
+							},
+						}),
+					},
+				},
+			})
+		})
+
+		it("updates images that differ from the template", func() {
+			images := desiredImages(imageSet)
+			outdated := images[0].DeepCopy()
+			outdated.Spec.Builder.Name = "old-builder"
+			outdated.Labels = nil
+
+			rt.Test(rtesting.TableRow{
+				Key:     key,
+				Objects: []runtime.Object{imageSet, outdated, images[1]},
+				WantUpdates: []clientgotesting.UpdateActionImpl{
+					{
+						Object: images[0],
+					},
+				},
+				WantStatusUpdates: []clientgotesting.UpdateActionImpl{
+					{
+						Object: imageSetWithStatus(imageSet, buildapi.ImageSetStatus{
+							Status: corev1alpha1.Status{
+								ObservedGeneration: originalGeneration,
+								Conditions: corev1alpha1.Conditions{
+									{
+										Type:    corev1alpha1.ConditionReady,
+										Status:  corev1.ConditionUnknown,
+										Message: "0 of 2 images are ready",
+									},
+								},
+							},
+							Images: []buildapi.ImageSetImageStatus{
+								{Name: "apps-api", Ready: corev1.ConditionUnknown},
+								{Name: "apps-web", Ready: corev1.ConditionUnknown},
+							},
+						}),
+					},
+				},
+			})
+		})
+
+		it("deletes images that are no longer in the set", func() {
+			images := desiredImages(imageSet)
+			removed := images[0].DeepCopy()
+			removed.Name = "apps-old"
+			notControlled := removed.DeepCopy()
+			notControlled.Name = "apps-not-controlled"
+			notControlled.OwnerReferences = nil
+
+			rt.Test(rtesting.TableRow{
+				Key:     key,
+				Objects: []runtime.Object{imageSet, images[0], images[1], removed, notControlled},
+				WantDeletes: []clientgotesting.DeleteActionImpl{
+					{
+						ActionImpl: clientgotesting.ActionImpl{
+							Namespace: namespace,
+							Resource: schema.GroupVersionResource{
+								Resource: "images",
+							},
+						},
+						Name: "apps-old",
+					},
+				},
+				WantStatusUpdates: []clientgotesting.UpdateActionImpl{
+					{
+						Object: imageSetWithStatus(imageSet, buildapi.ImageSetStatus{
+							Status: corev1alpha1.Status{
+								ObservedGeneration: originalGeneration,
+								Conditions: corev1alpha1.Conditions{
+									{
+										Type:    corev1alpha1.ConditionReady,
+										Status:  corev1.ConditionUnknown,
+										Message: "0 of 2 images are ready",
+									},
+								},
+							},
+							Images: []buildapi.ImageSetImageStatus{
+								{Name: "apps-api", Ready: corev1.ConditionUnknown},
+								{Name: "apps-web", Ready: corev1.ConditionUnknown},
+							},
+						}),
+					},
+				},
+			})
+		})
+
+		it("reports images of the set whose name is taken by an image it does not control", func() {
+			images := desiredImages(imageSet)
+			notControlled := images[0].DeepCopy()
+			notControlled.OwnerReferences = nil
+
+			rt.Test(rtesting.TableRow{
+				Key:     key,
+				Objects: []runtime.Object{imageSet, notControlled},
+				WantCreates: []runtime.Object{
+					images[1],
+				},
+				WantStatusUpdates: []clientgotesting.UpdateActionImpl{
+					{
+						Object: imageSetWithStatus(imageSet, buildapi.ImageSetStatus{
+							Status: corev1alpha1.Status{
+								ObservedGeneration: originalGeneration,
+								Conditions: corev1alpha1.Conditions{
+									{
+										Type:    corev1alpha1.ConditionReady,
+										Status:  corev1.ConditionFalse,
+										Reason:  buildapi.ImageConflict,
+										Message: "images not controlled by this resource already exist: apps-api",
+									},
+								},
+							},
+							Images: []buildapi.ImageSetImageStatus{
+								{Name: "apps-web", Ready: corev1.ConditionUnknown},
+							},
+						}),
+					},
+				},
+			})
+
+			require.Equal(t, discoveryInterval, enqueuedAfter)
+		})
+
+		it("is ready when all images are ready", func() {
+			images := desiredImages(imageSet)
+
+			rt.Test(rtesting.TableRow{
+				Key: key,
+				Objects: []runtime.Object{
+					imageSet,
+					withReady(images[0], corev1.ConditionTrue),
+					withReady(images[1], corev1.ConditionTrue),
+				},
+				WantStatusUpdates: []clientgotesting.UpdateActionImpl{
+					{
+						Object: imageSetWithStatus(imageSet, buildapi.ImageSetStatus{
+							Status: corev1alpha1.Status{
+								ObservedGeneration: originalGeneration,
+								Conditions: corev1alpha1.Conditions{
+									{
+										Type:   corev1alpha1.ConditionReady,
+										Status: corev1.ConditionTrue,
+									},
+								},
+							},
+							Images: []buildapi.ImageSetImageStatus{
+								{Name: "apps-api", Ready: corev1.ConditionTrue, LatestImage: "registry.io/apps-api@sha256:abc"},
+								{Name: "apps-web", Ready: corev1.ConditionTrue, LatestImage: "registry.io/apps-web@sha256:abc"},
+							},
+						}),
+					},
+				},
+			})
+		})
+
+		it("is not ready when any image is not ready", func() {
+			images := desiredImages(imageSet)
+
+			rt.Test(rtesting.TableRow{
+				Key: key,
+				Objects: []runtime.Object{
+					imageSet,
+					withReady(images[0], corev1.ConditionTrue),
+					withReady(images[1], corev1.ConditionFalse),
+				},
+				WantStatusUpdates: []clientgotesting.UpdateActionImpl{
+					{
+						Object: imageSetWithStatus(imageSet, buildapi.ImageSetStatus{
+							Status: corev1alpha1.Status{
+								ObservedGeneration: originalGeneration,
+								Conditions: corev1alpha1.Conditions{
+									{
+										Type:    corev1alpha1.ConditionReady,
+										Status:  corev1.ConditionFalse,
+										Reason:  buildapi.ImagesNotReady,
+										Message: "1 of 2 images are not ready: apps-web",
+									},
+								},
+							},
+							Images: []buildapi.ImageSetImageStatus{
+								{Name: "apps-api", Ready: corev1.ConditionTrue, LatestImage: "registry.io/apps-api@sha256:abc"},
+								{Name: "apps-web", Ready: corev1.ConditionFalse, LatestImage: "registry.io/apps-web@sha256:abc"},
+							},
+						}),
+					},
+				},
+			})
+		})
+
+		it("does not update the status when it is unchanged", func() {
+			images := desiredImages(imageSet)
+
+			rt.Test(rtesting.TableRow{
+				Key: key,
+				Objects: []runtime.Object{
+					imageSetWithStatus(imageSet, buildapi.ImageSetStatus{
+						Status: corev1alpha1.Status{
+							ObservedGeneration: originalGeneration,
+							Conditions: corev1alpha1.Conditions{
+								{
+									Type:   corev1alpha1.ConditionReady,
+									Status: corev1.ConditionTrue,
+								},
+							},
+						},
+						Images: []buildapi.ImageSetImageStatus{
+							{Name: "apps-api", Ready: corev1.ConditionTrue, LatestImage: "registry.io/apps-api@sha256:abc"},
+							{Name: "apps-web", Ready: corev1.ConditionTrue, LatestImage: "registry.io/apps-web@sha256:abc"},
+						},
+					}),
+					withReady(images[0], corev1.ConditionTrue),
+					withReady(images[1], corev1.ConditionTrue),
+				},
+			})
+		})
+
+		when("discovering images", func() {
+			discoveringImageSet := imageSet.DeepCopy()
+			discoveringImageSet.Spec.Parameters = nil
+			discoveringImageSet.Spec.Discovery = &buildapi.ImageSetDiscovery{
+				Path: "services",
+				File: "project.toml",
+			}
+
+			ignoreDiscoveryTime := cmpopts.IgnoreFields(buildapi.ImageSetStatus{}, "LastDiscoveryTime")
+
+			it("creates an image for each discovered path", func() {
+				discoverer.paths = []string{"services/api"}
+				images := desiredImages(discoveringImageSet, "services/api")
+
+				rt.Test(rtesting.TableRow{
+					Key:         key,
+					Objects:     []runtime.Object{discoveringImageSet},
+					CmpOpts:     []cmp.Option{ignoreDiscoveryTime},
+					WantCreates: []runtime.Object{images[0]},
+					WantStatusUpdates: []clientgotesting.UpdateActionImpl{
+						{
+							Object: imageSetWithStatus(discoveringImageSet, buildapi.ImageSetStatus{
+								Status: corev1alpha1.Status{
+									ObservedGeneration: originalGeneration,
+									Conditions: corev1alpha1.Conditions{
+										{
+											Type:    corev1alpha1.ConditionReady,
+											Status:  corev1.ConditionUnknown,
+											Message: "0 of 1 images are ready",
+										},
+									},
+								},
+								Images: []buildapi.ImageSetImageStatus{
+									{Name: "apps-api", Ready: corev1.ConditionUnknown},
+								},
+								DiscoveredPaths: []string{"services/api"},
+							}),
+						},
+					},
+				})
+
+				assert.Equal(t, 1, discoverer.calls)
+				assert.Equal(t, discoveryInterval, enqueuedAfter)
+			})
+
+			it("does not rediscover before the discovery interval has passed", func() {
+				images := desiredImages(discoveringImageSet, "services/api")
+				discovered := imageSetWithStatus(discoveringImageSet, buildapi.ImageSetStatus{
+					Status: corev1alpha1.Status{
+						ObservedGeneration: originalGeneration,
+						Conditions: corev1alpha1.Conditions{
+							{
+								Type:   corev1alpha1.ConditionReady,
+								Status: corev1.ConditionTrue,
+							},
+						},
+					},
+					Images: []buildapi.ImageSetImageStatus{
+						{Name: "apps-api", Ready: corev1.ConditionTrue, LatestImage: "registry.io/apps-api@sha256:abc"},
+					},
+					DiscoveredPaths:   []string{"services/api"},
+					LastDiscoveryTime: &metav1.Time{Time: time.Now().Add(-time.Minute)},
+				})
+
+				rt.Test(rtesting.TableRow{
+					Key:     key,
+					Objects: []runtime.Object{discovered, withReady(images[0], corev1.ConditionTrue)},
+				})
+
+				assert.Equal(t, 0, discoverer.calls)
+				assert.InDelta(t, 4*time.Minute, enqueuedAfter, float64(time.Second))
+			})
+
+			it("keeps the images of previously discovered paths when discovery fails", func() {
+				discoverer.err = errors.New("directory services not found at main")
+				images := desiredImages(discoveringImageSet, "services/api")
+				discovered := imageSetWithStatus(discoveringImageSet, buildapi.ImageSetStatus{
+					Status: corev1alpha1.Status{
+						ObservedGeneration: originalGeneration,
+					},
+					DiscoveredPaths:   []string{"services/api"},
+					LastDiscoveryTime: &metav1.Time{Time: time.Now().Add(-time.Hour)},
+				})
+
+				rt.Test(rtesting.TableRow{
+					Key:     key,
+					Objects: []runtime.Object{discovered, withReady(images[0], corev1.ConditionTrue)},
+					WantStatusUpdates: []clientgotesting.UpdateActionImpl{
+						{
+							Object: imageSetWithStatus(discovered, buildapi.ImageSetStatus{
+								Status: corev1alpha1.Status{
+									ObservedGeneration: originalGeneration,
+									Conditions: corev1alpha1.Conditions{
+										{
+											Type:    corev1alpha1.ConditionReady,
+											Status:  corev1.ConditionFalse,
+											Reason:  buildapi.DiscoveryFailed,
+											Message: "directory services not found at main",
+										},
+									},
+								},
+								Images: []buildapi.ImageSetImageStatus{
+									{Name: "apps-api", Ready: corev1.ConditionTrue, LatestImage: "registry.io/apps-api@sha256:abc"},
+								},
+								DiscoveredPaths:   []string{"services/api"},
+								LastDiscoveryTime: discovered.Status.LastDiscoveryTime,
+							}),
+						},
+					},
+				})
+
+				assert.Equal(t, 1, discoverer.calls)
+			})
+		})
+	})
+}
+
+type testDiscoverer struct {
+	paths []string
+	err   error
+	calls int
+}
+
+func (d *testDiscoverer) Discover(_ context.Context, _ *buildapi.ImageSet) ([]string, error) {
+	d.calls++
+	return d.paths, d.err
+}
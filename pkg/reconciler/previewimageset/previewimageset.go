@@ -0,0 +1,142 @@
+package previewimageset
+
+import (
+	"context"
+	"time"
+
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/api/equality"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/cache"
+	"knative.dev/pkg/controller"
+
+	buildapi "github.com/pivotal/kpack/pkg/apis/build/v1alpha2"
+	"github.com/pivotal/kpack/pkg/client/clientset/versioned"
+	buildinformers "github.com/pivotal/kpack/pkg/client/informers/externalversions/build/v1alpha2"
+	buildlisters "github.com/pivotal/kpack/pkg/client/listers/build/v1alpha2"
+	"github.com/pivotal/kpack/pkg/reconciler"
+	"github.com/pivotal/kpack/pkg/reconciler/imageset"
+)
+
+const (
+	ReconcilerName = "PreviewImageSets"
+	Kind           = "PreviewImageSet"
+)
+
+//go:generate counterfeiter . PreviewProvider
+type PreviewProvider interface {
+	Previews(context.Context, *buildapi.PreviewImageSet) ([]buildapi.Preview, error)
+}
+
+func NewController(opt reconciler.Options, previewImageSetInformer buildinformers.PreviewImageSetInformer, imageInformer buildinformers.ImageInformer, provider PreviewProvider) *controller.Impl {
+	c := &Reconciler{
+		Client:                opt.Client,
+		PreviewImageSetLister: previewImageSetInformer.Lister(),
+		ImageLister:           imageInformer.Lister(),
+		PreviewProvider:       provider,
+		PollInterval:          opt.SourcePollingFrequency,
+	}
+
+	impl := controller.NewImpl(c, opt.Logger, ReconcilerName)
+	c.EnqueueAfter = impl.EnqueueAfter
+
+	previewImageSetInformer.Informer().AddEventHandler(reconciler.Handler(impl.Enqueue))
+
+	imageInformer.Informer().AddEventHandler(cache.FilteringResourceEventHandler{
+		FilterFunc: controller.FilterControllerGK(buildapi.SchemeGroupVersion.WithKind(Kind).GroupKind()),
+		Handler:    reconciler.Handler(impl.EnqueueControllerOf),
+	})
+
+	return impl
+}
+
+type Reconciler struct {
+	Client                versioned.Interface
+	PreviewImageSetLister buildlisters.PreviewImageSetLister
+	ImageLister           buildlisters.ImageLister
+	PreviewProvider       PreviewProvider
+	PollInterval          time.Duration
+	EnqueueAfter          func(obj interface{}, after time.Duration)
+}
+
+func (c *Reconciler) Reconcile(ctx context.Context, key string) error {
+	namespace, previewImageSetName, err := cache.SplitMetaNamespaceKey(key)
+	if err != nil {
+		return err
+	}
+
+	previewImageSet, err := c.PreviewImageSetLister.PreviewImageSets(namespace).Get(previewImageSetName)
+	if k8serrors.IsNotFound(err) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+
+	previewImageSet = previewImageSet.DeepCopy()
+	previewImageSet.SetDefaults(ctx)
+
+	pollErr := c.reconcilePreviews(ctx, previewImageSet)
+
+	desired, err := previewImageSet.Images(previewImageSet.Status.Previews)
+	if err != nil {
+		return controller.NewPermanentError(err)
+	}
+
+	reason, readyErr := buildapi.ListPreviewsFailed, pollErr
+	images, err := imageset.ChildImages{Client: c.Client, ImageLister: c.ImageLister}.Reconcile(ctx, previewImageSet, desired)
+	var conflictErr *imageset.ImageConflictError
+	if errors.As(err, &conflictErr) {
+		reason, readyErr = buildapi.ImageConflict, conflictErr
+	} else if err != nil {
+		return err
+	}
+
+	previewImageSet.Status.Images = imageset.ImageStatuses(images)
+	previewImageSet.Status.Conditions = imageset.ReadyConditions(previewImageSet.Status.Images, reason, readyErr)
+	previewImageSet.Status.ObservedGeneration = previewImageSet.Generation
+	return c.updateStatus(ctx, previewImageSet)
+}
+
+// reconcilePreviews records the previews of previewImageSet when its spec
+// changed or the poll interval has passed since they were last listed. The
+// previously listed previews are kept when listing fails so that their images
+// are not deleted.
+func (c *Reconciler) reconcilePreviews(ctx context.Context, previewImageSet *buildapi.PreviewImageSet) error {
+	now := time.Now()
+	last := previewImageSet.Status.LastPollTime
+	if last != nil && previewImageSet.Status.ObservedGeneration == previewImageSet.Generation && now.Sub(last.Time) < c.PollInterval {
+		c.enqueueAfter(previewImageSet, c.PollInterval-now.Sub(last.Time))
+		return nil
+	}
+
+	c.enqueueAfter(previewImageSet, c.PollInterval)
+	previews, err := c.PreviewProvider.Previews(ctx, previewImageSet)
+	if err != nil {
+		return err
+	}
+
+	previewImageSet.Status.Previews = previews
+	previewImageSet.Status.LastPollTime = &metav1.Time{Time: now}
+	return nil
+}
+
+func (c *Reconciler) enqueueAfter(previewImageSet *buildapi.PreviewImageSet, after time.Duration) {
+	if c.EnqueueAfter != nil {
+		c.EnqueueAfter(previewImageSet, after)
+	}
+}
+
+func (c *Reconciler) updateStatus(ctx context.Context, desired *buildapi.PreviewImageSet) error {
+	original, err := c.PreviewImageSetLister.PreviewImageSets(desired.Namespace).Get(desired.Name)
+	if err != nil {
+		return err
+	}
+
+	if equality.Semantic.DeepEqual(desired.Status, original.Status) {
+		return nil
+	}
+
+	_, err = c.Client.KpackV1alpha2().PreviewImageSets(desired.Namespace).UpdateStatus(ctx, desired, metav1.UpdateOptions{})
+	return err
+}
@@ -0,0 +1,277 @@
+package previewimageset_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/sclevine/spec"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	clientgotesting "k8s.io/client-go/testing"
+	"k8s.io/client-go/tools/record"
+	"knative.dev/pkg/controller"
+	rtesting "knative.dev/pkg/reconciler/testing"
+
+	buildapi "github.com/pivotal/kpack/pkg/apis/build/v1alpha2"
+	corev1alpha1 "github.com/pivotal/kpack/pkg/apis/core/v1alpha1"
+	"github.com/pivotal/kpack/pkg/client/clientset/versioned/fake"
+	"github.com/pivotal/kpack/pkg/reconciler/previewimageset"
+	"github.com/pivotal/kpack/pkg/reconciler/previewimageset/previewimagesetfakes"
+	"github.com/pivotal/kpack/pkg/reconciler/testhelpers"
+)
+
+func TestPreviewImageSetReconciler(t *testing.T) {
+	spec.Run(t, "Preview Image Set Reconciler", testPreviewImageSetReconciler)
+}
+
+func testPreviewImageSetReconciler(t *testing.T, when spec.G, it spec.S) {
+	const (
+		previewImageSetName = "app"
+		namespace           = "some-namespace"
+		key                 = "some-namespace/app"
+		originalGeneration  = 1
+		pollInterval        = 5 * time.Minute
+	)
+
+	var (
+		fakeProvider  = &previewimagesetfakes.FakePreviewProvider{}
+		enqueuedAfter time.Duration
+	)
+
+	rt := testhelpers.ReconcilerTester(t,
+		func(t *testing.T, row *rtesting.TableRow) (reconciler controller.Reconciler, lists rtesting.ActionRecorderList, list rtesting.EventList) {
+			listers := testhelpers.NewListers(row.Objects)
+			fakeClient := fake.NewSimpleClientset(listers.BuildServiceObjects()...)
+			eventRecorder := record.NewFakeRecorder(10)
+			r := &previewimageset.Reconciler{
+				Client:                fakeClient,
+				PreviewImageSetLister: listers.GetPreviewImageSetLister(),
+				ImageLister:           listers.GetImageLister(),
+				PreviewProvider:       fakeProvider,
+				PollInterval:          pollInterval,
+				EnqueueAfter: func(_ interface{}, after time.Duration) {
+					enqueuedAfter = after
+				},
+			}
+			return r, rtesting.ActionRecorderList{fakeClient}, rtesting.EventList{Recorder: eventRecorder}
+		})
+
+	previewImageSet := &buildapi.PreviewImageSet{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:       previewImageSetName,
+			Namespace:  namespace,
+			UID:        "some-uid",
+			Generation: originalGeneration,
+		},
+		Spec: buildapi.PreviewImageSetSpec{
+			Template: buildapi.ImageSpec{
+				Tag: "registry.io/app:pr-{{.Number}}",
+				Builder: corev1.ObjectReference{
+					Kind: "ClusterBuilder",
+					Name: "builder-name",
+				},
+				ServiceAccountName: "service-account",
+				Source: corev1alpha1.SourceConfig{
+					Git: &corev1alpha1.Git{
+						URL:      "https://github.com/some/app",
+						Revision: "main",
+					},
+				},
+			},
+			Provider: buildapi.PreviewProvider{
+				GitHub: &buildapi.PullRequestProvider{},
+			},
+		},
+	}
+	previewImageSet.SetDefaults(context.TODO())
+
+	pr42 := buildapi.PullRequestPreview(42, "feature/login", "refs/pull/42/head")
+	pr43 := buildapi.PullRequestPreview(43, "fix", "refs/pull/43/head")
+
+	ignorePollTime := cmpopts.IgnoreFields(buildapi.PreviewImageSetStatus{}, "LastPollTime")
+
+	desiredImages := func(previews ...buildapi.Preview) []*buildapi.Image {
+		images, err := previewImageSet.Images(previews)
+		require.NoError(t, err)
+		return images
+	}
+
+	withStatus := func(status buildapi.PreviewImageSetStatus) *buildapi.PreviewImageSet {
+		previewImageSet := previewImageSet.DeepCopy()
+		previewImageSet.Status = status
+		return previewImageSet
+	}
+
+	when("#Reconcile", func() {
+		it("creates an image for each open pull request", func() {
+			fakeProvider.PreviewsReturns([]buildapi.Preview{pr42, pr43}, nil)
+			images := desiredImages(pr42, pr43)
+
+			rt.Test(rtesting.TableRow{
+				Key:         key,
+				Objects:     []runtime.Object{previewImageSet},
+				CmpOpts:     []cmp.Option{ignorePollTime},
+				WantCreates: []runtime.Object{images[0], images[1]},
+				WantStatusUpdates: []clientgotesting.UpdateActionImpl{
+					{
+						Object: withStatus(buildapi.PreviewImageSetStatus{
+							Status: corev1alpha1.Status{
+								ObservedGeneration: originalGeneration,
+								Conditions: corev1alpha1.Conditions{
+									{
+										Type:    corev1alpha1.ConditionReady,
+										Status:  corev1.ConditionUnknown,
+										Message: "0 of 2 images are ready",
+									},
+								},
+							},
+							Images: []buildapi.ImageSetImageStatus{
+								{Name: "app-pr-42", Ready: corev1.ConditionUnknown},
+								{Name: "app-pr-43", Ready: corev1.ConditionUnknown},
+							},
+							Previews: []buildapi.Preview{pr42, pr43},
+						}),
+					},
+				},
+			})
+
+			require.Equal(t, 1, fakeProvider.PreviewsCallCount())
+			_, listed := fakeProvider.PreviewsArgsForCall(0)
+			assert.Equal(t, previewImageSetName, listed.Name)
+			assert.Equal(t, pollInterval, enqueuedAfter)
+		})
+
+		it("deletes the image of a closed pull request", func() {
+			fakeProvider.PreviewsReturns([]buildapi.Preview{pr43}, nil)
+			images := desiredImages(pr42, pr43)
+
+			rt.Test(rtesting.TableRow{
+				Key: key,
+				Objects: []runtime.Object{
+					withStatus(buildapi.PreviewImageSetStatus{
+						Status: corev1alpha1.Status{
+							ObservedGeneration: originalGeneration,
+						},
+						Previews:     []buildapi.Preview{pr42, pr43},
+						LastPollTime: &metav1.Time{Time: time.Now().Add(-time.Hour)},
+					}),
+					images[0],
+					images[1],
+				},
+				CmpOpts: []cmp.Option{ignorePollTime},
+				WantDeletes: []clientgotesting.DeleteActionImpl{
+					{
+						ActionImpl: clientgotesting.ActionImpl{
+							Namespace: namespace,
+							Resource: schema.GroupVersionResource{
+								Resource: "images",
+							},
+						},
+						Name: "app-pr-42",
+					},
+				},
+				WantStatusUpdates: []clientgotesting.UpdateActionImpl{
+					{
+						Object: withStatus(buildapi.PreviewImageSetStatus{
+							Status: corev1alpha1.Status{
+								ObservedGeneration: originalGeneration,
+								Conditions: corev1alpha1.Conditions{
+									{
+										Type:    corev1alpha1.ConditionReady,
+										Status:  corev1.ConditionUnknown,
+										Message: "0 of 1 images are ready",
+									},
+								},
+							},
+							Images: []buildapi.ImageSetImageStatus{
+								{Name: "app-pr-43", Ready: corev1.ConditionUnknown},
+							},
+							Previews: []buildapi.Preview{pr43},
+						}),
+					},
+				},
+			})
+		})
+
+		it("does not list previews before the poll interval has passed", func() {
+			images := desiredImages(pr42)
+			images[0].Status.Conditions = corev1alpha1.Conditions{{Type: corev1alpha1.ConditionReady, Status: corev1.ConditionTrue}}
+
+			rt.Test(rtesting.TableRow{
+				Key: key,
+				Objects: []runtime.Object{
+					withStatus(buildapi.PreviewImageSetStatus{
+						Status: corev1alpha1.Status{
+							ObservedGeneration: originalGeneration,
+							Conditions: corev1alpha1.Conditions{
+								{
+									Type:   corev1alpha1.ConditionReady,
+									Status: corev1.ConditionTrue,
+								},
+							},
+						},
+						Images: []buildapi.ImageSetImageStatus{
+							{Name: "app-pr-42", Ready: corev1.ConditionTrue},
+						},
+						Previews:     []buildapi.Preview{pr42},
+						LastPollTime: &metav1.Time{Time: time.Now().Add(-time.Minute)},
+					}),
+					images[0],
+				},
+			})
+
+			assert.Equal(t, 0, fakeProvider.PreviewsCallCount())
+			assert.InDelta(t, 4*time.Minute, enqueuedAfter, float64(time.Second))
+		})
+
+		it("keeps the images of previous previews when listing fails", func() {
+			fakeProvider.PreviewsReturns(nil, errors.New("listing pull requests of some/app: unexpected status 401"))
+			images := desiredImages(pr42)
+			lastPollTime := &metav1.Time{Time: time.Now().Add(-time.Hour)}
+
+			rt.Test(rtesting.TableRow{
+				Key: key,
+				Objects: []runtime.Object{
+					withStatus(buildapi.PreviewImageSetStatus{
+						Status: corev1alpha1.Status{
+							ObservedGeneration: originalGeneration,
+						},
+						Previews:     []buildapi.Preview{pr42},
+						LastPollTime: lastPollTime,
+					}),
+					images[0],
+				},
+				WantStatusUpdates: []clientgotesting.UpdateActionImpl{
+					{
+						Object: withStatus(buildapi.PreviewImageSetStatus{
+							Status: corev1alpha1.Status{
+								ObservedGeneration: originalGeneration,
+								Conditions: corev1alpha1.Conditions{
+									{
+										Type:    corev1alpha1.ConditionReady,
+										Status:  corev1.ConditionFalse,
+										Reason:  buildapi.ListPreviewsFailed,
+										Message: "listing pull requests of some/app: unexpected status 401",
+									},
+								},
+							},
+							Images: []buildapi.ImageSetImageStatus{
+								{Name: "app-pr-42", Ready: corev1.ConditionUnknown},
+							},
+							Previews:     []buildapi.Preview{pr42},
+							LastPollTime: lastPollTime,
+						}),
+					},
+				},
+			})
+		})
+	})
+}
@@ -18,10 +18,10 @@ func TestEnqueueAfter(t *testing.T) {
 	}
 
 	enqueuer := &workQueueEnqueuer{
-		delay: time.Minute,
+		delay: 10 * time.Minute,
 		enqueueAfter: func(obj interface{}, after time.Duration) {
 			require.Equal(t, sourceResolver, obj)
-			require.Equal(t, after, time.Minute)
+			require.Equal(t, after, 10*time.Minute)
 		},
 	}
 
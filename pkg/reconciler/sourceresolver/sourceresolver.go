@@ -35,11 +35,13 @@ func NewController(
 	gitResolver Resolver,
 	blobResolver Resolver,
 	registryResolver Resolver,
+	objectStoreResolver Resolver,
 ) *controller.Impl {
 	c := &Reconciler{
 		GitResolver:          gitResolver,
 		BlobResolver:         blobResolver,
 		RegistryResolver:     registryResolver,
+		ObjectStoreResolver:  objectStoreResolver,
 		Client:               opt.Client,
 		SourceResolverLister: sourceResolverInformer.Lister(),
 	}
@@ -56,6 +58,23 @@ func NewController(
 	return impl
 }
 
+// ConditionError is returned by a Resolver when the source cannot be resolved
+// until the user intervenes, such as when the host cannot be verified. It is
+// reported on the SourceResolver status instead of being returned.
+type ConditionError interface {
+	error
+	Reason() string
+}
+
+// PollingError is returned by a Resolver when a source that may have been
+// resolved before cannot be polled, such as when its server is unreachable.
+// Temporary errors keep the last resolved source and are reported on the
+// ActivePolling condition.
+type PollingError interface {
+	ConditionError
+	Temporary() bool
+}
+
 //go:generate counterfeiter . Enqueuer
 type Enqueuer interface {
 	Enqueue(*buildapi.SourceResolver) error
@@ -65,6 +84,7 @@ type Reconciler struct {
 	GitResolver          Resolver
 	BlobResolver         Resolver
 	RegistryResolver     Resolver
+	ObjectStoreResolver  Resolver
 	Enqueuer             Enqueuer
 	Client               versioned.Interface
 	SourceResolverLister buildlisters.SourceResolverLister
@@ -91,13 +111,22 @@ func (c *Reconciler) Reconcile(ctx context.Context, key string) error {
 	}
 
 	resolvedSource, err := sourceReconciler.Resolve(ctx, sourceResolver)
-	if err != nil {
+	var (
+		pollingErr   PollingError
+		conditionErr ConditionError
+	)
+	switch {
+	case errors.As(err, &pollingErr) && pollingErr.Temporary():
+		sourceResolver.PollingFailed(pollingErr.Reason(), pollingErr.Error())
+	case errors.As(err, &conditionErr):
+		sourceResolver.ResolveFailed(conditionErr.Reason(), conditionErr.Error())
+	case err != nil:
 		return err
+	default:
+		sourceResolver.ResolvedSource(resolvedSource)
 	}
 
-	sourceResolver.ResolvedSource(resolvedSource)
-
-	if sourceResolver.PollingReady() {
+	if sourceResolver.PollingReady() || err != nil {
 		err := c.Enqueuer.Enqueue(sourceResolver)
 		if err != nil {
 			return err
@@ -115,6 +144,8 @@ func (c *Reconciler) sourceReconciler(sourceResolver *buildapi.SourceResolver) (
 		return c.BlobResolver, nil
 	} else if c.RegistryResolver.CanResolve(sourceResolver) {
 		return c.RegistryResolver, nil
+	} else if c.ObjectStoreResolver.CanResolve(sourceResolver) {
+		return c.ObjectStoreResolver, nil
 	}
 	return nil, errors.New("invalid source type")
 }
@@ -38,6 +38,7 @@ func testSourceResolver(t *testing.T, when spec.G, it spec.S) {
 	fakeGitResolver := &sourceresolverfakes.FakeResolver{}
 	fakeBlobResolver := &sourceresolverfakes.FakeResolver{}
 	fakeRegistryResolver := &sourceresolverfakes.FakeResolver{}
+	fakeObjectStoreResolver := &sourceresolverfakes.FakeResolver{}
 	fakeEnqueuer := &sourceresolverfakes.FakeEnqueuer{}
 
 	rt := testhelpers.ReconcilerTester(t,
@@ -55,6 +56,7 @@ func testSourceResolver(t *testing.T, when spec.G, it spec.S) {
 				GitResolver:          fakeGitResolver,
 				BlobResolver:         fakeBlobResolver,
 				RegistryResolver:     fakeRegistryResolver,
+				ObjectStoreResolver:  fakeObjectStoreResolver,
 				Enqueuer:             fakeEnqueuer,
 				Client:               fakeClient,
 				SourceResolverLister: listers.GetSourceResolverLister(),
@@ -322,6 +324,43 @@ func testSourceResolver(t *testing.T, when spec.G, it spec.S) {
 					})
 				})
 			})
+			when("git fails with a condition error", func() {
+				fakeGitResolver.ResolveReturns(corev1alpha1.ResolvedSourceConfig{}, conditionError{reason: "HostVerificationFailed", message: "host key mismatch"})
+				fakeGitResolver.CanResolveReturns(true)
+
+				it("reports the failure as a condition and keeps polling", func() {
+					rt.Test(rtesting.TableRow{
+						Key: key,
+						Objects: []runtime.Object{
+							sourceResolver,
+						},
+						WantErr: false,
+						WantStatusUpdates: []clientgotesting.UpdateActionImpl{
+							{
+								Object: &buildapi.SourceResolver{
+									ObjectMeta: sourceResolver.ObjectMeta,
+									Spec:       sourceResolver.Spec,
+									Status: buildapi.SourceResolverStatus{
+										Status: corev1alpha1.Status{
+											ObservedGeneration: originalGeneration,
+											Conditions: corev1alpha1.Conditions{
+												{
+													Type:    corev1alpha1.ConditionReady,
+													Status:  corev1.ConditionFalse,
+													Reason:  "HostVerificationFailed",
+													Message: "host key mismatch",
+												},
+											},
+										},
+									},
+								},
+							},
+						},
+					})
+
+					require.Equal(t, 1, fakeEnqueuer.EnqueueCallCount())
+				})
+			})
 		})
 
 		when("a blob based source config", func() {
@@ -387,6 +426,89 @@ func testSourceResolver(t *testing.T, when spec.G, it spec.S) {
 					},
 				})
 			})
+			when("the blob cannot be polled", func() {
+				it.Before(func() {
+					fakeBlobResolver.ResolveReturns(corev1alpha1.ResolvedSourceConfig{}, pollingError{reason: "BlobFingerprintFailed", message: "unexpected status code 503"})
+				})
+
+				it("keeps the last resolved source and reports the failure on the polling condition", func() {
+					fingerprinted := resolvedSourceResolver(sourceResolver.DeepCopy(), corev1alpha1.ResolvedSourceConfig{
+						Blob: &corev1alpha1.ResolvedBlobSource{
+							URL:         "https://some-blobstore.example.com/some-blob",
+							Fingerprint: &corev1alpha1.BlobFingerprint{ETag: `"some-etag"`},
+						},
+					})
+
+					rt.Test(rtesting.TableRow{
+						Key: key,
+						Objects: []runtime.Object{
+							fingerprinted,
+						},
+						WantErr: false,
+						WantStatusUpdates: []clientgotesting.UpdateActionImpl{
+							{
+								Object: &buildapi.SourceResolver{
+									ObjectMeta: sourceResolver.ObjectMeta,
+									Spec:       sourceResolver.Spec,
+									Status: buildapi.SourceResolverStatus{
+										Status: corev1alpha1.Status{
+											ObservedGeneration: originalGeneration,
+											Conditions: corev1alpha1.Conditions{
+												{
+													Type:   corev1alpha1.ConditionReady,
+													Status: corev1.ConditionTrue,
+												},
+												{
+													Type:    buildapi.ActivePolling,
+													Status:  corev1.ConditionTrue,
+													Reason:  "BlobFingerprintFailed",
+													Message: "unexpected status code 503",
+												},
+											},
+										},
+										Source: fingerprinted.Status.Source,
+									},
+								},
+							},
+						},
+					})
+
+					require.Equal(t, 1, fakeEnqueuer.EnqueueCallCount())
+				})
+
+				it("fails a source that was not resolved before", func() {
+					rt.Test(rtesting.TableRow{
+						Key: key,
+						Objects: []runtime.Object{
+							sourceResolver,
+						},
+						WantErr: false,
+						WantStatusUpdates: []clientgotesting.UpdateActionImpl{
+							{
+								Object: &buildapi.SourceResolver{
+									ObjectMeta: sourceResolver.ObjectMeta,
+									Spec:       sourceResolver.Spec,
+									Status: buildapi.SourceResolverStatus{
+										Status: corev1alpha1.Status{
+											ObservedGeneration: originalGeneration,
+											Conditions: corev1alpha1.Conditions{
+												{
+													Type:    corev1alpha1.ConditionReady,
+													Status:  corev1.ConditionFalse,
+													Reason:  "BlobFingerprintFailed",
+													Message: "unexpected status code 503",
+												},
+											},
+										},
+									},
+								},
+							},
+						},
+					})
+
+					require.Equal(t, 1, fakeEnqueuer.EnqueueCallCount())
+				})
+			})
 		})
 
 		when("a registry based source config", func() {
@@ -453,6 +575,77 @@ func testSourceResolver(t *testing.T, when spec.G, it spec.S) {
 				})
 			})
 		})
+
+		when("an object store based source config", func() {
+			sourceResolver := &buildapi.SourceResolver{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:       sourceResolverName,
+					Namespace:  namespace,
+					Generation: originalGeneration,
+				},
+				Spec: buildapi.SourceResolverSpec{
+					ServiceAccountName: serviceAccount,
+					Source: corev1alpha1.SourceConfig{
+						ObjectStore: &corev1alpha1.ObjectStore{
+							Endpoint:  "https://minio.example.com",
+							Bucket:    "some-bucket",
+							Key:       "app.tar.gz",
+							SecretRef: &corev1.LocalObjectReference{Name: "minio-creds"},
+						},
+					},
+				},
+			}
+
+			resolvedSource := corev1alpha1.ResolvedSourceConfig{
+				ObjectStore: &corev1alpha1.ResolvedObjectStoreSource{
+					Endpoint:  "https://minio.example.com",
+					Bucket:    "some-bucket",
+					Key:       "app.tar.gz",
+					SecretRef: &corev1.LocalObjectReference{Name: "minio-creds"},
+					Version:   corev1alpha1.ObjectVersion{VersionID: "some-version", ETag: `"some-etag"`},
+				},
+			}
+
+			fakeObjectStoreResolver.ResolveReturns(resolvedSource, nil)
+			fakeObjectStoreResolver.CanResolveReturns(true)
+
+			it("reconciles to ready and active polling", func() {
+				rt.Test(rtesting.TableRow{
+					Key: key,
+					Objects: []runtime.Object{
+						sourceResolver,
+					},
+					WantErr: false,
+					WantStatusUpdates: []clientgotesting.UpdateActionImpl{
+						{
+							Object: &buildapi.SourceResolver{
+								ObjectMeta: sourceResolver.ObjectMeta,
+								Spec:       sourceResolver.Spec,
+								Status: buildapi.SourceResolverStatus{
+									Status: corev1alpha1.Status{
+										ObservedGeneration: originalGeneration,
+										Conditions: corev1alpha1.Conditions{
+											{
+												Type:   corev1alpha1.ConditionReady,
+												Status: corev1.ConditionTrue,
+											},
+											{
+												Type:   buildapi.ActivePolling,
+												Status: corev1.ConditionTrue,
+											},
+										},
+									},
+									Source: resolvedSource,
+								},
+							},
+						},
+					},
+				})
+
+				require.Equal(t, 1, fakeEnqueuer.EnqueueCallCount())
+				require.Equal(t, 1, fakeObjectStoreResolver.ResolveCallCount())
+			})
+		})
 	})
 }
 
@@ -460,3 +653,33 @@ func resolvedSourceResolver(sourceResolver *buildapi.SourceResolver, resolvedSou
 	sourceResolver.ResolvedSource(resolvedSource)
 	return sourceResolver
 }
+
+type conditionError struct {
+	reason  string
+	message string
+}
+
+func (e conditionError) Error() string {
+	return e.message
+}
+
+func (e conditionError) Reason() string {
+	return e.reason
+}
+
+type pollingError struct {
+	reason  string
+	message string
+}
+
+func (e pollingError) Error() string {
+	return e.message
+}
+
+func (e pollingError) Reason() string {
+	return e.reason
+}
+
+func (e pollingError) Temporary() bool {
+	return true
+}
@@ -55,6 +55,18 @@ func (l *Listers) GetImageLister() buildlisters.ImageLister {
 	return buildlisters.NewImageLister(l.indexerFor(&buildapi.Image{}))
 }
 
+func (l *Listers) GetImageSetLister() buildlisters.ImageSetLister {
+	return buildlisters.NewImageSetLister(l.indexerFor(&buildapi.ImageSet{}))
+}
+
+func (l *Listers) GetPreviewImageSetLister() buildlisters.PreviewImageSetLister {
+	return buildlisters.NewPreviewImageSetLister(l.indexerFor(&buildapi.PreviewImageSet{}))
+}
+
+func (l *Listers) GetBuildRequestLister() buildlisters.BuildRequestLister {
+	return buildlisters.NewBuildRequestLister(l.indexerFor(&buildapi.BuildRequest{}))
+}
+
 func (l *Listers) GetBuildLister() buildlisters.BuildLister {
 	return buildlisters.NewBuildLister(l.indexerFor(&buildapi.Build{}))
 }
@@ -87,6 +99,10 @@ func (l *Listers) GetPodLister() corev1listers.PodLister {
 	return corev1listers.NewPodLister(l.indexerFor(&corev1.Pod{}))
 }
 
+func (l *Listers) GetSecretLister() corev1listers.SecretLister {
+	return corev1listers.NewSecretLister(l.indexerFor(&corev1.Secret{}))
+}
+
 func (l *Listers) GetDuckBuilderLister() *duckbuilder.DuckBuilderLister {
 	return &duckbuilder.DuckBuilderLister{
 		BuilderLister:        l.GetBuilderLister(),
@@ -33,6 +33,7 @@ type Fetcher struct {
 	Logger   *log.Logger
 	Client   ImageClient
 	Keychain authn.Keychain
+	Limits   archive.Limits
 }
 
 func (f *Fetcher) Fetch(dir, registryImage string) error {
@@ -48,7 +49,7 @@ func (f *Fetcher) Fetch(dir, registryImage string) error {
 		return err
 	}
 
-	var handler func(img v1.Image, dir string) error
+	var handler func(img v1.Image, dir string, limits archive.Limits) error
 	switch cType {
 	case zip, jar, war:
 		handler = handleZip
@@ -60,7 +61,7 @@ func (f *Fetcher) Fetch(dir, registryImage string) error {
 		handler = handleSource
 	}
 
-	if err := handler(img, dir); err != nil {
+	if err := handler(img, dir, f.Limits); err != nil {
 		return err
 	}
 
@@ -87,14 +88,14 @@ func getContentType(img v1.Image) (contentType, error) {
 	return contentType(val), nil
 }
 
-func handleSource(img v1.Image, dir string) error {
+func handleSource(img v1.Image, dir string, limits archive.Limits) error {
 	layers, err := img.Layers()
 	if err != nil {
 		return err
 	}
 
 	for _, layer := range layers {
-		err := fetchLayer(layer, dir)
+		err := fetchLayer(layer, dir, limits)
 		if err != nil {
 			return err
 		}
@@ -103,14 +104,14 @@ func handleSource(img v1.Image, dir string) error {
 	return nil
 }
 
-func handleZip(img v1.Image, dir string) error {
+func handleZip(img v1.Image, dir string, limits archive.Limits) error {
 	tmpDir, err := ioutil.TempDir("", "")
 	if err != nil {
 		return err
 	}
 	defer os.RemoveAll(tmpDir)
 
-	file, err := getSourceFile(img, tmpDir)
+	file, err := getSourceFile(img, tmpDir, limits)
 	if err != nil {
 		return err
 	}
@@ -125,17 +126,17 @@ func handleZip(img v1.Image, dir string) error {
 		return err
 	}
 
-	return archive.ExtractZip(file, info.Size(), dir)
+	return archive.ExtractZip(file, info.Size(), dir, limits)
 }
 
-func handleTar(img v1.Image, dir string) error {
+func handleTar(img v1.Image, dir string, limits archive.Limits) error {
 	tmpDir, err := ioutil.TempDir("", "")
 	if err != nil {
 		return err
 	}
 	defer os.RemoveAll(tmpDir)
 
-	file, err := getSourceFile(img, tmpDir)
+	file, err := getSourceFile(img, tmpDir, limits)
 	if err != nil {
 		return err
 	}
@@ -145,26 +146,26 @@ func handleTar(img v1.Image, dir string) error {
 		return errors.Errorf("expected file '%s' to be a tar archive", file.Name())
 	}
 
-	return archive.ExtractTar(file, dir)
+	return archive.ExtractTar(file, dir, limits)
 }
 
-func handleTarGZ(img v1.Image, dir string) error {
+func handleTarGZ(img v1.Image, dir string, limits archive.Limits) error {
 	tmpDir, err := ioutil.TempDir("", "")
 	if err != nil {
 		return err
 	}
 	defer os.RemoveAll(tmpDir)
 
-	file, err := getSourceFile(img, tmpDir)
+	file, err := getSourceFile(img, tmpDir, limits)
 	if err != nil {
 		return err
 	}
 	defer file.Close()
 
-	return archive.ExtractTarGZ(file, dir)
+	return archive.ExtractTarGZ(file, dir, limits)
 }
 
-func getSourceFile(img v1.Image, dir string) (*os.File, error) {
+func getSourceFile(img v1.Image, dir string, limits archive.Limits) (*os.File, error) {
 	layers, err := img.Layers()
 	if err != nil {
 		return nil, err
@@ -174,7 +175,7 @@ func getSourceFile(img v1.Image, dir string) (*os.File, error) {
 		return nil, errors.Errorf("expected image to have exactly one layer")
 	}
 
-	err = fetchLayer(layers[0], dir)
+	err = fetchLayer(layers[0], dir, limits)
 	if err != nil {
 		return nil, err
 	}
@@ -191,12 +192,12 @@ func getSourceFile(img v1.Image, dir string) (*os.File, error) {
 	return os.Open(filepath.Join(dir, infos[0].Name()))
 }
 
-func fetchLayer(layer v1.Layer, dir string) error {
+func fetchLayer(layer v1.Layer, dir string, limits archive.Limits) error {
 	reader, err := layer.Uncompressed()
 	if err != nil {
 		return err
 	}
 	defer reader.Close()
 
-	return archive.ExtractTar(reader, dir)
+	return archive.ExtractTar(reader, dir, limits)
 }
@@ -0,0 +1,209 @@
+package registry
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/google/go-containerregistry/pkg/authn"
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/google/go-containerregistry/pkg/v1/remote/transport"
+	"github.com/pkg/errors"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/client-go/kubernetes"
+
+	buildapi "github.com/pivotal/kpack/pkg/apis/build/v1alpha2"
+	"github.com/pivotal/kpack/pkg/secret"
+)
+
+// cosignSuffixes are the tag suffixes of the cosign signatures and
+// attestations of an image.
+var cosignSuffixes = []string{"sig", "att"}
+
+// SignatureNotFoundError is returned when an image built with cosign secrets
+// has no cosign signature in any of the repositories of the secrets.
+type SignatureNotFoundError struct {
+	image        name.Digest
+	repositories []name.Repository
+}
+
+func (e *SignatureNotFoundError) Error() string {
+	names := make([]string, 0, len(e.repositories))
+	for _, r := range e.repositories {
+		names = append(names, r.Name())
+	}
+	return fmt.Sprintf("no cosign signature of %s found in %s", e.image, strings.Join(names, ", "))
+}
+
+func (e *SignatureNotFoundError) Reason() string {
+	return "SignatureNotFound"
+}
+
+// Promoter copies built images to the repositories of their promotions.
+type Promoter struct {
+	KeychainFactory KeychainFactory
+	K8sClient       kubernetes.Interface
+}
+
+// Promote copies the image built by build, and its cosign signatures and
+// attestations, to the repository of promotion with the tag of the build. It
+// returns the promoted image with its digest.
+func (p *Promoter) Promote(ctx context.Context, build *buildapi.Build, promotion buildapi.ImagePromotion) (string, error) {
+	built, err := name.NewDigest(build.Status.LatestImage, name.WeakValidation)
+	if err != nil {
+		return "", errors.Wrapf(err, "parsing built image %s", build.Status.LatestImage)
+	}
+
+	tag, err := name.NewTag(build.Tag(), name.WeakValidation)
+	if err != nil {
+		return "", errors.Wrapf(err, "parsing tag %s", build.Tag())
+	}
+
+	destination, err := name.NewRepository(promotion.Repository, name.WeakValidation)
+	if err != nil {
+		return "", errors.Wrapf(err, "parsing repository %s", promotion.Repository)
+	}
+
+	sourceKeychain, err := p.KeychainFactory.KeychainForSecretRef(ctx, SecretRef{
+		ServiceAccount: build.Spec.ServiceAccountName,
+		Namespace:      build.Namespace,
+	})
+	if err != nil {
+		return "", errors.Wrap(err, "unable to create built image keychain")
+	}
+
+	destinationKeychain, err := p.KeychainFactory.KeychainForSecretRef(ctx, SecretRef{
+		ServiceAccount:   build.Spec.ServiceAccountName,
+		Namespace:        build.Namespace,
+		ImagePullSecrets: promotion.ImagePullSecrets,
+	})
+	if err != nil {
+		return "", errors.Wrapf(err, "unable to create keychain for %s", promotion.Repository)
+	}
+
+	err = copyImage(ctx, built, destination.Tag(tag.TagStr()), sourceKeychain, destinationKeychain)
+	if err != nil {
+		return "", errors.Wrapf(err, "copying %s to %s", built, destination)
+	}
+
+	signatures, err := cosignRepositories(ctx, p.K8sClient, build.Namespace, build.Spec.ServiceAccountName, built)
+	if err != nil {
+		return "", err
+	}
+
+	err = copyCosignArtifacts(ctx, built, signatures, destination, sourceKeychain, destinationKeychain)
+	if err != nil {
+		return "", err
+	}
+
+	return destination.Name() + "@" + built.DigestStr(), nil
+}
+
+// cosignSignatures are the repositories the cosign signatures and attestations
+// of a built image are stored in.
+type cosignSignatures struct {
+	repositories []name.Repository
+	// signed is true when the image was built with cosign secrets and must
+	// have a signature.
+	signed bool
+}
+
+// cosignRepositories returns the repositories the cosign signatures of images
+// built with serviceAccount are stored in, chosen the same way as by the
+// signer of builds: the kpack.io/cosign.repository annotation of each cosign
+// secret of the service account, or the repository of the built image.
+func cosignRepositories(ctx context.Context, k8sClient kubernetes.Interface, namespace, serviceAccount string, built name.Digest) (cosignSignatures, error) {
+	if k8sClient == nil {
+		return cosignSignatures{repositories: []name.Repository{built.Context()}}, nil
+	}
+
+	secrets, err := (&secret.Fetcher{Client: k8sClient}).SecretsForServiceAccount(ctx, serviceAccount, namespace)
+	if err != nil && !k8serrors.IsNotFound(err) {
+		return cosignSignatures{}, errors.Wrap(err, "fetching cosign secrets")
+	}
+
+	var signatures cosignSignatures
+	seen := map[string]bool{}
+	for _, s := range secrets {
+		if len(s.Data[buildapi.COSIGNSecretDataCosignKey]) == 0 {
+			continue
+		}
+		signatures.signed = true
+
+		repository := built.Context()
+		if annotation := s.Annotations[buildapi.COSIGNRespositoryAnnotationPrefix]; annotation != "" {
+			repository, err = name.NewRepository(annotation, name.WeakValidation)
+			if err != nil {
+				return cosignSignatures{}, errors.Wrapf(err, "parsing cosign repository of secret %s", s.Name)
+			}
+		}
+
+		if seen[repository.Name()] {
+			continue
+		}
+		seen[repository.Name()] = true
+		signatures.repositories = append(signatures.repositories, repository)
+	}
+
+	if len(signatures.repositories) == 0 {
+		signatures.repositories = []name.Repository{built.Context()}
+	}
+	return signatures, nil
+}
+
+// copyCosignArtifacts copies the cosign signatures and attestations of built,
+// from the first of the signature repositories they are found in, to
+// destination. A signed image without a signature returns a
+// SignatureNotFoundError.
+func copyCosignArtifacts(ctx context.Context, built name.Digest, signatures cosignSignatures, destination name.Repository, sourceKeychain, destinationKeychain authn.Keychain) error {
+	for _, suffix := range cosignSuffixes {
+		cosignTag := fmt.Sprintf("%s.%s", strings.Replace(built.DigestStr(), ":", "-", 1), suffix)
+
+		found := false
+		for _, repository := range signatures.repositories {
+			err := copyImage(ctx, repository.Tag(cosignTag), destination.Tag(cosignTag), sourceKeychain, destinationKeychain)
+			if isNotFound(err) {
+				continue
+			} else if err != nil {
+				return errors.Wrapf(err, "copying %s to %s", repository.Tag(cosignTag), destination)
+			}
+			found = true
+			break
+		}
+
+		if !found && suffix == "sig" && signatures.signed {
+			return &SignatureNotFoundError{image: built, repositories: signatures.repositories}
+		}
+	}
+	return nil
+}
+
+// copyImage copies the image or index of source to destination.
+func copyImage(ctx context.Context, source, destination name.Reference, sourceKeychain, destinationKeychain authn.Keychain) error {
+	descriptor, err := remote.Get(source, remote.WithAuthFromKeychain(sourceKeychain), remote.WithContext(ctx))
+	if err != nil {
+		return err
+	}
+
+	options := []remote.Option{remote.WithAuthFromKeychain(destinationKeychain), remote.WithContext(ctx)}
+	if descriptor.MediaType.IsIndex() {
+		index, err := descriptor.ImageIndex()
+		if err != nil {
+			return err
+		}
+		return remote.WriteIndex(destination, index, options...)
+	}
+
+	image, err := descriptor.Image()
+	if err != nil {
+		return err
+	}
+	return remote.Write(destination, image, options...)
+}
+
+func isNotFound(err error) bool {
+	var transportErr *transport.Error
+	return errors.As(err, &transportErr) && transportErr.StatusCode == http.StatusNotFound
+}
@@ -0,0 +1,203 @@
+package registry_test
+
+import (
+	"context"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/google/go-containerregistry/pkg/name"
+	ggcrregistry "github.com/google/go-containerregistry/pkg/registry"
+	"github.com/google/go-containerregistry/pkg/v1/random"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/sclevine/spec"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8sfake "k8s.io/client-go/kubernetes/fake"
+
+	buildapi "github.com/pivotal/kpack/pkg/apis/build/v1alpha2"
+	"github.com/pivotal/kpack/pkg/registry"
+	"github.com/pivotal/kpack/pkg/registry/registryfakes"
+)
+
+func TestPromoter(t *testing.T) {
+	spec.Run(t, "testPromoter", testPromoter)
+}
+
+func testPromoter(t *testing.T, when spec.G, it spec.S) {
+	var (
+		keychainFactory = &registryfakes.FakeKeychainFactory{}
+		k8sClient       = k8sfake.NewSimpleClientset()
+		promoter        = &registry.Promoter{KeychainFactory: keychainFactory, K8sClient: k8sClient}
+		pullSecrets     = []corev1.LocalObjectReference{{Name: "dr-registry-secret"}}
+		source          string
+		destination     string
+		servers         []*httptest.Server
+	)
+
+	testRegistry := func() string {
+		server := httptest.NewServer(ggcrregistry.New())
+		servers = append(servers, server)
+		u, err := url.Parse(server.URL)
+		require.NoError(t, err)
+		return u.Host
+	}
+
+	push := func(ref string) string {
+		image, err := random.Image(512, 2)
+		require.NoError(t, err)
+
+		tag, err := name.NewTag(ref)
+		require.NoError(t, err)
+		require.NoError(t, remote.Write(tag, image))
+
+		digest, err := image.Digest()
+		require.NoError(t, err)
+		return digest.String()
+	}
+
+	digestOf := func(ref string) string {
+		descriptor, err := remote.Get(mustParse(t, ref))
+		require.NoError(t, err)
+		return descriptor.Digest.String()
+	}
+
+	addCosignSecret := func(annotations map[string]string) {
+		_, err := k8sClient.CoreV1().Secrets("some-namespace").Create(context.TODO(), &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        "cosign-secret",
+				Namespace:   "some-namespace",
+				Annotations: annotations,
+			},
+			Data: map[string][]byte{
+				buildapi.COSIGNSecretDataCosignKey: []byte("some-key"),
+			},
+		}, metav1.CreateOptions{})
+		require.NoError(t, err)
+
+		_, err = k8sClient.CoreV1().ServiceAccounts("some-namespace").Create(context.TODO(), &corev1.ServiceAccount{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "some-service-account",
+				Namespace: "some-namespace",
+			},
+			Secrets: []corev1.ObjectReference{{Name: "cosign-secret"}},
+		}, metav1.CreateOptions{})
+		require.NoError(t, err)
+	}
+
+	build := func(latestImage string) *buildapi.Build {
+		return &buildapi.Build{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "some-build",
+				Namespace: "some-namespace",
+			},
+			Spec: buildapi.BuildSpec{
+				Tags:               []string{source + "/app:v1"},
+				ServiceAccountName: "some-service-account",
+			},
+			Status: buildapi.BuildStatus{
+				LatestImage: latestImage,
+			},
+		}
+	}
+
+	it.Before(func() {
+		source = testRegistry()
+		destination = testRegistry()
+
+		keychainFactory.AddKeychainForSecretRef(t, registry.SecretRef{
+			ServiceAccount: "some-service-account",
+			Namespace:      "some-namespace",
+		}, &registryfakes.FakeKeychain{Name: "build-keychain"})
+		keychainFactory.AddKeychainForSecretRef(t, registry.SecretRef{
+			ServiceAccount:   "some-service-account",
+			Namespace:        "some-namespace",
+			ImagePullSecrets: pullSecrets,
+		}, &registryfakes.FakeKeychain{Name: "dr-keychain"})
+	})
+
+	it.After(func() {
+		for _, server := range servers {
+			server.Close()
+		}
+	})
+
+	it("copies the built image with the tag of the build", func() {
+		digest := push(source + "/app:v1")
+
+		promoted, err := promoter.Promote(context.TODO(), build(source+"/app@"+digest), buildapi.ImagePromotion{
+			Repository:       destination + "/dr/app",
+			ImagePullSecrets: pullSecrets,
+		})
+		require.NoError(t, err)
+
+		assert.Equal(t, destination+"/dr/app@"+digest, promoted)
+		assert.Equal(t, digest, digestOf(destination+"/dr/app:v1"))
+	})
+
+	it("copies the cosign signatures and attestations of the built image", func() {
+		digest := push(source + "/app:v1")
+		signature := push(source + "/app:" + strings.Replace(digest, ":", "-", 1) + ".sig")
+		attestation := push(source + "/app:" + strings.Replace(digest, ":", "-", 1) + ".att")
+
+		_, err := promoter.Promote(context.TODO(), build(source+"/app@"+digest), buildapi.ImagePromotion{
+			Repository:       destination + "/dr/app",
+			ImagePullSecrets: pullSecrets,
+		})
+		require.NoError(t, err)
+
+		assert.Equal(t, signature, digestOf(destination+"/dr/app:"+strings.Replace(digest, ":", "-", 1)+".sig"))
+		assert.Equal(t, attestation, digestOf(destination+"/dr/app:"+strings.Replace(digest, ":", "-", 1)+".att"))
+	})
+
+	it("copies the cosign signatures from the cosign repository of the service account", func() {
+		signatures := testRegistry()
+		addCosignSecret(map[string]string{
+			buildapi.COSIGNRespositoryAnnotationPrefix: signatures + "/signatures",
+		})
+
+		digest := push(source + "/app:v1")
+		signature := push(signatures + "/signatures:" + strings.Replace(digest, ":", "-", 1) + ".sig")
+
+		_, err := promoter.Promote(context.TODO(), build(source+"/app@"+digest), buildapi.ImagePromotion{
+			Repository:       destination + "/dr/app",
+			ImagePullSecrets: pullSecrets,
+		})
+		require.NoError(t, err)
+
+		assert.Equal(t, signature, digestOf(destination+"/dr/app:"+strings.Replace(digest, ":", "-", 1)+".sig"))
+	})
+
+	it("returns a SignatureNotFoundError when a signed image has no signature", func() {
+		addCosignSecret(nil)
+
+		digest := push(source + "/app:v1")
+
+		_, err := promoter.Promote(context.TODO(), build(source+"/app@"+digest), buildapi.ImagePromotion{
+			Repository:       destination + "/dr/app",
+			ImagePullSecrets: pullSecrets,
+		})
+
+		var signatureErr *registry.SignatureNotFoundError
+		require.ErrorAs(t, err, &signatureErr)
+		assert.Equal(t, "SignatureNotFound", signatureErr.Reason())
+		assert.Contains(t, err.Error(), source+"/app")
+	})
+
+	it("returns an error when the built image does not exist", func() {
+		_, err := promoter.Promote(context.TODO(), build(source+"/app@sha256:0000000000000000000000000000000000000000000000000000000000000000"), buildapi.ImagePromotion{
+			Repository:       destination + "/dr/app",
+			ImagePullSecrets: pullSecrets,
+		})
+		assert.Error(t, err)
+	})
+}
+
+func mustParse(t *testing.T, ref string) name.Reference {
+	reference, err := name.ParseReference(ref)
+	require.NoError(t, err)
+	return reference
+}
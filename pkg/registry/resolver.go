@@ -2,19 +2,71 @@ package registry
 
 import (
 	"context"
+	"net"
+	"net/http"
+	"strings"
+
+	"github.com/google/go-containerregistry/pkg/v1/remote/transport"
+	"github.com/pkg/errors"
 
 	buildapi "github.com/pivotal/kpack/pkg/apis/build/v1alpha2"
 	corev1alpha1 "github.com/pivotal/kpack/pkg/apis/core/v1alpha1"
 )
 
+const ImageNotResolvedReason = "SourceImageNotResolved"
+
+// ResolveError is returned when the source image cannot be pulled with the
+// credentials of the SourceResolver.
+type ResolveError struct {
+	err       error
+	temporary bool
+}
+
+func (e *ResolveError) Error() string {
+	return e.err.Error()
+}
+
+func (e *ResolveError) Reason() string {
+	return ImageNotResolvedReason
+}
+
+// Temporary reports whether the registry could not be reached or failed to
+// respond. The last resolved digest is kept for temporary errors while a
+// missing or unauthorized image fails the SourceResolver.
+func (e *ResolveError) Temporary() bool {
+	return e.temporary
+}
+
 type Resolver struct {
+	KeychainFactory KeychainFactory
+	Client          ImageClient
 }
 
-func (*Resolver) Resolve(ctx context.Context, sourceResolver *buildapi.SourceResolver) (corev1alpha1.ResolvedSourceConfig, error) {
+func (r *Resolver) Resolve(ctx context.Context, sourceResolver *buildapi.SourceResolver) (corev1alpha1.ResolvedSourceConfig, error) {
+	registry := sourceResolver.Spec.Source.Registry
+
+	keychain, err := r.KeychainFactory.KeychainForSecretRef(ctx, SecretRef{
+		ServiceAccount:   sourceResolver.Spec.ServiceAccountName,
+		Namespace:        sourceResolver.Namespace,
+		ImagePullSecrets: registry.ImagePullSecrets,
+	})
+	if err != nil {
+		return corev1alpha1.ResolvedSourceConfig{}, err
+	}
+
+	_, identifier, err := r.Client.Fetch(keychain, registry.Image)
+	if err != nil {
+		return corev1alpha1.ResolvedSourceConfig{}, &ResolveError{
+			err:       errors.Wrapf(err, "resolving source image %s", registry.Image),
+			temporary: isTemporary(err),
+		}
+	}
+
 	return corev1alpha1.ResolvedSourceConfig{
 		Registry: &corev1alpha1.ResolvedRegistrySource{
-			Image:            sourceResolver.Spec.Source.Registry.Image,
-			ImagePullSecrets: sourceResolver.Spec.Source.Registry.ImagePullSecrets,
+			Image:            registry.Image,
+			Digest:           identifier[strings.LastIndex(identifier, "@")+1:],
+			ImagePullSecrets: registry.ImagePullSecrets,
 			SubPath:          sourceResolver.Spec.Source.SubPath,
 		},
 	}, nil
@@ -23,3 +75,13 @@ func (*Resolver) Resolve(ctx context.Context, sourceResolver *buildapi.SourceRes
 func (*Resolver) CanResolve(sourceResolver *buildapi.SourceResolver) bool {
 	return sourceResolver.IsRegistry()
 }
+
+func isTemporary(err error) bool {
+	var transportErr *transport.Error
+	if errors.As(err, &transportErr) {
+		return transportErr.Temporary() || transportErr.StatusCode == http.StatusTooManyRequests
+	}
+
+	var netErr net.Error
+	return errors.As(err, &netErr)
+}
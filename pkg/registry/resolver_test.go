@@ -0,0 +1,160 @@
+package registry_test
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"testing"
+
+	"github.com/google/go-containerregistry/pkg/v1/random"
+	"github.com/google/go-containerregistry/pkg/v1/remote/transport"
+	"github.com/sclevine/spec"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	buildapi "github.com/pivotal/kpack/pkg/apis/build/v1alpha2"
+	corev1alpha1 "github.com/pivotal/kpack/pkg/apis/core/v1alpha1"
+	"github.com/pivotal/kpack/pkg/registry"
+	"github.com/pivotal/kpack/pkg/registry/registryfakes"
+)
+
+func TestRegistryResolver(t *testing.T) {
+	spec.Run(t, "testRegistryResolver", testRegistryResolver)
+}
+
+func testRegistryResolver(t *testing.T, when spec.G, it spec.S) {
+	const image = "some-registry.io/some-source:latest"
+
+	var (
+		client          = registryfakes.NewFakeClient()
+		keychainFactory = &registryfakes.FakeKeychainFactory{}
+		keychain        = &registryfakes.FakeKeychain{Name: "source-keychain"}
+		resolver        = &registry.Resolver{
+			KeychainFactory: keychainFactory,
+			Client:          client,
+		}
+		pullSecrets = []corev1.LocalObjectReference{{Name: "some-pull-secret"}}
+	)
+
+	it.Before(func() {
+		keychainFactory.AddKeychainForSecretRef(t, registry.SecretRef{
+			ServiceAccount:   "some-service-account",
+			Namespace:        "some-namespace",
+			ImagePullSecrets: pullSecrets,
+		}, keychain)
+	})
+
+	sourceResolver := func(image string) *buildapi.SourceResolver {
+		return &buildapi.SourceResolver{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "some-source-resolver",
+				Namespace: "some-namespace",
+			},
+			Spec: buildapi.SourceResolverSpec{
+				ServiceAccountName: "some-service-account",
+				Source: corev1alpha1.SourceConfig{
+					Registry: &corev1alpha1.Registry{
+						Image:            image,
+						ImagePullSecrets: pullSecrets,
+					},
+					SubPath: "some-path",
+				},
+			},
+		}
+	}
+
+	when("#Resolve", func() {
+		it("resolves the image to a digest with the pull secrets", func() {
+			img, err := random.Image(10, 1)
+			require.NoError(t, err)
+			client.AddImage(image, img, keychain)
+
+			digest, err := img.Digest()
+			require.NoError(t, err)
+
+			resolved, err := resolver.Resolve(context.Background(), sourceResolver(image))
+			require.NoError(t, err)
+
+			assert.Equal(t, corev1alpha1.ResolvedSourceConfig{
+				Registry: &corev1alpha1.ResolvedRegistrySource{
+					Image:            image,
+					Digest:           digest.String(),
+					ImagePullSecrets: pullSecrets,
+					SubPath:          "some-path",
+				},
+			}, resolved)
+			assert.True(t, resolved.Registry.IsPollable())
+		})
+
+		it("is not pollable when the image is referenced by digest", func() {
+			img, err := random.Image(10, 1)
+			require.NoError(t, err)
+			digest, err := img.Digest()
+			require.NoError(t, err)
+
+			pinned := "some-registry.io/some-source@" + digest.String()
+			client.AddImage(pinned, img, keychain)
+
+			resolved, err := resolver.Resolve(context.Background(), sourceResolver(pinned))
+			require.NoError(t, err)
+
+			assert.Equal(t, digest.String(), resolved.Registry.Digest)
+			assert.False(t, resolved.Registry.IsPollable())
+		})
+
+		it("returns a condition error when the image cannot be pulled", func() {
+			client.SetFetchError(errors.New("UNAUTHORIZED"))
+
+			_, err := resolver.Resolve(context.Background(), sourceResolver(image))
+			require.EqualError(t, err, "resolving source image some-registry.io/some-source:latest: UNAUTHORIZED")
+
+			var resolveErr *registry.ResolveError
+			require.True(t, errors.As(err, &resolveErr))
+			assert.Equal(t, registry.ImageNotResolvedReason, resolveErr.Reason())
+			assert.False(t, resolveErr.Temporary())
+		})
+
+		it("returns a permanent error when the image does not exist", func() {
+			client.SetFetchError(&transport.Error{StatusCode: http.StatusNotFound})
+
+			_, err := resolver.Resolve(context.Background(), sourceResolver(image))
+
+			var resolveErr *registry.ResolveError
+			require.True(t, errors.As(err, &resolveErr))
+			assert.False(t, resolveErr.Temporary())
+		})
+
+		it("returns a temporary error when the registry is unavailable", func() {
+			client.SetFetchError(&transport.Error{StatusCode: http.StatusServiceUnavailable})
+
+			_, err := resolver.Resolve(context.Background(), sourceResolver(image))
+
+			var resolveErr *registry.ResolveError
+			require.True(t, errors.As(err, &resolveErr))
+			assert.True(t, resolveErr.Temporary())
+		})
+
+		it("returns a temporary error when the registry is rate limited", func() {
+			client.SetFetchError(&transport.Error{StatusCode: http.StatusTooManyRequests})
+
+			_, err := resolver.Resolve(context.Background(), sourceResolver(image))
+
+			var resolveErr *registry.ResolveError
+			require.True(t, errors.As(err, &resolveErr))
+			assert.True(t, resolveErr.Temporary())
+		})
+
+		it("returns a temporary error when the registry cannot be reached", func() {
+			client.SetFetchError(&net.OpError{Op: "dial", Net: "tcp", Err: errors.New("connection refused")})
+
+			_, err := resolver.Resolve(context.Background(), sourceResolver(image))
+
+			var resolveErr *registry.ResolveError
+			require.True(t, errors.As(err, &resolveErr))
+			assert.True(t, resolveErr.Temporary())
+		})
+	})
+}
@@ -0,0 +1,117 @@
+package registry
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+
+	"github.com/buildpacks/lifecycle/platform"
+	"github.com/google/go-containerregistry/pkg/authn"
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+
+	buildapi "github.com/pivotal/kpack/pkg/apis/build/v1alpha2"
+)
+
+// NotarySigner signs the tags of an exported image with notary v1.
+type NotarySigner interface {
+	Sign(url, notarySecretDir string, report platform.ExportReport, keychain authn.Keychain) error
+}
+
+// Retagger points the tags of images at the images of earlier builds.
+type Retagger struct {
+	KeychainFactory KeychainFactory
+	K8sClient       kubernetes.Interface
+	NotarySigner    NotarySigner
+}
+
+// Retag points the tag and additional tags of image at the image built by
+// build. The cosign signatures and attestations of the built image are bound
+// to its digest and are copied to the repositories of tags outside of the
+// built repository. With a notary configuration the tags are signed again.
+// It returns the image of the tag with its digest.
+func (r *Retagger) Retag(ctx context.Context, image *buildapi.Image, build *buildapi.Build) (string, error) {
+	built, err := name.NewDigest(build.Status.LatestImage, name.WeakValidation)
+	if err != nil {
+		return "", errors.Wrapf(err, "parsing built image %s", build.Status.LatestImage)
+	}
+
+	keychain, err := r.KeychainFactory.KeychainForSecretRef(ctx, SecretRef{
+		ServiceAccount: image.Spec.ServiceAccountName,
+		Namespace:      image.Namespace,
+	})
+	if err != nil {
+		return "", errors.Wrap(err, "unable to create image keychain")
+	}
+
+	signatures, err := cosignRepositories(ctx, r.K8sClient, image.Namespace, image.Spec.ServiceAccountName, built)
+	if err != nil {
+		return "", err
+	}
+
+	tags := append([]string{image.Spec.Tag}, image.Spec.AdditionalTags...)
+	repositories := map[string]bool{built.Context().Name(): true}
+	for _, t := range tags {
+		tag, err := name.NewTag(t, name.WeakValidation)
+		if err != nil {
+			return "", errors.Wrapf(err, "parsing tag %s", t)
+		}
+
+		err = copyImage(ctx, built, tag, keychain, keychain)
+		if err != nil {
+			return "", errors.Wrapf(err, "copying %s to %s", built, tag)
+		}
+
+		if repositories[tag.Context().Name()] {
+			continue
+		}
+		repositories[tag.Context().Name()] = true
+
+		err = copyCosignArtifacts(ctx, built, signatures, tag.Context(), keychain, keychain)
+		if err != nil {
+			return "", err
+		}
+	}
+
+	if image.Spec.Notary != nil && image.Spec.Notary.V1 != nil {
+		err = r.notarySign(ctx, image, tags, built.DigestStr(), keychain)
+		if err != nil {
+			return "", errors.Wrap(err, "notary sign")
+		}
+	}
+
+	tag, err := name.NewTag(image.Spec.Tag, name.WeakValidation)
+	if err != nil {
+		return "", errors.Wrapf(err, "parsing tag %s", image.Spec.Tag)
+	}
+	return tag.Context().Name() + "@" + built.DigestStr(), nil
+}
+
+func (r *Retagger) notarySign(ctx context.Context, image *buildapi.Image, tags []string, digest string, keychain authn.Keychain) error {
+	notary := image.Spec.Notary.V1
+	secret, err := r.K8sClient.CoreV1().Secrets(image.Namespace).Get(ctx, notary.SecretRef.Name, metav1.GetOptions{})
+	if err != nil {
+		return errors.Wrapf(err, "getting notary secret %s", notary.SecretRef.Name)
+	}
+
+	dir, err := os.MkdirTemp("", "notary")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(dir)
+
+	for key, value := range secret.Data {
+		if err := os.WriteFile(filepath.Join(dir, key), value, 0600); err != nil {
+			return err
+		}
+	}
+
+	return r.NotarySigner.Sign(notary.URL, dir, platform.ExportReport{
+		Image: platform.ImageReport{
+			Tags:   tags,
+			Digest: digest,
+		},
+	}, keychain)
+}
@@ -0,0 +1,207 @@
+package registry_test
+
+import (
+	"context"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/buildpacks/lifecycle/platform"
+	"github.com/google/go-containerregistry/pkg/authn"
+	"github.com/google/go-containerregistry/pkg/name"
+	ggcrregistry "github.com/google/go-containerregistry/pkg/registry"
+	"github.com/google/go-containerregistry/pkg/v1/random"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/sclevine/spec"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+
+	buildapi "github.com/pivotal/kpack/pkg/apis/build/v1alpha2"
+	corev1alpha1 "github.com/pivotal/kpack/pkg/apis/core/v1alpha1"
+	"github.com/pivotal/kpack/pkg/registry"
+	"github.com/pivotal/kpack/pkg/registry/registryfakes"
+)
+
+func TestRetagger(t *testing.T) {
+	spec.Run(t, "testRetagger", testRetagger)
+}
+
+func testRetagger(t *testing.T, when spec.G, it spec.S) {
+	var (
+		keychainFactory = &registryfakes.FakeKeychainFactory{}
+		keychain        = &registryfakes.FakeKeychain{Name: "image-keychain"}
+		notarySigner    = &fakeNotarySigner{}
+		k8sClient       = fake.NewSimpleClientset(&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "notary-secret",
+				Namespace: "some-namespace",
+			},
+			Data: map[string][]byte{
+				"password":   []byte("some-password"),
+				"signer.key": []byte("some-key"),
+			},
+		})
+		retagger = &registry.Retagger{
+			KeychainFactory: keychainFactory,
+			K8sClient:       k8sClient,
+			NotarySigner:    notarySigner,
+		}
+		server *httptest.Server
+		host   string
+	)
+
+	push := func(ref string) string {
+		image, err := random.Image(512, 2)
+		require.NoError(t, err)
+
+		tag, err := name.NewTag(ref)
+		require.NoError(t, err)
+		require.NoError(t, remote.Write(tag, image))
+
+		digest, err := image.Digest()
+		require.NoError(t, err)
+		return digest.String()
+	}
+
+	digestOf := func(ref string) string {
+		descriptor, err := remote.Get(mustParse(t, ref))
+		require.NoError(t, err)
+		return descriptor.Digest.String()
+	}
+
+	it.Before(func() {
+		server = httptest.NewServer(ggcrregistry.New())
+		u, err := url.Parse(server.URL)
+		require.NoError(t, err)
+		host = u.Host
+
+		keychainFactory.AddKeychainForSecretRef(t, registry.SecretRef{
+			ServiceAccount: "some-service-account",
+			Namespace:      "some-namespace",
+		}, keychain)
+	})
+
+	it.After(func() {
+		server.Close()
+	})
+
+	image := func(additionalTags ...string) *buildapi.Image {
+		return &buildapi.Image{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "some-image",
+				Namespace: "some-namespace",
+			},
+			Spec: buildapi.ImageSpec{
+				Tag:                host + "/app",
+				AdditionalTags:     additionalTags,
+				ServiceAccountName: "some-service-account",
+			},
+		}
+	}
+
+	build := func(latestImage string) *buildapi.Build {
+		return &buildapi.Build{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "some-image-build-1",
+				Namespace: "some-namespace",
+			},
+			Status: buildapi.BuildStatus{
+				LatestImage: latestImage,
+			},
+		}
+	}
+
+	it("points the tag and additional tags at the built image", func() {
+		previous := push(host + "/app:b1")
+		push(host + "/app")
+
+		retagged, err := retagger.Retag(context.TODO(), image(host+"/app:stable"), build(host+"/app@"+previous))
+		require.NoError(t, err)
+
+		assert.Equal(t, host+"/app@"+previous, retagged)
+		assert.Equal(t, previous, digestOf(host+"/app"))
+		assert.Equal(t, previous, digestOf(host+"/app:stable"))
+		assert.Empty(t, notarySigner.calls)
+	})
+
+	it("copies the cosign signatures and attestations to other repositories", func() {
+		previous := push(host + "/app:b1")
+		signatureTag := strings.Replace(previous, ":", "-", 1) + ".sig"
+		signature := push(host + "/app:" + signatureTag)
+
+		_, err := retagger.Retag(context.TODO(), image(host+"/mirror/app:stable"), build(host+"/app@"+previous))
+		require.NoError(t, err)
+
+		assert.Equal(t, previous, digestOf(host+"/mirror/app:stable"))
+		assert.Equal(t, signature, digestOf(host+"/mirror/app:"+signatureTag))
+	})
+
+	it("signs the tags with notary when configured", func() {
+		previous := push(host + "/app:b1")
+		notaryImage := image(host + "/app:stable")
+		notaryImage.Spec.Notary = &corev1alpha1.NotaryConfig{
+			V1: &corev1alpha1.NotaryV1Config{
+				URL: "https://notary.example.com",
+				SecretRef: corev1alpha1.NotarySecretRef{
+					Name: "notary-secret",
+				},
+			},
+		}
+
+		_, err := retagger.Retag(context.TODO(), notaryImage, build(host+"/app@"+previous))
+		require.NoError(t, err)
+
+		require.Len(t, notarySigner.calls, 1)
+		call := notarySigner.calls[0]
+		assert.Equal(t, "https://notary.example.com", call.url)
+		assert.Equal(t, keychain, call.keychain)
+		assert.Equal(t, platform.ImageReport{
+			Tags:   []string{host + "/app", host + "/app:stable"},
+			Digest: previous,
+		}, call.report.Image)
+		assert.Equal(t, map[string]string{
+			"password":   "some-password",
+			"signer.key": "some-key",
+		}, call.secrets)
+	})
+
+	it("returns an error when the built image does not exist", func() {
+		_, err := retagger.Retag(context.TODO(), image(), build(host+"/app@sha256:0000000000000000000000000000000000000000000000000000000000000000"))
+		assert.Error(t, err)
+	})
+}
+
+type notarySignCall struct {
+	url      string
+	report   platform.ExportReport
+	keychain authn.Keychain
+	secrets  map[string]string
+}
+
+type fakeNotarySigner struct {
+	calls []notarySignCall
+}
+
+func (s *fakeNotarySigner) Sign(url, notarySecretDir string, report platform.ExportReport, keychain authn.Keychain) error {
+	secrets := map[string]string{}
+	files, err := os.ReadDir(notarySecretDir)
+	if err != nil {
+		return err
+	}
+	for _, file := range files {
+		contents, err := os.ReadFile(filepath.Join(notarySecretDir, file.Name()))
+		if err != nil {
+			return err
+		}
+		secrets[file.Name()] = string(contents)
+	}
+
+	s.calls = append(s.calls, notarySignCall{url: url, report: report, keychain: keychain, secrets: secrets})
+	return nil
+}
@@ -1,10 +1,27 @@
 package secret
 
+const (
+	SSHAuthKnownHostsKey = "known_hosts"
+	CACertKey            = "ca.crt"
+	BearerTokenKey       = "token"
+
+	ObjectStoreAccessKeyIDKey     = "accessKeyId"
+	ObjectStoreSecretAccessKeyKey = "secretAccessKey"
+	ObjectStoreSessionTokenKey    = "sessionToken"
+)
+
 type BasicAuth struct {
 	Username string
 	Password string
+	CACert   string
 }
 
 type SSH struct {
 	PrivateKey string
+	KnownHosts string
+}
+
+type BearerToken struct {
+	Token  string
+	CACert string
 }
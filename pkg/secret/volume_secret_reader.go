@@ -3,6 +3,7 @@ package secret
 import (
 	"fmt"
 	"io/ioutil"
+	"os"
 	"path/filepath"
 
 	corev1 "k8s.io/api/core/v1"
@@ -22,9 +23,33 @@ func ReadBasicAuthSecret(secretVolume, secretName string) (BasicAuth, error) {
 	}
 	password := string(pb)
 
+	caCert, err := ReadOptionalFile(filepath.Join(secretPath, CACertKey))
+	if err != nil {
+		return BasicAuth{}, err
+	}
+
 	return BasicAuth{
 		Username: username,
 		Password: password,
+		CACert:   caCert,
+	}, nil
+}
+
+func ReadBearerTokenSecret(secretVolume, secretName string) (BearerToken, error) {
+	secretPath := volumeName(secretVolume, secretName)
+	token, err := ioutil.ReadFile(filepath.Join(secretPath, BearerTokenKey))
+	if err != nil {
+		return BearerToken{}, err
+	}
+
+	caCert, err := ReadOptionalFile(filepath.Join(secretPath, CACertKey))
+	if err != nil {
+		return BearerToken{}, err
+	}
+
+	return BearerToken{
+		Token:  string(token),
+		CACert: caCert,
 	}, nil
 }
 
@@ -35,11 +60,27 @@ func ReadSshSecret(secretVolume, secretName string) (SSH, error) {
 		return SSH{}, err
 	}
 
+	knownHosts, err := ReadOptionalFile(filepath.Join(secretPath, SSHAuthKnownHostsKey))
+	if err != nil {
+		return SSH{}, err
+	}
+
 	return SSH{
 		PrivateKey: string(privateKey),
+		KnownHosts: knownHosts,
 	}, nil
 }
 
+// ReadOptionalFile returns the contents of the secret key mounted at path, or
+// an empty string when the key is not set.
+func ReadOptionalFile(path string) (string, error) {
+	b, err := ioutil.ReadFile(path)
+	if os.IsNotExist(err) {
+		return "", nil
+	}
+	return string(b), err
+}
+
 func volumeName(VolumePath, secretName string) string {
 	return fmt.Sprintf("%s/%s", VolumePath, secretName)
 }
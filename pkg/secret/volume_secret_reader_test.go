@@ -41,6 +41,54 @@ func testVolumeSecretReader(t *testing.T, when spec.G, it spec.S) {
 				Password: "saved-password",
 			})
 		})
+
+		it("returns the ca cert when present", func() {
+			testDir, err := ioutil.TempDir("", "secret-volume")
+			require.NoError(t, err)
+
+			defer func() {
+				require.NoError(t, os.RemoveAll(testDir))
+			}()
+
+			require.NoError(t, os.MkdirAll(path.Join(testDir, "creds"), 0777))
+
+			require.NoError(t, ioutil.WriteFile(path.Join(testDir, "creds", corev1.BasicAuthUsernameKey), []byte("saved-username"), 0600))
+			require.NoError(t, ioutil.WriteFile(path.Join(testDir, "creds", corev1.BasicAuthPasswordKey), []byte("saved-password"), 0600))
+			require.NoError(t, ioutil.WriteFile(path.Join(testDir, "creds", secret.CACertKey), []byte("some-ca"), 0600))
+
+			auth, err := secret.ReadBasicAuthSecret(testDir, "creds")
+			require.NoError(t, err)
+
+			assert.Equal(t, auth, secret.BasicAuth{
+				Username: "saved-username",
+				Password: "saved-password",
+				CACert:   "some-ca",
+			})
+		})
+	})
+
+	when("#readBearerTokenSecret", func() {
+		it("returns the token and ca cert from the secret", func() {
+			testDir, err := ioutil.TempDir("", "secret-volume")
+			require.NoError(t, err)
+
+			defer func() {
+				require.NoError(t, os.RemoveAll(testDir))
+			}()
+
+			require.NoError(t, os.MkdirAll(path.Join(testDir, "creds"), 0777))
+
+			require.NoError(t, ioutil.WriteFile(path.Join(testDir, "creds", secret.BearerTokenKey), []byte("saved-token"), 0600))
+			require.NoError(t, ioutil.WriteFile(path.Join(testDir, "creds", secret.CACertKey), []byte("some-ca"), 0600))
+
+			token, err := secret.ReadBearerTokenSecret(testDir, "creds")
+			require.NoError(t, err)
+
+			assert.Equal(t, token, secret.BearerToken{
+				Token:  "saved-token",
+				CACert: "some-ca",
+			})
+		})
 	})
 
 	when("#readSshSecret", func() {
@@ -63,5 +111,27 @@ func testVolumeSecretReader(t *testing.T, when spec.G, it spec.S) {
 				PrivateKey: "foobar",
 			})
 		})
+
+		it("returns the known hosts when present", func() {
+			testDir, err := ioutil.TempDir("", "secret-volume")
+			require.NoError(t, err)
+
+			defer func() {
+				require.NoError(t, os.RemoveAll(testDir))
+			}()
+
+			require.NoError(t, os.MkdirAll(path.Join(testDir, "creds"), 0777))
+
+			require.NoError(t, ioutil.WriteFile(path.Join(testDir, "creds", corev1.SSHAuthPrivateKey), []byte("foobar"), 0600))
+			require.NoError(t, ioutil.WriteFile(path.Join(testDir, "creds", secret.SSHAuthKnownHostsKey), []byte("github.com ssh-ed25519 AAAA"), 0600))
+
+			auth, err := secret.ReadSshSecret(testDir, "creds")
+			require.NoError(t, err)
+
+			assert.Equal(t, auth, secret.SSH{
+				PrivateKey: "foobar",
+				KnownHosts: "github.com ssh-ed25519 AAAA",
+			})
+		})
 	})
 }